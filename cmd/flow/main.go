@@ -0,0 +1,189 @@
+// Command flow runs declarative flowfx flow files.
+//
+// Usage:
+//
+//	flow [flags] <flow.json>
+//
+// Steps run shell commands as a sequence, in parallel or as a dependency
+// graph (see flowfx.FlowSpec). On a terminal each step is shown as a live
+// progress bar and command output is printed after the run; otherwise output
+// is streamed and every step is logged.
+//
+// Exit codes:
+//
+//	0  the flow succeeded
+//	1  a step failed
+//	2  invalid usage or flow file
+//	130  interrupted
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/garaekz/tfx/flowfx"
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/logfx"
+	"github.com/garaekz/tfx/progress"
+	"github.com/garaekz/tfx/runfx"
+)
+
+const (
+	exitOK          = 0
+	exitFailed      = 1
+	exitUsage       = 2
+	exitInterrupted = 130
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	fs := flag.NewFlagSet("flow", flag.ContinueOnError)
+	jsonLogs := fs.Bool("json", false, "write logs as JSON")
+	noProgress := fs.Bool("no-progress", false, "stream output instead of showing progress bars")
+	validate := fs.Bool("validate", false, "validate the flow file without running it")
+	report := fs.Bool("report", false, "print a step report after the run")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: flow [flags] <flow.json>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	logger := logfx.GetLogger()
+	logger.SetOutput(os.Stderr)
+	if *jsonLogs {
+		logger.SetFormat(share.FormatJSON)
+	}
+
+	spec, err := flowfx.LoadFlowSpec(fs.Arg(0))
+	if err != nil {
+		logger.WithFields(share.Fields{}).WithError(err).Error("cannot load flow")
+		return exitUsage
+	}
+	if *validate {
+		if _, err := spec.Build(flowfx.SpecOptions{}); err != nil {
+			logger.WithFields(share.Fields{}).WithError(err).Error("invalid flow")
+			return exitUsage
+		}
+		logger.WithFields(share.Fields{"flow": spec.Name}).Success("flow is valid")
+		return exitOK
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	showProgress := !*noProgress && runfx.DetectTTY().IsTTY
+	opts := flowfx.SpecOptions{Stdout: os.Stdout, Stderr: os.Stderr}
+
+	var output lockedBuffer
+	var loop runfx.Loop
+	if showProgress {
+		bars := progress.NewFlowBars()
+		loop = runfx.Start(runfx.WithOutput(os.Stderr))
+		if _, err := loop.Mount(bars); err != nil {
+			logger.WithFields(share.Fields{}).WithError(err).Error("cannot start progress display")
+			return exitFailed
+		}
+		opts.Stdout, opts.Stderr = &output, &output
+		opts.Reporter = bars
+	} else {
+		opts.Middleware = []flowfx.Middleware{logSteps(logger)}
+	}
+
+	flow, err := spec.Build(opts)
+	if err != nil {
+		logger.WithFields(share.Fields{}).WithError(err).Error("invalid flow")
+		return exitUsage
+	}
+
+	var loopDone sync.WaitGroup
+	if loop != nil {
+		loopDone.Add(1)
+		go func() {
+			defer loopDone.Done()
+			loop.Run(ctx)
+		}()
+	}
+
+	result, err := flowfx.RunWithReport(ctx, flow)
+
+	if loop != nil {
+		loop.Stop()
+		loopDone.Wait()
+		io.Copy(os.Stdout, &output)
+	}
+	if *report {
+		fmt.Fprintln(os.Stderr, result)
+	}
+
+	fields := share.Fields{"flow": spec.Name, "duration": result.Duration.Round(time.Millisecond).String()}
+	switch {
+	case err == nil:
+		logger.WithFields(fields).Success("flow succeeded")
+		return exitOK
+	case ctx.Err() != nil:
+		logger.WithFields(fields).Warn("flow interrupted")
+		return exitInterrupted
+	default:
+		logger.WithFields(fields).WithError(err).Error("flow failed")
+		return exitFailed
+	}
+}
+
+// logSteps logs the start and outcome of every step.
+func logSteps(logger *logfx.Logger) flowfx.Middleware {
+	return func(next flowfx.StepFunc) flowfx.StepFunc {
+		return func(ctx context.Context) error {
+			info := flowfx.StepInfoFrom(ctx)
+			fields := share.Fields{"flow": info.Flow, "step": info.Step}
+			logger.WithFields(fields).Info("step started")
+
+			start := time.Now()
+			err := next(ctx)
+			fields["duration"] = time.Since(start).Round(time.Millisecond).String()
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.WithFields(fields).WithError(err).Error("step failed")
+			} else if err == nil && flowfx.StepSkipped(ctx) {
+				logger.WithFields(fields).Info("step skipped")
+			} else if err == nil {
+				logger.WithFields(fields).Success("step finished")
+			}
+			return err
+		}
+	}
+}
+
+// lockedBuffer collects command output from concurrently running steps.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Read(p)
+}
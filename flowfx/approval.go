@@ -0,0 +1,224 @@
+package flowfx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// AttrApprover is the span attribute holding who answered an approval gate.
+const AttrApprover = "flowfx.approver"
+
+// ApprovalRequest describes the decision an Approver is asked for.
+type ApprovalRequest struct {
+	Flow    string `json:"flow"`
+	Step    string `json:"step"`
+	Message string `json:"message"`
+}
+
+// Approval is the answer of an Approver.
+type Approval struct {
+	Approved bool   `json:"approved"`
+	Approver string `json:"approver"` // Identity of whoever answered
+	Comment  string `json:"comment,omitempty"`
+}
+
+// Approver answers approval gates. Implementations block until a decision is
+// made or ctx is done.
+type Approver interface {
+	Approve(ctx context.Context, req ApprovalRequest) (Approval, error)
+}
+
+// ApproverFunc adapts a function to the Approver interface.
+type ApproverFunc func(ctx context.Context, req ApprovalRequest) (Approval, error)
+
+// Approve implements Approver.
+func (f ApproverFunc) Approve(ctx context.Context, req ApprovalRequest) (Approval, error) {
+	return f(ctx, req)
+}
+
+// PromptApprover asks for approval through a Prompter. A nil Prompter uses
+// the Prompter of the running wizard (see PrompterFrom), which answers "no"
+// when nobody can be asked.
+type PromptApprover struct {
+	Prompter Prompter
+	Identity string // Defaults to the current OS user
+}
+
+// Approve implements Approver.
+func (a PromptApprover) Approve(ctx context.Context, req ApprovalRequest) (Approval, error) {
+	prompter := a.Prompter
+	if prompter == nil {
+		prompter = PrompterFrom(ctx)
+	}
+	ok, err := prompter.Confirm(ctx, req.Message, false)
+	if err != nil {
+		return Approval{}, err
+	}
+	identity := a.Identity
+	if identity == "" {
+		identity = currentUser()
+	}
+	return Approval{Approved: ok, Approver: identity}, nil
+}
+
+// AutoApprove returns an Approver that approves every request on behalf of
+// identity, e.g. AutoApprove("ci") for unattended pipelines.
+func AutoApprove(identity string) Approver {
+	return ApproverFunc(func(ctx context.Context, req ApprovalRequest) (Approval, error) {
+		return Approval{Approved: true, Approver: identity, Comment: "auto-approved"}, nil
+	})
+}
+
+// HTTPApprover posts the ApprovalRequest as JSON to URL and decodes the
+// Approval from the response. The endpoint may hold the request open until
+// someone decides.
+type HTTPApprover struct {
+	URL    string
+	Client *http.Client // Defaults to http.DefaultClient
+}
+
+// Approve implements Approver.
+func (a HTTPApprover) Approve(ctx context.Context, req ApprovalRequest) (Approval, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return Approval{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return Approval{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return Approval{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Approval{}, fmt.Errorf("approval callback: %s", resp.Status)
+	}
+
+	var approval Approval
+	if err := json.NewDecoder(resp.Body).Decode(&approval); err != nil {
+		return Approval{}, fmt.Errorf("approval callback: %w", err)
+	}
+	return approval, nil
+}
+
+// ApprovalConfig provides configuration for an approval gate.
+type ApprovalConfig struct {
+	Name     string        // Step label; defaults to the message
+	Approver Approver      // Defaults to a PromptApprover
+	Timeout  time.Duration // How long to wait for an answer; 0 waits indefinitely
+}
+
+// DefaultApprovalConfig returns the default configuration for an approval gate.
+func DefaultApprovalConfig() ApprovalConfig {
+	return ApprovalConfig{}
+}
+
+// WithApprovalName sets the step label of the gate.
+func WithApprovalName(name string) share.Option[ApprovalConfig] {
+	return func(cfg *ApprovalConfig) {
+		cfg.Name = name
+	}
+}
+
+// WithApprover sets the Approver that answers the gate.
+func WithApprover(approver Approver) share.Option[ApprovalConfig] {
+	return func(cfg *ApprovalConfig) {
+		cfg.Approver = approver
+	}
+}
+
+// WithApprovalTimeout sets how long the gate waits for an answer.
+func WithApprovalTimeout(d time.Duration) share.Option[ApprovalConfig] {
+	return func(cfg *ApprovalConfig) {
+		cfg.Timeout = d
+	}
+}
+
+// ApprovalGate is a step that pauses the flow until its Approver answers.
+// A denied request fails the step with ErrApprovalDenied. Who answered is
+// recorded in the FlowReport and on the step's span.
+type ApprovalGate struct {
+	message string
+	cfg     ApprovalConfig
+}
+
+// ApprovalStep creates a gate that asks message, e.g. "Deploy to prod?".
+// opts Type: any = Option[ApprovalConfig] | ApprovalConfig
+func ApprovalStep(message string, opts ...any) *ApprovalGate {
+	cfg := share.OverloadWithOptions(opts, DefaultApprovalConfig())
+	if cfg.Name == "" {
+		cfg.Name = message
+	}
+	if cfg.Approver == nil {
+		cfg.Approver = PromptApprover{}
+	}
+	return &ApprovalGate{message: message, cfg: cfg}
+}
+
+// Label returns the step label of the gate.
+func (g *ApprovalGate) Label() string {
+	return g.cfg.Name
+}
+
+// Execute implements the Step interface.
+func (g *ApprovalGate) Execute(ctx context.Context) error {
+	info := StepInfoFrom(ctx)
+	req := ApprovalRequest{Flow: info.Flow, Step: info.Step, Message: g.message}
+	if req.Step == "" {
+		req.Step = g.cfg.Name
+	}
+
+	askCtx := ctx
+	if g.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		askCtx, cancel = context.WithTimeout(ctx, g.cfg.Timeout)
+		defer cancel()
+	}
+
+	approval, err := g.cfg.Approver.Approve(askCtx, req)
+	if err != nil {
+		if ctx.Err() == nil && askCtx.Err() != nil {
+			return fmt.Errorf("%w: no answer within %s", ErrTimeout, g.cfg.Timeout)
+		}
+		return err
+	}
+
+	recordApproval(ctx, approval)
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		span.SetAttributes(Attribute{Key: AttrApprover, Value: approval.Approver})
+	}
+	if !approval.Approved {
+		if approval.Comment != "" {
+			return fmt.Errorf("%w by %s: %s", ErrApprovalDenied, approval.Approver, approval.Comment)
+		}
+		return fmt.Errorf("%w by %s", ErrApprovalDenied, approval.Approver)
+	}
+	return nil
+}
+
+// currentUser returns the name of the OS user running the flow.
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
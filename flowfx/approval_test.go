@@ -0,0 +1,167 @@
+package flowfx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestApprovalStep(t *testing.T) {
+	errOffline := errors.New("approver offline")
+	block := ApproverFunc(func(ctx context.Context, req ApprovalRequest) (Approval, error) {
+		<-ctx.Done()
+		return Approval{}, ctx.Err()
+	})
+	tests := []struct {
+		name         string
+		approver     Approver
+		timeout      time.Duration
+		canceled     bool
+		wantErr      error
+		wantApproval *Approval
+	}{
+		{
+			name:         "approved",
+			approver:     AutoApprove("ci"),
+			wantApproval: &Approval{Approved: true, Approver: "ci", Comment: "auto-approved"},
+		},
+		{
+			name: "denied",
+			approver: ApproverFunc(func(ctx context.Context, req ApprovalRequest) (Approval, error) {
+				return Approval{Approver: "alice", Comment: "freeze"}, nil
+			}),
+			wantErr:      ErrApprovalDenied,
+			wantApproval: &Approval{Approver: "alice", Comment: "freeze"},
+		},
+		{
+			name: "approver fails",
+			approver: ApproverFunc(func(ctx context.Context, req ApprovalRequest) (Approval, error) {
+				return Approval{}, errOffline
+			}),
+			wantErr: errOffline,
+		},
+		{
+			name:     "no answer in time",
+			approver: block,
+			timeout:  10 * time.Millisecond,
+			wantErr:  ErrTimeout,
+		},
+		{
+			name:     "canceled",
+			approver: block,
+			canceled: true,
+			wantErr:  context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ApprovalRequest
+			approver := ApproverFunc(func(ctx context.Context, req ApprovalRequest) (Approval, error) {
+				got = req
+				return tt.approver.Approve(ctx, req)
+			})
+			gate := ApprovalStep("Deploy to prod?", WithApprovalName("gate"), WithApprover(approver), WithApprovalTimeout(tt.timeout))
+			flow := NewSequenceBuilder().Name("release").Step(gate).Build()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			report, err := RunWithReport(ctx, flow)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.canceled {
+				return
+			}
+			if want := (ApprovalRequest{Flow: "release", Step: "gate", Message: "Deploy to prod?"}); got != want {
+				t.Errorf("request %+v, want %+v", got, want)
+			}
+			if len(report.Steps) != 1 {
+				t.Fatalf("expected one step in the report, got %d", len(report.Steps))
+			}
+			approval := report.Steps[0].Approval
+			if (approval == nil) != (tt.wantApproval == nil) || (approval != nil && *approval != *tt.wantApproval) {
+				t.Errorf("report approval %+v, want %+v", approval, tt.wantApproval)
+			}
+		})
+	}
+}
+
+func TestPromptApprover(t *testing.T) {
+	errPrompt := errors.New("terminal closed")
+	tests := []struct {
+		name    string
+		answer  answer
+		want    Approval
+		wantErr error
+	}{
+		{name: "yes", answer: answer{value: true}, want: Approval{Approved: true, Approver: "bob"}},
+		{name: "no", answer: answer{value: false}, want: Approval{Approver: "bob"}},
+		{name: "prompt fails", answer: answer{err: errPrompt}, wantErr: errPrompt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter := &scriptedPrompter{script: []answer{tt.answer}}
+			approval, err := PromptApprover{Prompter: prompter, Identity: "bob"}.
+				Approve(context.Background(), ApprovalRequest{Message: "Deploy?"})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if approval != tt.want {
+				t.Errorf("approval %+v, want %+v", approval, tt.want)
+			}
+			if len(prompter.defaults) != 1 || prompter.defaults[0] != false {
+				t.Errorf("expected the prompt to default to no, got %v", prompter.defaults)
+			}
+		})
+	}
+}
+
+func TestHTTPApprover(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    Approval
+		wantErr bool
+	}{
+		{name: "approved", status: http.StatusOK, body: `{"approved":true,"approver":"carol"}`, want: Approval{Approved: true, Approver: "carol"}},
+		{name: "error status", status: http.StatusBadGateway, wantErr: true},
+		{name: "malformed body", status: http.StatusOK, body: `{`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ApprovalRequest
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decode request: %v", err)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			req := ApprovalRequest{Flow: "release", Step: "gate", Message: "Deploy?"}
+			approval, err := HTTPApprover{URL: srv.URL}.Approve(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if approval != tt.want {
+				t.Errorf("approval %+v, want %+v", approval, tt.want)
+			}
+			if got != req {
+				t.Errorf("server received %+v, want %+v", got, req)
+			}
+		})
+	}
+}
@@ -74,7 +74,14 @@ func (b *Branch) Else(falsePath Flow) *Branch {
 }
 
 // Run implements the Flow interface.
-func (b *Branch) Run(ctx context.Context) error {
+func (b *Branch) Run(ctx context.Context) (err error) {
+	// Share the run's store and typed results with nested flows and steps
+	ctx = withFlowState(ctx)
+
+	// Trace the flow run when a Tracer is configured
+	ctx, span := startFlowSpan(ctx, b.name)
+	defer func() { span.finish(err) }()
+
 	// Call onStart hook if defined
 	if b.onStart != nil {
 		b.onStart(ctx, b.name, nil)
@@ -0,0 +1,231 @@
+package flowfx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every execution through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen short-circuits executions until the cooldown elapses.
+	BreakerOpen
+	// BreakerHalfOpen lets a single trial execution through after the cooldown.
+	BreakerHalfOpen
+)
+
+// String returns the state name.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig provides configuration for a CircuitBreaker.
+type BreakerConfig struct {
+	Name      string        // Label passed to hooks; defaults to the step label
+	Threshold int           // Consecutive failures that open the circuit
+	Cooldown  time.Duration // How long the circuit stays open
+
+	// State-change hooks. OnOpen receives the failure that opened the circuit.
+	OnOpen     Hook
+	OnHalfOpen Hook
+	OnClose    Hook
+}
+
+// DefaultBreakerConfig returns the default configuration for a CircuitBreaker.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		Threshold: 5,
+		Cooldown:  30 * time.Second,
+	}
+}
+
+// WithBreakerName sets the label passed to the breaker's hooks.
+func WithBreakerName(name string) share.Option[BreakerConfig] {
+	return func(cfg *BreakerConfig) {
+		cfg.Name = name
+	}
+}
+
+// WithBreakerThreshold sets how many consecutive failures open the circuit.
+func WithBreakerThreshold(n int) share.Option[BreakerConfig] {
+	return func(cfg *BreakerConfig) {
+		cfg.Threshold = n
+	}
+}
+
+// WithBreakerCooldown sets how long the circuit stays open.
+func WithBreakerCooldown(d time.Duration) share.Option[BreakerConfig] {
+	return func(cfg *BreakerConfig) {
+		cfg.Cooldown = d
+	}
+}
+
+// WithOnBreakerOpen sets the hook called when the circuit opens.
+func WithOnBreakerOpen(hook Hook) share.Option[BreakerConfig] {
+	return func(cfg *BreakerConfig) {
+		cfg.OnOpen = hook
+	}
+}
+
+// WithOnBreakerHalfOpen sets the hook called when the circuit lets a trial through.
+func WithOnBreakerHalfOpen(hook Hook) share.Option[BreakerConfig] {
+	return func(cfg *BreakerConfig) {
+		cfg.OnHalfOpen = hook
+	}
+}
+
+// WithOnBreakerClose sets the hook called when the circuit closes again.
+func WithOnBreakerClose(hook Hook) share.Option[BreakerConfig] {
+	return func(cfg *BreakerConfig) {
+		cfg.OnClose = hook
+	}
+}
+
+// Breaker wraps a step with a circuit breaker. After Threshold consecutive
+// failures the circuit opens and executions fail with ErrCircuitOpen until
+// Cooldown elapses. Then a single trial runs: success closes the circuit,
+// failure opens it again. A Breaker is a Step and is safe for concurrent use,
+// so the same breaker can guard a step shared by several flows.
+type Breaker struct {
+	step Step
+	cfg  BreakerConfig
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	trial    bool // A half-open trial is in flight
+}
+
+// CircuitBreaker wraps step with a circuit breaker.
+// opts Type: any = Option[BreakerConfig] | BreakerConfig
+func CircuitBreaker(step Step, opts ...any) *Breaker {
+	cfg := share.OverloadWithOptions(opts, DefaultBreakerConfig())
+	if cfg.Threshold < 1 {
+		cfg.Threshold = 1
+	}
+	if task, ok := step.(*Task); ok && cfg.Name == "" {
+		cfg.Name = task.Label
+	}
+	if cfg.Name == "" {
+		cfg.Name = "breaker"
+	}
+	return &Breaker{step: step, cfg: cfg}
+}
+
+// State returns the current state of the circuit.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cfg.Cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Reset closes the circuit and clears the failure count.
+func (b *Breaker) Reset() {
+	b.mu.Lock()
+	b.state = BreakerClosed
+	b.failures = 0
+	b.trial = false
+	b.mu.Unlock()
+}
+
+// Execute implements the Step interface.
+func (b *Breaker) Execute(ctx context.Context) error {
+	if err := b.allow(ctx); err != nil {
+		return err
+	}
+	err := b.step.Execute(ctx)
+	b.record(ctx, err)
+	return err
+}
+
+// allow reports whether an execution may proceed, moving an expired open
+// circuit to half-open.
+func (b *Breaker) allow(ctx context.Context) error {
+	b.mu.Lock()
+	switch b.state {
+	case BreakerClosed:
+		b.mu.Unlock()
+		return nil
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cfg.Cooldown {
+			b.mu.Unlock()
+			return NewFlowError("breaker", b.cfg.Name, ErrCircuitOpen)
+		}
+		b.state = BreakerHalfOpen
+		b.trial = true
+		b.mu.Unlock()
+		b.emit(ctx, b.cfg.OnHalfOpen, nil)
+		return nil
+	default: // BreakerHalfOpen
+		if b.trial {
+			b.mu.Unlock()
+			return NewFlowError("breaker", b.cfg.Name, ErrCircuitOpen)
+		}
+		b.trial = true
+		b.mu.Unlock()
+		return nil
+	}
+}
+
+// record updates the circuit with the outcome of an execution.
+func (b *Breaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	prev := b.state
+	b.trial = false
+
+	if err == nil {
+		b.failures = 0
+		b.state = BreakerClosed
+		b.mu.Unlock()
+		if prev != BreakerClosed {
+			b.emit(ctx, b.cfg.OnClose, nil)
+		}
+		return
+	}
+
+	// Cancellation says nothing about the health of the step
+	if ctx.Err() != nil {
+		if prev == BreakerHalfOpen {
+			b.state = BreakerOpen
+		}
+		b.mu.Unlock()
+		return
+	}
+
+	b.failures++
+	if prev == BreakerHalfOpen || b.failures >= b.cfg.Threshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+	opened := prev != BreakerOpen && b.state == BreakerOpen
+	b.mu.Unlock()
+	if opened {
+		b.emit(ctx, b.cfg.OnOpen, err)
+	}
+}
+
+// emit calls hook if set.
+func (b *Breaker) emit(ctx context.Context, hook Hook, err error) {
+	if hook != nil {
+		hook(ctx, b.cfg.Name, err)
+	}
+}
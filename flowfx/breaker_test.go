@@ -0,0 +1,116 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	const cooldown = 20 * time.Millisecond
+	errDown := errors.New("service down")
+
+	// call is one execution through the breaker.
+	type call struct {
+		wait      bool  // Let the cooldown elapse first
+		canceled  bool  // Run with a canceled context
+		stepErr   error // Returned by the step when it runs
+		wantErr   error
+		wantRun   bool
+		wantState BreakerState
+	}
+	tests := []struct {
+		name       string
+		calls      []call
+		wantEvents []string
+	}{
+		{
+			name: "opens after consecutive failures and short-circuits",
+			calls: []call{
+				{stepErr: errDown, wantErr: errDown, wantRun: true, wantState: BreakerClosed},
+				{stepErr: errDown, wantErr: errDown, wantRun: true, wantState: BreakerOpen},
+				{wantErr: ErrCircuitOpen, wantState: BreakerOpen},
+			},
+			wantEvents: []string{"open"},
+		},
+		{
+			name: "successful trial closes the circuit",
+			calls: []call{
+				{stepErr: errDown, wantErr: errDown, wantRun: true, wantState: BreakerClosed},
+				{stepErr: errDown, wantErr: errDown, wantRun: true, wantState: BreakerOpen},
+				{wait: true, wantRun: true, wantState: BreakerClosed},
+			},
+			wantEvents: []string{"open", "half-open", "close"},
+		},
+		{
+			name: "failed trial opens the circuit again",
+			calls: []call{
+				{stepErr: errDown, wantErr: errDown, wantRun: true, wantState: BreakerClosed},
+				{stepErr: errDown, wantErr: errDown, wantRun: true, wantState: BreakerOpen},
+				{wait: true, stepErr: errDown, wantErr: errDown, wantRun: true, wantState: BreakerOpen},
+				{wantErr: ErrCircuitOpen, wantState: BreakerOpen},
+			},
+			wantEvents: []string{"open", "half-open", "open"},
+		},
+		{
+			name: "cancellation is not a failure",
+			calls: []call{
+				{stepErr: errDown, wantErr: errDown, wantRun: true, wantState: BreakerClosed},
+				{canceled: true, stepErr: context.Canceled, wantErr: context.Canceled, wantRun: true, wantState: BreakerClosed},
+				{wantRun: true, wantState: BreakerClosed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+			event := func(name string) Hook {
+				return func(ctx context.Context, label string, err error) {
+					if label != "api" {
+						t.Errorf("hook got label %q, want api", label)
+					}
+					events = append(events, name)
+				}
+			}
+			var stepErr error
+			ran := false
+			b := CircuitBreaker(
+				StepFunc(func(context.Context) error { ran = true; return stepErr }),
+				WithBreakerName("api"),
+				WithBreakerThreshold(2),
+				WithBreakerCooldown(cooldown),
+				WithOnBreakerOpen(event("open")),
+				WithOnBreakerHalfOpen(event("half-open")),
+				WithOnBreakerClose(event("close")),
+			)
+
+			for i, c := range tt.calls {
+				if c.wait {
+					time.Sleep(cooldown + 5*time.Millisecond)
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				if c.canceled {
+					cancel()
+				}
+				stepErr, ran = c.stepErr, false
+				err := b.Execute(ctx)
+				cancel()
+
+				if !errors.Is(err, c.wantErr) || (c.wantErr == nil && err != nil) {
+					t.Fatalf("call %d: expected %v, got %v", i, c.wantErr, err)
+				}
+				if ran != c.wantRun {
+					t.Errorf("call %d: step ran = %v, want %v", i, ran, c.wantRun)
+				}
+				if state := b.State(); state != c.wantState {
+					t.Errorf("call %d: state %v, want %v", i, state, c.wantState)
+				}
+			}
+			if !slices.Equal(events, tt.wantEvents) {
+				t.Errorf("events %v, want %v", events, tt.wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package flowfx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache stores the outcome of successful task executions, keyed by the
+// task's idempotency key. Implementations must be safe for concurrent use;
+// a persistent Cache lets results survive across processes.
+type Cache interface {
+	// Get returns the value stored under key, if present and not expired.
+	Get(key string) (any, bool)
+	// Set stores value under key. A ttl of zero keeps it indefinitely.
+	Set(key string, value any, ttl time.Duration)
+}
+
+// DefaultCache is the process-wide Cache used by tasks configured with
+// WithCache and no WithCacheStore.
+var DefaultCache Cache = NewMemoryCache()
+
+// MemoryCache is an in-memory Cache.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// cacheEntry is a cached value with its expiry; a zero expiry never expires.
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// NewMemoryCache creates an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]cacheEntry)}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, value any, ttl time.Duration) {
+	entry := cacheEntry{value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+}
+
+// Clear removes all entries.
+func (c *MemoryCache) Clear() {
+	c.mu.Lock()
+	clear(c.entries)
+	c.mu.Unlock()
+}
+
+// WithCache memoizes the task. key derives an idempotency key from the
+// task's inputs; when a previous execution with the same key succeeded
+// within ttl (zero means for the life of the cache), the task is not run
+// again and a typed task returns its cached result. An empty key disables
+// caching for that execution.
+//
+//	flowfx.NewTypedTask("checksum", sum, flowfx.WithCache(func(ctx context.Context) string {
+//		path, _ := flowfx.StoreValue[string](ctx, "path")
+//		return path
+//	}, time.Hour))
+func WithCache(key func(ctx context.Context) string, ttl time.Duration) TaskOption {
+	return func(t *Task) {
+		t.CacheKey = key
+		t.CacheTTL = ttl
+	}
+}
+
+// WithCacheStore sets the Cache used by a task configured with WithCache.
+func WithCacheStore(cache Cache) TaskOption {
+	return func(t *Task) {
+		t.Cache = cache
+	}
+}
+
+// cacheSlotKey is the context key for the slot that receives the result of
+// a typed task being cached.
+type cacheSlotKey struct{}
+
+// cacheSlot receives the result of a typed task.
+type cacheSlot struct {
+	value any
+}
+
+// cacheLookup returns the cache and full key of t for this execution, or a
+// nil cache when caching does not apply.
+func (t *Task) cacheLookup(ctx context.Context) (Cache, string) {
+	if t.CacheKey == nil {
+		return nil, ""
+	}
+	key := t.CacheKey(ctx)
+	if key == "" {
+		return nil, ""
+	}
+	cache := t.Cache
+	if cache == nil {
+		cache = DefaultCache
+	}
+	return cache, t.Label + ":" + key
+}
+
+// restoreCached records a cached typed result in the running flow.
+func (t *Task) restoreCached(ctx context.Context, value any) {
+	recordCacheHit(ctx)
+	if value == nil {
+		return
+	}
+	if results, ok := ctx.Value(resultsKey{}).(*results); ok {
+		results.set(t.Label, value)
+	}
+}
@@ -0,0 +1,128 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskCache(t *testing.T) {
+	errHash := errors.New("hash failed")
+	// run is one run of a flow holding the cached task.
+	type run struct {
+		key        string
+		err        error         // Returned by the task when it executes
+		canceled   bool          // Cancel the run while the task executes
+		wait       time.Duration // Sleep before the run
+		wantResult int           // Result read by the next step; 0 when the run fails
+		wantCached bool
+		wantErr    error
+	}
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		runs     []run
+		wantExec int
+	}{
+		{
+			name: "same key is served from the cache",
+			runs: []run{
+				{key: "a.txt", wantResult: 1},
+				{key: "a.txt", wantResult: 1, wantCached: true},
+			},
+			wantExec: 1,
+		},
+		{
+			name: "different keys execute",
+			runs: []run{
+				{key: "a.txt", wantResult: 1},
+				{key: "b.txt", wantResult: 2},
+			},
+			wantExec: 2,
+		},
+		{
+			name: "empty key disables caching",
+			runs: []run{
+				{wantResult: 1},
+				{wantResult: 2},
+			},
+			wantExec: 2,
+		},
+		{
+			name: "failures are not cached",
+			runs: []run{
+				{key: "a.txt", err: errHash, wantErr: errHash},
+				{key: "a.txt", wantResult: 2},
+				{key: "a.txt", wantResult: 2, wantCached: true},
+			},
+			wantExec: 2,
+		},
+		{
+			name: "entries expire",
+			ttl:  10 * time.Millisecond,
+			runs: []run{
+				{key: "a.txt", wantResult: 1},
+				{key: "a.txt", wait: 20 * time.Millisecond, wantResult: 2},
+			},
+			wantExec: 2,
+		},
+		{
+			name: "canceled runs are not cached",
+			runs: []run{
+				{key: "a.txt", canceled: true, wantErr: context.Canceled},
+				{key: "a.txt", wantResult: 2},
+			},
+			wantExec: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewMemoryCache()
+			execs := 0
+			for i, r := range tt.runs {
+				time.Sleep(r.wait)
+				ctx, cancel := context.WithCancel(context.Background())
+				checksum := NewTypedTask("checksum", func(ctx context.Context) (int, error) {
+					execs++
+					if r.canceled {
+						cancel()
+						return 0, ctx.Err()
+					}
+					return execs, r.err
+				},
+					WithCache(func(context.Context) string { return r.key }, tt.ttl),
+					WithCacheStore(cache),
+					WithRetry(RetryConfig{MaxAttempts: 1}),
+				)
+				var result int
+				flow := NewSequenceBuilder().Name("build").
+					Task(checksum).
+					Task(once("publish", func(ctx context.Context) (err error) {
+						result, err = Get[int](ctx, "checksum")
+						return err
+					})).
+					Build()
+
+				report, err := RunWithReport(ctx, flow)
+				cancel()
+
+				if r.wantErr == nil && err != nil {
+					t.Fatalf("run %d: unexpected error: %v", i, err)
+				}
+				if !errors.Is(err, r.wantErr) {
+					t.Fatalf("run %d: expected %v, got %v", i, r.wantErr, err)
+				}
+				if result != r.wantResult {
+					t.Errorf("run %d: result %d, want %d", i, result, r.wantResult)
+				}
+				if r.wantErr == nil && report.Steps[0].Cached != r.wantCached {
+					t.Errorf("run %d: cached = %v, want %v", i, report.Steps[0].Cached, r.wantCached)
+				}
+			}
+			if execs != tt.wantExec {
+				t.Errorf("executed %d times, want %d", execs, tt.wantExec)
+			}
+		})
+	}
+}
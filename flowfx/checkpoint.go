@@ -0,0 +1,287 @@
+package flowfx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Checkpoint is the persisted progress of a flow run.
+type Checkpoint struct {
+	Flow      string            `json:"flow"`
+	Completed []string          `json:"completed"`
+	Values    map[string]string `json:"values,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at"`
+
+	// Store holds the JSON-encoded values of the flow's Store.
+	Store map[string]json.RawMessage `json:"store,omitempty"`
+	// Results holds the JSON-encoded results of typed tasks, see Get.
+	Results map[string]json.RawMessage `json:"results,omitempty"`
+}
+
+// Checkpointer persists flow progress so that a re-run skips steps that
+// already finished. Load returns nil and no error when nothing was saved.
+type Checkpointer interface {
+	Load(ctx context.Context, flow string) (*Checkpoint, error)
+	Save(ctx context.Context, cp *Checkpoint) error
+	Clear(ctx context.Context, flow string) error
+}
+
+// FileCheckpointer stores one JSON file per flow in a directory.
+type FileCheckpointer struct {
+	dir string
+}
+
+// NewFileCheckpointer creates a Checkpointer that writes to dir, creating it
+// on first save.
+func NewFileCheckpointer(dir string) *FileCheckpointer {
+	return &FileCheckpointer{dir: dir}
+}
+
+// path returns the checkpoint file for flow.
+func (f *FileCheckpointer) path(flow string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, flow)
+	return filepath.Join(f.dir, name+".checkpoint.json")
+}
+
+// Load implements Checkpointer.
+func (f *FileCheckpointer) Load(ctx context.Context, flow string) (*Checkpoint, error) {
+	data, err := os.ReadFile(f.path(flow))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("decode checkpoint %s: %w", f.path(flow), err)
+	}
+	return &cp, nil
+}
+
+// Save implements Checkpointer. The file is replaced atomically so an
+// interrupted write never leaves a corrupt checkpoint behind.
+func (f *FileCheckpointer) Save(ctx context.Context, cp *Checkpoint) error {
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(f.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(cp.Flow))
+}
+
+// Clear implements Checkpointer.
+func (f *FileCheckpointer) Clear(ctx context.Context, flow string) error {
+	err := os.Remove(f.path(flow))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// MemoryCheckpointer keeps checkpoints in memory. It is useful for tests and
+// for resuming flows within a single process.
+type MemoryCheckpointer struct {
+	mu   sync.Mutex
+	data map[string]Checkpoint
+}
+
+// NewMemoryCheckpointer creates an empty in-memory Checkpointer.
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{data: make(map[string]Checkpoint)}
+}
+
+// Load implements Checkpointer.
+func (m *MemoryCheckpointer) Load(ctx context.Context, flow string) (*Checkpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp, ok := m.data[flow]
+	if !ok {
+		return nil, nil
+	}
+	cp.Completed = append([]string(nil), cp.Completed...)
+	cp.Values = copyValues(cp.Values)
+	cp.Store = maps.Clone(cp.Store)
+	cp.Results = maps.Clone(cp.Results)
+	return &cp, nil
+}
+
+// Save implements Checkpointer.
+func (m *MemoryCheckpointer) Save(ctx context.Context, cp *Checkpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	saved := *cp
+	saved.Completed = append([]string(nil), cp.Completed...)
+	saved.Values = copyValues(cp.Values)
+	saved.Store = maps.Clone(cp.Store)
+	saved.Results = maps.Clone(cp.Results)
+	m.data[cp.Flow] = saved
+	return nil
+}
+
+// Clear implements Checkpointer.
+func (m *MemoryCheckpointer) Clear(ctx context.Context, flow string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.data, flow)
+	return nil
+}
+
+func copyValues(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]string, len(values))
+	for k, v := range values {
+		out[k] = v
+	}
+	return out
+}
+
+// checkpointKey is the context key for the active checkpoint run.
+type checkpointKey struct{}
+
+// checkpointRun tracks progress of a single flow run. A nil run disables
+// checkpointing, so flows can call its methods unconditionally.
+type checkpointRun struct {
+	store Checkpointer
+	mu    sync.Mutex
+	state Checkpoint
+	done  map[string]bool
+}
+
+// startCheckpoint loads previous progress for flow and returns a context
+// through which steps can read and write checkpoint values.
+func startCheckpoint(ctx context.Context, store Checkpointer, flow string) (*checkpointRun, context.Context, error) {
+	if store == nil {
+		return nil, ctx, nil
+	}
+	cp, err := store.Load(ctx, flow)
+	if err != nil {
+		return nil, ctx, NewFlowError(flow, "", fmt.Errorf("%w: %w", ErrCheckpoint, err))
+	}
+	if cp == nil {
+		cp = &Checkpoint{Flow: flow}
+	}
+
+	run := &checkpointRun{store: store, state: *cp, done: make(map[string]bool)}
+	run.state.Flow = flow
+	if run.state.Values == nil {
+		run.state.Values = make(map[string]string)
+	}
+	for _, id := range cp.Completed {
+		run.done[id] = true
+	}
+	if store, ok := ctx.Value(storeKey{}).(*Store); ok {
+		store.restore(cp.Store)
+	}
+	if results, ok := ctx.Value(resultsKey{}).(*results); ok {
+		results.restore(cp.Results)
+	}
+	return run, context.WithValue(ctx, checkpointKey{}, run), nil
+}
+
+// completed reports whether step id finished in a previous run.
+func (r *checkpointRun) completed(id string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.done[id]
+}
+
+// markDone records step id as finished and persists the checkpoint.
+func (r *checkpointRun) markDone(ctx context.Context, id string) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	if !r.done[id] {
+		r.done[id] = true
+		r.state.Completed = append(r.state.Completed, id)
+	}
+	r.state.UpdatedAt = time.Now()
+	snapshot := r.state
+	snapshot.Completed = append([]string(nil), r.state.Completed...)
+	snapshot.Values = copyValues(r.state.Values)
+	r.mu.Unlock()
+	if store, ok := ctx.Value(storeKey{}).(*Store); ok {
+		snapshot.Store = store.encode()
+	}
+	if results, ok := ctx.Value(resultsKey{}).(*results); ok {
+		snapshot.Results = results.encode()
+	}
+
+	if err := r.store.Save(ctx, &snapshot); err != nil {
+		return NewFlowError(snapshot.Flow, id, fmt.Errorf("%w: %w", ErrCheckpoint, err))
+	}
+	return nil
+}
+
+// finish clears the checkpoint after the whole flow succeeded.
+func (r *checkpointRun) finish(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	if err := r.store.Clear(ctx, r.state.Flow); err != nil {
+		return NewFlowError(r.state.Flow, "", fmt.Errorf("%w: %w", ErrCheckpoint, err))
+	}
+	return nil
+}
+
+// SetCheckpointValue stores a value in the checkpoint of the running flow so
+// it survives a restart. It is a no-op when the flow is not checkpointed.
+// The value is persisted together with the next completed step.
+func SetCheckpointValue(ctx context.Context, key, value string) {
+	run, ok := ctx.Value(checkpointKey{}).(*checkpointRun)
+	if !ok {
+		return
+	}
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	run.state.Values[key] = value
+}
+
+// CheckpointValue returns a value stored with SetCheckpointValue in this or
+// a previous run of the flow.
+func CheckpointValue(ctx context.Context, key string) (string, bool) {
+	run, ok := ctx.Value(checkpointKey{}).(*checkpointRun)
+	if !ok {
+		return "", false
+	}
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	v, ok := run.state.Values[key]
+	return v, ok
+}
@@ -0,0 +1,107 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// failingCheckpointer fails every save.
+type failingCheckpointer struct{ *MemoryCheckpointer }
+
+func (f *failingCheckpointer) Save(ctx context.Context, cp *Checkpoint) error {
+	return errors.New("disk full")
+}
+
+func TestCheckpointResume(t *testing.T) {
+	checkpointers := map[string]func(t *testing.T) Checkpointer{
+		"file":   func(t *testing.T) Checkpointer { return NewFileCheckpointer(t.TempDir()) },
+		"memory": func(t *testing.T) Checkpointer { return NewMemoryCheckpointer() },
+	}
+	for name, newCheckpointer := range checkpointers {
+		t.Run(name, func(t *testing.T) {
+			cp := newCheckpointer(t)
+			rec := &recorder{}
+			failInstall := true
+			var restored string
+			flow := NewSequenceBuilder().
+				Name("installer").
+				Checkpointer(cp).
+				Func("download", func(ctx context.Context) error {
+					rec.step("download", nil).Execute(ctx)
+					SetCheckpointValue(ctx, "archive", "/tmp/app.tgz")
+					return nil
+				}).
+				Task(once("install", func(ctx context.Context) error {
+					restored, _ = CheckpointValue(ctx, "archive")
+					if failInstall {
+						return rec.step("install", errors.New("disk full")).Execute(ctx)
+					}
+					return rec.step("install", nil).Execute(ctx)
+				})).
+				Step(rec.step("configure", nil)).
+				Build()
+
+			if err := flow.Run(context.Background()); err == nil {
+				t.Fatal("expected the first run to fail")
+			}
+			saved, err := cp.Load(context.Background(), "installer")
+			if err != nil || saved == nil {
+				t.Fatalf("expected a saved checkpoint, got %v, %v", saved, err)
+			}
+			if !slices.Equal(saved.Completed, []string{"download"}) {
+				t.Errorf("completed = %v, want [download]", saved.Completed)
+			}
+
+			failInstall = false
+			if err := flow.Run(context.Background()); err != nil {
+				t.Fatalf("expected the resumed run to succeed, got %v", err)
+			}
+			want := []string{"download", "install", "install", "configure"}
+			if got := rec.names(); !slices.Equal(got, want) {
+				t.Errorf("ran %v, want %v", got, want)
+			}
+			if restored != "/tmp/app.tgz" {
+				t.Errorf("expected the checkpoint value to survive the restart, got %q", restored)
+			}
+			if saved, _ := cp.Load(context.Background(), "installer"); saved != nil {
+				t.Errorf("expected the checkpoint to be cleared after success, got %+v", saved)
+			}
+		})
+	}
+}
+
+func TestCheckpointErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		cp       Checkpointer
+		canceled bool
+		wantErr  error
+	}{
+		{"save failure", &failingCheckpointer{NewMemoryCheckpointer()}, false, ErrCheckpoint},
+		{"canceled", NewMemoryCheckpointer(), true, context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := NewSequenceBuilder().
+				Name("installer").
+				Checkpointer(tt.cp).
+				Step(rec.step("download", nil)).
+				Step(rec.step("install", nil)).
+				Run(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if got := rec.names(); len(got) > 1 {
+				t.Errorf("expected the flow to stop at the first step, ran %v", got)
+			}
+		})
+	}
+}
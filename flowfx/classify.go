@@ -0,0 +1,64 @@
+package flowfx
+
+import "errors"
+
+// retryableError marks an error as transient.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// fatalError marks an error as permanent.
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string { return e.err.Error() }
+func (e *fatalError) Unwrap() error { return e.err }
+
+// Retryable marks err as transient, e.g. a timeout talking to a service.
+// Retry policies whose ShouldRetry is IsRetryable retry only such errors.
+// Retryable(nil) returns nil.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err}
+}
+
+// Fatal marks err as permanent, e.g. invalid credentials. A fatal error is
+// never retried and aborts the flow even when returned by a non-critical
+// step of a Script or Saga, or by a step of a Parallel flow or DAG without
+// FailFast. Fatal(nil) returns nil.
+func Fatal(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &fatalError{err: err}
+}
+
+// IsRetryable reports whether err, or any error it wraps, was marked with
+// Retryable.
+func IsRetryable(err error) bool {
+	var target *retryableError
+	return errors.As(err, &target)
+}
+
+// IsFatal reports whether err, or any error it wraps, was marked with Fatal.
+func IsFatal(err error) bool {
+	var target *fatalError
+	return errors.As(err, &target)
+}
+
+// shouldRetry applies the retry policy of cfg to err.
+func (cfg RetryConfig) shouldRetry(err error) bool {
+	if IsFatal(err) {
+		return false
+	}
+	if cfg.ShouldRetry != nil {
+		return cfg.ShouldRetry(err)
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryClassification(t *testing.T) {
+	errConn := errors.New("connection reset")
+	tests := []struct {
+		name         string
+		err          error
+		shouldRetry  func(error) bool
+		canceled     bool
+		wantAttempts int
+		wantErr      error
+		wantNotErr   error
+	}{
+		{name: "success", wantAttempts: 1},
+		{name: "plain errors retry by default", err: errConn, wantAttempts: 3, wantErr: ErrRetryExhausted},
+		{name: "retryable error", err: Retryable(errConn), shouldRetry: IsRetryable, wantAttempts: 3, wantErr: ErrRetryExhausted},
+		{name: "unmarked error with IsRetryable", err: errConn, shouldRetry: IsRetryable, wantAttempts: 1, wantErr: errConn, wantNotErr: ErrRetryExhausted},
+		{name: "fatal error", err: Fatal(errConn), wantAttempts: 1, wantErr: errConn, wantNotErr: ErrRetryExhausted},
+		{name: "fatal wins over the policy", err: fmt.Errorf("login: %w", Fatal(errConn)), shouldRetry: func(error) bool { return true }, wantAttempts: 1, wantErr: errConn},
+		{name: "canceled", err: errConn, canceled: true, wantAttempts: 0, wantErr: ErrCanceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			task := NewTask("fetch", func(context.Context) error {
+				attempts++
+				return tt.err
+			}, WithRetry(RetryConfig{MaxAttempts: 3, Delay: time.Millisecond, Backoff: 1, ShouldRetry: tt.shouldRetry}))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := task.Execute(ctx)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantNotErr != nil && errors.Is(err, tt.wantNotErr) {
+				t.Errorf("error %v should not match %v", err, tt.wantNotErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("ran %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestFatalStopsNonCriticalSteps(t *testing.T) {
+	errDisk := errors.New("disk full")
+	tests := []struct {
+		name        string
+		err         error
+		wantRanNext bool
+	}{
+		{name: "plain error continues", err: errDisk, wantRanNext: true},
+		{name: "fatal error aborts", err: Fatal(errDisk), wantRanNext: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranNext := false
+			script := NewScript(ScriptConfig{Name: "cleanup"}).
+				AddTask("purge", once("purge", fail(tt.err))).
+				AddTask("report", once("report", func(context.Context) error { ranNext = true; return nil }))
+
+			if err := script.Run(context.Background()); !errors.Is(err, errDisk) {
+				t.Fatalf("expected %v, got %v", errDisk, err)
+			}
+			if ranNext != tt.wantRanNext {
+				t.Errorf("next step ran = %v, want %v", ranNext, tt.wantRanNext)
+			}
+		})
+	}
+}
+
+func TestClassifyNil(t *testing.T) {
+	if Retryable(nil) != nil || Fatal(nil) != nil {
+		t.Error("marking a nil error should return nil")
+	}
+	if IsRetryable(nil) || IsFatal(nil) {
+		t.Error("a nil error is neither retryable nor fatal")
+	}
+}
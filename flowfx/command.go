@@ -0,0 +1,57 @@
+package flowfx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// CommandStep runs a shell command. It is the step type used by declarative
+// flow files, but can be added to any flow. Use Exec to run a program without
+// a shell, with streamed output and typed exit errors.
+type CommandStep struct {
+	Command string            // Passed to "sh -c" ("cmd /C" on Windows)
+	Dir     string            // Working directory; empty means the current one
+	Env     map[string]string // Added to the inherited environment
+	Stdout  io.Writer         // Defaults to os.Stdout
+	Stderr  io.Writer         // Defaults to os.Stderr
+}
+
+// NewCommandTask creates a Task that runs command in a shell.
+func NewCommandTask(label, command string, opts ...TaskOption) *Task {
+	step := &CommandStep{Command: command}
+	return NewTask(label, step.Execute, opts...)
+}
+
+// Execute implements the Step interface.
+func (c *CommandStep) Execute(ctx context.Context) error {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	cmd := exec.CommandContext(ctx, shell, flag, c.Command)
+	cmd.Dir = c.Dir
+	cmd.Stdout = c.Stdout
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	cmd.Stderr = c.Stderr
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	if len(c.Env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range c.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", c.Command, err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package flowfx
+
+import (
+	"context"
+	"fmt"
+)
+
+// ifClause pairs a condition with the flow it guards.
+type ifClause struct {
+	cond Condition
+	flow Flow
+}
+
+// IfFlow is a declarative conditional built with If(...).Then(...).
+// It implements both Flow and Step, so it can be nested inside sequences,
+// scripts and other flows.
+type IfFlow struct {
+	clauses  []ifClause
+	elseFlow Flow
+	name     string
+}
+
+// If starts a conditional flow. Chain Then, ElseIf and Else to describe the
+// paths:
+//
+//	flowfx.If(isLinux).Then(installApt).ElseIf(isMac).Then(installBrew).Else(skip)
+func If(cond Condition) *IfFlow {
+	return &IfFlow{
+		clauses: []ifClause{{cond: cond}},
+		name:    "if",
+	}
+}
+
+// Then sets the flow run when the most recent condition holds.
+func (f *IfFlow) Then(flow Flow) *IfFlow {
+	f.clauses[len(f.clauses)-1].flow = flow
+	return f
+}
+
+// ElseIf adds another condition, checked when all previous ones failed.
+func (f *IfFlow) ElseIf(cond Condition) *IfFlow {
+	f.clauses = append(f.clauses, ifClause{cond: cond})
+	return f
+}
+
+// Else sets the flow run when no condition holds.
+func (f *IfFlow) Else(flow Flow) *IfFlow {
+	f.elseFlow = flow
+	return f
+}
+
+// Named sets the name used in errors.
+func (f *IfFlow) Named(name string) *IfFlow {
+	f.name = name
+	return f
+}
+
+// Run evaluates the conditions in order and runs the first matching path.
+// It implements the Flow interface.
+func (f *IfFlow) Run(ctx context.Context) error {
+	for i, clause := range f.clauses {
+		if clause.cond == nil {
+			return NewFlowError(f.name, fmt.Sprintf("condition_%d", i+1), ErrInvalidCondition)
+		}
+		if !clause.cond(ctx) {
+			continue
+		}
+		if clause.flow == nil {
+			return nil
+		}
+		if err := clause.flow.Run(ctx); err != nil {
+			return NewFlowError(f.name, fmt.Sprintf("then_%d", i+1), err)
+		}
+		return nil
+	}
+
+	if f.elseFlow == nil {
+		return nil
+	}
+	if err := f.elseFlow.Run(ctx); err != nil {
+		return NewFlowError(f.name, "else", err)
+	}
+	return nil
+}
+
+// Execute implements the Step interface.
+func (f *IfFlow) Execute(ctx context.Context) error {
+	return f.Run(ctx)
+}
+
+// switchCase pairs a selector value with its flow.
+type switchCase[K comparable] struct {
+	value K
+	flow  Flow
+}
+
+// SwitchFlow runs the flow registered for the value returned by a selector.
+// It implements both Flow and Step.
+type SwitchFlow[K comparable] struct {
+	selector    func(ctx context.Context) (K, error)
+	cases       []switchCase[K]
+	defaultFlow Flow
+	name        string
+}
+
+// Switch starts a flow that picks a path based on selector's result:
+//
+//	flowfx.Switch(detectOS).
+//		Case("linux", installApt).
+//		Case("darwin", installBrew).
+//		Default(unsupported)
+func Switch[K comparable](selector func(ctx context.Context) (K, error)) *SwitchFlow[K] {
+	return &SwitchFlow[K]{selector: selector, name: "switch"}
+}
+
+// Case registers the flow run when the selector returns value. When several
+// cases share a value, the first one wins.
+func (s *SwitchFlow[K]) Case(value K, flow Flow) *SwitchFlow[K] {
+	s.cases = append(s.cases, switchCase[K]{value: value, flow: flow})
+	return s
+}
+
+// Default sets the flow run when no case matches. Without a default,
+// unmatched values are a no-op.
+func (s *SwitchFlow[K]) Default(flow Flow) *SwitchFlow[K] {
+	s.defaultFlow = flow
+	return s
+}
+
+// Named sets the name used in errors.
+func (s *SwitchFlow[K]) Named(name string) *SwitchFlow[K] {
+	s.name = name
+	return s
+}
+
+// Run evaluates the selector and runs the matching case.
+// It implements the Flow interface.
+func (s *SwitchFlow[K]) Run(ctx context.Context) error {
+	if s.selector == nil {
+		return NewFlowError(s.name, "selector", ErrInvalidCondition)
+	}
+	value, err := s.selector(ctx)
+	if err != nil {
+		return NewFlowError(s.name, "selector", err)
+	}
+
+	for _, c := range s.cases {
+		if c.value != value {
+			continue
+		}
+		if c.flow == nil {
+			return nil
+		}
+		if err := c.flow.Run(ctx); err != nil {
+			return NewFlowError(s.name, fmt.Sprintf("case %v", value), err)
+		}
+		return nil
+	}
+
+	if s.defaultFlow == nil {
+		return nil
+	}
+	if err := s.defaultFlow.Run(ctx); err != nil {
+		return NewFlowError(s.name, "default", err)
+	}
+	return nil
+}
+
+// Execute implements the Step interface.
+func (s *SwitchFlow[K]) Execute(ctx context.Context) error {
+	return s.Run(ctx)
+}
+
+// stepFlow adapts a Step to the Flow interface.
+type stepFlow struct {
+	step Step
+}
+
+// Run implements the Flow interface.
+func (s stepFlow) Run(ctx context.Context) error {
+	return s.step.Execute(ctx)
+}
+
+// AsFlow wraps a single step, such as a Task, so it can be used where a Flow
+// is expected, e.g. as a branch of If or Switch.
+func AsFlow(step Step) Flow {
+	return stepFlow{step: step}
+}
@@ -0,0 +1,117 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// is returns a condition holding when want is true.
+func is(want bool) Condition {
+	return func(context.Context) bool { return want }
+}
+
+// ctxErr is a step failing with the error of its context.
+var ctxErr = StepFunc(func(ctx context.Context) error { return ctx.Err() })
+
+func TestIf(t *testing.T) {
+	errApt := errors.New("apt failed")
+	tests := []struct {
+		name     string
+		linux    bool
+		mac      bool
+		aptErr   error
+		canceled bool
+		wantRan  []string
+		wantErr  error
+		wantStep string
+	}{
+		{name: "then", linux: true, wantRan: []string{"apt"}},
+		{name: "else if", mac: true, wantRan: []string{"brew"}},
+		{name: "else", wantRan: []string{"skip"}},
+		{name: "failing branch", linux: true, aptErr: errApt, wantRan: []string{"apt"}, wantErr: errApt, wantStep: "then_1"},
+		{name: "canceled", mac: true, canceled: true, wantErr: context.Canceled, wantStep: "then_2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			brew := rec.step("brew", nil)
+			if tt.canceled {
+				brew = ctxErr
+			}
+			flow := If(is(tt.linux)).Then(AsFlow(rec.step("apt", tt.aptErr))).
+				ElseIf(is(tt.mac)).Then(AsFlow(brew)).
+				Else(AsFlow(rec.step("skip", nil))).
+				Named("install")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := flow.Run(ctx)
+
+			if got := rec.names(); !slices.Equal(got, tt.wantRan) {
+				t.Errorf("ran %v, want %v", got, tt.wantRan)
+			}
+			checkFlowError(t, err, tt.wantErr, "install", tt.wantStep)
+		})
+	}
+
+	if err := If(nil).Then(AsFlow(ctxErr)).Run(context.Background()); !errors.Is(err, ErrInvalidCondition) {
+		t.Errorf("expected a nil condition to fail with ErrInvalidCondition, got %v", err)
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	errDetect := errors.New("unknown platform")
+	tests := []struct {
+		name     string
+		os       string
+		selErr   error
+		wantRan  []string
+		wantErr  error
+		wantStep string
+	}{
+		{name: "case", os: "darwin", wantRan: []string{"brew"}},
+		{name: "default", os: "plan9", wantRan: []string{"unsupported"}},
+		{name: "selector failure", selErr: errDetect, wantErr: errDetect, wantStep: "selector"},
+		{name: "failing case", os: "linux", wantRan: []string{"apt"}, wantErr: ErrCanceled, wantStep: "case linux"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			flow := Switch(func(context.Context) (string, error) { return tt.os, tt.selErr }).
+				Case("linux", AsFlow(rec.step("apt", ErrCanceled))).
+				Case("darwin", AsFlow(rec.step("brew", nil))).
+				Default(AsFlow(rec.step("unsupported", nil))).
+				Named("install")
+			err := flow.Run(context.Background())
+
+			if got := rec.names(); !slices.Equal(got, tt.wantRan) {
+				t.Errorf("ran %v, want %v", got, tt.wantRan)
+			}
+			checkFlowError(t, err, tt.wantErr, "install", tt.wantStep)
+		})
+	}
+}
+
+// checkFlowError fails t unless err is nil when want is, or a FlowError of
+// flow and step wrapping want.
+func checkFlowError(t *testing.T, err, want error, flow, step string) {
+	t.Helper()
+	if want == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	var flowErr *FlowError
+	if !errors.As(err, &flowErr) || !errors.Is(err, want) {
+		t.Fatalf("expected a FlowError wrapping %v, got %v", want, err)
+	}
+	if flowErr.Flow != flow || flowErr.Step != step {
+		t.Errorf("error at %s/%s, want %s/%s", flowErr.Flow, flowErr.Step, flow, step)
+	}
+}
@@ -0,0 +1,444 @@
+package flowfx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// dagNode is a single step in a DAG together with its dependencies.
+type dagNode struct {
+	id   string
+	step Step
+	deps []string
+}
+
+// DAG represents a flow whose steps declare dependencies on each other.
+// Steps run in topological order; steps whose dependencies are satisfied run
+// concurrently, bounded by MaxParallel.
+type DAG struct {
+	nodes       []*dagNode
+	index       map[string]*dagNode
+	name        string
+	onStart     Hook
+	onComplete  Hook
+	onError     Hook
+	middleware  []Middleware
+	failFast    bool // If true, cancel running steps when one fails
+	maxParallel int  // Maximum concurrent steps; 0 means unlimited
+}
+
+// DAGConfig provides configuration for a DAG flow.
+type DAGConfig struct {
+	Name        string
+	OnStart     Hook
+	OnComplete  Hook
+	OnError     Hook
+	Middleware  []Middleware // Wraps every step execution, see Use
+	FailFast    bool
+	MaxParallel int
+}
+
+// DefaultDAGConfig returns the default configuration for a DAG flow.
+func DefaultDAGConfig() DAGConfig {
+	return DAGConfig{
+		Name:     "dag",
+		FailFast: false,
+	}
+}
+
+// newDAG creates a new DAG flow with the given configuration.
+func newDAG(cfg DAGConfig) *DAG {
+	return &DAG{
+		nodes:       make([]*dagNode, 0),
+		index:       make(map[string]*dagNode),
+		name:        cfg.Name,
+		onStart:     cfg.OnStart,
+		onComplete:  cfg.OnComplete,
+		onError:     cfg.OnError,
+		middleware:  cfg.Middleware,
+		failFast:    cfg.FailFast,
+		maxParallel: cfg.MaxParallel,
+	}
+}
+
+// --- MULTIPATH API FUNCTIONS ---
+
+// NewDAG creates a new dependency-driven flow with multipath configuration support.
+// Supports two usage patterns:
+//   - NewDAG()                               // Zero-config, uses defaults
+//   - NewDAG(config)                         // Config struct
+func NewDAG(args ...any) *DAG {
+	cfg := share.Overload(args, DefaultDAGConfig())
+	return newDAG(cfg)
+}
+
+// NewDAGBuilder creates a new DAGBuilder for DSL chaining.
+func NewDAGBuilder() *DAGBuilder {
+	return &DAGBuilder{config: DefaultDAGConfig()}
+}
+
+// Add registers a step under id, running after all steps listed in deps.
+// Duplicate ids and unknown dependencies are reported by Validate and Run.
+func (d *DAG) Add(id string, step Step, deps ...string) *DAG {
+	node := &dagNode{id: id, step: step, deps: append([]string(nil), deps...)}
+	d.nodes = append(d.nodes, node)
+	if _, exists := d.index[id]; !exists {
+		d.index[id] = node
+	}
+	return d
+}
+
+// AddTask registers a Task using its label as id and its declared
+// dependencies (see Task.DependsOn).
+func (d *DAG) AddTask(task *Task) *DAG {
+	return d.Add(task.Label, task, task.Dependencies...)
+}
+
+// AddFunc is a convenience method to add a function as a step.
+func (d *DAG) AddFunc(id string, fn func(ctx context.Context) error, deps ...string) *DAG {
+	return d.AddTask(NewTask(id, fn).DependsOn(deps...))
+}
+
+// Validate checks for duplicate ids, unknown dependencies and cycles.
+func (d *DAG) Validate() error {
+	_, err := d.Layers()
+	return err
+}
+
+// Layers returns the step ids grouped by depth: every step in a layer only
+// depends on steps in earlier layers. It fails if the graph has a cycle.
+func (d *DAG) Layers() ([][]string, error) {
+	indegree := make(map[string]int, len(d.nodes))
+	dependents := make(map[string][]string, len(d.nodes))
+
+	for _, node := range d.nodes {
+		if d.index[node.id] != node {
+			return nil, NewFlowError(d.name, node.id, ErrDuplicateStep)
+		}
+		indegree[node.id] = len(node.deps)
+		for _, dep := range node.deps {
+			if _, ok := d.index[dep]; !ok {
+				return nil, NewFlowError(d.name, node.id, fmt.Errorf("%w: %q", ErrUnknownDependency, dep))
+			}
+			dependents[dep] = append(dependents[dep], node.id)
+		}
+	}
+
+	var layers [][]string
+	var current []string
+	for _, node := range d.nodes {
+		if indegree[node.id] == 0 {
+			current = append(current, node.id)
+		}
+	}
+
+	visited := 0
+	for len(current) > 0 {
+		layers = append(layers, current)
+		visited += len(current)
+
+		var next []string
+		for _, id := range current {
+			for _, dependent := range dependents[id] {
+				indegree[dependent]--
+				if indegree[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		current = next
+	}
+
+	if visited != len(d.nodes) {
+		return nil, NewFlowError(d.name, "", fmt.Errorf("%w: %s", ErrDependencyCycle, d.findCycle()))
+	}
+	return layers, nil
+}
+
+// findCycle returns a readable description of one dependency cycle.
+func (d *DAG) findCycle() string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int, len(d.nodes))
+	var path []string
+	var cycle []string
+
+	var visit func(id string) bool
+	visit = func(id string) bool {
+		state[id] = inProgress
+		path = append(path, id)
+		for _, dep := range d.index[id].deps {
+			switch state[dep] {
+			case inProgress:
+				for i, p := range path {
+					if p == dep {
+						cycle = append(append([]string(nil), path[i:]...), dep)
+						return true
+					}
+				}
+			case unvisited:
+				if visit(dep) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+		return false
+	}
+
+	for _, node := range d.nodes {
+		if state[node.id] == unvisited && visit(node.id) {
+			break
+		}
+	}
+	// Dependencies point backwards, so reverse to read in execution order.
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return strings.Join(cycle, " -> ")
+}
+
+// dagResult is the outcome of a single step.
+type dagResult struct {
+	id  string
+	err error
+}
+
+// Run executes the steps in dependency order with maximum safe parallelism.
+// Steps whose dependencies failed are skipped and reported with
+// ErrDependencyFailed. It implements the Flow interface.
+func (d *DAG) Run(ctx context.Context) (err error) {
+	if len(d.nodes) == 0 {
+		return NewFlowError(d.name, "", ErrEmptyFlow)
+	}
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	// Share the run's store and typed results with nested flows and steps
+	ctx = withFlowState(ctx)
+	ctx = withMiddleware(ctx, d.middleware)
+
+	// Trace the flow run when a Tracer is configured
+	ctx, span := startFlowSpan(ctx, d.name)
+	defer func() { span.finish(err) }()
+
+	// Call onStart hook if provided
+	if d.onStart != nil {
+		d.onStart(ctx, d.name, nil)
+	}
+
+	execCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	remaining := make(map[string]int, len(d.nodes))
+	dependents := make(map[string][]string, len(d.nodes))
+	var ready []string
+	for _, node := range d.nodes {
+		remaining[node.id] = len(node.deps)
+		for _, dep := range node.deps {
+			dependents[dep] = append(dependents[dep], node.id)
+		}
+		if len(node.deps) == 0 {
+			ready = append(ready, node.id)
+		}
+	}
+
+	results := make(chan dagResult, len(d.nodes))
+	failed := make(map[string]bool)
+	multiErr := NewMultiError()
+	var wg sync.WaitGroup
+	running, finished := 0, 0
+
+	// skip marks id and everything depending on it as not run.
+	var skip func(id, cause string)
+	skip = func(id, cause string) {
+		if failed[id] {
+			return
+		}
+		failed[id] = true
+		finished++
+		multiErr.Add(NewFlowError(d.name, id, fmt.Errorf("%w: %s", ErrDependencyFailed, cause)))
+		for _, dependent := range dependents[id] {
+			skip(dependent, cause)
+		}
+	}
+
+	for finished < len(d.nodes) {
+		// Launch every ready step the parallelism limit allows.
+		for len(ready) > 0 && (d.maxParallel <= 0 || running < d.maxParallel) {
+			id := ready[0]
+			ready = ready[1:]
+			if failed[id] {
+				continue
+			}
+
+			running++
+			wg.Add(1)
+			go func(node *dagNode) {
+				defer wg.Done()
+				results <- dagResult{id: node.id, err: execStep(execCtx, StepInfo{Flow: d.name, Step: node.id, Critical: true}, node.step)}
+			}(d.index[id])
+		}
+
+		if running == 0 {
+			break
+		}
+
+		res := <-results
+		running--
+		finished++
+
+		if res.err != nil {
+			failed[res.id] = true
+			multiErr.Add(NewFlowError(d.name, res.id, res.err))
+			if d.failFast || IsFatal(res.err) {
+				cancel()
+			}
+			for _, dependent := range dependents[res.id] {
+				skip(dependent, res.id)
+			}
+			continue
+		}
+
+		for _, dependent := range dependents[res.id] {
+			remaining[dependent]--
+			if remaining[dependent] == 0 && !failed[dependent] {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	wg.Wait()
+
+	// Handle results
+	if multiErr.HasErrors() {
+		if d.onError != nil {
+			d.onError(ctx, d.name, multiErr.ToError())
+		}
+		return multiErr.ToError()
+	}
+
+	// All steps completed successfully
+	if d.onComplete != nil {
+		d.onComplete(ctx, d.name, nil)
+	}
+
+	return nil
+}
+
+// IDs returns the step ids in the order they were added.
+func (d *DAG) IDs() []string {
+	ids := make([]string, len(d.nodes))
+	for i, node := range d.nodes {
+		ids[i] = node.id
+	}
+	return ids
+}
+
+// Len returns the number of steps in the DAG.
+func (d *DAG) Len() int {
+	return len(d.nodes)
+}
+
+// Use adds middleware that wraps every step execution of the flow,
+// including steps of nested flows.
+func (d *DAG) Use(mws ...Middleware) *DAG {
+	d.middleware = append(d.middleware, mws...)
+	return d
+}
+
+// --- DSL BUILDER ---
+
+// DAGBuilder provides a fluent API for building DAG flows.
+type DAGBuilder struct {
+	config DAGConfig
+	nodes  []*dagNode
+}
+
+// Name sets the name of the DAG flow.
+func (db *DAGBuilder) Name(name string) *DAGBuilder {
+	db.config.Name = name
+	return db
+}
+
+// OnStart sets the start hook.
+func (db *DAGBuilder) OnStart(hook Hook) *DAGBuilder {
+	db.config.OnStart = hook
+	return db
+}
+
+// OnComplete sets the complete hook.
+func (db *DAGBuilder) OnComplete(hook Hook) *DAGBuilder {
+	db.config.OnComplete = hook
+	return db
+}
+
+// OnError sets the error hook.
+func (db *DAGBuilder) OnError(hook Hook) *DAGBuilder {
+	db.config.OnError = hook
+	return db
+}
+
+// Use adds middleware that wraps every step execution.
+func (db *DAGBuilder) Use(mws ...Middleware) *DAGBuilder {
+	db.config.Middleware = append(db.config.Middleware, mws...)
+	return db
+}
+
+// FailFast enables fail-fast mode.
+func (db *DAGBuilder) FailFast(enabled bool) *DAGBuilder {
+	db.config.FailFast = enabled
+	return db
+}
+
+// MaxParallel limits how many steps run at the same time (0 means unlimited).
+func (db *DAGBuilder) MaxParallel(n int) *DAGBuilder {
+	db.config.MaxParallel = n
+	return db
+}
+
+// Step adds a step with its dependencies.
+func (db *DAGBuilder) Step(id string, step Step, deps ...string) *DAGBuilder {
+	db.nodes = append(db.nodes, &dagNode{id: id, step: step, deps: append([]string(nil), deps...)})
+	return db
+}
+
+// Task adds a task using its label as id and its declared dependencies.
+func (db *DAGBuilder) Task(task *Task) *DAGBuilder {
+	return db.Step(task.Label, task, task.Dependencies...)
+}
+
+// Func adds a function as a step with its dependencies.
+func (db *DAGBuilder) Func(id string, fn func(ctx context.Context) error, deps ...string) *DAGBuilder {
+	return db.Task(NewTask(id, fn).DependsOn(deps...))
+}
+
+// Build creates a new DAG instance without running it. Duplicate ids,
+// unknown dependencies and cycles are reported here.
+func (db *DAGBuilder) Build() (*DAG, error) {
+	dag := newDAG(db.config)
+	for _, node := range db.nodes {
+		dag.Add(node.id, node.step, node.deps...)
+	}
+	if err := dag.Validate(); err != nil {
+		return nil, err
+	}
+	return dag, nil
+}
+
+// Run creates and runs the DAG flow.
+func (db *DAGBuilder) Run(ctx context.Context) error {
+	dag, err := db.Build()
+	if err != nil {
+		return err
+	}
+	return dag.Run(ctx)
+}
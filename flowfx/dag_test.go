@@ -0,0 +1,151 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// recorder collects the names of the steps that ran, safe for concurrent
+// steps.
+type recorder struct {
+	mu  sync.Mutex
+	ran []string
+}
+
+// step returns a step recording name, then failing with err.
+func (r *recorder) step(name string, err error) Step {
+	return StepFunc(func(context.Context) error {
+		r.mu.Lock()
+		r.ran = append(r.ran, name)
+		r.mu.Unlock()
+		return err
+	})
+}
+
+func (r *recorder) names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.ran)
+}
+
+func TestDAGRun(t *testing.T) {
+	errTest := errors.New("tests failed")
+	tests := []struct {
+		name        string
+		failing     string // Step that fails
+		canceled    bool
+		wantRan     []string // In order for chained steps, sorted within a layer
+		wantErrs    []error
+		wantSkipped []string
+	}{
+		{
+			name:    "success",
+			wantRan: []string{"build", "lint", "test", "deploy"},
+		},
+		{
+			name:        "failing middle node skips its dependents",
+			failing:     "test",
+			wantRan:     []string{"build", "lint", "test"},
+			wantErrs:    []error{errTest, ErrDependencyFailed},
+			wantSkipped: []string{"deploy"},
+		},
+		{
+			name:     "canceled",
+			canceled: true,
+			wantErrs: []error{context.Canceled},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			stepErr := func(name string) error {
+				if name == tt.failing {
+					return errTest
+				}
+				return nil
+			}
+			canceled := func(ctx context.Context) error { return ctx.Err() }
+			build := rec.step("build", stepErr("build"))
+			if tt.canceled {
+				build = StepFunc(canceled)
+			}
+			dag, err := NewDAGBuilder().
+				Name("ci").
+				Step("build", build).
+				Step("lint", rec.step("lint", stepErr("lint")), "build").
+				Step("test", rec.step("test", stepErr("test")), "build").
+				Step("deploy", rec.step("deploy", stepErr("deploy")), "lint", "test").
+				Build()
+			if err != nil {
+				t.Fatalf("Build: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err = dag.Run(ctx)
+
+			ran := rec.names()
+			if len(ran) > 2 {
+				slices.Sort(ran[1:3]) // lint and test run concurrently
+			}
+			if !slices.Equal(ran, tt.wantRan) {
+				t.Errorf("ran %v, want %v", ran, tt.wantRan)
+			}
+			if len(tt.wantErrs) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("expected errors.Is(%v), got %v", want, err)
+				}
+			}
+			var multi *MultiError
+			if len(tt.wantSkipped) > 0 && errors.As(err, &multi) {
+				for _, id := range tt.wantSkipped {
+					if skipped := multi.ByStep(id); len(skipped) != 1 || !errors.Is(skipped[0], ErrDependencyFailed) {
+						t.Errorf("expected %s to be skipped, got %v", id, skipped)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDAGBuildErrors(t *testing.T) {
+	noop := StepFunc(func(context.Context) error { return nil })
+	tests := []struct {
+		name    string
+		builder *DAGBuilder
+		wantErr error
+	}{
+		{"cycle", NewDAGBuilder().Step("a", noop, "c").Step("b", noop, "a").Step("c", noop, "b"), ErrDependencyCycle},
+		{"unknown dependency", NewDAGBuilder().Step("a", noop, "missing"), ErrUnknownDependency},
+		{"duplicate id", NewDAGBuilder().Step("a", noop).Step("a", noop), ErrDuplicateStep},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.builder.Build(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDAGLayers(t *testing.T) {
+	noop := StepFunc(func(context.Context) error { return nil })
+	dag := NewDAG().Add("build", noop).Add("lint", noop, "build").Add("test", noop, "build").Add("deploy", noop, "lint", "test")
+	layers, err := dag.Layers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"build"}, {"lint", "test"}, {"deploy"}}
+	if !slices.EqualFunc(layers, want, slices.Equal[[]string]) {
+		t.Errorf("layers = %v, want %v", layers, want)
+	}
+}
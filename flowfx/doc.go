@@ -25,22 +25,232 @@
 //	par := flowfx.NewParallel().
 //		Add(flowfx.NewTask("Download A", downloadA)).
 //		Add(flowfx.NewTask("Download B", downloadB)).
-//		Add(flowfx.NewTask("Download C", downloadC))
+//		Add(flowfx.NewTask("Download C", downloadC)).
+//		MaxConcurrency(2) // at most two downloads at a time
 //
 //	err := par.Run(ctx)
 //
+//	// Dependency-driven execution
+//	dag, err := flowfx.NewDAGBuilder().
+//		Task(flowfx.NewTask("build", build)).
+//		Task(flowfx.NewTask("lint", lint)).
+//		Task(flowfx.NewTask("test", test).DependsOn("build")).
+//		Task(flowfx.NewTask("package", pkg).DependsOn("test", "lint")).
+//		Build() // reports cycles and unknown dependencies
+//
+//	err = dag.Run(ctx)
+//
+//	// Rollback on failure
+//	saga := flowfx.NewSaga().
+//		AddFunc("Create bucket", createBucket, deleteBucket).
+//		AddFunc("Upload files", upload, removeFiles).
+//		AddTask(flowfx.NewTask("Publish", publish))
+//
+//	var sagaErr *flowfx.SagaError
+//	if errors.As(saga.Run(ctx), &sagaErr) {
+//		fmt.Println(sagaErr.Report)
+//	}
+//
+// # Declarative Branching
+//
+// If and Switch describe branching as part of the flow instead of inside
+// task functions. Both are Flows and Steps, so they nest in other flows:
+//
+//	install := flowfx.Switch(detectPackageManager).
+//		Case("apt", aptInstall).
+//		Case("brew", brewInstall).
+//		Default(flowfx.AsFlow(flowfx.NewTask("Manual install", printInstructions)))
+//
+//	seq.Add(flowfx.If(needsMigration).Then(migrate).Else(skipMigration))
+//	seq.Add(install)
+//
+// # Typed Results
+//
+// Tasks created with NewTypedTask record their result under their label.
+// Later steps of the same flow run, including steps of nested flows, read it
+// with Get instead of sharing variables through closures:
+//
+//	build := flowfx.NewTypedTask("build", compile) // compile returns (BuildArtifact, error)
+//	publish := flowfx.NewTask("publish", func(ctx context.Context) error {
+//		artifact, err := flowfx.Get[BuildArtifact](ctx, "build")
+//		if err != nil {
+//			return err
+//		}
+//		return upload(ctx, artifact)
+//	})
+//
+// # Flow Store
+//
+// Each flow run carries a Store, a concurrency-safe key/value map shared by
+// all of its steps and nested flows. It is the explicit place to pass data
+// between otherwise stateless steps:
+//
+//	flowfx.StoreFrom(ctx).Set("region", "eu-west-1")
+//	region, ok := flowfx.StoreValue[string](ctx, "region")
+//
+// Checkpointed flows save the store with each checkpoint and restore it on
+// resume; values that cannot be encoded as JSON are not persisted.
+//
+// # Subflows
+//
+// Subflow runs a flow as a named step of another flow. Failures inside it
+// are reported as FlowErrors whose Path names every level, and task hooks
+// receive the qualified step name:
+//
+//	checks := flowfx.NewParallel().Add(lint).Add(test)
+//	deploy := flowfx.NewSequence(flowfx.SequenceConfig{Name: "deploy"}).
+//		Add(flowfx.Subflow("checks", checks)).
+//		Add(push)
+//
+//	var flowErr *flowfx.FlowError
+//	if errors.As(deploy.Run(ctx), &flowErr) {
+//		fmt.Println(flowErr.Path()) // deploy/checks/test
+//	}
+//
+// # Middleware
+//
+// Use wraps every step execution of a flow, and of its nested flows, with
+// cross-cutting behavior such as logging, metrics or panic recovery:
+//
+//	seq.Use(flowfx.Recover(), flowfx.Timing(func(info flowfx.StepInfo, d time.Duration, err error) {
+//		metrics.Observe(info.Flow, info.Step, d, err)
+//	}))
+//
+// # Lifecycle Events
+//
+// Hooks only receive a label and an error. Tasks also report structured
+// Events with the qualified step path, attempt number, timing and status,
+// either to a per-task hook (WithOnEvent) or to every task of a run:
+//
+//	ctx = flowfx.WithEvents(ctx, func(ctx context.Context, ev flowfx.Event) {
+//		if ev.Status == flowfx.EventRetrying {
+//			log.Printf("%s: attempt %d failed after %s: %v", ev.Path, ev.Attempt, ev.Duration(), ev.Err)
+//		}
+//	})
+//
+// # Live Tree View
+//
+// TreeView is a runfx Visual that shows the running flow as a tree, with
+// nested flows below the step that runs them, status icons, durations and
+// spinners. It is fed by middleware registered on the outermost flow; steps
+// of the flows passed to NewTreeView are shown as pending until they start:
+//
+//	view := flowfx.NewTreeView(seq)
+//	loop.Mount(view)
+//	err := seq.Use(view.Middleware()).Run(ctx)
+//
+// # Tracing
+//
+// A context created with WithTracer opens a span for every flow run and every
+// step, carrying the flow and step names, the critical flag and the current
+// retry attempt. Tracer mirrors an OpenTelemetry tracer, so a small adapter
+// puts CLI workflows into the same traces as the services they call:
+//
+//	ctx = flowfx.WithTracer(ctx, otelTracer{otel.Tracer("deploy")})
+//	err := seq.Run(ctx)
+//
+// # Metrics
+//
+// RunWithReport returns a FlowReport with the duration, attempts and outcome
+// of every step, including steps of nested flows. WithMetrics streams the
+// same data as Prometheus-style counters to a MetricsExporter:
+//
+//	exporter := flowfx.NewMemoryExporter()
+//	report, err := flowfx.RunWithReport(flowfx.WithMetrics(ctx, exporter), seq)
+//	fmt.Println(report)
+//	exporter.WriteTo(os.Stdout)
+//
+// # Checkpointing
+//
+// Sequences and Scripts accept a Checkpointer that records completed steps
+// under the flow name. Re-running the same flow after a failure or an
+// interruption skips the steps that already finished; a successful run clears
+// the checkpoint. Steps can persist small values with SetCheckpointValue:
+//
+//	seq := flowfx.NewSequenceBuilder().
+//		Name("installer").
+//		Checkpointer(flowfx.NewFileCheckpointer(stateDir)).
+//		Task(download).
+//		Task(extract).
+//		Build()
+//
+// Checkpoints remember what a previous run did. To skip work that is already
+// done regardless of history, e.g. a binary that is already installed, give
+// the step a precondition with SkipIf, or WithSkipIf for a task. Skipped
+// steps succeed and are reported as skipped rather than completed:
+//
+//	install := flowfx.NewTask("install", installGo,
+//		flowfx.WithSkipIf(func(ctx context.Context) bool { return hasBinary("go") }))
+//
+// # Interactive Wizards
+//
+// Wizards can ask questions through a Prompter. Answers are stored in the
+// wizard state under the given key and become the defaults when a step is
+// revisited. A prompter returning ErrBack moves the wizard back one step.
+// formfx.NewFlowPrompter provides terminal prompts; without a TTY, or with
+// NonInteractive set, every question is answered with its default:
+//
+//	wizard := flowfx.New().
+//		Prompter(formfx.NewFlowPrompter(formfx.WithCancelError(flowfx.ErrBack))).
+//		Ask("name", "Project name", "demo").
+//		Choose("license", "License", []string{"MIT", "Apache-2.0"}, 0).
+//		Confirm("git", "Initialize git?", true).
+//		Build()
+//
+// # Approval Gates
+//
+// ApprovalStep pauses a flow until an Approver answers. PromptApprover asks
+// through a Prompter, HTTPApprover posts the request to a callback URL and
+// AutoApprove approves unattended runs. A denial fails the step with
+// ErrApprovalDenied; who answered appears in the FlowReport:
+//
+//	approver := flowfx.Approver(flowfx.PromptApprover{Prompter: formfx.NewFlowPrompter()})
+//	if os.Getenv("CI") != "" {
+//		approver = flowfx.AutoApprove("ci")
+//	}
+//	seq.Add(flowfx.ApprovalStep("Deploy to prod?", flowfx.WithApprover(approver)))
+//
+// # Flow Files
+//
+// LoadFlowSpec reads a declarative JSON flow whose steps run shell commands
+// as a sequence, in parallel or as a DAG; FlowSpec.Build turns it into a Flow.
+// The cmd/flow command runs such files with progress bars, structured logs
+// and meaningful exit codes:
+//
+//	go run github.com/garaekz/tfx/cmd/flow release.json
+//
 // # Advanced Features
 //
 //   - Context-aware cancellation and timeouts
-//   - Retry mechanisms with exponential backoff
-//   - Progress reporting through injectable interfaces
+//   - Retry mechanisms with exponential backoff; Retryable and Fatal classify
+//     errors so only transient failures are retried and fatal ones abort
+//   - Progress reporting through injectable interfaces (ProgressReporter per
+//     task, FlowReporter per flow; progress.NewFlowBars renders one bar per step)
 //   - Conditional branching and wizard-style flows
-//   - Hierarchical tree execution
+//   - Hierarchical tree execution and named subflows
+//   - Dependency graphs (DAG) with topological, maximally parallel execution
+//   - Streaming pipelines whose stages are connected by buffered channels
+//   - Topology export to Graphviz DOT and Mermaid (ExportDOT, ExportMermaid)
+//   - Sagas with reverse-order compensation and rollback reports
+//   - Circuit breakers that short-circuit repeatedly failing steps
+//   - Manual approval gates with injectable approvers
+//   - Delayed and polling steps (After, Every(...).Until(...)) with
+//     iteration caps
+//   - Bounded concurrency and token-bucket rate limiting for Parallel and Sequence
+//   - Task priorities and pluggable schedulers (WithPriority, Scheduler) for
+//     bounded Parallel flows
+//   - Named resource semaphores (WithResources, WithResource) shared by steps
+//     of different groups
+//   - Checkpointing and resume for long-running sequences and scripts
+//   - Skip-if-done preconditions (SkipIf, WithSkipIf) for idempotent re-runs
+//   - External commands (Exec) with streamed output and typed exit errors
+//   - Result caching keyed by idempotency keys (WithCache) to skip repeated
+//     expensive steps
 //   - Non-interactive execution support
 //
 // # Integration
 //
 // FlowFX integrates with external systems through user-defined interfaces.
-// It only imports foundational utilities (terminal, writer, color) and expects
+// It only imports foundational utilities (terminal, writer, color, runfx) and expects
 // users to provide integration points for progress reporting, user input, etc.
 package flowfx
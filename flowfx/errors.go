@@ -30,6 +30,54 @@ var (
 
 	// ErrNotRunning indicates an attempt to stop a runner that's not running
 	ErrNotRunning = errors.New("runner is not running")
+
+	// ErrDependencyCycle indicates the steps of a DAG depend on each other in a loop
+	ErrDependencyCycle = errors.New("dependency cycle detected")
+
+	// ErrUnknownDependency indicates a step depends on an id that was never added
+	ErrUnknownDependency = errors.New("unknown dependency")
+
+	// ErrDuplicateStep indicates two steps were registered under the same id
+	ErrDuplicateStep = errors.New("duplicate step id")
+
+	// ErrBack is returned by a wizard step or Prompter to go back one step
+	ErrBack = errors.New("wizard: go back")
+
+	// ErrCheckpoint indicates flow progress could not be loaded or saved
+	ErrCheckpoint = errors.New("checkpoint failed")
+
+	// ErrDependencyFailed indicates a step was skipped because a dependency failed
+	ErrDependencyFailed = errors.New("skipped: dependency failed")
+
+	// ErrCircuitOpen indicates a step was short-circuited by an open CircuitBreaker
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+
+	// ErrNoResult indicates a step has not produced a typed result
+	ErrNoResult = errors.New("no result for step")
+
+	// ErrResultType indicates a step result has a different type than requested
+	ErrResultType = errors.New("result type mismatch")
+
+	// ErrPanic indicates a step panicked and was recovered by the Recover middleware
+	ErrPanic = errors.New("step panicked")
+
+	// ErrInvalidSpec indicates a declarative flow file is malformed
+	ErrInvalidSpec = errors.New("invalid flow spec")
+
+	// ErrApprovalDenied indicates an approval gate was rejected
+	ErrApprovalDenied = errors.New("approval denied")
+
+	// ErrUnknownResource indicates a step needs a resource the context does not provide
+	ErrUnknownResource = errors.New("unknown resource")
+
+	// ErrMaxIterations indicates a repeating step gave up before its condition held
+	ErrMaxIterations = errors.New("condition not met within max iterations")
+
+	// ErrExitStatus indicates a command exited with a non-zero status, see ExitError
+	ErrExitStatus = errors.New("command exited with non-zero status")
+
+	// ErrCommandNotFound indicates the program of a command could not be found
+	ErrCommandNotFound = errors.New("command not found")
 )
 
 // FlowError represents an error that occurred during flow execution.
@@ -39,6 +87,8 @@ type FlowError struct {
 	Step    string // Name or identifier of the step
 	Err     error  // The underlying error
 	Attempt int    // Which retry attempt failed (0 for first attempt)
+
+	subflow bool // Returned by a Subflow; merged into the parent's error
 }
 
 // Error implements the error interface.
@@ -57,13 +107,35 @@ func (e *FlowError) Unwrap() error {
 	return e.Err
 }
 
+// Path returns the fully-qualified location of the failure, e.g.
+// "deploy/checks/test" for step test of subflow checks in flow deploy.
+func (e *FlowError) Path() string {
+	if e.Step == "" {
+		return e.Flow
+	}
+	return e.Flow + "/" + e.Step
+}
+
 // Is checks if the error matches the target error.
 func (e *FlowError) Is(target error) bool {
 	return errors.Is(e.Err, target)
 }
 
-// NewFlowError creates a new FlowError.
+// NewFlowError creates a new FlowError. When err comes from a Subflow, the
+// two are merged so Step holds the path below flow, e.g. "checks/test".
 func NewFlowError(flow, step string, err error) *FlowError {
+	if sub, ok := err.(*FlowError); ok && sub.subflow {
+		path := sub.Path()
+		if step != "" && step != sub.Flow {
+			path = step + "/" + path
+		}
+		return &FlowError{
+			Flow:    flow,
+			Step:    path,
+			Err:     sub.Err,
+			Attempt: sub.Attempt,
+		}
+	}
 	return &FlowError{
 		Flow: flow,
 		Step: step,
@@ -82,6 +154,8 @@ func NewFlowErrorWithAttempt(flow, step string, err error, attempt int) *FlowErr
 }
 
 // MultiError represents multiple errors that occurred during parallel execution.
+// It follows the Go 1.20 multi-error convention, so errors.Is and errors.As
+// look through every collected error.
 type MultiError struct {
 	Errors []error
 }
@@ -97,12 +171,37 @@ func (m *MultiError) Error() string {
 	return fmt.Sprintf("multiple errors: %d failures", len(m.Errors))
 }
 
-// Unwrap returns the first error for compatibility with errors.Is/As.
-func (m *MultiError) Unwrap() error {
-	if len(m.Errors) == 0 {
-		return nil
+// Unwrap returns the collected errors, so errors.Is and errors.As match any
+// of them.
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// ByStep returns the errors of the step named step. The name is matched
+// against the Step and the Path of each FlowError, so both "test" and
+// "ci/test" select the failure of step test in flow ci.
+func (m *MultiError) ByStep(step string) []error {
+	var matched []error
+	for _, err := range m.Errors {
+		var flowErr *FlowError
+		if errors.As(err, &flowErr) && (flowErr.Step == step || flowErr.Path() == step) {
+			matched = append(matched, err)
+		}
+	}
+	return matched
+}
+
+// Steps returns the names of the failed steps in the order they failed.
+// Errors that do not belong to a step are skipped.
+func (m *MultiError) Steps() []string {
+	var steps []string
+	for _, err := range m.Errors {
+		var flowErr *FlowError
+		if errors.As(err, &flowErr) && flowErr.Step != "" {
+			steps = append(steps, flowErr.Step)
+		}
 	}
-	return m.Errors[0]
+	return steps
 }
 
 // Add appends an error to the MultiError.
@@ -0,0 +1,144 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// statusError is a typed error that tests find with errors.As.
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string { return "unexpected status" }
+
+func TestMultiErrorMatching(t *testing.T) {
+	errLint := errors.New("lint failed")
+	tests := []struct {
+		name      string
+		steps     map[string]error
+		canceled  bool
+		wantNil   bool
+		wantIs    []error
+		wantCode  int // Status code found with errors.As; 0 for none
+		wantSteps []string
+	}{
+		{
+			name:    "success",
+			steps:   map[string]error{"lint": nil, "test": nil},
+			wantNil: true,
+		},
+		{
+			name:      "failures",
+			steps:     map[string]error{"lint": errLint, "test": &statusError{code: 503}},
+			wantIs:    []error{errLint},
+			wantCode:  503,
+			wantSteps: []string{"lint", "test"},
+		},
+		{
+			name:     "canceled",
+			steps:    map[string]error{"lint": nil, "test": nil},
+			canceled: true,
+			wantIs:   []error{ErrCanceled},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewParallelBuilder().Name("ci").MaxConcurrency(1)
+			for _, label := range []string{"lint", "test"} {
+				b.Task(once(label, fail(tt.steps[label])))
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := b.Run(ctx)
+
+			if tt.wantNil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			for _, want := range tt.wantIs {
+				if !errors.Is(err, want) {
+					t.Errorf("errors.Is(%v, %v) = false", err, want)
+				}
+			}
+			var status *statusError
+			if found := errors.As(err, &status); found != (tt.wantCode != 0) || (found && status.code != tt.wantCode) {
+				t.Errorf("errors.As found %v, want code %d", status, tt.wantCode)
+			}
+			var multi *MultiError
+			if tt.wantSteps != nil {
+				if !errors.As(err, &multi) {
+					t.Fatalf("expected a MultiError, got %T", err)
+				}
+				if steps := multi.Steps(); !slices.Equal(steps, tt.wantSteps) {
+					t.Errorf("steps %v, want %v", steps, tt.wantSteps)
+				}
+			}
+		})
+	}
+}
+
+func TestMultiErrorByStep(t *testing.T) {
+	errBuild := errors.New("build failed")
+	errTest := errors.New("tests failed")
+	multi := NewMultiError()
+	multi.Add(NewFlowError("ci", "build", errBuild))
+	multi.Add(NewFlowError("ci", "checks/test", errTest))
+	multi.Add(errors.New("stray"))
+	multi.Add(nil)
+
+	tests := []struct {
+		step string
+		want []error
+	}{
+		{step: "build", want: []error{errBuild}},
+		{step: "ci/build", want: []error{errBuild}},
+		{step: "checks/test", want: []error{errTest}},
+		{step: "ci/checks/test", want: []error{errTest}},
+		{step: "test"},
+		{step: "deploy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.step, func(t *testing.T) {
+			got := multi.ByStep(tt.step)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ByStep(%q) = %v, want %v", tt.step, got, tt.want)
+			}
+			for i, err := range got {
+				if !errors.Is(err, tt.want[i]) {
+					t.Errorf("ByStep(%q)[%d] = %v, want %v", tt.step, i, err, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if got, want := multi.Steps(), []string{"build", "checks/test"}; !slices.Equal(got, want) {
+		t.Errorf("Steps() = %v, want %v", got, want)
+	}
+	if len(multi.Errors) != 3 {
+		t.Errorf("Add kept %d errors, want 3 without the nil", len(multi.Errors))
+	}
+	if got := multi.Error(); got != "multiple errors: 3 failures" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestMultiErrorToError(t *testing.T) {
+	multi := NewMultiError()
+	if err := multi.ToError(); err != nil {
+		t.Fatalf("empty MultiError converted to %v", err)
+	}
+	errOne := errors.New("one")
+	multi.Add(errOne)
+	err := multi.ToError()
+	if !errors.Is(err, errOne) || err.Error() != "one" {
+		t.Errorf("single error MultiError = %v", err)
+	}
+}
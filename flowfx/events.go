@@ -0,0 +1,163 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// EventStatus is the lifecycle stage reported by an Event.
+type EventStatus string
+
+// Event statuses, in the order a task reports them.
+const (
+	EventStarted   EventStatus = "started"   // An attempt started
+	EventRetrying  EventStatus = "retrying"  // An attempt failed and will be retried
+	EventSucceeded EventStatus = "succeeded" // The task finished successfully
+	EventFailed    EventStatus = "failed"    // The task failed for good
+	EventCanceled  EventStatus = "canceled"  // The task was canceled
+	EventSkipped   EventStatus = "skipped"   // The task's SkipIf condition held
+)
+
+// Event describes a point in the lifecycle of a task. Unlike a Hook, it
+// distinguishes retries from first attempts and carries timing.
+type Event struct {
+	Flow    string      // Flow running the task
+	Step    string      // Task label
+	Path    string      // Label qualified by enclosing subflows, e.g. "checks/test"
+	Status  EventStatus // What happened
+	Attempt int         // 1 for the first attempt; 0 when canceled before it
+	Start   time.Time   // Start of the attempt, or of the task for final statuses
+	End     time.Time   // Zero for EventStarted
+	Err     error       // The failure for EventRetrying, EventFailed and EventCanceled
+}
+
+// Duration returns how long the attempt or task took, or zero for
+// EventStarted.
+func (e Event) Duration() time.Duration {
+	if e.End.IsZero() {
+		return 0
+	}
+	return e.End.Sub(e.Start)
+}
+
+// EventHook receives the events of tasks.
+type EventHook func(ctx context.Context, event Event)
+
+// WithOnEvent sets a hook that receives every lifecycle event of the task.
+func WithOnEvent(hook EventHook) TaskOption {
+	return func(t *Task) {
+		t.OnEvent = hook
+	}
+}
+
+// eventHooksKey is the context key for event hooks registered with WithEvents.
+type eventHooksKey struct{}
+
+// WithEvents returns a context whose tasks, including tasks of nested flows,
+// report their lifecycle events to hook.
+func WithEvents(ctx context.Context, hook EventHook) context.Context {
+	hooks, _ := ctx.Value(eventHooksKey{}).([]EventHook)
+	return context.WithValue(ctx, eventHooksKey{}, append(slices.Clip(hooks), hook))
+}
+
+// taskEvents emits the events of one task execution. A nil taskEvents is
+// valid and emits nothing.
+type taskEvents struct {
+	hooks        []EventHook
+	base         Event
+	start        time.Time
+	attemptStart time.Time
+}
+
+// newTaskEvents collects the hooks interested in t, or returns nil when
+// there are none.
+func newTaskEvents(ctx context.Context, t *Task) *taskEvents {
+	hooks, _ := ctx.Value(eventHooksKey{}).([]EventHook)
+	if t.OnEvent != nil {
+		hooks = append(slices.Clip(hooks), t.OnEvent)
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+	now := time.Now()
+	return &taskEvents{
+		hooks: hooks,
+		base: Event{
+			Flow: StepInfoFrom(ctx).Flow,
+			Step: t.Label,
+			Path: QualifiedName(ctx, t.Label),
+		},
+		start:        now,
+		attemptStart: now,
+	}
+}
+
+// emit sends event to every hook.
+func (e *taskEvents) emit(ctx context.Context, event Event) {
+	for _, hook := range e.hooks {
+		hook(ctx, event)
+	}
+}
+
+// started reports the start of attempt.
+func (e *taskEvents) started(ctx context.Context, attempt int) {
+	if e == nil {
+		return
+	}
+	e.attemptStart = time.Now()
+	event := e.base
+	event.Status = EventStarted
+	event.Attempt = attempt
+	event.Start = e.attemptStart
+	e.emit(ctx, event)
+}
+
+// retrying reports that attempt failed with err and will be retried.
+func (e *taskEvents) retrying(ctx context.Context, attempt int, err error) {
+	if e == nil {
+		return
+	}
+	event := e.base
+	event.Status = EventRetrying
+	event.Attempt = attempt
+	event.Start = e.attemptStart
+	event.End = time.Now()
+	event.Err = err
+	e.emit(ctx, event)
+}
+
+// finished reports the outcome of the task after attempt.
+func (e *taskEvents) finished(ctx context.Context, attempt int, err error) {
+	if e == nil {
+		return
+	}
+	event := e.base
+	event.Attempt = attempt
+	event.Start = e.start
+	event.End = time.Now()
+	event.Err = err
+	switch {
+	case err == nil:
+		event.Status = EventSucceeded
+	case errors.Is(err, ErrCanceled) || errors.Is(err, context.Canceled):
+		event.Status = EventCanceled
+	default:
+		event.Status = EventFailed
+	}
+	e.emit(ctx, event)
+}
+
+// skipped reports that the task did not run because its SkipIf condition
+// held.
+func (e *taskEvents) skipped(ctx context.Context) {
+	if e == nil {
+		return
+	}
+	event := e.base
+	event.Status = EventSkipped
+	event.Start = e.start
+	event.End = time.Now()
+	e.emit(ctx, event)
+}
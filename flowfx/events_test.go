@@ -0,0 +1,128 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTaskEvents(t *testing.T) {
+	errFlaky := errors.New("connection reset")
+	tests := []struct {
+		name       string
+		results    []error // Result of each attempt; missing attempts succeed
+		fatal      bool    // Mark the errors Fatal
+		skip       bool
+		cancelAt   int  // Attempt that cancels the context; 0 for none
+		canceled   bool // Cancel before the task starts
+		wantEvents []string
+	}{
+		{
+			name:       "succeeds",
+			wantEvents: []string{"started/1", "succeeded/1"},
+		},
+		{
+			name:       "retried then succeeds",
+			results:    []error{errFlaky},
+			wantEvents: []string{"started/1", "retrying/1", "started/2", "succeeded/2"},
+		},
+		{
+			name:       "retries exhausted",
+			results:    []error{errFlaky, errFlaky, errFlaky},
+			wantEvents: []string{"started/1", "retrying/1", "started/2", "retrying/2", "started/3", "failed/3"},
+		},
+		{
+			name:       "fatal error is not retried",
+			results:    []error{errFlaky},
+			fatal:      true,
+			wantEvents: []string{"started/1", "failed/1"},
+		},
+		{
+			name:       "canceled during an attempt",
+			results:    []error{errFlaky},
+			cancelAt:   1,
+			wantEvents: []string{"started/1", "canceled/1"},
+		},
+		{
+			name:       "canceled before the first attempt",
+			canceled:   true,
+			wantEvents: []string{"canceled/0"},
+		},
+		{
+			name:       "skipped",
+			skip:       true,
+			wantEvents: []string{"skipped/0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.canceled {
+				cancel()
+			}
+
+			var events []Event
+			attempt := 0
+			task := NewTask("fetch", func(ctx context.Context) error {
+				attempt++
+				if attempt == tt.cancelAt {
+					cancel()
+				}
+				if attempt > len(tt.results) {
+					return nil
+				}
+				if tt.fatal {
+					return Fatal(tt.results[attempt-1])
+				}
+				return tt.results[attempt-1]
+			},
+				WithRetry(RetryConfig{MaxAttempts: 3, Delay: time.Millisecond, Backoff: 1}),
+				WithSkipIf(func(context.Context) bool { return tt.skip }),
+				WithOnEvent(func(ctx context.Context, e Event) { events = append(events, e) }),
+			)
+			task.Execute(ctx)
+
+			var got []string
+			for _, e := range events {
+				got = append(got, fmt.Sprintf("%s/%d", e.Status, e.Attempt))
+				if e.Step != "fetch" {
+					t.Errorf("event %s names step %q", e.Status, e.Step)
+				}
+				if e.Status != EventStarted && e.End.Before(e.Start) {
+					t.Errorf("event %s ends before it starts", e.Status)
+				}
+				wantErr := e.Status == EventRetrying || e.Status == EventFailed || e.Status == EventCanceled
+				if (e.Err != nil) != wantErr {
+					t.Errorf("event %s has error %v", e.Status, e.Err)
+				}
+			}
+			if !slices.Equal(got, tt.wantEvents) {
+				t.Errorf("events %v, want %v", got, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestWithEventsInSubflows(t *testing.T) {
+	var paths []string
+	ctx := WithEvents(context.Background(), func(ctx context.Context, e Event) {
+		if e.Status == EventSucceeded {
+			paths = append(paths, e.Flow+" "+e.Path)
+		}
+	})
+	checks := NewSequenceBuilder().Name("checks").Task(once("test", fail(nil))).Build()
+	flow := NewSequenceBuilder().Name("ci").
+		Task(once("build", fail(nil))).
+		Step(Subflow("checks", checks)).
+		Build()
+	if err := flow.Run(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"ci build", "checks checks/test"}; !slices.Equal(paths, want) {
+		t.Errorf("events for %v, want %v", paths, want)
+	}
+}
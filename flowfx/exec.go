@@ -0,0 +1,278 @@
+package flowfx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stream identifies the output stream of a command.
+type Stream string
+
+// Output streams of a command.
+const (
+	Stdout Stream = "stdout"
+	Stderr Stream = "stderr"
+)
+
+// OutputSink receives the output of an ExecTask one line at a time, without
+// the trailing newline. Calls are serialized.
+type OutputSink func(stream Stream, line string)
+
+// WriterSink returns a sink that writes stdout lines to stdout and stderr
+// lines to stderr. Pass the same writer twice, e.g. a runfx.LogPane, to
+// interleave both streams.
+func WriterSink(stdout, stderr io.Writer) OutputSink {
+	return func(stream Stream, line string) {
+		w := stdout
+		if stream == Stderr {
+			w = stderr
+		}
+		io.WriteString(w, line+"\n")
+	}
+}
+
+// LogSink returns a sink that logs stdout lines with stdout and stderr lines
+// with stderr, e.g. LogSink(logger.Info, logger.Warn) for a logfx.Logger.
+func LogSink(stdout, stderr func(msg string)) OutputSink {
+	return func(stream Stream, line string) {
+		if stream == Stderr {
+			stderr(line)
+			return
+		}
+		stdout(line)
+	}
+}
+
+// stderrTailLines is the number of stderr lines kept for an ExitError.
+const stderrTailLines = 10
+
+// ExitError reports that a command exited with a non-zero status. It matches
+// ErrExitStatus with errors.Is, and the error mapped to Code with
+// ExecTask.ExitCode when there is one.
+type ExitError struct {
+	Command string // Command line
+	Code    int    // Exit status
+	Stderr  string // Last lines written to stderr
+	Err     error  // Error mapped to Code, or nil
+}
+
+// Error implements the error interface.
+func (e *ExitError) Error() string {
+	msg := fmt.Sprintf("%s: exit status %d", e.Command, e.Code)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns ErrExitStatus and the mapped error.
+func (e *ExitError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrExitStatus}
+	}
+	return []error{ErrExitStatus, e.Err}
+}
+
+// ExecTask runs an external program without a shell. It implements both Flow
+// and Step and is built with Exec:
+//
+//	build := flowfx.Exec("go", "build", "./...").
+//		Dir(repo).
+//		Env("CGO_ENABLED", "0").
+//		Timeout(5 * time.Minute).
+//		Output(flowfx.LogSink(logger.Info, logger.Warn)).
+//		ExitCode(2, flowfx.Fatal(errBadFlags))
+//
+// Output is streamed line by line to the sink, which defaults to os.Stdout
+// and os.Stderr. A non-zero exit status fails with an *ExitError, and a
+// missing program with ErrCommandNotFound marked Fatal. Use CommandStep to
+// run a shell command line instead.
+type ExecTask struct {
+	name      string
+	args      []string
+	label     string
+	dir       string
+	env       map[string]string
+	timeout   time.Duration
+	sink      OutputSink
+	exitCodes map[int]error
+}
+
+// Exec returns a step that runs the program name with args.
+func Exec(name string, args ...string) *ExecTask {
+	return &ExecTask{name: name, args: args}
+}
+
+// Named sets the label of the step. It defaults to the program name.
+func (e *ExecTask) Named(label string) *ExecTask {
+	e.label = label
+	return e
+}
+
+// Dir sets the working directory of the command. An empty directory means
+// the current one.
+func (e *ExecTask) Dir(dir string) *ExecTask {
+	e.dir = dir
+	return e
+}
+
+// Env adds a variable to the environment the command inherits.
+func (e *ExecTask) Env(key, value string) *ExecTask {
+	if e.env == nil {
+		e.env = make(map[string]string)
+	}
+	e.env[key] = value
+	return e
+}
+
+// Timeout bounds each run of the command. When it expires the process is
+// killed and the step fails with ErrTimeout.
+func (e *ExecTask) Timeout(d time.Duration) *ExecTask {
+	e.timeout = d
+	return e
+}
+
+// Output sets the sink that receives the output of the command.
+func (e *ExecTask) Output(sink OutputSink) *ExecTask {
+	e.sink = sink
+	return e
+}
+
+// ExitCode maps an exit status to err, which the resulting ExitError wraps.
+// Mark err with Fatal or Retryable to control retries.
+func (e *ExecTask) ExitCode(code int, err error) *ExecTask {
+	if e.exitCodes == nil {
+		e.exitCodes = make(map[int]error)
+	}
+	e.exitCodes[code] = err
+	return e
+}
+
+// Label returns the label of the step.
+func (e *ExecTask) Label() string {
+	if e.label != "" {
+		return e.label
+	}
+	return e.name
+}
+
+// String returns the command line.
+func (e *ExecTask) String() string {
+	return strings.Join(append([]string{e.name}, e.args...), " ")
+}
+
+// Execute implements the Step interface.
+func (e *ExecTask) Execute(ctx context.Context) error {
+	runCtx := ctx
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(runCtx, e.name, e.args...)
+	cmd.Dir = e.dir
+	// Do not wait forever for output of processes the command left behind
+	cmd.WaitDelay = time.Second
+	if len(e.env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range e.env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+
+	sink := e.sink
+	if sink == nil {
+		sink = WriterSink(os.Stdout, os.Stderr)
+	}
+	var mu sync.Mutex
+	stdout := &lineWriter{stream: Stdout, sink: sink, mu: &mu}
+	stderr := &lineWriter{stream: Stderr, sink: sink, mu: &mu}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	stdout.flush()
+	stderr.flush()
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, exec.ErrNotFound):
+		return Fatal(fmt.Errorf("%w: %s", ErrCommandNotFound, e.name))
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case runCtx.Err() != nil:
+		return fmt.Errorf("%s: %w after %s", e, ErrTimeout, e.timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		code := exitErr.ExitCode()
+		return &ExitError{
+			Command: e.String(),
+			Code:    code,
+			Stderr:  strings.Join(stderr.tail, "\n"),
+			Err:     e.exitCodes[code],
+		}
+	}
+	return fmt.Errorf("%s: %w", e, err)
+}
+
+// Run implements the Flow interface.
+func (e *ExecTask) Run(ctx context.Context) error {
+	return e.Execute(ctx)
+}
+
+// lineWriter splits the output of a command into lines for a sink. The
+// writers of one command share mu so the sink sees whole lines in order.
+type lineWriter struct {
+	stream  Stream
+	sink    OutputSink
+	mu      *sync.Mutex
+	partial []byte
+	tail    []string // Last lines, kept for stderr
+}
+
+// Write implements io.Writer.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(string(bytes.TrimRight(w.partial[:i], "\r")))
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush emits a trailing line without newline.
+func (w *lineWriter) flush() {
+	if len(w.partial) > 0 {
+		w.emit(string(w.partial))
+		w.partial = nil
+	}
+}
+
+// emit sends line to the sink.
+func (w *lineWriter) emit(line string) {
+	w.mu.Lock()
+	w.sink(w.stream, line)
+	w.mu.Unlock()
+	if w.stream == Stderr {
+		w.tail = append(w.tail, line)
+		if len(w.tail) > stderrTailLines {
+			w.tail = w.tail[1:]
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package flowfx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// requireShell skips tests that need a POSIX shell.
+func requireShell(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+}
+
+func TestExecTask(t *testing.T) {
+	requireShell(t)
+	errUsage := errors.New("bad usage")
+	dir := t.TempDir()
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		task      *ExecTask
+		canceled  bool
+		wantLines []string
+		wantErr   error
+		wantCode  int // Exit status of the ExitError; 0 for none
+		wantFatal bool
+	}{
+		{
+			name:      "streams output with env and dir",
+			task:      Exec("sh", "-c", `echo "$GREETING"; pwd -P; printf 'partial' >&2`).Env("GREETING", "hello").Dir(dir),
+			wantLines: []string{"stdout: hello", "stdout: " + realDir, "stderr: partial"},
+		},
+		{
+			name:      "mapped exit code",
+			task:      Exec("sh", "-c", "echo 'unknown flag' >&2; exit 2").ExitCode(2, Fatal(errUsage)),
+			wantLines: []string{"stderr: unknown flag"},
+			wantErr:   errUsage,
+			wantCode:  2,
+			wantFatal: true,
+		},
+		{
+			name:     "unmapped exit code",
+			task:     Exec("sh", "-c", "exit 3").ExitCode(2, errUsage),
+			wantErr:  ErrExitStatus,
+			wantCode: 3,
+		},
+		{
+			name:    "timeout",
+			task:    Exec("sleep", "5").Timeout(50 * time.Millisecond),
+			wantErr: ErrTimeout,
+		},
+		{
+			name:     "canceled",
+			task:     Exec("sleep", "5"),
+			canceled: true,
+			wantErr:  context.Canceled,
+		},
+		{
+			name:      "missing binary",
+			task:      Exec("tfx-no-such-binary"),
+			wantErr:   ErrCommandNotFound,
+			wantFatal: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			tt.task.Output(func(stream Stream, line string) {
+				lines = append(lines, string(stream)+": "+line)
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := tt.task.Run(ctx)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantLines != nil && !slices.Equal(lines, tt.wantLines) {
+				t.Errorf("output %q, want %q", lines, tt.wantLines)
+			}
+			var exitErr *ExitError
+			if found := errors.As(err, &exitErr); found != (tt.wantCode != 0) || (found && exitErr.Code != tt.wantCode) {
+				t.Errorf("expected exit status %d, got %v", tt.wantCode, err)
+			}
+			if IsFatal(err) != tt.wantFatal {
+				t.Errorf("IsFatal(%v) = %v, want %v", err, IsFatal(err), tt.wantFatal)
+			}
+		})
+	}
+}
+
+func TestExitErrorKeepsStderrTail(t *testing.T) {
+	requireShell(t)
+	err := Exec("sh", "-c", "for i in $(seq 1 15); do echo line$i >&2; done; exit 1").
+		Output(func(Stream, string) {}).
+		Run(context.Background())
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected an ExitError, got %v", err)
+	}
+	if want := "line6\nline7\nline8\nline9\nline10\nline11\nline12\nline13\nline14\nline15"; exitErr.Stderr != want {
+		t.Errorf("stderr tail %q, want %q", exitErr.Stderr, want)
+	}
+}
+
+func TestCommandStep(t *testing.T) {
+	requireShell(t)
+	tests := []struct {
+		name       string
+		command    string
+		canceled   bool
+		wantStdout string
+		wantErr    bool
+	}{
+		{name: "success", command: `echo "$NAME"`, wantStdout: "tfx\n"},
+		{name: "failure", command: "exit 4", wantErr: true},
+		{name: "canceled", command: "sleep 5", canceled: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			step := &CommandStep{Command: tt.command, Env: map[string]string{"NAME": "tfx"}, Stdout: &stdout, Stderr: &bytes.Buffer{}}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := step.Execute(ctx)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout %q, want %q", stdout.String(), tt.wantStdout)
+			}
+		})
+	}
+}
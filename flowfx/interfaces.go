@@ -86,6 +86,11 @@ type RetryConfig struct {
 	MaxAttempts int
 	Delay       time.Duration
 	Backoff     float64 // Multiplier for exponential backoff
+
+	// ShouldRetry decides whether a failed attempt is retried. Nil retries
+	// every error; use IsRetryable to retry only errors marked Retryable.
+	// Errors marked Fatal are never retried.
+	ShouldRetry func(err error) bool
 }
 
 // DefaultRetryConfig returns a sensible default retry configuration.
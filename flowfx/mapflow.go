@@ -18,6 +18,7 @@ type MapFlow struct {
 	onStart    Hook
 	onComplete Hook
 	onError    Hook
+	middleware []Middleware
 	config     map[string]any // Configuration data
 }
 
@@ -27,6 +28,7 @@ type MapFlowConfig struct {
 	OnStart    Hook
 	OnComplete Hook
 	OnError    Hook
+	Middleware []Middleware   // Wraps every step execution, see Use
 	Config     map[string]any // Initial configuration
 }
 
@@ -52,6 +54,7 @@ func newMapFlow(cfg MapFlowConfig) *MapFlow {
 		onStart:    cfg.OnStart,
 		onComplete: cfg.OnComplete,
 		onError:    cfg.OnError,
+		middleware: cfg.Middleware,
 		config:     config,
 	}
 }
@@ -115,11 +118,19 @@ func (mf *MapFlow) GetConfig(key string) (any, bool) {
 
 // Run executes steps according to the configured order.
 // It implements the Flow interface.
-func (mf *MapFlow) Run(ctx context.Context) error {
+func (mf *MapFlow) Run(ctx context.Context) (err error) {
 	if len(mf.steps) == 0 {
 		return NewFlowError(mf.name, "", ErrEmptyFlow)
 	}
 
+	// Share the run's store and typed results with nested flows and steps
+	ctx = withFlowState(ctx)
+	ctx = withMiddleware(ctx, mf.middleware)
+
+	// Trace the flow run when a Tracer is configured
+	ctx, span := startFlowSpan(ctx, mf.name)
+	defer func() { span.finish(err) }()
+
 	// Call onStart hook if provided
 	if mf.onStart != nil {
 		mf.onStart(ctx, mf.name, nil)
@@ -148,7 +159,7 @@ func (mf *MapFlow) Run(ctx context.Context) error {
 		}
 
 		// Execute the step
-		if err := step.Execute(ctx); err != nil {
+		if err := execStep(ctx, StepInfo{Flow: mf.name, Step: key, Critical: true}, step); err != nil {
 			flowErr := NewFlowError(mf.name, key, err)
 			if mf.onError != nil {
 				mf.onError(ctx, mf.name, flowErr)
@@ -225,6 +236,13 @@ func (mf *MapFlow) FromJSON(data []byte) error {
 	return nil
 }
 
+// Use adds middleware that wraps every step execution of the flow,
+// including steps of nested flows.
+func (mf *MapFlow) Use(mws ...Middleware) *MapFlow {
+	mf.middleware = append(mf.middleware, mws...)
+	return mf
+}
+
 // --- DSL BUILDER ---
 
 // MapFlowBuilder provides a fluent API for building map flows.
@@ -258,6 +276,12 @@ func (mfb *MapFlowBuilder) OnError(hook Hook) *MapFlowBuilder {
 	return mfb
 }
 
+// Use adds middleware that wraps every step execution.
+func (mfb *MapFlowBuilder) Use(mws ...Middleware) *MapFlowBuilder {
+	mfb.config.Middleware = append(mfb.config.Middleware, mws...)
+	return mfb
+}
+
 // Config sets the initial configuration.
 func (mfb *MapFlowBuilder) Config(config map[string]any) *MapFlowBuilder {
 	mfb.config.Config = config
@@ -0,0 +1,309 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Outcome is the result of a step execution.
+type Outcome string
+
+// Step outcomes.
+const (
+	OutcomeSucceeded Outcome = "succeeded"
+	OutcomeFailed    Outcome = "failed"
+	OutcomeCanceled  Outcome = "canceled"
+	OutcomeSkipped   Outcome = "skipped" // A SkipIf condition held
+)
+
+// StepMetrics describes a single step execution.
+type StepMetrics struct {
+	Flow     string
+	Step     string
+	Start    time.Time
+	Duration time.Duration
+	Attempts int // Executions including retries; 1 for steps without retries
+	Outcome  Outcome
+	Err      error
+	Approval *Approval // Answer of an approval gate; nil for other steps
+	Cached   bool      // The step was skipped because its result was cached
+}
+
+// Retries returns how often the step was retried.
+func (m StepMetrics) Retries() int {
+	return max(m.Attempts-1, 0)
+}
+
+// FlowReport collects the metrics of a flow run, including the steps of
+// nested flows.
+type FlowReport struct {
+	Start    time.Time
+	Duration time.Duration
+	Steps    []StepMetrics // In completion order
+	Err      error
+}
+
+// Count returns how many steps ended with outcome.
+func (r *FlowReport) Count(outcome Outcome) int {
+	n := 0
+	for _, s := range r.Steps {
+		if s.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+// Retries returns the total number of retries across all steps.
+func (r *FlowReport) Retries() int {
+	n := 0
+	for _, s := range r.Steps {
+		n += s.Retries()
+	}
+	return n
+}
+
+// String returns a human-readable summary, one line per step.
+func (r *FlowReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d steps in %s: %d succeeded, %d failed, %d retries",
+		len(r.Steps), r.Duration.Round(time.Millisecond),
+		r.Count(OutcomeSucceeded), r.Count(OutcomeFailed), r.Retries())
+	if skipped := r.Count(OutcomeSkipped); skipped > 0 {
+		fmt.Fprintf(&b, ", %d skipped", skipped)
+	}
+	for _, s := range r.Steps {
+		fmt.Fprintf(&b, "\n  %s/%s %s in %s", s.Flow, s.Step, s.Outcome, s.Duration.Round(time.Millisecond))
+		if s.Attempts > 1 {
+			fmt.Fprintf(&b, " (%d attempts)", s.Attempts)
+		}
+		if s.Cached {
+			b.WriteString(" (cached)")
+		}
+		if s.Approval != nil {
+			verdict := "approved"
+			if !s.Approval.Approved {
+				verdict = "denied"
+			}
+			fmt.Fprintf(&b, " (%s by %s)", verdict, s.Approval.Approver)
+		}
+	}
+	return b.String()
+}
+
+// RunWithReport runs flow and returns the metrics of every step it executed.
+func RunWithReport(ctx context.Context, flow Flow) (*FlowReport, error) {
+	report := &FlowReport{Start: time.Now()}
+	ctx = withMetricsSink(ctx, &metricsSink{report: report})
+	err := flow.Run(ctx)
+	report.Duration = time.Since(report.Start)
+	report.Err = err
+	return report, err
+}
+
+// MetricsExporter receives Prometheus-style metrics as steps finish.
+// Labels are flow, step and, for counters of executions, outcome.
+type MetricsExporter interface {
+	// AddCounter increments a monotonically increasing counter.
+	AddCounter(name string, labels map[string]string, delta float64)
+	// ObserveDuration records a duration sample, e.g. into a histogram.
+	ObserveDuration(name string, labels map[string]string, d time.Duration)
+}
+
+// Metric names reported to a MetricsExporter.
+const (
+	MetricStepsTotal   = "flowfx_steps_total"
+	MetricRetriesTotal = "flowfx_step_retries_total"
+	MetricStepDuration = "flowfx_step_duration_seconds"
+)
+
+// WithMetrics returns a context that exports the metrics of every step run
+// with it to exporter.
+func WithMetrics(ctx context.Context, exporter MetricsExporter) context.Context {
+	return withMetricsSink(ctx, &metricsSink{exporter: exporter})
+}
+
+// MemoryExporter is a MetricsExporter that keeps counters and duration sums
+// in memory and writes them in the Prometheus text format.
+type MemoryExporter struct {
+	mu     sync.Mutex
+	values map[string]float64 // Keyed by name{labels}
+}
+
+// NewMemoryExporter creates an empty MemoryExporter.
+func NewMemoryExporter() *MemoryExporter {
+	return &MemoryExporter{values: make(map[string]float64)}
+}
+
+// AddCounter implements MetricsExporter.
+func (e *MemoryExporter) AddCounter(name string, labels map[string]string, delta float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.values[seriesName(name, labels)] += delta
+}
+
+// ObserveDuration implements MetricsExporter by tracking the sum and count
+// of the observed durations in seconds.
+func (e *MemoryExporter) ObserveDuration(name string, labels map[string]string, d time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.values[seriesName(name+"_sum", labels)] += d.Seconds()
+	e.values[seriesName(name+"_count", labels)]++
+}
+
+// Value returns the current value of a series.
+func (e *MemoryExporter) Value(name string, labels map[string]string) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.values[seriesName(name, labels)]
+}
+
+// WriteTo writes all series in the Prometheus text exposition format.
+func (e *MemoryExporter) WriteTo(w io.Writer) (int64, error) {
+	e.mu.Lock()
+	series := slices.Sorted(maps.Keys(e.values))
+	var b strings.Builder
+	for _, s := range series {
+		fmt.Fprintf(&b, "%s %g\n", s, e.values[s])
+	}
+	e.mu.Unlock()
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// seriesName formats name with its labels sorted by key.
+func seriesName(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// metricsSink receives step metrics for a report, an exporter, or both.
+type metricsSink struct {
+	mu       sync.Mutex
+	report   *FlowReport
+	exporter MetricsExporter
+}
+
+// record adds m to the sink.
+func (s *metricsSink) record(m StepMetrics) {
+	if s.report != nil {
+		s.mu.Lock()
+		s.report.Steps = append(s.report.Steps, m)
+		s.mu.Unlock()
+	}
+	if s.exporter != nil {
+		labels := map[string]string{"flow": m.Flow, "step": m.Step}
+		s.exporter.ObserveDuration(MetricStepDuration, labels, m.Duration)
+		if m.Retries() > 0 {
+			s.exporter.AddCounter(MetricRetriesTotal, labels, float64(m.Retries()))
+		}
+		labels["outcome"] = string(m.Outcome)
+		s.exporter.AddCounter(MetricStepsTotal, labels, 1)
+	}
+}
+
+// metricsKey and stepRecordKey are the context keys for the metric sinks
+// and the record of the running step.
+type (
+	metricsKey    struct{}
+	stepRecordKey struct{}
+)
+
+// withMetricsSink adds sink to the sinks inherited from ctx.
+func withMetricsSink(ctx context.Context, sink *metricsSink) context.Context {
+	inherited, _ := ctx.Value(metricsKey{}).([]*metricsSink)
+	sinks := append(slices.Clip(inherited), sink)
+	return context.WithValue(ctx, metricsKey{}, sinks)
+}
+
+// stepRecord tracks the running step so attempts can be counted.
+type stepRecord struct {
+	mu       sync.Mutex
+	attempts int
+	approval *Approval
+	cached   bool
+}
+
+// startStepMetrics prepares the record of a step when ctx collects metrics.
+// The returned function reports the outcome.
+func startStepMetrics(ctx context.Context, info StepInfo) (context.Context, func(err error)) {
+	sinks, _ := ctx.Value(metricsKey{}).([]*metricsSink)
+	if len(sinks) == 0 {
+		return ctx, func(error) {}
+	}
+
+	rec := &stepRecord{}
+	start := time.Now()
+	ctx = context.WithValue(ctx, stepRecordKey{}, rec)
+	return ctx, func(err error) {
+		rec.mu.Lock()
+		attempts := max(rec.attempts, 1)
+		approval, cached := rec.approval, rec.cached
+		rec.mu.Unlock()
+
+		m := StepMetrics{
+			Flow:     info.Flow,
+			Step:     info.Step,
+			Start:    start,
+			Duration: time.Since(start),
+			Attempts: attempts,
+			Outcome:  OutcomeSucceeded,
+			Err:      err,
+			Approval: approval,
+			Cached:   cached,
+		}
+		switch {
+		case err == nil && StepSkipped(ctx):
+			m.Outcome = OutcomeSkipped
+		case err == nil:
+		case errors.Is(err, ErrCanceled) || errors.Is(err, context.Canceled):
+			m.Outcome = OutcomeCanceled
+		default:
+			m.Outcome = OutcomeFailed
+		}
+		for _, sink := range sinks {
+			sink.record(m)
+		}
+	}
+}
+
+// countAttempt records that the running step started attempt.
+func countAttempt(ctx context.Context, attempt int) {
+	if rec, ok := ctx.Value(stepRecordKey{}).(*stepRecord); ok {
+		rec.mu.Lock()
+		rec.attempts = max(rec.attempts, attempt)
+		rec.mu.Unlock()
+	}
+}
+
+// recordApproval attaches the answer of an approval gate to the running step.
+func recordApproval(ctx context.Context, approval Approval) {
+	if rec, ok := ctx.Value(stepRecordKey{}).(*stepRecord); ok {
+		rec.mu.Lock()
+		rec.approval = &approval
+		rec.mu.Unlock()
+	}
+}
+
+// recordCacheHit marks the running step as served from a cache.
+func recordCacheHit(ctx context.Context) {
+	if rec, ok := ctx.Value(stepRecordKey{}).(*stepRecord); ok {
+		rec.mu.Lock()
+		rec.cached = true
+		rec.mu.Unlock()
+	}
+}
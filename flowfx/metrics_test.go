@@ -0,0 +1,96 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunWithReportAndExporter(t *testing.T) {
+	errTest := errors.New("flaky test")
+	errDeploy := errors.New("deploy failed")
+	retry := WithRetry(RetryConfig{MaxAttempts: 2, Delay: time.Millisecond, Backoff: 1})
+	attempts := 0
+
+	exporter := NewMemoryExporter()
+	flow := NewSequenceBuilder().Name("ci").
+		Task(once("build", fail(nil))).
+		Task(NewTask("test", func(context.Context) error {
+			if attempts++; attempts == 1 {
+				return errTest
+			}
+			return nil
+		}, retry)).
+		Task(NewTask("deploy", fail(errDeploy), retry)).
+		Build()
+	report, err := RunWithReport(WithMetrics(context.Background(), exporter), flow)
+	if !errors.Is(err, errDeploy) {
+		t.Fatalf("expected %v, got %v", errDeploy, err)
+	}
+
+	if report.Err != err || report.Duration <= 0 {
+		t.Errorf("report error %v, duration %s", report.Err, report.Duration)
+	}
+	want := []struct {
+		step     string
+		outcome  Outcome
+		attempts int
+	}{
+		{"build", OutcomeSucceeded, 1},
+		{"test", OutcomeSucceeded, 2},
+		{"deploy", OutcomeFailed, 2},
+	}
+	if len(report.Steps) != len(want) {
+		t.Fatalf("report has %d steps, want %d: %+v", len(report.Steps), len(want), report.Steps)
+	}
+	for i, w := range want {
+		s := report.Steps[i]
+		if s.Flow != "ci" || s.Step != w.step || s.Outcome != w.outcome || s.Attempts != w.attempts {
+			t.Errorf("step %d = %s/%s %s after %d attempts, want ci/%s %s after %d",
+				i, s.Flow, s.Step, s.Outcome, s.Attempts, w.step, w.outcome, w.attempts)
+		}
+	}
+	if s := report.Steps[2]; !errors.Is(s.Err, errDeploy) {
+		t.Errorf("deploy error = %v, want %v", s.Err, errDeploy)
+	}
+	if got := report.Count(OutcomeSucceeded); got != 2 {
+		t.Errorf("succeeded = %d, want 2", got)
+	}
+	if got := report.Count(OutcomeFailed); got != 1 {
+		t.Errorf("failed = %d, want 1", got)
+	}
+	if got := report.Retries(); got != 2 {
+		t.Errorf("retries = %d, want 2", got)
+	}
+
+	var out strings.Builder
+	if _, err := exporter.WriteTo(&out); err != nil {
+		t.Fatal(err)
+	}
+	var series []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		// Duration sums vary from run to run
+		if !strings.Contains(line, "_sum{") {
+			series = append(series, line)
+		}
+	}
+	wantSeries := []string{
+		`flowfx_step_duration_seconds_count{flow="ci",step="build"} 1`,
+		`flowfx_step_duration_seconds_count{flow="ci",step="deploy"} 1`,
+		`flowfx_step_duration_seconds_count{flow="ci",step="test"} 1`,
+		`flowfx_step_retries_total{flow="ci",step="deploy"} 1`,
+		`flowfx_step_retries_total{flow="ci",step="test"} 1`,
+		`flowfx_steps_total{flow="ci",outcome="failed",step="deploy"} 1`,
+		`flowfx_steps_total{flow="ci",outcome="succeeded",step="build"} 1`,
+		`flowfx_steps_total{flow="ci",outcome="succeeded",step="test"} 1`,
+	}
+	if strings.Join(series, "\n") != strings.Join(wantSeries, "\n") {
+		t.Errorf("exported\n%s\nwant\n%s", strings.Join(series, "\n"), strings.Join(wantSeries, "\n"))
+	}
+	labels := map[string]string{"flow": "ci", "step": "deploy"}
+	if got := exporter.Value(MetricStepDuration+"_sum", labels); got <= 0 {
+		t.Errorf("deploy duration sum = %g, want > 0", got)
+	}
+}
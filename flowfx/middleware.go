@@ -0,0 +1,150 @@
+package flowfx
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+// StepInfo identifies the step being executed.
+type StepInfo struct {
+	Flow     string // Name of the flow running the step
+	Step     string // Step label, id or positional name
+	Critical bool   // Whether a failure of the step fails the flow
+}
+
+// Middleware wraps every step execution of a flow, like HTTP middleware
+// wraps handlers. It receives the next handler in the chain and returns a
+// handler that usually calls it:
+//
+//	logging := func(next flowfx.StepFunc) flowfx.StepFunc {
+//		return func(ctx context.Context) error {
+//			info := flowfx.StepInfoFrom(ctx)
+//			log.Printf("%s/%s started", info.Flow, info.Step)
+//			return next(ctx)
+//		}
+//	}
+//	seq.Use(logging, flowfx.Recover())
+//
+// Middleware registered on a flow also wraps the steps of its nested flows.
+// The first middleware is the outermost.
+type Middleware func(next StepFunc) StepFunc
+
+// stepInfoKey is the context key for the StepInfo of the running step.
+type stepInfoKey struct{}
+
+// middlewareKey is the context key for the middleware chain of a flow run.
+type middlewareKey struct{}
+
+// stepStatusKey is the context key for the status of the running step.
+type stepStatusKey struct{}
+
+// stepStatus records how the running step ended besides its error.
+type stepStatus struct {
+	skipped atomic.Bool
+}
+
+// markSkipped records that the running step skipped its work because a
+// precondition held.
+func markSkipped(ctx context.Context) {
+	if status, ok := ctx.Value(stepStatusKey{}).(*stepStatus); ok {
+		status.skipped.Store(true)
+	}
+}
+
+// StepSkipped reports whether the running step was skipped by its SkipIf
+// condition. Middleware calls it after the step returned to tell skipped
+// steps from completed ones.
+func StepSkipped(ctx context.Context) bool {
+	status, ok := ctx.Value(stepStatusKey{}).(*stepStatus)
+	return ok && status.skipped.Load()
+}
+
+// StepInfoFrom returns the step being executed. It is set for the whole
+// middleware chain and the step itself.
+func StepInfoFrom(ctx context.Context) StepInfo {
+	info, _ := ctx.Value(stepInfoKey{}).(StepInfo)
+	return info
+}
+
+// withMiddleware appends mws to the chain inherited from enclosing flows.
+func withMiddleware(ctx context.Context, mws []Middleware) context.Context {
+	if len(mws) == 0 {
+		return ctx
+	}
+	inherited, _ := ctx.Value(middlewareKey{}).([]Middleware)
+	chain := make([]Middleware, 0, len(inherited)+len(mws))
+	chain = append(chain, inherited...)
+	chain = append(chain, mws...)
+	return context.WithValue(ctx, middlewareKey{}, chain)
+}
+
+// execStep executes step through the middleware chain of ctx, inside a
+// trace span when a Tracer is configured and recording metrics when ctx
+// collects them.
+func execStep(ctx context.Context, info StepInfo, step Step) (err error) {
+	ctx, span := startSpan(ctx, info.Flow+"/"+info.Step,
+		Attribute{Key: AttrFlow, Value: info.Flow},
+		Attribute{Key: AttrStep, Value: info.Step},
+		Attribute{Key: AttrCritical, Value: info.Critical},
+	)
+	defer func() { span.finish(err) }()
+
+	ctx = context.WithValue(ctx, stepStatusKey{}, &stepStatus{})
+	defer func() {
+		if StepSkipped(ctx) {
+			traceSkipped(ctx)
+		}
+	}()
+
+	ctx, done := startStepMetrics(ctx, info)
+	defer func() { done(err) }()
+
+	ctx = context.WithValue(ctx, stepInfoKey{}, info)
+	chain, _ := ctx.Value(middlewareKey{}).([]Middleware)
+	if len(chain) == 0 {
+		return step.Execute(ctx)
+	}
+
+	handler := StepFunc(step.Execute)
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i](handler)
+	}
+	return handler(ctx)
+}
+
+// startAttempt records that the running step started attempt, counting
+// from 1, for tracing and metrics.
+func startAttempt(ctx context.Context, attempt int) {
+	traceAttempt(ctx, attempt)
+	countAttempt(ctx, attempt)
+}
+
+// Recover returns middleware that turns a panicking step into an error
+// wrapping ErrPanic, so one faulty step cannot crash the whole program.
+func Recover() Middleware {
+	return func(next StepFunc) StepFunc {
+		return func(ctx context.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%w: %v\n%s", ErrPanic, r, debug.Stack())
+				}
+			}()
+			return next(ctx)
+		}
+	}
+}
+
+// Timing returns middleware that reports how long each step took.
+func Timing(record func(info StepInfo, elapsed time.Duration, err error)) Middleware {
+	return func(next StepFunc) StepFunc {
+		return func(ctx context.Context) error {
+			start := time.Now()
+			err := next(ctx)
+			record(StepInfoFrom(ctx), time.Since(start), err)
+			return err
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tracing returns middleware that records entering and leaving each step
+// under name.
+func tracing(name string, log *[]string) Middleware {
+	return func(next StepFunc) StepFunc {
+		return func(ctx context.Context) error {
+			step := StepInfoFrom(ctx).Step
+			*log = append(*log, name+">"+step)
+			err := next(ctx)
+			*log = append(*log, "<"+name+" "+step)
+			return err
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var log []string
+	err := NewSequenceBuilder().Name("ci").
+		Use(tracing("outer", &log), tracing("inner", &log)).
+		Task(once("build", func(context.Context) error { log = append(log, "build"); return nil })).
+		Run(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"outer>build", "inner>build", "build", "<inner build", "<outer build"}
+	if !slices.Equal(log, want) {
+		t.Errorf("calls %v, want %v", log, want)
+	}
+}
+
+func TestMiddlewareInheritedBySubflows(t *testing.T) {
+	var log []string
+	checks := NewSequenceBuilder().Name("checks").
+		Use(tracing("checks", &log)).
+		Task(once("test", fail(nil))).
+		Build()
+	err := NewSequenceBuilder().Name("ci").
+		Use(tracing("ci", &log)).
+		Step(Subflow("checks", checks)).
+		Run(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"ci>checks",
+		"ci>test", "checks>test", "<checks test", "<ci test",
+		"<ci checks",
+	}
+	if !slices.Equal(log, want) {
+		t.Errorf("calls %v, want %v", log, want)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	errStep := errors.New("step failed")
+	tests := []struct {
+		name     string
+		step     func(context.Context) error
+		canceled bool
+		wantErr  error
+	}{
+		{name: "success", step: fail(nil)},
+		{name: "error passes through", step: fail(errStep), wantErr: errStep},
+		{name: "panic becomes an error", step: func(context.Context) error { panic("nil map") }, wantErr: ErrPanic},
+		{name: "canceled", step: fail(nil), canceled: true, wantErr: context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := NewSequenceBuilder().Name("ci").Use(Recover()).Task(once("build", tt.step)).Run(ctx)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTiming(t *testing.T) {
+	errStep := errors.New("step failed")
+	type record struct {
+		info    StepInfo
+		elapsed time.Duration
+		err     error
+	}
+	var (
+		mu      sync.Mutex
+		records []record
+	)
+	timing := Timing(func(info StepInfo, elapsed time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, record{info, elapsed, err})
+	})
+	err := NewParallelBuilder().Name("ci").Use(timing).
+		Task(once("slow", func(context.Context) error { time.Sleep(20 * time.Millisecond); return nil })).
+		Task(once("broken", fail(errStep))).
+		Run(context.Background())
+	if !errors.Is(err, errStep) {
+		t.Fatalf("expected %v, got %v", errStep, err)
+	}
+
+	byStep := make(map[string]record)
+	for _, r := range records {
+		byStep[r.info.Step] = r
+	}
+	if len(byStep) != 2 {
+		t.Fatalf("expected two timed steps, got %+v", records)
+	}
+	if r := byStep["slow"]; r.info.Flow != "ci" || r.elapsed < 20*time.Millisecond || r.err != nil {
+		t.Errorf("slow step recorded as %+v", r)
+	}
+	if r := byStep["broken"]; !errors.Is(r.err, errStep) {
+		t.Errorf("broken step recorded as %+v", r)
+	}
+}
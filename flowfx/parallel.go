@@ -2,21 +2,28 @@ package flowfx
 
 import (
 	"context"
-	"fmt"
 	"sync"
+	"time"
 
 	"github.com/garaekz/tfx/internal/share"
 )
 
 // Parallel represents a parallel flow that executes steps concurrently.
-// All steps run simultaneously, and the flow waits for all to complete.
+// Steps run on a pool of at most MaxConcurrency workers (all at once when
+// unset), started in the order chosen by its Scheduler, and the flow waits
+// for all to complete.
 type Parallel struct {
-	steps      []Step
-	name       string
-	onStart    Hook
-	onComplete Hook
-	onError    Hook
-	failFast   bool // If true, cancel all steps when one fails
+	steps          []Step
+	name           string
+	onStart        Hook
+	onComplete     Hook
+	onError        Hook
+	middleware     []Middleware
+	failFast       bool // If true, cancel all steps when one fails
+	maxConcurrency int  // Maximum concurrent steps; 0 means unlimited
+	limiter        Limiter
+	reporter       FlowReporter
+	scheduler      Scheduler
 }
 
 // ParallelConfig provides configuration for a Parallel flow.
@@ -25,7 +32,22 @@ type ParallelConfig struct {
 	OnStart    Hook
 	OnComplete Hook
 	OnError    Hook
+	Middleware []Middleware // Wraps every step execution, see Use
 	FailFast   bool
+
+	// MaxConcurrency caps how many steps run at the same time. Zero or a
+	// negative value runs every step at once.
+	MaxConcurrency int
+
+	// Limiter throttles how often steps start, e.g. NewRateLimiter(5, time.Second).
+	Limiter Limiter
+
+	// Reporter receives start, progress and completion events for every step.
+	Reporter FlowReporter
+
+	// Scheduler orders the steps waiting for a worker. Nil uses a
+	// PriorityScheduler, which starts high-priority tasks first.
+	Scheduler Scheduler
 }
 
 // DefaultParallelConfig returns the default configuration for a Parallel flow.
@@ -39,12 +61,17 @@ func DefaultParallelConfig() ParallelConfig {
 // newParallel creates a new parallel flow with the given configuration.
 func newParallel(cfg ParallelConfig) *Parallel {
 	return &Parallel{
-		steps:      make([]Step, 0),
-		name:       cfg.Name,
-		onStart:    cfg.OnStart,
-		onComplete: cfg.OnComplete,
-		onError:    cfg.OnError,
-		failFast:   cfg.FailFast,
+		steps:          make([]Step, 0),
+		name:           cfg.Name,
+		onStart:        cfg.OnStart,
+		onComplete:     cfg.OnComplete,
+		onError:        cfg.OnError,
+		middleware:     cfg.Middleware,
+		failFast:       cfg.FailFast,
+		maxConcurrency: cfg.MaxConcurrency,
+		limiter:        cfg.Limiter,
+		reporter:       cfg.Reporter,
+		scheduler:      cfg.Scheduler,
 	}
 }
 
@@ -83,59 +110,108 @@ func (p *Parallel) AddFunc(label string, fn func(ctx context.Context) error) *Pa
 	return p
 }
 
+// MaxConcurrency caps how many steps run at the same time (0 means unlimited).
+func (p *Parallel) MaxConcurrency(n int) *Parallel {
+	p.maxConcurrency = n
+	return p
+}
+
+// Scheduler sets the Scheduler that orders steps waiting for a worker.
+func (p *Parallel) Scheduler(s Scheduler) *Parallel {
+	p.scheduler = s
+	return p
+}
+
 // Run executes all steps in parallel and waits for completion.
 // It implements the Flow interface.
-func (p *Parallel) Run(ctx context.Context) error {
+func (p *Parallel) Run(ctx context.Context) (err error) {
 	if len(p.steps) == 0 {
 		return NewFlowError(p.name, "", ErrEmptyFlow)
 	}
 
+	// Share the run's store and typed results with nested flows and steps
+	ctx = withFlowState(ctx)
+	ctx = withMiddleware(ctx, p.middleware)
+
+	// Trace the flow run when a Tracer is configured
+	ctx, span := startFlowSpan(ctx, p.name)
+	defer func() { span.finish(err) }()
+
 	// Call onStart hook if provided
 	if p.onStart != nil {
 		p.onStart(ctx, p.name, nil)
 	}
 
-	// Create context for cancellation in fail-fast mode
-	execCtx := ctx
-	var cancel context.CancelFunc
-	if p.failFast {
-		execCtx, cancel = context.WithCancel(ctx)
-		defer cancel()
+	// Create context for cancellation in fail-fast mode and on fatal errors
+	execCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Queue the steps for a bounded pool of workers
+	workers := len(p.steps)
+	if p.maxConcurrency > 0 && p.maxConcurrency < workers {
+		workers = p.maxConcurrency
+	}
+	scheduler := p.scheduler
+	if scheduler == nil {
+		scheduler = NewPriorityScheduler()
+	}
+	for i, step := range p.steps {
+		scheduler.Push(ScheduledStep{Step: step, Index: i, Priority: stepPriority(step)})
+	}
+	var queueMu sync.Mutex
+	next := func() (ScheduledStep, bool) {
+		queueMu.Lock()
+		defer queueMu.Unlock()
+		return scheduler.Pop()
 	}
 
-	// Channel to collect errors from goroutines
+	// Channel to collect errors from workers
 	errCh := make(chan error, len(p.steps))
 	var wg sync.WaitGroup
 
-	// Start all steps concurrently
-	for i, step := range p.steps {
+	for range workers {
 		wg.Add(1)
-		go func(stepIndex int, s Step) {
+		go func() {
 			defer wg.Done()
-
-			// Execute the step
-			if err := s.Execute(execCtx); err != nil {
-				stepName := fmt.Sprintf("step_%d", stepIndex+1)
-				if task, ok := s.(*Task); ok && task.Label != "" {
-					stepName = task.Label
+			for {
+				queued, ok := next()
+				if !ok {
+					return
 				}
 
-				flowErr := NewFlowError(p.name, stepName, err)
-				errCh <- flowErr
+				// Steps still queued after cancellation are not started
+				s := queued.Step
+				stepName := stepLabel(s, queued.Index)
+				if err := waitLimiter(execCtx, p.limiter); err != nil {
+					if execCtx.Err() == nil {
+						errCh <- NewFlowError(p.name, stepName, err)
+					}
+					continue
+				}
+				if execCtx.Err() != nil {
+					continue
+				}
 
-				// Cancel other steps if fail-fast is enabled
-				if p.failFast && cancel != nil {
-					cancel()
+				stepCtx := startStep(execCtx, p.reporter, p.name, stepName)
+				err := execStep(stepCtx, StepInfo{Flow: p.name, Step: stepName, Critical: true}, s)
+				finishStep(p.reporter, p.name, stepName, err)
+				if err != nil {
+					errCh <- NewFlowError(p.name, stepName, err)
+
+					// Cancel other steps if fail-fast is enabled or the error is fatal
+					if p.failFast || IsFatal(err) {
+						cancel()
+					}
+					continue
 				}
-				return
-			}
 
-			// Send nil to indicate success
-			errCh <- nil
-		}(i, step)
+				// Send nil to indicate success
+				errCh <- nil
+			}
+		}()
 	}
 
-	// Wait for all goroutines to complete
+	// Wait for all workers to complete
 	go func() {
 		wg.Wait()
 		close(errCh)
@@ -153,6 +229,11 @@ func (p *Parallel) Run(ctx context.Context) error {
 		}
 	}
 
+	// Steps skipped because the caller canceled still fail the flow
+	if !multiErr.HasErrors() && successCount < len(p.steps) {
+		multiErr.Add(NewFlowError(p.name, "", ErrCanceled))
+	}
+
 	// Handle results
 	if multiErr.HasErrors() {
 		if p.onError != nil {
@@ -181,6 +262,13 @@ func (p *Parallel) Len() int {
 	return len(p.steps)
 }
 
+// Use adds middleware that wraps every step execution of the flow,
+// including steps of nested flows.
+func (p *Parallel) Use(mws ...Middleware) *Parallel {
+	p.middleware = append(p.middleware, mws...)
+	return p
+}
+
 // --- DSL BUILDER ---
 
 // ParallelBuilder provides a fluent API for building parallel flows.
@@ -213,12 +301,49 @@ func (pb *ParallelBuilder) OnError(hook Hook) *ParallelBuilder {
 	return pb
 }
 
+// Use adds middleware that wraps every step execution.
+func (pb *ParallelBuilder) Use(mws ...Middleware) *ParallelBuilder {
+	pb.config.Middleware = append(pb.config.Middleware, mws...)
+	return pb
+}
+
 // FailFast enables fail-fast mode.
 func (pb *ParallelBuilder) FailFast(enabled bool) *ParallelBuilder {
 	pb.config.FailFast = enabled
 	return pb
 }
 
+// MaxConcurrency caps how many steps run at the same time (0 means unlimited).
+func (pb *ParallelBuilder) MaxConcurrency(n int) *ParallelBuilder {
+	pb.config.MaxConcurrency = n
+	return pb
+}
+
+// RateLimit allows at most n steps to start per interval.
+func (pb *ParallelBuilder) RateLimit(n int, interval time.Duration) *ParallelBuilder {
+	pb.config.Limiter = NewRateLimiter(n, interval)
+	return pb
+}
+
+// Limiter sets the limiter that throttles step starts. Share one limiter
+// between flows to enforce a common limit.
+func (pb *ParallelBuilder) Limiter(l Limiter) *ParallelBuilder {
+	pb.config.Limiter = l
+	return pb
+}
+
+// Reporter sets the FlowReporter that receives step events.
+func (pb *ParallelBuilder) Reporter(r FlowReporter) *ParallelBuilder {
+	pb.config.Reporter = r
+	return pb
+}
+
+// Scheduler sets the Scheduler that orders steps waiting for a worker.
+func (pb *ParallelBuilder) Scheduler(s Scheduler) *ParallelBuilder {
+	pb.config.Scheduler = s
+	return pb
+}
+
 // Step adds a step to the parallel flow.
 func (pb *ParallelBuilder) Step(step Step) *ParallelBuilder {
 	pb.steps = append(pb.steps, step)
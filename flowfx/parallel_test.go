@@ -0,0 +1,99 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// gauge tracks how many steps run at once and the peak.
+type gauge struct {
+	running, peak, ran atomic.Int32
+}
+
+// step returns a step that holds a slot for a moment, then fails with err.
+func (g *gauge) step(err error) Step {
+	return StepFunc(func(ctx context.Context) error {
+		n := g.running.Add(1)
+		defer g.running.Add(-1)
+		for {
+			peak := g.peak.Load()
+			if n <= peak || g.peak.CompareAndSwap(peak, n) {
+				break
+			}
+		}
+		g.ran.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		return err
+	})
+}
+
+func TestParallelMaxConcurrency(t *testing.T) {
+	errStep := errors.New("download failed")
+	tests := []struct {
+		name        string
+		max         int
+		failFast    bool
+		failing     int // Index of the failing step; -1 for none
+		canceled    bool
+		wantRan     int32
+		wantMaxPeak int32
+		wantErr     error
+	}{
+		{name: "bounded", max: 2, failing: -1, wantRan: 8, wantMaxPeak: 2},
+		{name: "unbounded", max: 0, failing: -1, wantRan: 8, wantMaxPeak: 8},
+		{name: "failure keeps running the rest", max: 2, failing: 0, wantRan: 8, wantMaxPeak: 2, wantErr: errStep},
+		{name: "fail fast stops queued steps", max: 1, failFast: true, failing: 0, wantRan: 1, wantMaxPeak: 1, wantErr: errStep},
+		{name: "canceled", max: 2, failing: -1, canceled: true, wantRan: 0, wantErr: ErrCanceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &gauge{}
+			b := NewParallelBuilder().Name("downloads").MaxConcurrency(tt.max).FailFast(tt.failFast)
+			for i := range 8 {
+				var err error
+				if i == tt.failing {
+					err = errStep
+				}
+				b.Step(g.step(err))
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := b.Run(ctx)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if got := g.ran.Load(); got != tt.wantRan {
+				t.Errorf("ran %d steps, want %d", got, tt.wantRan)
+			}
+			if peak := g.peak.Load(); peak > tt.wantMaxPeak || (tt.max > 0 && tt.wantRan > 1 && peak != tt.wantMaxPeak) {
+				t.Errorf("peak concurrency %d, want %d", peak, tt.wantMaxPeak)
+			}
+		})
+	}
+}
+
+func TestParallelErrorsNameSteps(t *testing.T) {
+	b := NewParallelBuilder().Name("downloads")
+	for i := range 3 {
+		b.Task(once(fmt.Sprintf("file%d", i), fail(fmt.Errorf("file%d missing", i))))
+	}
+	var multi *MultiError
+	if err := b.Run(context.Background()); !errors.As(err, &multi) || len(multi.Errors) != 3 {
+		t.Fatalf("expected a MultiError with 3 errors, got %v", err)
+	}
+	if got := multi.ByStep("file1"); len(got) != 1 {
+		t.Errorf("expected the failure of file1, got %v", got)
+	}
+}
@@ -0,0 +1,337 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// StageFunc processes the values of a Pipeline stage. It reads from in until
+// it is closed and sends results to out with Send; the pipeline closes out
+// once the function returns. The first stage receives a closed in channel,
+// and the output of the last stage is discarded.
+type StageFunc func(ctx context.Context, in <-chan any, out chan<- any) error
+
+// pipelineStage is a named stage of a Pipeline.
+type pipelineStage struct {
+	name string
+	fn   StageFunc
+}
+
+// Pipeline is a streaming flow whose stages run concurrently, connected by
+// channels. Each channel holds up to Buffer values, so a slow stage applies
+// backpressure to the stages before it. When a stage fails, the pipeline
+// cancels every other stage and returns the failure; when a stage returns
+// early, the stages before it are canceled.
+//
+//	p := flowfx.NewPipeline().
+//		Stage("read", flowfx.SourceStage(readLines)).
+//		Stage("parse", flowfx.MapStage(parseRecord)).
+//		Stage("store", flowfx.SinkStage(saveRecord))
+type Pipeline struct {
+	stages     []pipelineStage
+	name       string
+	buffer     int
+	onStart    Hook
+	onComplete Hook
+	onError    Hook
+	middleware []Middleware
+}
+
+// PipelineConfig provides configuration for a Pipeline.
+type PipelineConfig struct {
+	Name       string
+	OnStart    Hook
+	OnComplete Hook
+	OnError    Hook
+	Middleware []Middleware // Wraps every stage, see Use
+
+	// Buffer is the capacity of the channel between two stages. Zero makes
+	// every send wait for the next stage to receive.
+	Buffer int
+}
+
+// DefaultPipelineConfig returns the default configuration for a Pipeline.
+func DefaultPipelineConfig() PipelineConfig {
+	return PipelineConfig{
+		Name:   "pipeline",
+		Buffer: 16,
+	}
+}
+
+// newPipeline creates a new pipeline with the given configuration.
+func newPipeline(cfg PipelineConfig) *Pipeline {
+	return &Pipeline{
+		stages:     make([]pipelineStage, 0),
+		name:       cfg.Name,
+		buffer:     max(cfg.Buffer, 0),
+		onStart:    cfg.OnStart,
+		onComplete: cfg.OnComplete,
+		onError:    cfg.OnError,
+		middleware: cfg.Middleware,
+	}
+}
+
+// --- MULTIPATH API FUNCTIONS ---
+
+// NewPipeline creates a new pipeline with multipath configuration support.
+// Supports two usage patterns:
+//   - NewPipeline()                          // Zero-config, uses defaults
+//   - NewPipeline(config)                    // Config struct
+func NewPipeline(args ...any) *Pipeline {
+	cfg := share.Overload(args, DefaultPipelineConfig())
+	return newPipeline(cfg)
+}
+
+// NewPipelineBuilder creates a new PipelineBuilder for DSL chaining.
+func NewPipelineBuilder() *PipelineBuilder {
+	return &PipelineBuilder{config: DefaultPipelineConfig()}
+}
+
+// Stage appends a stage to the pipeline.
+func (p *Pipeline) Stage(name string, fn StageFunc) *Pipeline {
+	p.stages = append(p.stages, pipelineStage{name: name, fn: fn})
+	return p
+}
+
+// Use adds middleware that wraps every stage, including steps of nested
+// flows.
+func (p *Pipeline) Use(mws ...Middleware) *Pipeline {
+	p.middleware = append(p.middleware, mws...)
+	return p
+}
+
+// Len returns the number of stages in the pipeline.
+func (p *Pipeline) Len() int {
+	return len(p.stages)
+}
+
+// Run starts every stage and waits until all have finished.
+// It implements the Flow interface.
+func (p *Pipeline) Run(ctx context.Context) (err error) {
+	if len(p.stages) == 0 {
+		return NewFlowError(p.name, "", ErrEmptyFlow)
+	}
+
+	// Share the run's store and typed results with nested flows and steps
+	ctx = withFlowState(ctx)
+	ctx = withMiddleware(ctx, p.middleware)
+
+	// Trace the flow run when a Tracer is configured
+	ctx, span := startFlowSpan(ctx, p.name)
+	defer func() { span.finish(err) }()
+
+	// Call onStart hook if provided
+	if p.onStart != nil {
+		p.onStart(ctx, p.name, nil)
+	}
+
+	// A failing stage cancels the others
+	execCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Wire the stages: stage i reads chans[i] and writes chans[i+1]
+	chans := make([]chan any, len(p.stages)+1)
+	chans[0] = make(chan any)
+	close(chans[0])
+	for i := 1; i < len(chans); i++ {
+		chans[i] = make(chan any, p.buffer)
+	}
+
+	// Each stage's context is derived from the next stage's, so a stage that
+	// returns early stops every stage before it
+	stageCtxs := make([]context.Context, len(p.stages))
+	stageCancels := make([]context.CancelFunc, len(p.stages))
+	parent := execCtx
+	for i := len(p.stages) - 1; i >= 0; i-- {
+		stageCtxs[i], stageCancels[i] = context.WithCancel(parent)
+		parent = stageCtxs[i]
+	}
+
+	var mu sync.Mutex
+	multiErr := NewMultiError()
+	var wg sync.WaitGroup
+	for i, stage := range p.stages {
+		in, out := chans[i], chans[i+1]
+		stageCtx, stageCancel := stageCtxs[i], stageCancels[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			step := StepFunc(func(ctx context.Context) error {
+				return stage.fn(ctx, in, out)
+			})
+			stageErr := execStep(stageCtx, StepInfo{Flow: p.name, Step: stage.name, Critical: true}, step)
+			stageCancel()
+			close(out)
+
+			// Unblock upstream stages that send without watching the context
+			go func() {
+				for range in {
+				}
+			}()
+
+			// Being stopped by another stage or by the caller is not a
+			// failure of this one; a canceled run reports ErrCanceled below
+			if stageErr == nil || (stageCtx.Err() != nil && errors.Is(stageErr, stageCtx.Err())) {
+				return
+			}
+			mu.Lock()
+			multiErr.Add(NewFlowError(p.name, stage.name, stageErr))
+			mu.Unlock()
+			cancel()
+		}()
+	}
+
+	// Discard the output of the last stage
+	for range chans[len(chans)-1] {
+	}
+	wg.Wait()
+
+	if !multiErr.HasErrors() && ctx.Err() != nil {
+		multiErr.Add(NewFlowError(p.name, "", ErrCanceled))
+	}
+	if multiErr.HasErrors() {
+		if p.onError != nil {
+			p.onError(ctx, p.name, multiErr.ToError())
+		}
+		return multiErr.ToError()
+	}
+
+	// All stages completed successfully
+	if p.onComplete != nil {
+		p.onComplete(ctx, p.name, nil)
+	}
+
+	return nil
+}
+
+// Execute implements the Step interface, so a pipeline can run inside other
+// flows.
+func (p *Pipeline) Execute(ctx context.Context) error {
+	return p.Run(ctx)
+}
+
+// Send delivers v to out, waiting for room in the channel. It returns the
+// context error when ctx is done first, which happens when another stage of
+// the pipeline failed or a later stage stopped reading.
+func Send(ctx context.Context, out chan<- any, v any) error {
+	select {
+	case out <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SourceStage creates a first stage that produces values by calling emit.
+func SourceStage[T any](fn func(ctx context.Context, emit func(T) error) error) StageFunc {
+	return func(ctx context.Context, in <-chan any, out chan<- any) error {
+		return fn(ctx, func(v T) error {
+			return Send(ctx, out, v)
+		})
+	}
+}
+
+// MapStage creates a stage that transforms each value with fn.
+func MapStage[In, Out any](fn func(ctx context.Context, v In) (Out, error)) StageFunc {
+	return func(ctx context.Context, in <-chan any, out chan<- any) error {
+		for value := range in {
+			typed, ok := value.(In)
+			if !ok {
+				var zero In
+				return fmt.Errorf("%w: stage received %T, not %T", ErrResultType, value, zero)
+			}
+			result, err := fn(ctx, typed)
+			if err != nil {
+				return err
+			}
+			if err := Send(ctx, out, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// SinkStage creates a last stage that consumes each value with fn.
+func SinkStage[T any](fn func(ctx context.Context, v T) error) StageFunc {
+	return func(ctx context.Context, in <-chan any, out chan<- any) error {
+		for value := range in {
+			typed, ok := value.(T)
+			if !ok {
+				var zero T
+				return fmt.Errorf("%w: stage received %T, not %T", ErrResultType, value, zero)
+			}
+			if err := fn(ctx, typed); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// --- DSL BUILDER ---
+
+// PipelineBuilder provides a fluent API for building pipelines.
+type PipelineBuilder struct {
+	config PipelineConfig
+	stages []pipelineStage
+}
+
+// Name sets the name of the pipeline.
+func (pb *PipelineBuilder) Name(name string) *PipelineBuilder {
+	pb.config.Name = name
+	return pb
+}
+
+// OnStart sets the start hook.
+func (pb *PipelineBuilder) OnStart(hook Hook) *PipelineBuilder {
+	pb.config.OnStart = hook
+	return pb
+}
+
+// OnComplete sets the complete hook.
+func (pb *PipelineBuilder) OnComplete(hook Hook) *PipelineBuilder {
+	pb.config.OnComplete = hook
+	return pb
+}
+
+// OnError sets the error hook.
+func (pb *PipelineBuilder) OnError(hook Hook) *PipelineBuilder {
+	pb.config.OnError = hook
+	return pb
+}
+
+// Use adds middleware that wraps every stage.
+func (pb *PipelineBuilder) Use(mws ...Middleware) *PipelineBuilder {
+	pb.config.Middleware = append(pb.config.Middleware, mws...)
+	return pb
+}
+
+// Buffer sets the capacity of the channels between stages.
+func (pb *PipelineBuilder) Buffer(n int) *PipelineBuilder {
+	pb.config.Buffer = n
+	return pb
+}
+
+// Stage adds a stage to the pipeline.
+func (pb *PipelineBuilder) Stage(name string, fn StageFunc) *PipelineBuilder {
+	pb.stages = append(pb.stages, pipelineStage{name: name, fn: fn})
+	return pb
+}
+
+// Build creates a new Pipeline instance without running it.
+func (pb *PipelineBuilder) Build() *Pipeline {
+	pipeline := newPipeline(pb.config)
+	pipeline.stages = make([]pipelineStage, len(pb.stages))
+	copy(pipeline.stages, pb.stages)
+	return pipeline
+}
+
+// Run creates and runs the pipeline.
+func (pb *PipelineBuilder) Run(ctx context.Context) error {
+	return pb.Build().Run(ctx)
+}
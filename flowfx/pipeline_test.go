@@ -0,0 +1,100 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	errParse := errors.New("bad record")
+	tests := []struct {
+		name        string
+		records     int  // Records produced by the source
+		failAt      int  // Record the parse stage rejects; 0 for none
+		stopAfter   int  // Records the sink reads before returning; 0 for all
+		sendStrings bool // Make the source emit strings the parser cannot take
+		canceled    bool
+		wantStored  []int
+		wantErr     error
+		wantStep    string
+		wantPartial bool // The source must have been stopped before producing every record
+	}{
+		{name: "success", records: 5, wantStored: []int{2, 4, 6, 8, 10}},
+		{name: "failing stage stops upstream", records: 1_000_000, failAt: 3, wantStored: []int{2, 4}, wantErr: errParse, wantStep: "parse", wantPartial: true},
+		{name: "sink returning early stops upstream", records: 1_000_000, stopAfter: 2, wantStored: []int{2, 4}, wantPartial: true},
+		{name: "unexpected value type", records: 1_000_000, sendStrings: true, wantErr: ErrResultType, wantStep: "parse", wantPartial: true},
+		{name: "canceled", records: 1_000_000, canceled: true, wantErr: ErrCanceled, wantPartial: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var produced atomic.Int64
+			var stored []int
+			source := func(ctx context.Context, in <-chan any, out chan<- any) error {
+				for i := 1; i <= tt.records; i++ {
+					var v any = i
+					if tt.sendStrings {
+						v = strconv.Itoa(i)
+					}
+					if err := Send(ctx, out, v); err != nil {
+						return err
+					}
+					produced.Add(1)
+				}
+				return nil
+			}
+			sink := func(ctx context.Context, in <-chan any, out chan<- any) error {
+				for v := range in {
+					stored = append(stored, v.(int))
+					if len(stored) == tt.stopAfter {
+						return nil
+					}
+				}
+				return nil
+			}
+			p := NewPipelineBuilder().Name("import").Buffer(1).
+				Stage("read", source).
+				Stage("parse", MapStage(func(ctx context.Context, n int) (int, error) {
+					if n == tt.failAt {
+						return 0, errParse
+					}
+					return n * 2, nil
+				})).
+				Stage("store", sink).
+				Build()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := p.Run(ctx)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			var flowErr *FlowError
+			if tt.wantStep != "" && (!errors.As(err, &flowErr) || flowErr.Step != tt.wantStep) {
+				t.Errorf("expected the failure of step %s, got %v", tt.wantStep, err)
+			}
+			if !tt.canceled && !slices.Equal(stored, tt.wantStored) {
+				t.Errorf("stored %v, want %v", stored, tt.wantStored)
+			}
+			if partial := produced.Load() < int64(tt.records); partial != tt.wantPartial {
+				t.Errorf("source produced %d of %d records", produced.Load(), tt.records)
+			}
+		})
+	}
+}
+
+func TestPipelineEmpty(t *testing.T) {
+	if err := NewPipeline().Run(context.Background()); !errors.Is(err, ErrEmptyFlow) {
+		t.Fatalf("expected ErrEmptyFlow, got %v", err)
+	}
+}
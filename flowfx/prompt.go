@@ -0,0 +1,116 @@
+package flowfx
+
+import (
+	"context"
+	"fmt"
+)
+
+// Prompter asks the user for input during a Wizard. It is satisfied by
+// formfx.FlowPrompter, or by any custom implementation, so flowfx does not
+// depend on a particular UI.
+//
+// Implementations may return ErrBack to ask the wizard to return to the
+// previous step.
+type Prompter interface {
+	Input(ctx context.Context, label, defaultValue string) (string, error)
+	Confirm(ctx context.Context, label string, defaultValue bool) (bool, error)
+	Select(ctx context.Context, label string, options []string, defaultIndex int) (int, error)
+}
+
+// DefaultsPrompter answers every question with its default value. Wizards use
+// it automatically when no TTY is available.
+type DefaultsPrompter struct{}
+
+// Input implements Prompter.
+func (DefaultsPrompter) Input(ctx context.Context, label, defaultValue string) (string, error) {
+	return defaultValue, nil
+}
+
+// Confirm implements Prompter.
+func (DefaultsPrompter) Confirm(ctx context.Context, label string, defaultValue bool) (bool, error) {
+	return defaultValue, nil
+}
+
+// Select implements Prompter.
+func (DefaultsPrompter) Select(ctx context.Context, label string, options []string, defaultIndex int) (int, error) {
+	if defaultIndex < 0 || defaultIndex >= len(options) {
+		return 0, fmt.Errorf("%w: default index %d out of range", ErrNonInteractive, defaultIndex)
+	}
+	return defaultIndex, nil
+}
+
+// prompterKey is the context key for the wizard's Prompter.
+type prompterKey struct{}
+
+// PrompterFrom returns the Prompter of the running wizard. Outside a wizard
+// it returns a DefaultsPrompter.
+func PrompterFrom(ctx context.Context) Prompter {
+	if p, ok := ctx.Value(prompterKey{}).(Prompter); ok {
+		return p
+	}
+	return DefaultsPrompter{}
+}
+
+// inputStep asks for free text and stores the answer under key.
+type inputStep struct {
+	key, label, def string
+}
+
+func (s inputStep) Label() string { return s.label }
+
+func (s inputStep) Execute(ctx context.Context, state map[string]any) (map[string]any, error) {
+	def := s.def
+	if prev, ok := state[s.key].(string); ok {
+		def = prev
+	}
+	answer, err := PrompterFrom(ctx).Input(ctx, s.label, def)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{s.key: answer}, nil
+}
+
+// confirmStep asks a yes/no question and stores the answer under key.
+type confirmStep struct {
+	key, label string
+	def        bool
+}
+
+func (s confirmStep) Label() string { return s.label }
+
+func (s confirmStep) Execute(ctx context.Context, state map[string]any) (map[string]any, error) {
+	def := s.def
+	if prev, ok := state[s.key].(bool); ok {
+		def = prev
+	}
+	answer, err := PrompterFrom(ctx).Confirm(ctx, s.label, def)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{s.key: answer}, nil
+}
+
+// selectStep asks to pick an option and stores the chosen option under key.
+type selectStep struct {
+	key, label string
+	options    []string
+	def        int
+}
+
+func (s selectStep) Label() string { return s.label }
+
+func (s selectStep) Execute(ctx context.Context, state map[string]any) (map[string]any, error) {
+	def := s.def
+	if prev, ok := state[s.key].(string); ok {
+		for i, opt := range s.options {
+			if opt == prev {
+				def = i
+			}
+		}
+	}
+	idx, err := PrompterFrom(ctx).Select(ctx, s.label, s.options, def)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{s.key: s.options[idx]}, nil
+}
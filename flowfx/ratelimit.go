@@ -0,0 +1,88 @@
+package flowfx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter delays step execution. Wait blocks until the next step may start
+// or ctx is done. *golang.org/x/time/rate.Limiter satisfies it as well.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// RateLimiter is a token bucket allowing n steps per interval. The bucket
+// starts full, so up to n steps may start at once; tokens are then refilled
+// evenly across the interval. It is safe for concurrent use and may be shared
+// between flows to enforce a global limit.
+type RateLimiter struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	every    time.Duration // Time to refill one token
+	last     time.Time
+}
+
+// NewRateLimiter creates a token bucket allowing n steps per interval.
+// n < 1 is treated as 1.
+func NewRateLimiter(n int, interval time.Duration) *RateLimiter {
+	if n < 1 {
+		n = 1
+	}
+	return &RateLimiter{
+		capacity: float64(n),
+		tokens:   float64(n),
+		every:    interval / time.Duration(n),
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a token is available and takes it.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := r.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long
+// until the next one.
+func (r *RateLimiter) reserve() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if r.every <= 0 {
+		return 0
+	}
+	r.tokens += float64(now.Sub(r.last)) / float64(r.every)
+	if r.tokens > r.capacity {
+		r.tokens = r.capacity
+	}
+	r.last = now
+
+	if r.tokens >= 1 {
+		r.tokens--
+		return 0
+	}
+	return time.Duration((1 - r.tokens) * float64(r.every))
+}
+
+// waitLimiter waits on limiter, which may be nil.
+func waitLimiter(ctx context.Context, limiter Limiter) error {
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,80 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// limiterFunc adapts a function to Limiter.
+type limiterFunc func(ctx context.Context) error
+
+func (f limiterFunc) Wait(ctx context.Context) error { return f(ctx) }
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(2, 100*time.Millisecond)
+	start := time.Now()
+	for range 2 {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("expected the full bucket to allow a burst of 2, waited %v", elapsed)
+	}
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected the third token to wait for a refill of ~50ms, waited %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected an empty bucket to stop waiting on cancel, got %v", err)
+	}
+}
+
+func TestFlowRateLimit(t *testing.T) {
+	errLimiter := errors.New("quota exceeded")
+	tests := []struct {
+		name    string
+		limiter func() Limiter
+		minTime time.Duration
+		wantRan int
+		wantErr error
+	}{
+		{"throttled", func() Limiter { return NewRateLimiter(1, 30*time.Millisecond) }, 55 * time.Millisecond, 3, nil},
+		{"limiter failure", func() Limiter {
+			return limiterFunc(func(context.Context) error { return errLimiter })
+		}, 0, 0, errLimiter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, kind := range []string{"sequence", "parallel"} {
+				rec := &recorder{}
+				var flow Flow
+				if kind == "sequence" {
+					flow = NewSequenceBuilder().Limiter(tt.limiter()).
+						Steps(rec.step("a", nil), rec.step("b", nil), rec.step("c", nil)).Build()
+				} else {
+					flow = NewParallelBuilder().Limiter(tt.limiter()).
+						Steps(rec.step("a", nil), rec.step("b", nil), rec.step("c", nil)).Build()
+				}
+				start := time.Now()
+				err := flow.Run(context.Background())
+				if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+					t.Fatalf("%s: expected %v, got %v", kind, tt.wantErr, err)
+				}
+				if got := len(rec.names()); got != tt.wantRan {
+					t.Errorf("%s: ran %d steps, want %d", kind, got, tt.wantRan)
+				}
+				if elapsed := time.Since(start); elapsed < tt.minTime {
+					t.Errorf("%s: expected the limiter to spread the steps over %v, took %v", kind, tt.minTime, elapsed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package flowfx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultMaxIterations bounds repeating steps that set no explicit limit.
+const DefaultMaxIterations = 1000
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// DelayedStep runs a step after a delay. It implements both Flow and Step.
+type DelayedStep struct {
+	delay time.Duration
+	step  Step
+}
+
+// After returns a step that waits d before running step. Cancelling the
+// context interrupts the wait.
+func After(d time.Duration, step Step) *DelayedStep {
+	return &DelayedStep{delay: d, step: step}
+}
+
+// Label returns the label of the delayed step.
+func (s *DelayedStep) Label() string {
+	if l, ok := s.step.(labeled); ok {
+		return l.Label()
+	}
+	if task, ok := s.step.(*Task); ok {
+		return task.Label
+	}
+	return ""
+}
+
+// Execute implements the Step interface.
+func (s *DelayedStep) Execute(ctx context.Context) error {
+	if err := sleep(ctx, s.delay); err != nil {
+		return err
+	}
+	return s.step.Execute(ctx)
+}
+
+// Run implements the Flow interface.
+func (s *DelayedStep) Run(ctx context.Context) error {
+	return s.Execute(ctx)
+}
+
+// RepeatStep runs a step at a fixed interval until a condition holds. It is
+// built with Every(...).Until(...) and implements both Flow and Step:
+//
+//	// Wait until the database accepts connections, checking every 2s
+//	flowfx.Every(2 * time.Second).Until(dbReady).MaxIterations(30)
+//
+//	// Poll a job and stop once it finished
+//	flowfx.Every(time.Second).Do(refreshStatus).Until(jobDone)
+//
+// Each iteration runs the step, if any, then checks the condition. A failing
+// step aborts the loop. When the condition still does not hold after
+// MaxIterations iterations, the step fails with ErrMaxIterations.
+type RepeatStep struct {
+	interval      time.Duration
+	step          Step
+	until         Condition
+	maxIterations int
+	name          string
+}
+
+// Every starts a repeating step that iterates every d.
+func Every(d time.Duration) *RepeatStep {
+	return &RepeatStep{
+		interval:      d,
+		maxIterations: DefaultMaxIterations,
+		name:          "every",
+	}
+}
+
+// Do sets the step run on each iteration.
+func (r *RepeatStep) Do(step Step) *RepeatStep {
+	r.step = step
+	return r
+}
+
+// Until sets the condition that ends the loop.
+func (r *RepeatStep) Until(cond Condition) *RepeatStep {
+	r.until = cond
+	return r
+}
+
+// MaxIterations caps the number of iterations. Zero or a negative value
+// removes the cap, so only the condition or the context ends the loop.
+func (r *RepeatStep) MaxIterations(n int) *RepeatStep {
+	r.maxIterations = n
+	return r
+}
+
+// Named sets the name used in errors.
+func (r *RepeatStep) Named(name string) *RepeatStep {
+	r.name = name
+	return r
+}
+
+// Label returns the name of the repeating step.
+func (r *RepeatStep) Label() string {
+	return r.name
+}
+
+// Execute implements the Step interface.
+func (r *RepeatStep) Execute(ctx context.Context) error {
+	if r.until == nil && r.maxIterations <= 0 {
+		return NewFlowError(r.name, "until", ErrInvalidCondition)
+	}
+
+	for i := 1; r.maxIterations <= 0 || i <= r.maxIterations; i++ {
+		if r.step != nil {
+			if err := r.step.Execute(ctx); err != nil {
+				return NewFlowError(r.name, fmt.Sprintf("iteration_%d", i), err)
+			}
+		}
+		if r.until != nil && r.until(ctx) {
+			return nil
+		}
+		if r.maxIterations > 0 && i == r.maxIterations {
+			break
+		}
+		if err := sleep(ctx, r.interval); err != nil {
+			return NewFlowError(r.name, fmt.Sprintf("iteration_%d", i), ErrCanceled)
+		}
+	}
+
+	// Without a condition the loop simply runs MaxIterations times
+	if r.until == nil {
+		return nil
+	}
+	return NewFlowError(r.name, "", fmt.Errorf("%w: %d", ErrMaxIterations, r.maxIterations))
+}
+
+// Run implements the Flow interface.
+func (r *RepeatStep) Run(ctx context.Context) error {
+	return r.Execute(ctx)
+}
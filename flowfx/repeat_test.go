@@ -0,0 +1,117 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRepeatStep(t *testing.T) {
+	errPoll := errors.New("status endpoint down")
+	tests := []struct {
+		name     string
+		interval time.Duration
+		readyAt  int  // Iteration at which the condition holds; 0 for never
+		failAt   int  // Iteration at which the step fails; 0 for never
+		noUntil  bool // Build the loop without a condition
+		max      int
+		canceled bool
+		wantRuns int
+		wantErr  error
+		wantStep string
+	}{
+		{name: "condition holds", readyAt: 3, max: 10, wantRuns: 3},
+		{name: "step fails", readyAt: 5, failAt: 2, max: 10, wantRuns: 2, wantErr: errPoll, wantStep: "iteration_2"},
+		{name: "max iterations", max: 4, wantRuns: 4, wantErr: ErrMaxIterations},
+		{name: "no condition runs max times", noUntil: true, max: 3, wantRuns: 3},
+		{name: "no condition and no cap", noUntil: true, max: 0, wantRuns: 0, wantErr: ErrInvalidCondition, wantStep: "until"},
+		{name: "canceled between iterations", interval: time.Hour, max: 10, canceled: true, wantRuns: 1, wantErr: ErrCanceled, wantStep: "iteration_1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := 0
+			poll := StepFunc(func(context.Context) error {
+				runs++
+				if runs == tt.failAt {
+					return errPoll
+				}
+				return nil
+			})
+			r := Every(tt.interval).Do(poll).MaxIterations(tt.max).Named("wait-db")
+			if !tt.noUntil {
+				r.Until(func(context.Context) bool { return tt.readyAt > 0 && runs >= tt.readyAt })
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := r.Run(ctx)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("ran %d times, want %d", runs, tt.wantRuns)
+			}
+			var flowErr *FlowError
+			if tt.wantStep != "" && (!errors.As(err, &flowErr) || flowErr.Flow != "wait-db" || flowErr.Step != tt.wantStep) {
+				t.Errorf("expected a FlowError for wait-db/%s, got %v", tt.wantStep, err)
+			}
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	errDeploy := errors.New("deploy failed")
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		stepErr  error
+		canceled bool
+		wantRun  bool
+		wantErr  error
+	}{
+		{name: "runs after the delay", delay: 10 * time.Millisecond, wantRun: true},
+		{name: "step fails", delay: time.Millisecond, stepErr: errDeploy, wantRun: true, wantErr: errDeploy},
+		{name: "canceled during the delay", delay: time.Hour, canceled: true, wantErr: context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ranAt time.Time
+			step := After(tt.delay, once("deploy", func(context.Context) error {
+				ranAt = time.Now()
+				return tt.stepErr
+			}))
+			if step.Label() != "deploy" {
+				t.Errorf("label %q, want deploy", step.Label())
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			start := time.Now()
+			err := step.Run(ctx)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if ran := !ranAt.IsZero(); ran != tt.wantRun {
+				t.Fatalf("step ran = %v, want %v", ran, tt.wantRun)
+			}
+			if tt.wantRun && ranAt.Sub(start) < tt.delay {
+				t.Errorf("step ran after %s, want at least %s", ranAt.Sub(start), tt.delay)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package flowfx
+
+import "context"
+
+// FlowReporter receives step lifecycle events from Sequence and Parallel
+// flows. Unlike ProgressReporter, which tracks a single task, a FlowReporter
+// sees every step of a flow and can render them together, e.g. as the
+// multi-bar view provided by progress.NewFlowBars.
+//
+// Implementations must be safe for concurrent use, since Parallel reports
+// from several goroutines.
+type FlowReporter interface {
+	// StepStarted is called before a step executes.
+	StepStarted(flow, step string)
+	// StepProgress reports the completion of a running step, from 0 to 1.
+	StepProgress(flow, step string, percent float64)
+	// StepDone is called after a step finished; err is nil on success.
+	StepDone(flow, step string, err error)
+}
+
+// reportKey is the context key for the reporter of the running step.
+type reportKey struct{}
+
+// stepReport binds a reporter to the step being executed.
+type stepReport struct {
+	reporter FlowReporter
+	flow     string
+	step     string
+}
+
+// startStep reports the start of step and returns the context the step runs
+// with. A nil reporter leaves ctx untouched.
+func startStep(ctx context.Context, r FlowReporter, flow, step string) context.Context {
+	if r == nil {
+		return ctx
+	}
+	r.StepStarted(flow, step)
+	return context.WithValue(ctx, reportKey{}, stepReport{reporter: r, flow: flow, step: step})
+}
+
+// finishStep reports the end of step to r, which may be nil.
+func finishStep(r FlowReporter, flow, step string, err error) {
+	if r != nil {
+		r.StepDone(flow, step, err)
+	}
+}
+
+// ReportProgress lets a running step report how far along it is, from 0 to 1.
+// It is a no-op when the flow has no FlowReporter.
+func ReportProgress(ctx context.Context, percent float64) {
+	sr, ok := ctx.Value(reportKey{}).(stepReport)
+	if !ok {
+		return
+	}
+	sr.reporter.StepProgress(sr.flow, sr.step, min(max(percent, 0), 1))
+}
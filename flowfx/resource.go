@@ -0,0 +1,98 @@
+package flowfx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Resources is a set of named semaphores that limit how many steps use a
+// shared resource at once, across every flow run with the same context:
+//
+//	ctx = flowfx.WithResources(ctx, map[string]int{"db-connections": 4})
+//
+//	migrate := flowfx.NewTask("migrate", migrateFn, flowfx.WithResource("db-connections"))
+//
+// Steps wait for a free slot before each attempt and release it when the
+// attempt ends.
+type Resources struct {
+	slots map[string]chan struct{}
+}
+
+// NewResources creates the semaphores described by limits, which maps a
+// resource name to how many steps may hold it at the same time. It panics
+// when a limit is not positive, as no step could ever hold the resource.
+func NewResources(limits map[string]int) *Resources {
+	r := &Resources{slots: make(map[string]chan struct{}, len(limits))}
+	for name, n := range limits {
+		if n <= 0 {
+			panic(fmt.Sprintf("flowfx: resource %q has limit %d, limits must be positive", name, n))
+		}
+		r.slots[name] = make(chan struct{}, n)
+	}
+	return r
+}
+
+// resourcesKey is the context key for the Resources of a flow run.
+type resourcesKey struct{}
+
+// WithResources returns a context whose steps share semaphores sized by
+// limits. Like NewResources, it panics when a limit is not positive.
+func WithResources(ctx context.Context, limits map[string]int) context.Context {
+	return context.WithValue(ctx, resourcesKey{}, NewResources(limits))
+}
+
+// WithResourceSet returns a context whose steps share the semaphores of r,
+// e.g. to coordinate flows run with unrelated contexts.
+func WithResourceSet(ctx context.Context, r *Resources) context.Context {
+	return context.WithValue(ctx, resourcesKey{}, r)
+}
+
+// Acquire waits until a slot of every named resource is free and takes it.
+// The returned function releases the slots. It fails with ErrUnknownResource
+// when ctx has no such resource, or with the context error when ctx is done
+// first.
+func Acquire(ctx context.Context, names ...string) (release func(), err error) {
+	if len(names) == 0 {
+		return func() {}, nil
+	}
+	r, _ := ctx.Value(resourcesKey{}).(*Resources)
+
+	// Acquire in a fixed order so steps sharing resources cannot deadlock
+	sorted := slices.Clone(names)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	var held []chan struct{}
+	release = func() {
+		for _, slot := range held {
+			<-slot
+		}
+	}
+	for _, name := range sorted {
+		var slot chan struct{}
+		if r != nil {
+			slot = r.slots[name]
+		}
+		if slot == nil {
+			release()
+			return nil, fmt.Errorf("%w: %s", ErrUnknownResource, name)
+		}
+		select {
+		case slot <- struct{}{}:
+			held = append(held, slot)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// WithResource makes the task hold a slot of each named resource while an
+// attempt runs. See WithResources.
+func WithResource(names ...string) TaskOption {
+	return func(t *Task) {
+		t.Resources = append(t.Resources, names...)
+	}
+}
@@ -0,0 +1,132 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResourcesAcrossGroups(t *testing.T) {
+	errMigrate := errors.New("migration failed")
+	tests := []struct {
+		name     string
+		limits   map[string]int
+		resource string
+		failing  int // Index of the failing step; -1 for none
+		canceled bool
+		wantRan  int32
+		wantPeak int32
+		wantErr  error
+	}{
+		{name: "limit shared by both groups", limits: map[string]int{"db": 2}, resource: "db", failing: -1, wantRan: 8, wantPeak: 2},
+		{name: "failure releases its slot", limits: map[string]int{"db": 1}, resource: "db", failing: 0, wantRan: 8, wantPeak: 1, wantErr: errMigrate},
+		{name: "unknown resource", limits: map[string]int{"db": 2}, resource: "cache", failing: -1, wantRan: 0, wantErr: ErrUnknownResource},
+		{name: "canceled", limits: map[string]int{"db": 2}, resource: "db", failing: -1, canceled: true, wantRan: 0, wantErr: ErrCanceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &gauge{}
+			root := NewParallelBuilder().Name("deploy")
+			for group := range 2 {
+				b := NewParallelBuilder().Name(fmt.Sprintf("group%d", group))
+				for i := range 4 {
+					var err error
+					if group*4+i == tt.failing {
+						err = errMigrate
+					}
+					b.Task(NewTask(fmt.Sprintf("step%d", i), g.step(err).Execute,
+						WithResource(tt.resource), WithRetry(RetryConfig{MaxAttempts: 1})))
+				}
+				root.Step(Subflow(fmt.Sprintf("group%d", group), b.Build()))
+			}
+
+			ctx, cancel := context.WithCancel(WithResources(context.Background(), tt.limits))
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := root.Run(ctx)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if got := g.ran.Load(); got != tt.wantRan {
+				t.Errorf("ran %d steps, want %d", got, tt.wantRan)
+			}
+			if peak := g.peak.Load(); peak != tt.wantPeak {
+				t.Errorf("peak concurrency %d, want %d", peak, tt.wantPeak)
+			}
+		})
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	res := NewResources(map[string]int{"db": 1, "cache": 1})
+	ctx := WithResourceSet(context.Background(), res)
+
+	// Duplicate names take a single slot
+	release, err := Acquire(ctx, "db", "cache", "db")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr error
+	}{
+		{name: "busy resource waits until the context ends", names: []string{"db"}, wantErr: context.DeadlineExceeded},
+		{name: "unknown resource", names: []string{"queue"}, wantErr: ErrUnknownResource},
+		{name: "no resources", names: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+			rel, err := Acquire(waitCtx, tt.names...)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if err == nil {
+				rel()
+			}
+		})
+	}
+
+	// A failed Acquire must not keep the free resources it took
+	release()
+	if _, err := Acquire(context.Background(), "db"); !errors.Is(err, ErrUnknownResource) {
+		t.Errorf("expected ErrUnknownResource without resources in the context, got %v", err)
+	}
+	again, err := Acquire(ctx, "cache", "db")
+	if err != nil {
+		t.Fatalf("Acquire after release: %v", err)
+	}
+	again()
+}
+
+func TestNewResourcesRejectsNonPositiveLimits(t *testing.T) {
+	for _, limit := range []int{0, -1} {
+		t.Run(fmt.Sprint(limit), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatal("expected NewResources to panic")
+				}
+				if msg := fmt.Sprint(r); !strings.Contains(msg, `resource "db"`) {
+					t.Errorf("panic %q does not name the resource", msg)
+				}
+			}()
+			NewResources(map[string]int{"cache": 2, "db": limit})
+		})
+	}
+}
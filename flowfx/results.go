@@ -0,0 +1,141 @@
+package flowfx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// TaskFunc is a step function that produces a typed result. Use NewTypedTask
+// to turn it into a Task whose result later steps can read with Get.
+type TaskFunc[T any] func(ctx context.Context) (T, error)
+
+// NewTypedTask creates a Task from fn. When fn succeeds inside a flow, its
+// result is recorded under label:
+//
+//	build := flowfx.NewTypedTask("build", func(ctx context.Context) (BuildArtifact, error) {
+//		return compile(ctx)
+//	})
+//	publish := flowfx.NewTask("publish", func(ctx context.Context) error {
+//		artifact, err := flowfx.Get[BuildArtifact](ctx, "build")
+//		if err != nil {
+//			return err
+//		}
+//		return upload(ctx, artifact)
+//	})
+//
+// When the flow is checkpointed, results that can be encoded as JSON are
+// saved with each checkpoint, so Get still finds them after a resume skips
+// the task.
+func NewTypedTask[T any](label string, fn TaskFunc[T], opts ...TaskOption) *Task {
+	return NewTask(label, func(ctx context.Context) error {
+		result, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+		if results, ok := ctx.Value(resultsKey{}).(*results); ok {
+			results.set(label, result)
+		}
+		if slot, ok := ctx.Value(cacheSlotKey{}).(*cacheSlot); ok {
+			slot.value = result
+		}
+		return nil
+	}, opts...)
+}
+
+// Get returns the result recorded by the typed task labeled step in the
+// running flow. It fails with ErrNoResult when the step has not produced a
+// result, and with ErrResultType when the result is not a T. Results
+// restored from a checkpoint are decoded into T.
+func Get[T any](ctx context.Context, step string) (T, error) {
+	var zero T
+	results, ok := ctx.Value(resultsKey{}).(*results)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s", ErrNoResult, step)
+	}
+	value, ok := results.get(step)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s", ErrNoResult, step)
+	}
+	if typed, ok := value.(T); ok {
+		return typed, nil
+	}
+	if raw, ok := value.(json.RawMessage); ok {
+		var decoded T
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return zero, fmt.Errorf("%w: %s: %w", ErrResultType, step, err)
+		}
+		results.set(step, decoded)
+		return decoded, nil
+	}
+	return zero, fmt.Errorf("%w: %s holds %T, not %T", ErrResultType, step, value, zero)
+}
+
+// resultsKey is the context key for the results of a flow run.
+type resultsKey struct{}
+
+// results holds typed task outputs for one flow run. Nested flows share the
+// results of the outermost flow.
+type results struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+func (r *results) set(step string, value any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[step] = value
+}
+
+func (r *results) get(step string) (any, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	value, ok := r.values[step]
+	return value, ok
+}
+
+// encode returns the JSON encoding of every result that can be encoded.
+func (r *results) encode() map[string]json.RawMessage {
+	r.mu.RLock()
+	snapshot := maps.Clone(r.values)
+	r.mu.RUnlock()
+	if len(snapshot) == 0 {
+		return nil
+	}
+	encoded := make(map[string]json.RawMessage, len(snapshot))
+	for step, value := range snapshot {
+		if raw, ok := value.(json.RawMessage); ok {
+			encoded[step] = raw
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		encoded[step] = data
+	}
+	return encoded
+}
+
+// restore loads checkpointed results without overwriting existing ones.
+// They stay encoded until read through Get.
+func (r *results) restore(encoded map[string]json.RawMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for step, raw := range encoded {
+		if _, ok := r.values[step]; !ok {
+			r.values[step] = raw
+		}
+	}
+}
+
+// withResults returns ctx carrying a results store, reusing the one of an
+// enclosing flow.
+func withResults(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(resultsKey{}).(*results); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, resultsKey{}, &results{values: make(map[string]any)})
+}
@@ -0,0 +1,96 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type artifact struct {
+	Name string
+	Size int
+}
+
+func TestGet(t *testing.T) {
+	build := NewTypedTask("build", func(ctx context.Context) (artifact, error) {
+		return artifact{Name: "app", Size: 42}, nil
+	})
+	tests := []struct {
+		name    string
+		read    func(ctx context.Context) error
+		wantErr error
+	}{
+		{"typed result", func(ctx context.Context) error {
+			a, err := Get[artifact](ctx, "build")
+			if err == nil && a.Name != "app" {
+				return errors.New("wrong result")
+			}
+			return err
+		}, nil},
+		{"wrong type", func(ctx context.Context) error {
+			_, err := Get[string](ctx, "build")
+			return err
+		}, ErrResultType},
+		{"missing step", func(ctx context.Context) error {
+			_, err := Get[artifact](ctx, "deploy")
+			return err
+		}, ErrNoResult},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewSequenceBuilder().
+				Task(build).
+				Func("read", tt.read).
+				Run(context.Background())
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := Get[artifact](context.Background(), "build"); !errors.Is(err, ErrNoResult) {
+		t.Errorf("expected ErrNoResult outside a flow, got %v", err)
+	}
+}
+
+// TestGetAfterResume reads a typed result restored from a checkpoint after
+// the task that produced it was skipped.
+func TestGetAfterResume(t *testing.T) {
+	cp := NewFileCheckpointer(t.TempDir())
+	builds := 0
+	fail := true
+	flow := func() error {
+		return NewSequenceBuilder().
+			Name("release").
+			Checkpointer(cp).
+			Task(NewTypedTask("build", func(ctx context.Context) (artifact, error) {
+				builds++
+				return artifact{Name: "app", Size: 42}, nil
+			})).
+			Func("publish", func(ctx context.Context) error {
+				a, err := Get[artifact](ctx, "build")
+				if err != nil {
+					return err
+				}
+				if a != (artifact{Name: "app", Size: 42}) {
+					return errors.New("wrong artifact")
+				}
+				if fail {
+					return errors.New("upload failed")
+				}
+				return nil
+			}).
+			Run(context.Background())
+	}
+
+	if err := flow(); err == nil {
+		t.Fatal("expected the first run to fail")
+	}
+	fail = false
+	if err := flow(); err != nil {
+		t.Fatalf("expected the resumed run to read the restored result, got %v", err)
+	}
+	if builds != 1 {
+		t.Errorf("expected build to be skipped on resume, ran %d times", builds)
+	}
+}
@@ -0,0 +1,392 @@
+package flowfx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// Compensable is implemented by steps that can undo their effects.
+// Compensate is called when a later critical step in a Saga fails.
+type Compensable interface {
+	Step
+	Compensate(ctx context.Context) error
+}
+
+// CompensationResult records the outcome of a single rollback.
+type CompensationResult struct {
+	Step string
+	Err  error
+}
+
+// RollbackReport describes the rollbacks performed after a saga step failed.
+type RollbackReport struct {
+	FailedStep    string               // The critical step whose failure triggered the rollback
+	Cause         error                // The error returned by the failed step
+	Compensations []CompensationResult // In execution order (reverse of completion)
+}
+
+// Succeeded returns the names of steps rolled back successfully.
+func (r *RollbackReport) Succeeded() []string {
+	var names []string
+	for _, c := range r.Compensations {
+		if c.Err == nil {
+			names = append(names, c.Step)
+		}
+	}
+	return names
+}
+
+// Failed returns the rollbacks that returned an error.
+func (r *RollbackReport) Failed() []CompensationResult {
+	var failed []CompensationResult
+	for _, c := range r.Compensations {
+		if c.Err != nil {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Clean reports whether every compensation succeeded.
+func (r *RollbackReport) Clean() bool {
+	return len(r.Failed()) == 0
+}
+
+// String returns a human-readable summary of the rollback.
+func (r *RollbackReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "step %s failed: %v", r.FailedStep, r.Cause)
+	for _, c := range r.Compensations {
+		if c.Err != nil {
+			fmt.Fprintf(&b, "\n  rollback %s: %v", c.Step, c.Err)
+		} else {
+			fmt.Fprintf(&b, "\n  rollback %s: ok", c.Step)
+		}
+	}
+	return b.String()
+}
+
+// SagaError is returned when a critical saga step fails. It wraps the step
+// error and carries the report of the compensations that ran.
+type SagaError struct {
+	Flow   string
+	Report *RollbackReport
+}
+
+// Error implements the error interface.
+func (e *SagaError) Error() string {
+	rolledBack := len(e.Report.Compensations)
+	failed := len(e.Report.Failed())
+	if failed > 0 {
+		return fmt.Sprintf("flow %s, step %s: %v (rolled back %d steps, %d rollbacks failed)",
+			e.Flow, e.Report.FailedStep, e.Report.Cause, rolledBack, failed)
+	}
+	return fmt.Sprintf("flow %s, step %s: %v (rolled back %d steps)",
+		e.Flow, e.Report.FailedStep, e.Report.Cause, rolledBack)
+}
+
+// Unwrap returns the error of the failed step.
+func (e *SagaError) Unwrap() error {
+	return e.Report.Cause
+}
+
+// sagaStep is a step together with its saga options.
+type sagaStep struct {
+	step     Step
+	critical bool
+}
+
+// completedStep remembers a finished step for rollback.
+type completedStep struct {
+	step  Step
+	label string
+}
+
+// Saga represents a sequential flow with compensations. When a critical step
+// fails, the compensations of all previously completed steps run in reverse
+// order. Failures of non-critical steps are recorded and the saga continues.
+type Saga struct {
+	steps      []sagaStep
+	name       string
+	onStart    Hook
+	onComplete Hook
+	onError    Hook
+	onRollback Hook
+	middleware []Middleware
+	lastReport *RollbackReport
+}
+
+// SagaConfig provides configuration for a Saga.
+type SagaConfig struct {
+	Name       string
+	OnStart    Hook
+	OnComplete Hook
+	OnError    Hook
+	OnRollback Hook         // Called after each compensation with the step label and its error
+	Middleware []Middleware // Wraps every step execution, see Use
+}
+
+// DefaultSagaConfig returns the default configuration for a Saga.
+func DefaultSagaConfig() SagaConfig {
+	return SagaConfig{
+		Name: "saga",
+	}
+}
+
+// newSaga creates a new saga with the given configuration.
+func newSaga(cfg SagaConfig) *Saga {
+	return &Saga{
+		steps:      make([]sagaStep, 0),
+		name:       cfg.Name,
+		onStart:    cfg.OnStart,
+		onComplete: cfg.OnComplete,
+		onError:    cfg.OnError,
+		onRollback: cfg.OnRollback,
+		middleware: cfg.Middleware,
+	}
+}
+
+// --- MULTIPATH API FUNCTIONS ---
+
+// NewSaga creates a new saga flow with multipath configuration support.
+// Supports two usage patterns:
+//   - NewSaga()                              // Zero-config, uses defaults
+//   - NewSaga(config)                        // Config struct
+func NewSaga(args ...any) *Saga {
+	cfg := share.Overload(args, DefaultSagaConfig())
+	return newSaga(cfg)
+}
+
+// NewSagaBuilder creates a new SagaBuilder for DSL chaining.
+func NewSagaBuilder() *SagaBuilder {
+	return &SagaBuilder{config: DefaultSagaConfig()}
+}
+
+// Add appends a critical step; its failure triggers a rollback.
+func (s *Saga) Add(step Step) *Saga {
+	s.steps = append(s.steps, sagaStep{step: step, critical: true})
+	return s
+}
+
+// AddOptional appends a non-critical step; its failure is recorded but does
+// not trigger a rollback.
+func (s *Saga) AddOptional(step Step) *Saga {
+	s.steps = append(s.steps, sagaStep{step: step})
+	return s
+}
+
+// AddTask is a convenience method to add a Task as a critical step.
+func (s *Saga) AddTask(task *Task) *Saga {
+	return s.Add(task)
+}
+
+// AddFunc adds a critical step with its compensation.
+func (s *Saga) AddFunc(label string, fn, compensate func(ctx context.Context) error) *Saga {
+	return s.Add(NewTask(label, fn, WithCompensation(compensate)))
+}
+
+// Run executes the steps in order. If a critical step fails, completed steps
+// are compensated in reverse order and a *SagaError with the rollback report
+// is returned. It implements the Flow interface.
+func (s *Saga) Run(ctx context.Context) (err error) {
+	if len(s.steps) == 0 {
+		return NewFlowError(s.name, "", ErrEmptyFlow)
+	}
+	s.lastReport = nil
+
+	// Share the run's store and typed results with nested flows and steps
+	ctx = withFlowState(ctx)
+	ctx = withMiddleware(ctx, s.middleware)
+
+	// Trace the flow run when a Tracer is configured
+	ctx, span := startFlowSpan(ctx, s.name)
+	defer func() { span.finish(err) }()
+
+	// Call onStart hook if provided
+	if s.onStart != nil {
+		s.onStart(ctx, s.name, nil)
+	}
+
+	var completed []completedStep
+	optionalErr := NewMultiError()
+
+	for i, ss := range s.steps {
+		stepName := stepLabel(ss.step, i)
+
+		err := ctx.Err()
+		if err == nil {
+			err = execStep(ctx, StepInfo{Flow: s.name, Step: stepName, Critical: ss.critical}, ss.step)
+		}
+		if err == nil {
+			completed = append(completed, completedStep{step: ss.step, label: stepName})
+			continue
+		}
+		if !ss.critical && ctx.Err() == nil && !IsFatal(err) {
+			optionalErr.Add(NewFlowError(s.name, stepName, err))
+			continue
+		}
+
+		report := s.rollback(ctx, completed, stepName, err)
+		s.lastReport = report
+		sagaErr := &SagaError{Flow: s.name, Report: report}
+		if s.onError != nil {
+			s.onError(ctx, s.name, sagaErr)
+		}
+		return sagaErr
+	}
+
+	if optionalErr.HasErrors() {
+		if s.onError != nil {
+			s.onError(ctx, s.name, optionalErr.ToError())
+		}
+		return optionalErr.ToError()
+	}
+
+	// Call onComplete hook if provided
+	if s.onComplete != nil {
+		s.onComplete(ctx, s.name, nil)
+	}
+
+	return nil
+}
+
+// rollback compensates completed steps in reverse order. Compensations run
+// even when ctx was canceled, since undoing work is part of shutting down.
+func (s *Saga) rollback(ctx context.Context, completed []completedStep, failed string, cause error) *RollbackReport {
+	report := &RollbackReport{FailedStep: failed, Cause: cause}
+	rollbackCtx := context.WithoutCancel(ctx)
+
+	for i := len(completed) - 1; i >= 0; i-- {
+		c, ok := completed[i].step.(Compensable)
+		if !ok {
+			continue
+		}
+		if task, isTask := c.(*Task); isTask && task.Compensation == nil {
+			continue
+		}
+		label := completed[i].label
+		err := c.Compensate(rollbackCtx)
+		report.Compensations = append(report.Compensations, CompensationResult{Step: label, Err: err})
+		if s.onRollback != nil {
+			s.onRollback(rollbackCtx, label, err)
+		}
+	}
+	return report
+}
+
+// LastReport returns the rollback report of the most recent run, or nil if
+// no rollback happened.
+func (s *Saga) LastReport() *RollbackReport {
+	return s.lastReport
+}
+
+// Len returns the number of steps in the saga.
+func (s *Saga) Len() int {
+	return len(s.steps)
+}
+
+// labeled is implemented by steps that carry their own name, such as
+// subflows and approval gates.
+type labeled interface {
+	Label() string
+}
+
+// stepLabel returns the label of step, or a positional name.
+func stepLabel(step Step, index int) string {
+	if task, ok := step.(*Task); ok && task.Label != "" {
+		return task.Label
+	}
+	if l, ok := step.(labeled); ok && l.Label() != "" {
+		return l.Label()
+	}
+	return fmt.Sprintf("step_%d", index+1)
+}
+
+// Use adds middleware that wraps every step execution of the flow,
+// including steps of nested flows.
+func (s *Saga) Use(mws ...Middleware) *Saga {
+	s.middleware = append(s.middleware, mws...)
+	return s
+}
+
+// --- DSL BUILDER ---
+
+// SagaBuilder provides a fluent API for building sagas.
+type SagaBuilder struct {
+	config SagaConfig
+	steps  []sagaStep
+}
+
+// Name sets the name of the saga.
+func (sb *SagaBuilder) Name(name string) *SagaBuilder {
+	sb.config.Name = name
+	return sb
+}
+
+// OnStart sets the start hook.
+func (sb *SagaBuilder) OnStart(hook Hook) *SagaBuilder {
+	sb.config.OnStart = hook
+	return sb
+}
+
+// OnComplete sets the complete hook.
+func (sb *SagaBuilder) OnComplete(hook Hook) *SagaBuilder {
+	sb.config.OnComplete = hook
+	return sb
+}
+
+// OnError sets the error hook.
+func (sb *SagaBuilder) OnError(hook Hook) *SagaBuilder {
+	sb.config.OnError = hook
+	return sb
+}
+
+// Use adds middleware that wraps every step execution.
+func (sb *SagaBuilder) Use(mws ...Middleware) *SagaBuilder {
+	sb.config.Middleware = append(sb.config.Middleware, mws...)
+	return sb
+}
+
+// OnRollback sets the hook called after each compensation.
+func (sb *SagaBuilder) OnRollback(hook Hook) *SagaBuilder {
+	sb.config.OnRollback = hook
+	return sb
+}
+
+// Step adds a critical step to the saga.
+func (sb *SagaBuilder) Step(step Step) *SagaBuilder {
+	sb.steps = append(sb.steps, sagaStep{step: step, critical: true})
+	return sb
+}
+
+// Optional adds a non-critical step to the saga.
+func (sb *SagaBuilder) Optional(step Step) *SagaBuilder {
+	sb.steps = append(sb.steps, sagaStep{step: step})
+	return sb
+}
+
+// Task adds a task as a critical step to the saga.
+func (sb *SagaBuilder) Task(task *Task) *SagaBuilder {
+	return sb.Step(task)
+}
+
+// Func adds a critical step with its compensation.
+func (sb *SagaBuilder) Func(label string, fn, compensate func(ctx context.Context) error) *SagaBuilder {
+	return sb.Step(NewTask(label, fn, WithCompensation(compensate)))
+}
+
+// Build creates a new Saga instance without running it.
+func (sb *SagaBuilder) Build() *Saga {
+	saga := newSaga(sb.config)
+	saga.steps = make([]sagaStep, len(sb.steps))
+	copy(saga.steps, sb.steps)
+	return saga
+}
+
+// Run creates and runs the saga.
+func (sb *SagaBuilder) Run(ctx context.Context) error {
+	return sb.Build().Run(ctx)
+}
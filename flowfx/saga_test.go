@@ -0,0 +1,124 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSagaRollback(t *testing.T) {
+	errStep := errors.New("step failed")
+	errRefund := errors.New("refund failed")
+	tests := []struct {
+		name          string
+		failing       string // Critical step that fails
+		optionalFails bool
+		failingUndo   string // Compensation that fails
+		canceled      bool
+		wantErr       error
+		wantRollbacks []string // In the order they ran
+		wantSucceeded []string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:          "critical failure rolls back in reverse order",
+			failing:       "ship",
+			wantErr:       errStep,
+			wantRollbacks: []string{"charge", "reserve"},
+			wantSucceeded: []string{"charge", "reserve"},
+		},
+		{
+			name:          "failed compensation is reported",
+			failing:       "ship",
+			failingUndo:   "charge",
+			wantErr:       errStep,
+			wantRollbacks: []string{"charge", "reserve"},
+			wantSucceeded: []string{"reserve"},
+		},
+		{
+			name:          "optional failure does not roll back",
+			optionalFails: true,
+			wantErr:       errStep,
+		},
+		{
+			name:     "canceled",
+			canceled: true,
+			wantErr:  context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var undone []string
+			task := func(label string) *Task {
+				return NewTask(label,
+					func(context.Context) error {
+						if label == tt.failing {
+							return errStep
+						}
+						return nil
+					},
+					WithRetry(RetryConfig{MaxAttempts: 1}),
+					WithCompensation(func(context.Context) error {
+						undone = append(undone, label)
+						if label == tt.failingUndo {
+							return errRefund
+						}
+						return nil
+					}))
+			}
+			optional := func(context.Context) error {
+				if tt.optionalFails {
+					return errStep
+				}
+				return nil
+			}
+
+			saga := NewSagaBuilder().
+				Name("order").
+				Task(task("reserve")).
+				Task(task("charge")).
+				Optional(once("notify", optional)).
+				Task(task("ship")).
+				Build()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := saga.Run(ctx)
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if !slices.Equal(undone, tt.wantRollbacks) {
+				t.Errorf("compensated %v, want %v", undone, tt.wantRollbacks)
+			}
+
+			var sagaErr *SagaError
+			if !errors.As(err, &sagaErr) {
+				if saga.LastReport() != nil {
+					t.Errorf("expected no rollback report, got %v", saga.LastReport())
+				}
+				return
+			}
+			report := sagaErr.Report
+			if report != saga.LastReport() {
+				t.Error("expected LastReport to return the report of the error")
+			}
+			if got := report.Succeeded(); !slices.Equal(got, tt.wantSucceeded) {
+				t.Errorf("succeeded rollbacks %v, want %v", got, tt.wantSucceeded)
+			}
+			if clean := tt.failingUndo == ""; report.Clean() != clean {
+				t.Errorf("Clean() = %v, want %v", report.Clean(), clean)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package flowfx
+
+import "container/heap"
+
+// ScheduledStep is a step waiting for a worker of a Parallel flow.
+type ScheduledStep struct {
+	Step     Step
+	Index    int // Position of the step in the flow; lower was added first
+	Priority int // Task priority; higher runs first
+}
+
+// Scheduler decides the order in which a Parallel flow with bounded
+// concurrency starts its steps. Every step is pushed before the first Pop;
+// Pop reports false once the queue is empty. Calls are serialized by the
+// flow, and a run drains the queue, so a Scheduler may be reused by later
+// runs but not shared between concurrent ones.
+type Scheduler interface {
+	Push(step ScheduledStep)
+	Pop() (ScheduledStep, bool)
+}
+
+// PriorityScheduler starts steps with the highest priority first, and steps
+// of equal priority in the order they were added. It is the default
+// Scheduler of Parallel flows.
+type PriorityScheduler struct {
+	queue scheduledQueue
+}
+
+// NewPriorityScheduler creates an empty PriorityScheduler.
+func NewPriorityScheduler() *PriorityScheduler {
+	return &PriorityScheduler{}
+}
+
+// Push implements the Scheduler interface.
+func (s *PriorityScheduler) Push(step ScheduledStep) {
+	heap.Push(&s.queue, step)
+}
+
+// Pop implements the Scheduler interface.
+func (s *PriorityScheduler) Pop() (ScheduledStep, bool) {
+	if s.queue.Len() == 0 {
+		return ScheduledStep{}, false
+	}
+	return heap.Pop(&s.queue).(ScheduledStep), true
+}
+
+// FIFOScheduler starts steps in the order they were added, ignoring
+// priorities.
+type FIFOScheduler struct {
+	queue []ScheduledStep
+}
+
+// NewFIFOScheduler creates an empty FIFOScheduler.
+func NewFIFOScheduler() *FIFOScheduler {
+	return &FIFOScheduler{}
+}
+
+// Push implements the Scheduler interface.
+func (s *FIFOScheduler) Push(step ScheduledStep) {
+	s.queue = append(s.queue, step)
+}
+
+// Pop implements the Scheduler interface.
+func (s *FIFOScheduler) Pop() (ScheduledStep, bool) {
+	if len(s.queue) == 0 {
+		return ScheduledStep{}, false
+	}
+	step := s.queue[0]
+	s.queue = s.queue[1:]
+	return step, true
+}
+
+// scheduledQueue is a heap ordered by priority, then by index.
+type scheduledQueue []ScheduledStep
+
+func (q scheduledQueue) Len() int { return len(q) }
+
+func (q scheduledQueue) Less(i, j int) bool {
+	if q[i].Priority != q[j].Priority {
+		return q[i].Priority > q[j].Priority
+	}
+	return q[i].Index < q[j].Index
+}
+
+func (q scheduledQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *scheduledQueue) Push(x any) { *q = append(*q, x.(ScheduledStep)) }
+
+func (q *scheduledQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// stepPriority returns the priority of a Task, or zero for other steps.
+func stepPriority(step Step) int {
+	if task, ok := step.(*Task); ok {
+		return task.Priority
+	}
+	return 0
+}
@@ -0,0 +1,132 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// lifoScheduler starts the last added step first.
+type lifoScheduler struct {
+	stack []ScheduledStep
+}
+
+func (s *lifoScheduler) Push(step ScheduledStep) { s.stack = append(s.stack, step) }
+
+func (s *lifoScheduler) Pop() (ScheduledStep, bool) {
+	if len(s.stack) == 0 {
+		return ScheduledStep{}, false
+	}
+	step := s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+	return step, true
+}
+
+func TestParallelScheduling(t *testing.T) {
+	errBuild := errors.New("build failed")
+	type task struct {
+		label    string
+		priority int
+		err      error
+	}
+	tasks := []task{
+		{label: "docs", priority: 0},
+		{label: "build", priority: 10},
+		{label: "lint", priority: 5},
+		{label: "test", priority: 10},
+	}
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		failing   string
+		failFast  bool
+		canceled  bool
+		wantOrder []string
+		wantErr   error
+	}{
+		{
+			name:      "priority with ties in order added",
+			wantOrder: []string{"build", "test", "lint", "docs"},
+		},
+		{
+			name:      "fifo ignores priority",
+			scheduler: NewFIFOScheduler(),
+			wantOrder: []string{"docs", "build", "lint", "test"},
+		},
+		{
+			name:      "custom scheduler",
+			scheduler: &lifoScheduler{},
+			wantOrder: []string{"test", "lint", "build", "docs"},
+		},
+		{
+			name:      "fail fast skips lower priorities",
+			failing:   "build",
+			failFast:  true,
+			wantOrder: []string{"build"},
+			wantErr:   errBuild,
+		},
+		{
+			name:     "canceled",
+			canceled: true,
+			wantErr:  ErrCanceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu    sync.Mutex
+				order []string
+			)
+			b := NewParallelBuilder().Name("ci").MaxConcurrency(1).FailFast(tt.failFast)
+			if tt.scheduler != nil {
+				b.Scheduler(tt.scheduler)
+			}
+			for _, tk := range tasks {
+				var err error
+				if tk.label == tt.failing {
+					err = errBuild
+				}
+				b.Task(NewTask(tk.label, func(context.Context) error {
+					mu.Lock()
+					defer mu.Unlock()
+					order = append(order, tk.label)
+					return err
+				}, WithPriority(tk.priority), WithRetry(RetryConfig{MaxAttempts: 1})))
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+			err := b.Run(ctx)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if !slices.Equal(order, tt.wantOrder) {
+				t.Errorf("ran %v, want %v", order, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestSchedulerDrains(t *testing.T) {
+	for _, s := range []Scheduler{NewPriorityScheduler(), NewFIFOScheduler()} {
+		if _, ok := s.Pop(); ok {
+			t.Errorf("%T: Pop on an empty queue reported a step", s)
+		}
+		s.Push(ScheduledStep{Index: 0, Priority: 1})
+		if step, ok := s.Pop(); !ok || step.Priority != 1 {
+			t.Errorf("%T: Pop = %+v, %v", s, step, ok)
+		}
+		if _, ok := s.Pop(); ok {
+			t.Errorf("%T: queue not drained", s)
+		}
+	}
+}
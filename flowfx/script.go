@@ -16,7 +16,9 @@ type Script struct {
 	onStart    Hook
 	onComplete Hook
 	onError    Hook
+	middleware []Middleware
 	logger     ScriptLogger // Optional logger for enhanced traceability
+	checkpoint Checkpointer // Optional store for resuming interrupted runs
 }
 
 // ScriptStep represents a single step in a script flow with metadata.
@@ -45,7 +47,11 @@ type ScriptConfig struct {
 	OnStart    Hook
 	OnComplete Hook
 	OnError    Hook
+	Middleware []Middleware // Wraps every step execution, see Use
 	Logger     ScriptLogger
+
+	// Checkpointer records completed steps under Name so a re-run skips them.
+	Checkpointer Checkpointer
 }
 
 // DefaultScriptConfig returns the default configuration for a Script flow.
@@ -63,7 +69,9 @@ func newScript(cfg ScriptConfig) *Script {
 		onStart:    cfg.OnStart,
 		onComplete: cfg.OnComplete,
 		onError:    cfg.OnError,
+		middleware: cfg.Middleware,
 		logger:     cfg.Logger,
+		checkpoint: cfg.Checkpointer,
 	}
 }
 
@@ -133,11 +141,19 @@ func (s *Script) AddCriticalStep(name string, step Step) *Script {
 
 // Run executes all steps sequentially with enhanced logging and error traceability.
 // It implements the Flow interface.
-func (s *Script) Run(ctx context.Context) error {
+func (s *Script) Run(ctx context.Context) (err error) {
 	if len(s.steps) == 0 {
 		return NewFlowError(s.name, "", ErrEmptyFlow)
 	}
 
+	// Share the run's store and typed results with nested flows and steps
+	ctx = withFlowState(ctx)
+	ctx = withMiddleware(ctx, s.middleware)
+
+	// Trace the flow run when a Tracer is configured
+	ctx, span := startFlowSpan(ctx, s.name)
+	defer func() { span.finish(err) }()
+
 	// Call onStart hook if provided
 	if s.onStart != nil {
 		s.onStart(ctx, s.name, nil)
@@ -148,10 +164,30 @@ func (s *Script) Run(ctx context.Context) error {
 		s.logger.LogScriptStart(s.name)
 	}
 
+	// Resume from a previous run if checkpointing is enabled
+	checkpoint, ctx, err := startCheckpoint(ctx, s.checkpoint, s.name)
+	if err != nil {
+		if s.onError != nil {
+			s.onError(ctx, s.name, err)
+		}
+		if s.logger != nil {
+			s.logger.LogScriptError(s.name, err)
+		}
+		return err
+	}
+
 	var scriptErrors []error
 
 	// Execute each step sequentially
 	for i, scriptStep := range s.steps {
+		stepName := scriptStep.Name
+		if stepName == "" {
+			stepName = fmt.Sprintf("step_%d", i+1)
+		}
+		if checkpoint.completed(stepName) {
+			continue
+		}
+
 		select {
 		case <-ctx.Done():
 			err := NewFlowError(s.name, scriptStep.Name, ErrCanceled)
@@ -171,12 +207,7 @@ func (s *Script) Run(ctx context.Context) error {
 		}
 
 		// Execute the step
-		if err := scriptStep.Step.Execute(ctx); err != nil {
-			stepName := scriptStep.Name
-			if stepName == "" {
-				stepName = fmt.Sprintf("step_%d", i+1)
-			}
-
+		if err := execStep(ctx, StepInfo{Flow: s.name, Step: stepName, Critical: scriptStep.Critical}, scriptStep.Step); err != nil {
 			flowErr := NewFlowError(s.name, stepName, err)
 			scriptErrors = append(scriptErrors, flowErr)
 
@@ -185,8 +216,8 @@ func (s *Script) Run(ctx context.Context) error {
 				s.logger.LogStepError(scriptStep.Name, err)
 			}
 
-			// If this is a critical step, stop execution immediately
-			if scriptStep.Critical {
+			// Stop immediately on critical steps and fatal errors
+			if scriptStep.Critical || IsFatal(err) {
 				if s.onError != nil {
 					s.onError(ctx, s.name, flowErr)
 				}
@@ -204,6 +235,16 @@ func (s *Script) Run(ctx context.Context) error {
 		if s.logger != nil && !scriptStep.Silent {
 			s.logger.LogStepComplete(scriptStep.Name)
 		}
+
+		if err := checkpoint.markDone(ctx, stepName); err != nil {
+			if s.onError != nil {
+				s.onError(ctx, s.name, err)
+			}
+			if s.logger != nil {
+				s.logger.LogScriptError(s.name, err)
+			}
+			return err
+		}
 	}
 
 	// Check if we have any accumulated errors
@@ -223,6 +264,11 @@ func (s *Script) Run(ctx context.Context) error {
 		return scriptErr
 	}
 
+	// The script finished; the next run starts from scratch
+	if err := checkpoint.finish(ctx); err != nil {
+		return err
+	}
+
 	// All steps completed successfully
 	if s.onComplete != nil {
 		s.onComplete(ctx, s.name, nil)
@@ -269,6 +315,13 @@ func (s *Script) Summary() string {
 	return sb.String()
 }
 
+// Use adds middleware that wraps every step execution of the flow,
+// including steps of nested flows.
+func (s *Script) Use(mws ...Middleware) *Script {
+	s.middleware = append(s.middleware, mws...)
+	return s
+}
+
 // --- DSL BUILDER ---
 
 // ScriptBuilder provides a fluent API for building script flows.
@@ -301,12 +354,24 @@ func (sb *ScriptBuilder) OnError(hook Hook) *ScriptBuilder {
 	return sb
 }
 
+// Use adds middleware that wraps every step execution.
+func (sb *ScriptBuilder) Use(mws ...Middleware) *ScriptBuilder {
+	sb.config.Middleware = append(sb.config.Middleware, mws...)
+	return sb
+}
+
 // Logger sets the script logger.
 func (sb *ScriptBuilder) Logger(logger ScriptLogger) *ScriptBuilder {
 	sb.config.Logger = logger
 	return sb
 }
 
+// Checkpointer enables resuming the script from its last completed steps.
+func (sb *ScriptBuilder) Checkpointer(cp Checkpointer) *ScriptBuilder {
+	sb.config.Checkpointer = cp
+	return sb
+}
+
 // Step adds a script step to the flow.
 func (sb *ScriptBuilder) Step(scriptStep ScriptStep) *ScriptBuilder {
 	sb.steps = append(sb.steps, scriptStep)
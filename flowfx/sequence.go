@@ -3,6 +3,7 @@ package flowfx
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/garaekz/tfx/internal/share"
 )
@@ -15,6 +16,10 @@ type Sequence struct {
 	onStart    Hook
 	onComplete Hook
 	onError    Hook
+	middleware []Middleware
+	checkpoint Checkpointer
+	limiter    Limiter
+	reporter   FlowReporter
 }
 
 // SequenceConfig provides configuration for a Sequence.
@@ -23,6 +28,16 @@ type SequenceConfig struct {
 	OnStart    Hook
 	OnComplete Hook
 	OnError    Hook
+	Middleware []Middleware // Wraps every step execution, see Use
+
+	// Checkpointer records completed steps under Name so a re-run skips them.
+	Checkpointer Checkpointer
+
+	// Limiter throttles how often steps start, e.g. NewRateLimiter(5, time.Second).
+	Limiter Limiter
+
+	// Reporter receives start, progress and completion events for every step.
+	Reporter FlowReporter
 }
 
 // DefaultSequenceConfig returns the default configuration for a Sequence.
@@ -40,6 +55,10 @@ func newSequence(cfg SequenceConfig) *Sequence {
 		onStart:    cfg.OnStart,
 		onComplete: cfg.OnComplete,
 		onError:    cfg.OnError,
+		middleware: cfg.Middleware,
+		checkpoint: cfg.Checkpointer,
+		limiter:    cfg.Limiter,
+		reporter:   cfg.Reporter,
 	}
 }
 
@@ -80,18 +99,40 @@ func (s *Sequence) AddFunc(label string, fn func(ctx context.Context) error) *Se
 
 // Run executes all steps in the sequence sequentially.
 // It implements the Flow interface.
-func (s *Sequence) Run(ctx context.Context) error {
+func (s *Sequence) Run(ctx context.Context) (err error) {
 	if len(s.steps) == 0 {
 		return NewFlowError(s.name, "", ErrEmptyFlow)
 	}
 
+	// Share the run's store and typed results with nested flows and steps
+	ctx = withFlowState(ctx)
+	ctx = withMiddleware(ctx, s.middleware)
+
+	// Trace the flow run when a Tracer is configured
+	ctx, span := startFlowSpan(ctx, s.name)
+	defer func() { span.finish(err) }()
+
 	// Call onStart hook if provided
 	if s.onStart != nil {
 		s.onStart(ctx, s.name, nil)
 	}
 
+	// Resume from a previous run if checkpointing is enabled
+	checkpoint, ctx, err := startCheckpoint(ctx, s.checkpoint, s.name)
+	if err != nil {
+		if s.onError != nil {
+			s.onError(ctx, s.name, err)
+		}
+		return err
+	}
+
 	// Execute each step sequentially
 	for i, step := range s.steps {
+		stepName := stepLabel(step, i)
+		if checkpoint.completed(stepName) {
+			continue
+		}
+
 		// Check for cancellation before each step
 		select {
 		case <-ctx.Done():
@@ -103,19 +144,37 @@ func (s *Sequence) Run(ctx context.Context) error {
 		default:
 		}
 
-		// Execute the step
-		if err := step.Execute(ctx); err != nil {
+		// Wait for the rate limiter before starting the step
+		if err := waitLimiter(ctx, s.limiter); err != nil {
 			if s.onError != nil {
 				s.onError(ctx, s.name, err)
 			}
+			return NewFlowError(s.name, stepName, err)
+		}
 
-			stepName := fmt.Sprintf("step_%d", i+1)
-			if task, ok := step.(*Task); ok && task.Label != "" {
-				stepName = task.Label
+		// Execute the step
+		stepCtx := startStep(ctx, s.reporter, s.name, stepName)
+		err := execStep(stepCtx, StepInfo{Flow: s.name, Step: stepName, Critical: true}, step)
+		finishStep(s.reporter, s.name, stepName, err)
+		if err != nil {
+			if s.onError != nil {
+				s.onError(ctx, s.name, err)
 			}
 
 			return NewFlowError(s.name, stepName, err)
 		}
+
+		if err := checkpoint.markDone(ctx, stepName); err != nil {
+			if s.onError != nil {
+				s.onError(ctx, s.name, err)
+			}
+			return err
+		}
+	}
+
+	// The flow finished; the next run starts from scratch
+	if err := checkpoint.finish(ctx); err != nil {
+		return err
 	}
 
 	// Call onComplete hook if provided
@@ -138,6 +197,13 @@ func (s *Sequence) Len() int {
 	return len(s.steps)
 }
 
+// Use adds middleware that wraps every step execution of the flow,
+// including steps of nested flows.
+func (s *Sequence) Use(mws ...Middleware) *Sequence {
+	s.middleware = append(s.middleware, mws...)
+	return s
+}
+
 // --- DSL BUILDER ---
 
 // SequenceBuilder provides a fluent API for building sequences.
@@ -170,6 +236,37 @@ func (sb *SequenceBuilder) OnError(hook Hook) *SequenceBuilder {
 	return sb
 }
 
+// Use adds middleware that wraps every step execution.
+func (sb *SequenceBuilder) Use(mws ...Middleware) *SequenceBuilder {
+	sb.config.Middleware = append(sb.config.Middleware, mws...)
+	return sb
+}
+
+// Checkpointer enables resuming the sequence from its last completed step.
+func (sb *SequenceBuilder) Checkpointer(cp Checkpointer) *SequenceBuilder {
+	sb.config.Checkpointer = cp
+	return sb
+}
+
+// RateLimit allows at most n steps to start per interval.
+func (sb *SequenceBuilder) RateLimit(n int, interval time.Duration) *SequenceBuilder {
+	sb.config.Limiter = NewRateLimiter(n, interval)
+	return sb
+}
+
+// Limiter sets the limiter that throttles step starts. Share one limiter
+// between flows to enforce a common limit.
+func (sb *SequenceBuilder) Limiter(l Limiter) *SequenceBuilder {
+	sb.config.Limiter = l
+	return sb
+}
+
+// Reporter sets the FlowReporter that receives step events.
+func (sb *SequenceBuilder) Reporter(r FlowReporter) *SequenceBuilder {
+	sb.config.Reporter = r
+	return sb
+}
+
 // Step adds a step to the sequence.
 func (sb *SequenceBuilder) Step(step Step) *SequenceBuilder {
 	sb.steps = append(sb.steps, step)
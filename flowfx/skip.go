@@ -0,0 +1,55 @@
+package flowfx
+
+import "context"
+
+// SkippedStep runs a step unless a precondition shows its work is already
+// done. It implements both Flow and Step.
+type SkippedStep struct {
+	cond Condition
+	step Step
+}
+
+// SkipIf returns a step that runs step only when cond is false:
+//
+//	install := flowfx.SkipIf(binaryInstalled("terraform"), installTerraform)
+//
+// A skipped step succeeds without running and is reported as skipped rather
+// than completed: its StepMetrics outcome is OutcomeSkipped and StepSkipped
+// reports true to middleware. Unlike checkpoint resume, the condition is
+// checked on every run, which makes re-running a flow idempotent. Tasks can
+// use WithSkipIf instead.
+func SkipIf(cond Condition, step Step) *SkippedStep {
+	return &SkippedStep{cond: cond, step: step}
+}
+
+// Label returns the label of the wrapped step.
+func (s *SkippedStep) Label() string {
+	if l, ok := s.step.(labeled); ok {
+		return l.Label()
+	}
+	if task, ok := s.step.(*Task); ok {
+		return task.Label
+	}
+	return ""
+}
+
+// Execute implements the Step interface.
+func (s *SkippedStep) Execute(ctx context.Context) error {
+	if s.cond != nil && s.cond(ctx) {
+		markSkipped(ctx)
+		return nil
+	}
+	return s.step.Execute(ctx)
+}
+
+// Run implements the Flow interface.
+func (s *SkippedStep) Run(ctx context.Context) error {
+	return s.Execute(ctx)
+}
+
+// WithSkipIf makes the task skip its work when cond holds, see SkipIf.
+func WithSkipIf(cond Condition) TaskOption {
+	return func(t *Task) {
+		t.SkipIf = cond
+	}
+}
@@ -0,0 +1,119 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSkipIf(t *testing.T) {
+	errInstall := errors.New("download failed")
+	tests := []struct {
+		name        string
+		installed   bool
+		err         error
+		canceled    bool
+		useOption   bool // Skip with WithSkipIf instead of the SkipIf wrapper
+		wantRan     bool
+		wantOutcome Outcome
+		wantErr     error
+	}{
+		{name: "condition holds", installed: true, wantOutcome: OutcomeSkipped},
+		{name: "condition holds for a task", installed: true, useOption: true, wantOutcome: OutcomeSkipped},
+		{name: "runs when needed", wantRan: true, wantOutcome: OutcomeSucceeded},
+		{name: "runs a task when needed", useOption: true, wantRan: true, wantOutcome: OutcomeSucceeded},
+		{name: "failure is not a skip", err: errInstall, wantRan: true, wantOutcome: OutcomeFailed, wantErr: errInstall},
+		{name: "canceled inside the step", err: context.Canceled, canceled: true, wantRan: true, wantOutcome: OutcomeCanceled, wantErr: context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ran := false
+			installed := func(context.Context) bool { return tt.installed }
+			install := func(context.Context) error {
+				ran = true
+				if tt.canceled {
+					cancel()
+				}
+				return tt.err
+			}
+			var step Step
+			if tt.useOption {
+				step = NewTask("terraform", install, WithSkipIf(installed), WithRetry(RetryConfig{MaxAttempts: 1}))
+			} else {
+				step = SkipIf(installed, once("terraform", install))
+			}
+
+			var seen []bool
+			flow := NewSequenceBuilder().Name("setup").
+				Use(func(next StepFunc) StepFunc {
+					return func(ctx context.Context) error {
+						err := next(ctx)
+						seen = append(seen, StepSkipped(ctx))
+						return err
+					}
+				}).
+				Step(step).
+				Build()
+			report, err := RunWithReport(ctx, flow)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if ran != tt.wantRan {
+				t.Errorf("step ran = %v, want %v", ran, tt.wantRan)
+			}
+			if len(report.Steps) != 1 || report.Steps[0].Outcome != tt.wantOutcome || report.Steps[0].Step != "terraform" {
+				t.Fatalf("report %+v, want terraform %s", report.Steps, tt.wantOutcome)
+			}
+			wantSkipped := tt.wantOutcome == OutcomeSkipped
+			if len(seen) != 1 || seen[0] != wantSkipped {
+				t.Errorf("middleware saw skipped = %v, want [%v]", seen, wantSkipped)
+			}
+		})
+	}
+}
+
+func TestSkipDoesNotPropagate(t *testing.T) {
+	ranDeploy := false
+	inner := NewSequenceBuilder().Name("tools").
+		Step(SkipIf(func(context.Context) bool { return true }, once("terraform", fail(nil)))).
+		Build()
+	flow := NewDAGBuilder().Name("release").
+		Step("tools", Subflow("tools", inner)).
+		Task(once("deploy", func(context.Context) error { ranDeploy = true; return nil }).DependsOn("tools"))
+	dag, err := flow.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	report, err := RunWithReport(context.Background(), dag)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ranDeploy {
+		t.Error("a skipped dependency should let its dependents run")
+	}
+	outcomes := make(map[string]Outcome)
+	for _, s := range report.Steps {
+		outcomes[s.Flow+"/"+s.Step] = s.Outcome
+	}
+	want := map[string]Outcome{
+		"tools/terraform": OutcomeSkipped,
+		"release/tools":   OutcomeSucceeded,
+		"release/deploy":  OutcomeSucceeded,
+	}
+	for path, outcome := range want {
+		if outcomes[path] != outcome {
+			t.Errorf("%s: outcome %q, want %q (report %v)", path, outcomes[path], outcome, outcomes)
+		}
+	}
+	if report.Count(OutcomeSkipped) != 1 {
+		t.Errorf("expected one skipped step, got %d", report.Count(OutcomeSkipped))
+	}
+}
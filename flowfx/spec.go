@@ -0,0 +1,253 @@
+package flowfx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Flow modes of a FlowSpec.
+const (
+	ModeSequence = "sequence"
+	ModeParallel = "parallel"
+	ModeDAG      = "dag"
+)
+
+// FlowSpec is a declarative flow loaded from JSON. Each step either runs a
+// shell command or groups nested steps:
+//
+//	{
+//	  "name": "release",
+//	  "mode": "dag",
+//	  "steps": [
+//	    {"name": "build", "run": "go build ./..."},
+//	    {"name": "test", "run": "go test ./...", "retries": 2},
+//	    {"name": "publish", "run": "./publish.sh", "depends_on": ["build", "test"]}
+//	  ]
+//	}
+type FlowSpec struct {
+	Name           string     `json:"name"`
+	Mode           string     `json:"mode,omitempty"`            // sequence (default), parallel or dag
+	MaxConcurrency int        `json:"max_concurrency,omitempty"` // Parallel only
+	FailFast       bool       `json:"fail_fast,omitempty"`       // Parallel and dag
+	Steps          []StepSpec `json:"steps"`
+}
+
+// StepSpec is a step of a FlowSpec. Set Run for a command, or Steps (and
+// optionally Mode) for a nested group.
+type StepSpec struct {
+	Name      string            `json:"name"`
+	Run       string            `json:"run,omitempty"`
+	Dir       string            `json:"dir,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	Retries   int               `json:"retries,omitempty"`
+	Timeout   string            `json:"timeout,omitempty"` // Go duration, e.g. "5m"
+	DependsOn []string          `json:"depends_on,omitempty"`
+
+	Mode           string     `json:"mode,omitempty"`
+	MaxConcurrency int        `json:"max_concurrency,omitempty"`
+	FailFast       bool       `json:"fail_fast,omitempty"`
+	Steps          []StepSpec `json:"steps,omitempty"`
+}
+
+// SpecOptions configures how a FlowSpec is turned into a Flow.
+type SpecOptions struct {
+	Stdout     io.Writer    // Command output; defaults to os.Stdout
+	Stderr     io.Writer    // Command errors; defaults to os.Stderr
+	Reporter   FlowReporter // Receives step events of every group
+	Middleware []Middleware // Wraps every step
+}
+
+// LoadFlowSpec reads a FlowSpec from a JSON file.
+func LoadFlowSpec(path string) (*FlowSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := ParseFlowSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ParseFlowSpec decodes and validates a FlowSpec. Unknown fields, duplicate
+// step names in a group, invalid retries or timeouts and, in dag groups, unknown or
+// cyclic dependencies fail with ErrInvalidSpec.
+func ParseFlowSpec(data []byte) (*FlowSpec, error) {
+	var spec FlowSpec
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+	if spec.Name == "" {
+		spec.Name = "flow"
+	}
+	if err := validateSteps(spec.Mode, spec.Steps); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// validateSteps checks a group of steps and its nested groups.
+func validateSteps(mode string, steps []StepSpec) error {
+	switch mode {
+	case "", ModeSequence, ModeParallel, ModeDAG:
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidSpec, mode)
+	}
+	if len(steps) == 0 {
+		return fmt.Errorf("%w: no steps", ErrInvalidSpec)
+	}
+
+	var errs []error
+	seen := make(map[string]bool, len(steps))
+	for i, s := range steps {
+		name := s.Name
+		if name == "" {
+			name = fmt.Sprintf("step_%d", i+1)
+		}
+		if seen[name] {
+			errs = append(errs, fmt.Errorf("%w: duplicate step %s", ErrInvalidSpec, name))
+		}
+		seen[name] = true
+		switch {
+		case s.Run != "" && len(s.Steps) > 0:
+			errs = append(errs, fmt.Errorf("%w: step %s has both run and steps", ErrInvalidSpec, name))
+		case s.Run == "" && len(s.Steps) == 0:
+			errs = append(errs, fmt.Errorf("%w: step %s has neither run nor steps", ErrInvalidSpec, name))
+		case len(s.Steps) > 0:
+			if err := validateSteps(s.Mode, s.Steps); err != nil {
+				errs = append(errs, fmt.Errorf("step %s: %w", name, err))
+			}
+		}
+		if s.Retries < 0 {
+			errs = append(errs, fmt.Errorf("%w: step %s: negative retries", ErrInvalidSpec, name))
+		}
+		if s.Timeout != "" {
+			if _, err := time.ParseDuration(s.Timeout); err != nil {
+				errs = append(errs, fmt.Errorf("%w: step %s: invalid timeout %q", ErrInvalidSpec, name, s.Timeout))
+			}
+		}
+		if len(s.DependsOn) > 0 && mode != ModeDAG {
+			errs = append(errs, fmt.Errorf("%w: step %s: depends_on requires mode dag", ErrInvalidSpec, name))
+		}
+	}
+	if mode == ModeDAG && len(errs) == 0 {
+		if err := validateDependencies(steps); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidSpec, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateDependencies checks the depends_on of a dag group for unknown
+// steps and cycles, as DAG.Validate does when the flow is built.
+func validateDependencies(steps []StepSpec) error {
+	d := NewDAG(DefaultDAGConfig())
+	noop := StepFunc(func(context.Context) error { return nil })
+	for i, s := range steps {
+		name := s.Name
+		if name == "" {
+			name = fmt.Sprintf("step_%d", i+1)
+		}
+		d.Add(name, noop, s.DependsOn...)
+	}
+	return d.Validate()
+}
+
+// Build turns the spec into a runnable Flow.
+func (s *FlowSpec) Build(opts SpecOptions) (Flow, error) {
+	return buildGroup(s.Name, s.Mode, s.MaxConcurrency, s.FailFast, s.Steps, opts)
+}
+
+// buildGroup builds one group of steps in the given mode.
+func buildGroup(name, mode string, maxConcurrency int, failFast bool, specs []StepSpec, opts SpecOptions) (Flow, error) {
+	steps := make([]Step, len(specs))
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		names[i] = spec.Name
+		if names[i] == "" {
+			names[i] = fmt.Sprintf("step_%d", i+1)
+		}
+		step, err := buildStep(names[i], spec, opts)
+		if err != nil {
+			return nil, err
+		}
+		steps[i] = step
+	}
+
+	switch mode {
+	case ModeParallel:
+		p := NewParallel(ParallelConfig{
+			Name:           name,
+			FailFast:       failFast,
+			MaxConcurrency: maxConcurrency,
+			Reporter:       opts.Reporter,
+			Middleware:     opts.Middleware,
+		})
+		for _, step := range steps {
+			p.Add(step)
+		}
+		return p, nil
+	case ModeDAG:
+		cfg := DefaultDAGConfig()
+		cfg.Name = name
+		cfg.FailFast = failFast
+		cfg.Middleware = opts.Middleware
+		d := NewDAG(cfg)
+		for i, step := range steps {
+			d.Add(names[i], step, specs[i].DependsOn...)
+		}
+		if err := d.Validate(); err != nil {
+			return nil, err
+		}
+		return d, nil
+	default:
+		seq := NewSequence(SequenceConfig{
+			Name:       name,
+			Reporter:   opts.Reporter,
+			Middleware: opts.Middleware,
+		})
+		for _, step := range steps {
+			seq.Add(step)
+		}
+		return seq, nil
+	}
+}
+
+// buildStep builds a command task or a nested group.
+func buildStep(name string, spec StepSpec, opts SpecOptions) (Step, error) {
+	if len(spec.Steps) > 0 {
+		// Nested groups inherit the middleware of the top-level flow
+		nested := opts
+		nested.Middleware = nil
+		group, err := buildGroup(name, spec.Mode, spec.MaxConcurrency, spec.FailFast, spec.Steps, nested)
+		if err != nil {
+			return nil, err
+		}
+		return Subflow(name, group), nil
+	}
+
+	retry := DefaultRetryConfig()
+	retry.MaxAttempts = spec.Retries + 1
+	var timeout time.Duration
+	if spec.Timeout != "" {
+		timeout, _ = time.ParseDuration(spec.Timeout)
+	}
+
+	cmd := &CommandStep{
+		Command: spec.Run,
+		Dir:     spec.Dir,
+		Env:     spec.Env,
+		Stdout:  opts.Stdout,
+		Stderr:  opts.Stderr,
+	}
+	return NewTask(name, cmd.Execute, WithRetry(retry), WithTimeout(timeout)), nil
+}
@@ -0,0 +1,170 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFlowSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string // Substring of the error; empty for a valid spec
+	}{
+		{
+			name: "valid dag",
+			spec: `{"name": "release", "mode": "dag", "steps": [
+				{"name": "build", "run": "true"},
+				{"name": "publish", "run": "true", "depends_on": ["build"]}]}`,
+		},
+		{
+			name:    "unknown field",
+			spec:    `{"steps": [{"name": "build", "run": "true", "retry": 2}]}`,
+			wantErr: `unknown field "retry"`,
+		},
+		{
+			name:    "duplicate names",
+			spec:    `{"steps": [{"name": "build", "run": "true"}, {"name": "build", "run": "false"}]}`,
+			wantErr: "duplicate step build",
+		},
+		{
+			name:    "duplicate names in a nested group",
+			spec:    `{"steps": [{"name": "checks", "steps": [{"name": "lint", "run": "true"}, {"name": "lint", "run": "true"}]}]}`,
+			wantErr: "step checks: invalid flow spec: duplicate step lint",
+		},
+		{
+			name:    "bad duration",
+			spec:    `{"steps": [{"name": "build", "run": "true", "timeout": "5 minutes"}]}`,
+			wantErr: `invalid timeout "5 minutes"`,
+		},
+		{
+			name:    "negative retries",
+			spec:    `{"steps": [{"name": "build", "run": "true", "retries": -1}]}`,
+			wantErr: "negative retries",
+		},
+		{
+			name: "unknown dependency",
+			spec: `{"mode": "dag", "steps": [
+				{"name": "build", "run": "true"},
+				{"name": "publish", "run": "true", "depends_on": ["tset"]}]}`,
+			wantErr: ErrUnknownDependency.Error(),
+		},
+		{
+			name: "cycle",
+			spec: `{"mode": "dag", "steps": [
+				{"name": "a", "run": "true", "depends_on": ["b"]},
+				{"name": "b", "run": "true", "depends_on": ["a"]}]}`,
+			wantErr: ErrDependencyCycle.Error(),
+		},
+		{
+			name:    "depends_on outside a dag",
+			spec:    `{"steps": [{"name": "a", "run": "true"}, {"name": "b", "run": "true", "depends_on": ["a"]}]}`,
+			wantErr: "depends_on requires mode dag",
+		},
+		{
+			name:    "unknown mode",
+			spec:    `{"mode": "graph", "steps": [{"name": "a", "run": "true"}]}`,
+			wantErr: `unknown mode "graph"`,
+		},
+		{
+			name:    "step without run or steps",
+			spec:    `{"steps": [{"name": "a"}]}`,
+			wantErr: "neither run nor steps",
+		},
+		{
+			name:    "no steps",
+			spec:    `{"name": "empty"}`,
+			wantErr: "no steps",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := ParseFlowSpec([]byte(tt.spec))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if _, err := spec.Build(SpecOptions{}); err != nil {
+					t.Fatalf("Build: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidSpec) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected ErrInvalidSpec containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFlowSpecBuild(t *testing.T) {
+	spec, err := ParseFlowSpec([]byte(`{"name": "ci", "steps": [
+		{"name": "build", "run": "go build ./..."},
+		{"name": "test", "run": "go test ./...", "retries": 2, "timeout": "5m"},
+		{"name": "checks", "mode": "parallel", "steps": [{"name": "lint", "run": "true"}]}]}`))
+	if err != nil {
+		t.Fatalf("ParseFlowSpec: %v", err)
+	}
+	flow, err := spec.Build(SpecOptions{})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	seq, ok := flow.(*Sequence)
+	if !ok {
+		t.Fatalf("expected a Sequence, got %T", flow)
+	}
+	if len(seq.steps) != 3 {
+		t.Fatalf("expected 3 steps, got %d", len(seq.steps))
+	}
+
+	tests := []struct {
+		index        int
+		wantLabel    string
+		wantAttempts int
+		wantTimeout  time.Duration
+	}{
+		{index: 0, wantLabel: "build", wantAttempts: 1},
+		{index: 1, wantLabel: "test", wantAttempts: 3, wantTimeout: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantLabel, func(t *testing.T) {
+			task, ok := seq.steps[tt.index].(*Task)
+			if !ok {
+				t.Fatalf("expected a Task, got %T", seq.steps[tt.index])
+			}
+			if task.Label != tt.wantLabel || task.Retry.MaxAttempts != tt.wantAttempts || task.Timeout != tt.wantTimeout {
+				t.Errorf("task %s: %d attempts, timeout %s; want %s, %d attempts, timeout %s",
+					task.Label, task.Retry.MaxAttempts, task.Timeout, tt.wantLabel, tt.wantAttempts, tt.wantTimeout)
+			}
+		})
+	}
+	if sub, ok := seq.steps[2].(*SubflowStep); !ok || sub.Label() != "checks" {
+		t.Errorf("expected the checks subflow, got %T", seq.steps[2])
+	}
+}
+
+func TestFlowSpecRun(t *testing.T) {
+	requireShell(t)
+	spec, err := ParseFlowSpec([]byte(`{"name": "greet", "mode": "dag", "steps": [
+		{"name": "hello", "run": "echo \"hello $WHO\"", "env": {"WHO": "tfx"}},
+		{"name": "fail", "run": "exit 3", "depends_on": ["hello"]}]}`))
+	if err != nil {
+		t.Fatalf("ParseFlowSpec: %v", err)
+	}
+	var stdout strings.Builder
+	flow, err := spec.Build(SpecOptions{Stdout: &stdout, Stderr: &strings.Builder{}})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	err = flow.Run(context.Background())
+	var flowErr *FlowError
+	if !errors.As(err, &flowErr) || flowErr.Step != "fail" {
+		t.Fatalf("expected the failure of step fail, got %v", err)
+	}
+	if stdout.String() != "hello tfx\n" {
+		t.Errorf("stdout %q, want %q", stdout.String(), "hello tfx\n")
+	}
+}
@@ -0,0 +1,143 @@
+package flowfx
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Store is a concurrency-safe key/value store scoped to a flow run. Every
+// flow injects one into the context of its steps; nested flows share the
+// store of the outermost flow. Retrieve it with StoreFrom, or read typed
+// values directly with StoreValue.
+//
+// When the flow is checkpointed, values that can be encoded as JSON are saved
+// with each checkpoint and restored when the flow resumes.
+type Store struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+// NewStore creates an empty store.
+func NewStore() *Store {
+	return &Store{values: make(map[string]any)}
+}
+
+// Set stores value under key.
+func (s *Store) Set(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// Get returns the value stored under key.
+func (s *Store) Get(key string) (any, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// Delete removes key from the store.
+func (s *Store) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+}
+
+// Keys returns the stored keys in sorted order.
+func (s *Store) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Sorted(maps.Keys(s.values))
+}
+
+// Snapshot returns a shallow copy of the stored values.
+func (s *Store) Snapshot() map[string]any {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.values)
+}
+
+// encode returns the JSON encoding of every value that can be encoded.
+// Values such as functions or channels are left out of checkpoints.
+func (s *Store) encode() map[string]json.RawMessage {
+	snapshot := s.Snapshot()
+	if len(snapshot) == 0 {
+		return nil
+	}
+	encoded := make(map[string]json.RawMessage, len(snapshot))
+	for key, value := range snapshot {
+		if raw, ok := value.(json.RawMessage); ok {
+			encoded[key] = raw
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		encoded[key] = data
+	}
+	return encoded
+}
+
+// restore loads checkpointed values without overwriting existing keys. They
+// stay encoded until read through StoreValue.
+func (s *Store) restore(encoded map[string]json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, raw := range encoded {
+		if _, ok := s.values[key]; !ok {
+			s.values[key] = raw
+		}
+	}
+}
+
+// storeKey is the context key for the store of a flow run.
+type storeKey struct{}
+
+// StoreFrom returns the store of the running flow. Outside a flow it returns
+// a new, detached store.
+func StoreFrom(ctx context.Context) *Store {
+	if s, ok := ctx.Value(storeKey{}).(*Store); ok {
+		return s
+	}
+	return NewStore()
+}
+
+// StoreValue returns the value stored under key in the running flow's store
+// if it holds a T. Values restored from a checkpoint are decoded into T.
+func StoreValue[T any](ctx context.Context, key string) (T, bool) {
+	var zero T
+	s, ok := ctx.Value(storeKey{}).(*Store)
+	if !ok {
+		return zero, false
+	}
+	value, ok := s.Get(key)
+	if !ok {
+		return zero, false
+	}
+	if typed, ok := value.(T); ok {
+		return typed, true
+	}
+	if raw, ok := value.(json.RawMessage); ok {
+		var decoded T
+		if err := json.Unmarshal(raw, &decoded); err == nil {
+			s.Set(key, decoded)
+			return decoded, true
+		}
+	}
+	return zero, false
+}
+
+// withFlowState returns ctx carrying a store and a typed results store,
+// reusing those of an enclosing flow.
+func withFlowState(ctx context.Context) context.Context {
+	ctx = withResults(ctx)
+	if _, ok := ctx.Value(storeKey{}).(*Store); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, storeKey{}, NewStore())
+}
@@ -0,0 +1,110 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestStoreSharedByFlowSteps(t *testing.T) {
+	var (
+		count   int
+		countOK bool
+		keys    []string
+	)
+	checks := NewSequenceBuilder().Name("checks").
+		Func("test", func(ctx context.Context) error {
+			count, countOK = StoreValue[int](ctx, "count")
+			StoreFrom(ctx).Set("tested", true)
+			return nil
+		}).
+		Build()
+	err := NewSequenceBuilder().Name("ci").
+		Func("build", func(ctx context.Context) error {
+			StoreFrom(ctx).Set("count", 3)
+			return nil
+		}).
+		Step(Subflow("checks", checks)).
+		Func("publish", func(ctx context.Context) error {
+			keys = StoreFrom(ctx).Keys()
+			return nil
+		}).
+		Run(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !countOK || count != 3 {
+		t.Errorf("subflow read count = %d, %v; want 3, true", count, countOK)
+	}
+	if want := []string{"count", "tested"}; !slices.Equal(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+}
+
+func TestStoreValue(t *testing.T) {
+	ctx := withFlowState(context.Background())
+	StoreFrom(ctx).Set("count", 3)
+
+	if v, ok := StoreValue[int](ctx, "count"); !ok || v != 3 {
+		t.Errorf("StoreValue[int] = %d, %v; want 3, true", v, ok)
+	}
+	if v, ok := StoreValue[string](ctx, "count"); ok || v != "" {
+		t.Errorf("StoreValue[string] of an int = %q, %v; want the zero value, false", v, ok)
+	}
+	if _, ok := StoreValue[int](ctx, "missing"); ok {
+		t.Error("expected a missing key to report false")
+	}
+	if _, ok := StoreValue[int](context.Background(), "count"); ok {
+		t.Error("expected StoreValue outside a flow to report false")
+	}
+	if StoreFrom(context.Background()) == StoreFrom(context.Background()) {
+		t.Error("expected StoreFrom outside a flow to return detached stores")
+	}
+}
+
+func TestStoreCheckpointRoundTrip(t *testing.T) {
+	type manifest struct {
+		Version string
+		Files   []string
+	}
+	want := manifest{Version: "1.2.0", Files: []string{"app", "README"}}
+
+	failInstall := true
+	var (
+		restored   manifest
+		restoredOK bool
+		callbackOK bool
+	)
+	flow := NewSequenceBuilder().
+		Name("installer").
+		Checkpointer(NewMemoryCheckpointer()).
+		Func("download", func(ctx context.Context) error {
+			StoreFrom(ctx).Set("manifest", want)
+			StoreFrom(ctx).Set("callback", func() {})
+			return nil
+		}).
+		Task(once("install", func(ctx context.Context) error {
+			if failInstall {
+				return errors.New("disk full")
+			}
+			restored, restoredOK = StoreValue[manifest](ctx, "manifest")
+			_, callbackOK = StoreFrom(ctx).Get("callback")
+			return nil
+		})).
+		Build()
+
+	if err := flow.Run(context.Background()); err == nil {
+		t.Fatal("expected the first run to fail")
+	}
+	failInstall = false
+	if err := flow.Run(context.Background()); err != nil {
+		t.Fatalf("expected the resumed run to succeed, got %v", err)
+	}
+	if !restoredOK || restored.Version != want.Version || !slices.Equal(restored.Files, want.Files) {
+		t.Errorf("restored manifest = %+v, %v; want %+v", restored, restoredOK, want)
+	}
+	if callbackOK {
+		t.Error("expected a value that cannot be encoded to be left out of the checkpoint")
+	}
+}
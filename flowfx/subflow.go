@@ -0,0 +1,85 @@
+package flowfx
+
+import (
+	"context"
+	"strings"
+)
+
+// SubflowStep runs a flow as a named step of another flow. Errors of the
+// nested flow are reported with their full path, e.g. a failing step test in
+// Subflow("checks", ...) of flow deploy fails with a FlowError whose Path is
+// "deploy/checks/test".
+type SubflowStep struct {
+	name string
+	flow Flow
+}
+
+// Subflow wraps flow as a step named name.
+func Subflow(name string, flow Flow) *SubflowStep {
+	return &SubflowStep{name: name, flow: flow}
+}
+
+// Label returns the subflow name. Flows use it as the step name.
+func (s *SubflowStep) Label() string {
+	return s.name
+}
+
+// Execute implements the Step interface. When the nested flow fails with a
+// MultiError, every error in it is prefixed with the subflow name and the
+// rebuilt MultiError is returned; a single error is returned on its own.
+func (s *SubflowStep) Execute(ctx context.Context) error {
+	err := s.flow.Run(withSubflowPath(ctx, s.name))
+	if err == nil {
+		return nil
+	}
+	multi, ok := err.(*MultiError)
+	if !ok {
+		return s.prefix(err)
+	}
+	if len(multi.Errors) == 1 {
+		return s.prefix(multi.Errors[0])
+	}
+	prefixed := NewMultiError()
+	for _, err := range multi.Errors {
+		prefixed.Add(s.prefix(err))
+	}
+	return prefixed
+}
+
+// prefix reports err of the nested flow under the subflow name.
+func (s *SubflowStep) prefix(err error) *FlowError {
+	sub := &FlowError{Flow: s.name, Err: err, subflow: true}
+	if inner, ok := err.(*FlowError); ok {
+		sub.Step = inner.Step
+		sub.Err = inner.Err
+		sub.Attempt = inner.Attempt
+	}
+	return sub
+}
+
+// Run implements the Flow interface, so a subflow can also run on its own.
+func (s *SubflowStep) Run(ctx context.Context) error {
+	return s.Execute(ctx)
+}
+
+// subflowPathKey is the context key for the names of the enclosing subflows.
+type subflowPathKey struct{}
+
+// withSubflowPath appends name to the subflow path of ctx.
+func withSubflowPath(ctx context.Context, name string) context.Context {
+	path, _ := ctx.Value(subflowPathKey{}).([]string)
+	next := make([]string, 0, len(path)+1)
+	next = append(next, path...)
+	next = append(next, name)
+	return context.WithValue(ctx, subflowPathKey{}, next)
+}
+
+// QualifiedName prefixes name with the enclosing subflows, e.g. "checks/test"
+// for a step test running inside Subflow("checks", ...).
+func QualifiedName(ctx context.Context, name string) string {
+	path, _ := ctx.Value(subflowPathKey{}).([]string)
+	if len(path) == 0 {
+		return name
+	}
+	return strings.Join(path, "/") + "/" + name
+}
@@ -0,0 +1,102 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// once returns a task that runs fn without retrying.
+func once(label string, fn func(context.Context) error) *Task {
+	return NewTask(label, fn, WithRetry(RetryConfig{MaxAttempts: 1}))
+}
+
+// fail returns a task function failing with err.
+func fail(err error) func(context.Context) error {
+	return func(context.Context) error { return err }
+}
+
+func TestSubflowErrors(t *testing.T) {
+	errLint := errors.New("lint failed")
+	errTest := errors.New("tests failed")
+	tests := []struct {
+		name      string
+		checks    Flow
+		wantPaths []string
+		wantErrs  []error
+	}{
+		{
+			name:      "success",
+			checks:    NewSequenceBuilder().Name("inner").Task(once("test", fail(nil))).Build(),
+			wantPaths: nil,
+		},
+		{
+			name:      "single failure",
+			checks:    NewSequenceBuilder().Name("inner").Task(once("test", fail(errTest))).Build(),
+			wantPaths: []string{"checks/test"},
+			wantErrs:  []error{errTest},
+		},
+		{
+			name: "every error of a MultiError is prefixed",
+			checks: NewParallelBuilder().Name("inner").
+				Task(once("lint", fail(errLint))).
+				Task(once("test", fail(errTest))).
+				Build(),
+			wantPaths: []string{"checks/lint", "checks/test"},
+			wantErrs:  []error{errLint, errTest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Subflow("checks", tt.checks).Execute(context.Background())
+			if len(tt.wantPaths) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var errs []error
+			var multi *MultiError
+			if errors.As(err, &multi) {
+				errs = multi.Errors
+			} else {
+				errs = []error{err}
+			}
+			var paths []string
+			for _, err := range errs {
+				var flowErr *FlowError
+				if !errors.As(err, &flowErr) {
+					t.Fatalf("expected a FlowError, got %T: %v", err, err)
+				}
+				paths = append(paths, flowErr.Path())
+			}
+			slices.Sort(paths)
+			if !slices.Equal(paths, tt.wantPaths) {
+				t.Errorf("paths = %v, want %v", paths, tt.wantPaths)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("expected errors.Is(%v)", want)
+				}
+			}
+		})
+	}
+}
+
+func TestSubflowInParentFlow(t *testing.T) {
+	checks := NewParallelBuilder().Name("inner").
+		Task(once("lint", fail(errors.New("lint failed")))).
+		Task(once("test", fail(errors.New("tests failed")))).
+		Build()
+	err := NewSequenceBuilder().Name("deploy").Step(Subflow("checks", checks)).Run(context.Background())
+
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("expected the MultiError of the subflow, got %v", err)
+	}
+	if got := multi.ByStep("checks/test"); len(got) != 1 {
+		t.Errorf("expected ByStep to find checks/test, got %v", got)
+	}
+}
@@ -2,6 +2,8 @@ package flowfx
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -15,6 +17,33 @@ type Task struct {
 	OnStart    Hook
 	OnComplete Hook
 	OnError    Hook
+	OnEvent    EventHook // Structured lifecycle events, see Event
+
+	// Dependencies lists the ids of steps that must finish before this task
+	// runs when it is part of a DAG.
+	Dependencies []string
+
+	// Compensation undoes the task's effects when a later critical step of a
+	// Saga fails.
+	Compensation func(ctx context.Context) error
+
+	// Priority orders queued steps of a Parallel flow with bounded
+	// concurrency; higher priorities start first.
+	Priority int
+
+	// CacheKey, CacheTTL and Cache memoize successful executions, see
+	// WithCache.
+	CacheKey func(ctx context.Context) string
+	CacheTTL time.Duration
+	Cache    Cache
+
+	// Resources names the shared semaphores held while an attempt runs,
+	// see WithResource.
+	Resources []string
+
+	// SkipIf skips the task, reporting it as skipped, when it holds before
+	// the first attempt. See WithSkipIf.
+	SkipIf Condition
 }
 
 // TaskOption is a functional option for configuring a Task.
@@ -78,8 +107,71 @@ func WithOnError(hook Hook) TaskOption {
 	}
 }
 
+// WithDependencies declares the steps a task depends on inside a DAG.
+func WithDependencies(ids ...string) TaskOption {
+	return func(t *Task) {
+		t.Dependencies = append(t.Dependencies, ids...)
+	}
+}
+
+// WithCompensation sets the function that undoes the task inside a Saga.
+func WithCompensation(fn func(ctx context.Context) error) TaskOption {
+	return func(t *Task) {
+		t.Compensation = fn
+	}
+}
+
+// WithPriority sets the scheduling priority of the task inside a Parallel flow.
+func WithPriority(priority int) TaskOption {
+	return func(t *Task) {
+		t.Priority = priority
+	}
+}
+
+// Compensate implements the Compensable interface by running the task's
+// Compensation, if any.
+func (t *Task) Compensate(ctx context.Context) error {
+	if t.Compensation == nil {
+		return nil
+	}
+	return t.Compensation(ctx)
+}
+
+// DependsOn declares the steps this task depends on inside a DAG and returns
+// the task for chaining.
+func (t *Task) DependsOn(ids ...string) *Task {
+	t.Dependencies = append(t.Dependencies, ids...)
+	return t
+}
+
 // Execute implements the Step interface for Task.
 func (t *Task) Execute(ctx context.Context) error {
+	if t.SkipIf != nil && t.SkipIf(ctx) {
+		markSkipped(ctx)
+		newTaskEvents(ctx, t).skipped(ctx)
+		return nil
+	}
+
+	cache, key := t.cacheLookup(ctx)
+	if cache == nil {
+		return t.execute(ctx)
+	}
+
+	// Skip the task when an execution with the same key already succeeded
+	if value, ok := cache.Get(key); ok {
+		t.restoreCached(ctx, value)
+		return nil
+	}
+	slot := &cacheSlot{}
+	if err := t.execute(context.WithValue(ctx, cacheSlotKey{}, slot)); err != nil {
+		return err
+	}
+	cache.Set(key, slot.value, t.CacheTTL)
+	return nil
+}
+
+// execute runs the task with its timeout, hooks and retry policy.
+func (t *Task) execute(ctx context.Context) error {
 	// Create timeout context if specified
 	execCtx := ctx
 	var cancel context.CancelFunc
@@ -96,10 +188,12 @@ func (t *Task) Execute(ctx context.Context) error {
 		}()
 	}
 
-	// Call start hook
+	// Call start hook; hooks receive the fully-qualified name within subflows
+	name := QualifiedName(ctx, t.Label)
 	if t.OnStart != nil {
-		t.OnStart(execCtx, t.Label, nil)
+		t.OnStart(execCtx, name, nil)
 	}
+	events := newTaskEvents(ctx, t)
 
 	// Execute with retry logic
 	var lastErr error
@@ -115,34 +209,55 @@ func (t *Task) Execute(ctx context.Context) error {
 				lastErr = ErrCanceled
 			}
 			if t.OnError != nil {
-				t.OnError(execCtx, t.Label, lastErr)
+				t.OnError(execCtx, name, lastErr)
 			}
 			if t.Reporter != nil {
 				t.Reporter.Error(lastErr)
 			}
+			events.finished(execCtx, attempt, lastErr)
 			return NewFlowErrorWithAttempt("task", t.Label, lastErr, attempt)
 		default:
 		}
 
 		// Execute the task
-		err := t.Run(execCtx)
+		startAttempt(execCtx, attempt+1)
+		events.started(execCtx, attempt+1)
+		err := t.runAttempt(execCtx)
 		if err == nil {
 			// Success
 			if t.OnComplete != nil {
-				t.OnComplete(execCtx, t.Label, nil)
+				t.OnComplete(execCtx, name, nil)
 			}
 			if t.Reporter != nil {
 				t.Reporter.Update(1)
 			}
+			events.finished(execCtx, attempt+1, nil)
 			return nil
 		}
 
-		var lastErr error
+		lastErr = err
+
+		// Fatal and non-retryable errors end the task right away
+		if !t.Retry.shouldRetry(err) {
+			finalErr := NewFlowErrorWithAttempt("task", t.Label, err, attempt)
+			if t.OnError != nil {
+				t.OnError(execCtx, name, finalErr)
+			}
+			if t.Reporter != nil {
+				t.Reporter.Error(finalErr)
+			}
+			events.finished(execCtx, attempt+1, finalErr)
+			return finalErr
+		}
 
 		// If this is the last attempt, don't wait
 		if attempt == t.Retry.MaxAttempts-1 {
 			break
 		}
+		// A canceled task is reported as canceled below, not as retrying
+		if execCtx.Err() == nil {
+			events.retrying(execCtx, attempt+1, err)
+		}
 
 		// Wait before retry with exponential backoff
 		select {
@@ -153,11 +268,12 @@ func (t *Task) Execute(ctx context.Context) error {
 				lastErr = ErrCanceled
 			}
 			if t.OnError != nil {
-				t.OnError(execCtx, t.Label, lastErr)
+				t.OnError(execCtx, name, lastErr)
 			}
 			if t.Reporter != nil {
 				t.Reporter.Error(lastErr)
 			}
+			events.finished(execCtx, attempt+1, lastErr)
 			return NewFlowErrorWithAttempt("task", t.Label, lastErr, attempt)
 		case <-time.After(delay):
 			delay = time.Duration(float64(delay) * t.Retry.Backoff)
@@ -165,13 +281,33 @@ func (t *Task) Execute(ctx context.Context) error {
 	}
 
 	// All retries exhausted
-	finalErr := NewFlowErrorWithAttempt("task", t.Label, ErrRetryExhausted, t.Retry.MaxAttempts-1)
+	if lastErr == nil {
+		lastErr = ErrRetryExhausted
+	} else {
+		lastErr = fmt.Errorf("%w: %w", ErrRetryExhausted, lastErr)
+	}
+	finalErr := NewFlowErrorWithAttempt("task", t.Label, lastErr, t.Retry.MaxAttempts-1)
 	if t.OnError != nil {
-		t.OnError(execCtx, t.Label, finalErr)
+		t.OnError(execCtx, name, finalErr)
 	}
 	if t.Reporter != nil {
 		t.Reporter.Error(finalErr)
 	}
+	events.finished(execCtx, t.Retry.MaxAttempts, finalErr)
 
 	return finalErr
 }
+
+// runAttempt runs the task once while holding its resources. A resource
+// missing from the context is a configuration error and is not retried.
+func (t *Task) runAttempt(ctx context.Context) error {
+	release, err := Acquire(ctx, t.Resources...)
+	if err != nil {
+		if errors.Is(err, ErrUnknownResource) {
+			return Fatal(err)
+		}
+		return err
+	}
+	defer release()
+	return t.Run(ctx)
+}
@@ -0,0 +1,383 @@
+package flowfx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// nodeShape is how a topology node is drawn.
+type nodeShape int
+
+const (
+	shapeStep     nodeShape = iota // A plain step
+	shapeDecision                  // A condition choosing between paths
+	shapeGate                      // An approval gate
+)
+
+// topoNode is a step of a flow topology.
+type topoNode struct {
+	id    string
+	label string
+	shape nodeShape
+}
+
+// topoEdge connects two nodes; label is optional.
+type topoEdge struct {
+	from, to string
+	label    string
+}
+
+// topoGroup is a named flow containing nodes and nested flows.
+type topoGroup struct {
+	id     string
+	label  string
+	nodes  []*topoNode
+	groups []*topoGroup
+}
+
+// topology is the graph of a composed flow.
+type topology struct {
+	root  *topoGroup
+	edges []topoEdge
+	count int
+}
+
+// newTopology walks flow and builds its graph.
+func newTopology(flow Flow) *topology {
+	t := &topology{root: &topoGroup{}}
+	t.addFlow(t.root, flow)
+	return t
+}
+
+func (t *topology) nextID() string {
+	t.count++
+	return fmt.Sprintf("n%d", t.count)
+}
+
+// node adds a node to g and returns its id.
+func (t *topology) node(g *topoGroup, label string, shape nodeShape) string {
+	id := t.nextID()
+	g.nodes = append(g.nodes, &topoNode{id: id, label: label, shape: shape})
+	return id
+}
+
+// group adds a nested flow to g.
+func (t *topology) group(g *topoGroup, label string) *topoGroup {
+	sub := &topoGroup{id: "cluster_" + t.nextID(), label: label}
+	g.groups = append(g.groups, sub)
+	return sub
+}
+
+// connect adds an edge from every node in from to every node in to.
+func (t *topology) connect(from, to []string, label string) {
+	for _, f := range from {
+		for _, s := range to {
+			t.edges = append(t.edges, topoEdge{from: f, to: s, label: label})
+		}
+	}
+}
+
+// chain links steps one after another and returns the entry and exit nodes
+// of the whole chain.
+func (t *topology) chain(g *topoGroup, steps []Step, label func(int) string) (entries, exits []string) {
+	for i, step := range steps {
+		in, out := t.addStep(g, step, label(i))
+		if i == 0 {
+			entries = in
+		} else {
+			t.connect(exits, in, "")
+		}
+		exits = out
+	}
+	return entries, exits
+}
+
+// addFlow adds the steps of flow to g and returns its entry and exit nodes.
+func (t *topology) addFlow(g *topoGroup, flow Flow) (entries, exits []string) {
+	switch f := flow.(type) {
+	case *Sequence:
+		sub := t.group(g, f.name)
+		return t.chain(sub, f.steps, func(i int) string { return stepLabel(f.steps[i], i) })
+	case *Saga:
+		sub := t.group(g, f.name)
+		steps := make([]Step, len(f.steps))
+		for i, ss := range f.steps {
+			steps[i] = ss.step
+		}
+		return t.chain(sub, steps, func(i int) string { return stepLabel(steps[i], i) })
+	case *Script:
+		sub := t.group(g, f.name)
+		steps := make([]Step, len(f.steps))
+		for i, ss := range f.steps {
+			steps[i] = ss.Step
+		}
+		return t.chain(sub, steps, func(i int) string {
+			if f.steps[i].Name != "" {
+				return f.steps[i].Name
+			}
+			return stepLabel(steps[i], i)
+		})
+	case *MapFlow:
+		sub := t.group(g, f.name)
+		steps := make([]Step, 0, len(f.order))
+		keys := make([]string, 0, len(f.order))
+		for _, key := range f.order {
+			if step, ok := f.steps[key]; ok {
+				steps = append(steps, step)
+				keys = append(keys, key)
+			}
+		}
+		return t.chain(sub, steps, func(i int) string { return keys[i] })
+	case *Parallel:
+		sub := t.group(g, f.name)
+		for i, step := range f.steps {
+			in, out := t.addStep(sub, step, stepLabel(step, i))
+			entries = append(entries, in...)
+			exits = append(exits, out...)
+		}
+		return entries, exits
+	case *DAG:
+		sub := t.group(g, f.name)
+		ins := make(map[string][]string, len(f.nodes))
+		outs := make(map[string][]string, len(f.nodes))
+		dependents := make(map[string]int)
+		for _, node := range f.nodes {
+			ins[node.id], outs[node.id] = t.addStep(sub, node.step, node.id)
+			for _, dep := range node.deps {
+				dependents[dep]++
+			}
+		}
+		for _, node := range f.nodes {
+			if len(node.deps) == 0 {
+				entries = append(entries, ins[node.id]...)
+			}
+			for _, dep := range node.deps {
+				t.connect(outs[dep], ins[node.id], "")
+			}
+			if dependents[node.id] == 0 {
+				exits = append(exits, outs[node.id]...)
+			}
+		}
+		return entries, exits
+	case *Pipeline:
+		sub := t.group(g, f.name)
+		for i, stage := range f.stages {
+			id := []string{t.node(sub, stage.name, shapeStep)}
+			if i == 0 {
+				entries = id
+			} else {
+				t.connect(exits, id, "")
+			}
+			exits = id
+		}
+		return entries, exits
+	case *Tree:
+		sub := t.group(g, f.name)
+		if f.root == nil {
+			return nil, nil
+		}
+		return t.addTreeNode(sub, f.root)
+	case *Branch:
+		decision := t.node(g, f.name, shapeDecision)
+		exits = t.addPath(g, decision, f.truePath, "true")
+		if f.falsePath != nil {
+			exits = append(exits, t.addPath(g, decision, f.falsePath, "false")...)
+		} else {
+			exits = append(exits, decision)
+		}
+		return []string{decision}, exits
+	case *IfFlow:
+		decision := t.node(g, f.name, shapeDecision)
+		for i, clause := range f.clauses {
+			exits = append(exits, t.addPath(g, decision, clause.flow, fmt.Sprintf("if %d", i+1))...)
+		}
+		if f.elseFlow != nil {
+			exits = append(exits, t.addPath(g, decision, f.elseFlow, "else")...)
+		} else {
+			exits = append(exits, decision)
+		}
+		return []string{decision}, exits
+	case *SubflowStep:
+		sub := t.group(g, f.name)
+		entries, exits = t.addFlow(sub, f.flow)
+
+		// Name the nested flow's cluster after the subflow instead of wrapping it
+		if len(sub.nodes) == 0 && len(sub.groups) == 1 {
+			inner := sub.groups[0]
+			inner.label = f.name
+			*sub = *inner
+		}
+		return entries, exits
+	case stepFlow:
+		return t.addStep(g, f.step, stepLabel(f.step, 0))
+	default:
+		// Flows flowfx cannot look into are drawn as a single step
+		label := fmt.Sprintf("%T", flow)
+		if l, ok := flow.(labeled); ok && l.Label() != "" {
+			label = l.Label()
+		}
+		id := t.node(g, label, shapeStep)
+		return []string{id}, []string{id}
+	}
+}
+
+// addPath adds a conditional path starting at decision and returns its
+// exits. A nil path exits at the decision itself.
+func (t *topology) addPath(g *topoGroup, decision string, flow Flow, label string) []string {
+	if flow == nil {
+		return []string{decision}
+	}
+	in, out := t.addFlow(g, flow)
+	t.connect([]string{decision}, in, label)
+	return out
+}
+
+// addTreeNode adds node and its children, which run after it.
+func (t *topology) addTreeNode(g *topoGroup, node *TreeNode) (entries, exits []string) {
+	entries, exits = t.addStep(g, node.Step, node.Name)
+	if len(node.Children) == 0 {
+		return entries, exits
+	}
+	parent := exits
+	exits = nil
+	for _, child := range node.Children {
+		in, out := t.addTreeNode(g, child)
+		t.connect(parent, in, "")
+		exits = append(exits, out...)
+	}
+	return entries, exits
+}
+
+// addStep adds step, expanding it when it is itself a flow.
+func (t *topology) addStep(g *topoGroup, step Step, label string) (entries, exits []string) {
+	switch s := step.(type) {
+	case nil:
+		id := t.node(g, label, shapeStep)
+		return []string{id}, []string{id}
+	case *ApprovalGate:
+		id := t.node(g, s.cfg.Name, shapeGate)
+		return []string{id}, []string{id}
+	case *Breaker:
+		return t.addStep(g, s.step, label)
+	case *DelayedStep:
+		return t.addStep(g, s.step, label)
+	case *SkippedStep:
+		return t.addStep(g, s.step, label)
+	case *RepeatStep:
+		if s.step != nil {
+			return t.addStep(g, s.step, label)
+		}
+	case Flow:
+		return t.addFlow(g, s)
+	}
+	id := t.node(g, label, shapeStep)
+	return []string{id}, []string{id}
+}
+
+// ExportDOT writes the topology of flow as a Graphviz DOT digraph. Nested
+// flows become clusters; render it with e.g. "dot -Tsvg". Flows nested with
+// Subflow are expanded, while steps such as StepFunc(flow.Run) are opaque
+// and drawn as a single node.
+func ExportDOT(w io.Writer, flow Flow) error {
+	t := newTopology(flow)
+	var b strings.Builder
+	b.WriteString("digraph flow {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	t.writeDOTGroup(&b, t.root, "\t")
+	for _, e := range t.edges {
+		if e.label != "" {
+			fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", e.from, e.to, dotQuote(e.label))
+		} else {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", e.from, e.to)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (t *topology) writeDOTGroup(b *strings.Builder, g *topoGroup, indent string) {
+	for _, n := range g.nodes {
+		attrs := "label=" + dotQuote(n.label)
+		switch n.shape {
+		case shapeDecision:
+			attrs += ", shape=diamond"
+		case shapeGate:
+			attrs += ", shape=hexagon"
+		}
+		fmt.Fprintf(b, "%s%s [%s];\n", indent, n.id, attrs)
+	}
+	for _, sub := range g.groups {
+		fmt.Fprintf(b, "%ssubgraph %s {\n%s\tlabel=%s;\n", indent, sub.id, indent, dotQuote(sub.label))
+		t.writeDOTGroup(b, sub, indent+"\t")
+		fmt.Fprintf(b, "%s}\n", indent)
+	}
+}
+
+// dotQuote quotes s as a DOT string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// ExportMermaid writes the topology of flow as a Mermaid flowchart, which
+// renders directly in Markdown on most code hosts.
+func ExportMermaid(w io.Writer, flow Flow) error {
+	t := newTopology(flow)
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	t.writeMermaidGroup(&b, t.root, "    ")
+	for _, e := range t.edges {
+		if e.label != "" {
+			fmt.Fprintf(&b, "    %s -->|%s| %s\n", e.from, mermaidQuote(e.label), e.to)
+		} else {
+			fmt.Fprintf(&b, "    %s --> %s\n", e.from, e.to)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (t *topology) writeMermaidGroup(b *strings.Builder, g *topoGroup, indent string) {
+	for _, n := range g.nodes {
+		label := mermaidQuote(n.label)
+		switch n.shape {
+		case shapeDecision:
+			fmt.Fprintf(b, "%s%s{%s}\n", indent, n.id, label)
+		case shapeGate:
+			fmt.Fprintf(b, "%s%s{{%s}}\n", indent, n.id, label)
+		default:
+			fmt.Fprintf(b, "%s%s[%s]\n", indent, n.id, label)
+		}
+	}
+	for _, sub := range g.groups {
+		fmt.Fprintf(b, "%ssubgraph %s [%s]\n", indent, sub.id, mermaidQuote(sub.label))
+		t.writeMermaidGroup(b, sub, indent+"    ")
+		fmt.Fprintf(b, "%send\n", indent)
+	}
+}
+
+// mermaidQuote quotes s as a Mermaid label. Characters Mermaid would read
+// as markup are written as entity codes.
+func mermaidQuote(s string) string {
+	return `"` + mermaidEscaper.Replace(s) + `"`
+}
+
+var mermaidEscaper = strings.NewReplacer(`#`, "#35;", `"`, "#quot;", `<`, "#lt;", `>`, "#gt;", "\n", " ")
+
+// ExportDOT writes the sequence as a Graphviz DOT digraph, see ExportDOT.
+func (s *Sequence) ExportDOT(w io.Writer) error { return ExportDOT(w, s) }
+
+// ExportMermaid writes the sequence as a Mermaid flowchart, see ExportMermaid.
+func (s *Sequence) ExportMermaid(w io.Writer) error { return ExportMermaid(w, s) }
+
+// ExportDOT writes the parallel flow as a Graphviz DOT digraph, see ExportDOT.
+func (p *Parallel) ExportDOT(w io.Writer) error { return ExportDOT(w, p) }
+
+// ExportMermaid writes the parallel flow as a Mermaid flowchart, see ExportMermaid.
+func (p *Parallel) ExportMermaid(w io.Writer) error { return ExportMermaid(w, p) }
+
+// ExportDOT writes the DAG as a Graphviz DOT digraph, see ExportDOT.
+func (d *DAG) ExportDOT(w io.Writer) error { return ExportDOT(w, d) }
+
+// ExportMermaid writes the DAG as a Mermaid flowchart, see ExportMermaid.
+func (d *DAG) ExportMermaid(w io.Writer) error { return ExportMermaid(w, d) }
@@ -0,0 +1,131 @@
+package flowfx
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+// topologyFlows returns the flows of the topology golden tests.
+func topologyFlows(t *testing.T) map[string]Flow {
+	noop := StepFunc(func(context.Context) error { return nil })
+	checks := NewParallelBuilder().Name("checks").
+		Task(NewTask(`lint "strict"`, fail(nil))).
+		Task(NewTask("test\nrace", fail(nil))).
+		Build()
+	release, err := NewDAGBuilder().Name("release").
+		Step("build", noop).
+		Step("checks", Subflow(`checks <#1>`, checks), "build").
+		Step(`C:\publish`, noop, "build", "checks").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deploy := NewSequenceBuilder().Name("deploy").Task(NewTask("push", fail(nil))).Build()
+	onMain := NewBranchBuilder(func(context.Context) (bool, error) { return true, nil }).
+		Name(`on "main"?`).
+		When(deploy).
+		Build()
+	main := NewSequenceBuilder().Name("main").
+		Task(NewTask("build", fail(nil))).
+		Step(Subflow("ship", onMain)).
+		Build()
+
+	return map[string]Flow{"dag": release, "subflow": main}
+}
+
+func TestExportDOT(t *testing.T) {
+	want := map[string]string{
+		"dag": `digraph flow {
+	rankdir=LR;
+	node [shape=box];
+	subgraph cluster_n1 {
+		label="release";
+		n2 [label="build"];
+		n7 [label="C:\\publish"];
+		subgraph cluster_n4 {
+			label="checks <#1>";
+			n5 [label="lint \"strict\""];
+			n6 [label="test\nrace"];
+		}
+	}
+	n2 -> n5;
+	n2 -> n6;
+	n2 -> n7;
+	n5 -> n7;
+	n6 -> n7;
+}
+`,
+		"subflow": `digraph flow {
+	rankdir=LR;
+	node [shape=box];
+	subgraph cluster_n1 {
+		label="main";
+		n2 [label="build"];
+		subgraph cluster_n3 {
+			label="ship";
+			n4 [label="on \"main\"?", shape=diamond];
+			subgraph cluster_n5 {
+				label="deploy";
+				n6 [label="push"];
+			}
+		}
+	}
+	n4 -> n6 [label="true"];
+	n2 -> n4;
+}
+`,
+	}
+	testExport(t, ExportDOT, want)
+}
+
+func TestExportMermaid(t *testing.T) {
+	want := map[string]string{
+		"dag": `flowchart LR
+    subgraph cluster_n1 ["release"]
+        n2["build"]
+        n7["C:\publish"]
+        subgraph cluster_n4 ["checks #lt;#35;1#gt;"]
+            n5["lint #quot;strict#quot;"]
+            n6["test race"]
+        end
+    end
+    n2 --> n5
+    n2 --> n6
+    n2 --> n7
+    n5 --> n7
+    n6 --> n7
+`,
+		"subflow": `flowchart LR
+    subgraph cluster_n1 ["main"]
+        n2["build"]
+        subgraph cluster_n3 ["ship"]
+            n4{"on #quot;main#quot;?"}
+            subgraph cluster_n5 ["deploy"]
+                n6["push"]
+            end
+        end
+    end
+    n4 -->|"true"| n6
+    n2 --> n4
+`,
+	}
+	testExport(t, ExportMermaid, want)
+}
+
+// testExport compares the output of export for every topology flow with want.
+func testExport(t *testing.T, export func(io.Writer, Flow) error, want map[string]string) {
+	for name, flow := range topologyFlows(t) {
+		t.Run(name, func(t *testing.T) {
+			var b strings.Builder
+			if err := export(&b, flow); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := b.String(); got != want[name] {
+				t.Errorf("got\n%s\nwant\n%s", got, want[name])
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package flowfx
+
+import "context"
+
+// Attribute keys set on flow and step spans.
+const (
+	AttrFlow     = "flowfx.flow"
+	AttrStep     = "flowfx.step"
+	AttrAttempt  = "flowfx.attempt"
+	AttrCritical = "flowfx.critical"
+	AttrSkipped  = "flowfx.skipped"
+)
+
+// Attribute is a key/value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value any // string, bool, int or float64
+}
+
+// Span is a traced unit of work.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer opens spans for flows and steps. It mirrors the shape of an
+// OpenTelemetry tracer so a thin adapter connects flows to distributed
+// tracing without flowfx depending on the OpenTelemetry SDK:
+//
+//	type otelTracer struct{ t trace.Tracer }
+//
+//	func (o otelTracer) Start(ctx context.Context, name string, attrs ...flowfx.Attribute) (context.Context, flowfx.Span) {
+//		ctx, span := o.t.Start(ctx, name, trace.WithAttributes(toKeyValues(attrs)...))
+//		return ctx, otelSpan{span}
+//	}
+//
+// The returned context is passed on to the step, so spans created by the
+// services a step calls become its children.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// tracerKey and spanKey are the context keys for the tracer and current span.
+type (
+	tracerKey struct{}
+	spanKey   struct{}
+)
+
+// WithTracer returns a context that traces every flow and step run with it.
+func WithTracer(ctx context.Context, tracer Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// activeSpan wraps the span opened by startSpan. A nil activeSpan is valid
+// and does nothing, so callers need not check whether tracing is enabled.
+type activeSpan struct {
+	span Span
+}
+
+// startSpan opens a span named name when ctx carries a Tracer.
+func startSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, *activeSpan) {
+	tracer, ok := ctx.Value(tracerKey{}).(Tracer)
+	if !ok {
+		return ctx, nil
+	}
+	ctx, span := tracer.Start(ctx, name, attrs...)
+	return context.WithValue(ctx, spanKey{}, span), &activeSpan{span: span}
+}
+
+// startFlowSpan opens the span of a flow run.
+func startFlowSpan(ctx context.Context, flow string) (context.Context, *activeSpan) {
+	return startSpan(ctx, flow, Attribute{Key: AttrFlow, Value: flow})
+}
+
+// finish records err, if any, and ends the span.
+func (s *activeSpan) finish(err error) {
+	if s == nil {
+		return
+	}
+	if err != nil {
+		s.span.RecordError(err)
+	}
+	s.span.End()
+}
+
+// traceAttempt sets the attempt number on the current span.
+func traceAttempt(ctx context.Context, attempt int) {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		span.SetAttributes(Attribute{Key: AttrAttempt, Value: attempt})
+	}
+}
+
+// traceSkipped marks the current span as skipped.
+func traceSkipped(ctx context.Context) {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		span.SetAttributes(Attribute{Key: AttrSkipped, Value: true})
+	}
+}
@@ -0,0 +1,153 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingTracer records every span it opens.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+// recordedSpan is a span opened by recordingTracer.
+type recordedSpan struct {
+	tracer *recordingTracer
+	name   string
+	parent string
+	attrs  map[string]any
+	errs   []error
+	ended  bool
+}
+
+// recordedSpanKey is the context key for the current recordedSpan.
+type recordedSpanKey struct{}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &recordedSpan{tracer: r, name: name, attrs: make(map[string]any)}
+	if parent, ok := ctx.Value(recordedSpanKey{}).(*recordedSpan); ok {
+		span.parent = parent.name
+	}
+	span.SetAttributes(attrs...)
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+	return context.WithValue(ctx, recordedSpanKey{}, span), span
+}
+
+// span returns the span named name.
+func (r *recordingTracer) span(name string) *recordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.spans {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// tree returns "parent > name" for every span in start order.
+func (r *recordingTracer) tree() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, s := range r.spans {
+		out = append(out, strings.TrimPrefix(s.parent+" > "+s.name, " > "))
+	}
+	return out
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.errs = append(s.errs, err)
+}
+
+func (s *recordedSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.ended = true
+}
+
+func TestTracing(t *testing.T) {
+	errTest := errors.New("tests failed")
+	attempts := 0
+	checks := NewSequenceBuilder().Name("checks").
+		Task(NewTask("test", func(context.Context) error {
+			attempts++
+			return errTest
+		}, WithRetry(RetryConfig{MaxAttempts: 2, Delay: time.Millisecond, Backoff: 1}))).
+		Build()
+	tracer := &recordingTracer{}
+	err := NewSequenceBuilder().Name("ci").
+		Task(once("build", fail(nil))).
+		Task(NewTask("lint", fail(nil), WithSkipIf(func(context.Context) bool { return true }))).
+		Step(Subflow("checks", checks)).
+		Run(WithTracer(context.Background(), tracer))
+	if !errors.Is(err, errTest) {
+		t.Fatalf("expected %v, got %v", errTest, err)
+	}
+
+	wantTree := []string{
+		"ci",
+		"ci > ci/build",
+		"ci > ci/lint",
+		"ci > ci/checks",
+		"ci/checks > checks",
+		"checks > checks/test",
+	}
+	if got := tracer.tree(); !slices.Equal(got, wantTree) {
+		t.Errorf("spans %q, want %q", got, wantTree)
+	}
+	for _, s := range tracer.spans {
+		if !s.ended {
+			t.Errorf("span %s was not ended", s.name)
+		}
+	}
+
+	tests := []struct {
+		span    string
+		attrs   map[string]any
+		wantErr bool
+	}{
+		{"ci", map[string]any{AttrFlow: "ci"}, true},
+		{"ci/build", map[string]any{AttrFlow: "ci", AttrStep: "build", AttrCritical: true, AttrAttempt: 1}, false},
+		{"ci/lint", map[string]any{AttrFlow: "ci", AttrStep: "lint", AttrCritical: true, AttrSkipped: true}, false},
+		{"ci/checks", map[string]any{AttrFlow: "ci", AttrStep: "checks", AttrCritical: true}, true},
+		{"checks", map[string]any{AttrFlow: "checks"}, true},
+		{"checks/test", map[string]any{AttrFlow: "checks", AttrStep: "test", AttrCritical: true, AttrAttempt: 2}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.span, func(t *testing.T) {
+			s := tracer.span(tt.span)
+			if s == nil {
+				t.Fatal("span not recorded")
+			}
+			if got, want := fmt.Sprint(s.attrs), fmt.Sprint(tt.attrs); got != want {
+				t.Errorf("attributes %s, want %s", got, want)
+			}
+			if tt.wantErr != (len(s.errs) == 1) {
+				t.Fatalf("recorded errors %v, want error: %v", s.errs, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(s.errs[0], errTest) {
+				t.Errorf("recorded %v, want %v", s.errs[0], errTest)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ type Tree struct {
 	onStart    Hook
 	onComplete Hook
 	onError    Hook
+	middleware []Middleware
 }
 
 // TreeNode represents a single node in the tree flow.
@@ -32,6 +33,7 @@ type TreeConfig struct {
 	OnStart    Hook
 	OnComplete Hook
 	OnError    Hook
+	Middleware []Middleware // Wraps every step execution, see Use
 }
 
 // DefaultTreeConfig returns the default configuration for a Tree flow.
@@ -48,6 +50,7 @@ func newTree(cfg TreeConfig) *Tree {
 		onStart:    cfg.OnStart,
 		onComplete: cfg.OnComplete,
 		onError:    cfg.OnError,
+		middleware: cfg.Middleware,
 	}
 }
 
@@ -84,11 +87,19 @@ func (t *Tree) CreateRoot(name string, step Step) *Tree {
 
 // Run executes the tree flow starting from the root node.
 // It implements the Flow interface.
-func (t *Tree) Run(ctx context.Context) error {
+func (t *Tree) Run(ctx context.Context) (err error) {
 	if t.root == nil {
 		return NewFlowError(t.name, "", ErrEmptyFlow)
 	}
 
+	// Share the run's store and typed results with nested flows and steps
+	ctx = withFlowState(ctx)
+	ctx = withMiddleware(ctx, t.middleware)
+
+	// Trace the flow run when a Tracer is configured
+	ctx, span := startFlowSpan(ctx, t.name)
+	defer func() { span.finish(err) }()
+
 	// Call onStart hook if provided
 	if t.onStart != nil {
 		t.onStart(ctx, t.name, nil)
@@ -120,7 +131,7 @@ func (t *Tree) executeNode(ctx context.Context, node *TreeNode, depth int) error
 
 	// Execute the current node's step
 	if node.Step != nil {
-		if err := node.Step.Execute(ctx); err != nil {
+		if err := execStep(ctx, StepInfo{Flow: t.name, Step: node.Name, Critical: true}, node.Step); err != nil {
 			return NewFlowError(t.name, node.Name, err)
 		}
 	}
@@ -229,6 +240,13 @@ func (tn *TreeNode) GetPath() []string {
 	return append(parentPath, tn.Name)
 }
 
+// Use adds middleware that wraps every step execution of the flow,
+// including steps of nested flows.
+func (t *Tree) Use(mws ...Middleware) *Tree {
+	t.middleware = append(t.middleware, mws...)
+	return t
+}
+
 // --- DSL BUILDER ---
 
 // TreeBuilder provides a fluent API for building tree flows.
@@ -261,6 +279,12 @@ func (tb *TreeBuilder) OnError(hook Hook) *TreeBuilder {
 	return tb
 }
 
+// Use adds middleware that wraps every step execution.
+func (tb *TreeBuilder) Use(mws ...Middleware) *TreeBuilder {
+	tb.config.Middleware = append(tb.config.Middleware, mws...)
+	return tb
+}
+
 // Root sets the root node of the tree.
 func (tb *TreeBuilder) Root(node *TreeNode) *TreeBuilder {
 	tb.root = node
@@ -0,0 +1,312 @@
+package flowfx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/writer"
+)
+
+// nodeStatus is the execution state of a TreeView node.
+type nodeStatus int
+
+const (
+	nodePending nodeStatus = iota // Seeded from the flow, not started yet
+	nodeRunning
+	nodeSucceeded
+	nodeFailed
+	nodeCanceled
+	nodeSkipped
+)
+
+// viewNode is a step shown by a TreeView.
+type viewNode struct {
+	label    string
+	flow     string // Flow of the node's children, shown when it differs from label
+	status   nodeStatus
+	start    time.Time
+	elapsed  time.Duration
+	children []*viewNode
+}
+
+// TreeView is a runfx Visual that renders flow execution as a live tree:
+// nested flows appear below the step that runs them, each step with a status
+// icon, its duration and a spinner while it runs.
+//
+//	view := flowfx.NewTreeView(seq)
+//	unmount, _ := loop.Mount(view)
+//	defer unmount()
+//	err := seq.Use(view.Middleware()).Run(ctx)
+type TreeView struct {
+	mu     sync.Mutex
+	roots  []*viewNode
+	byFlow map[string]*viewNode // Root node of each top-level flow
+	frame  int
+}
+
+// spinnerFrames animate running steps.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// treeNodeKey is the context key for the TreeView node of the running step.
+type treeNodeKey struct{}
+
+// NewTreeView creates a TreeView showing the steps of flows as pending
+// until they start. Without flows, steps appear as they start.
+func NewTreeView(flows ...Flow) *TreeView {
+	v := &TreeView{byFlow: make(map[string]*viewNode)}
+	for _, flow := range flows {
+		name, children := seedFlow(flow)
+		root := &viewNode{label: name, flow: name, children: children}
+		v.byFlow[name] = root
+		v.roots = append(v.roots, root)
+	}
+	return v
+}
+
+// seedFlow returns the name of flow and pending nodes for the steps it
+// runs. Branches and flows whose steps are only known at run time seed
+// nothing; their steps appear as they start.
+func seedFlow(flow Flow) (string, []*viewNode) {
+	var (
+		name   string
+		labels []string
+		steps  []Step
+	)
+	switch f := flow.(type) {
+	case *Sequence:
+		name, steps = f.name, f.steps
+		for i, step := range f.steps {
+			labels = append(labels, stepLabel(step, i))
+		}
+	case *Parallel:
+		name, steps = f.name, f.steps
+		for i, step := range f.steps {
+			labels = append(labels, stepLabel(step, i))
+		}
+	case *Saga:
+		name = f.name
+		for i, ss := range f.steps {
+			labels = append(labels, stepLabel(ss.step, i))
+			steps = append(steps, ss.step)
+		}
+	case *Script:
+		name = f.name
+		for i, ss := range f.steps {
+			label := ss.Name
+			if label == "" {
+				label = fmt.Sprintf("step_%d", i+1)
+			}
+			labels = append(labels, label)
+			steps = append(steps, ss.Step)
+		}
+	case *DAG:
+		name = f.name
+		for _, node := range f.nodes {
+			labels = append(labels, node.id)
+			steps = append(steps, node.step)
+		}
+	case *MapFlow:
+		name = f.name
+		for _, key := range f.order {
+			if step, ok := f.steps[key]; ok {
+				labels = append(labels, key)
+				steps = append(steps, step)
+			}
+		}
+	case *Pipeline:
+		name = f.name
+		for _, stage := range f.stages {
+			labels = append(labels, stage.name)
+			steps = append(steps, nil)
+		}
+	default:
+		return "", nil
+	}
+
+	nodes := make([]*viewNode, len(labels))
+	for i, label := range labels {
+		nodes[i] = &viewNode{label: label}
+		if sub, ok := steps[i].(*SubflowStep); ok {
+			nodes[i].flow, nodes[i].children = seedFlow(sub.flow)
+		}
+	}
+	return name, nodes
+}
+
+// Middleware returns the middleware that feeds the view. Register it on the
+// outermost flow; nested flows inherit it.
+func (v *TreeView) Middleware() Middleware {
+	return func(next StepFunc) StepFunc {
+		return func(ctx context.Context) error {
+			info := StepInfoFrom(ctx)
+			node := v.start(ctx, info)
+			err := next(context.WithValue(ctx, treeNodeKey{}, node))
+			v.finish(ctx, node, err)
+			return err
+		}
+	}
+}
+
+// start marks the pending node for info as running, or adds a running node
+// below its parent when none was seeded.
+func (v *TreeView) start(ctx context.Context, info StepInfo) *viewNode {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	parent, ok := ctx.Value(treeNodeKey{}).(*viewNode)
+	if !ok {
+		parent = v.byFlow[info.Flow]
+		if parent == nil {
+			parent = &viewNode{label: info.Flow}
+			v.byFlow[info.Flow] = parent
+			v.roots = append(v.roots, parent)
+		}
+		if parent.status == nodePending {
+			parent.status, parent.start = nodeRunning, time.Now()
+		}
+	}
+	if parent.flow == "" {
+		parent.flow = info.Flow
+	}
+
+	for _, node := range parent.children {
+		if node.status == nodePending && node.label == info.Step {
+			node.status, node.start = nodeRunning, time.Now()
+			return node
+		}
+	}
+	node := &viewNode{label: info.Step, status: nodeRunning, start: time.Now()}
+	parent.children = append(parent.children, node)
+	return node
+}
+
+// finish records the outcome of node.
+func (v *TreeView) finish(ctx context.Context, node *viewNode, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	node.elapsed = time.Since(node.start)
+	switch {
+	case err == nil && StepSkipped(ctx):
+		node.status = nodeSkipped
+	case err == nil:
+		node.status = nodeSucceeded
+	case ctx.Err() != nil:
+		node.status = nodeCanceled
+	default:
+		node.status = nodeFailed
+	}
+	for _, root := range v.roots {
+		if root.status == nodePending {
+			continue
+		}
+		root.status = rollup(root)
+		if root.status != nodeRunning {
+			root.elapsed = time.Since(root.start)
+		}
+	}
+}
+
+// rollup derives the status of a flow root from its steps. Pending steps
+// keep the root running unless a step failed or was canceled, which stops
+// the flow before they start.
+func rollup(root *viewNode) nodeStatus {
+	status, pending := nodeSucceeded, false
+	for _, child := range root.children {
+		switch child.status {
+		case nodeRunning:
+			return nodeRunning
+		case nodePending:
+			pending = true
+		case nodeFailed:
+			status = nodeFailed
+		case nodeCanceled:
+			if status == nodeSucceeded {
+				status = nodeCanceled
+			}
+		}
+	}
+	if pending && status == nodeSucceeded {
+		return nodeRunning
+	}
+	return status
+}
+
+// Render implements runfx.Visual.
+func (v *TreeView) Render(w writer.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var b strings.Builder
+	for _, root := range v.roots {
+		v.renderNode(&b, root, "", "", true)
+	}
+	w.Write([]byte(b.String()))
+}
+
+// renderNode writes node and its children. prefix starts the node's line,
+// indent starts the lines of its children.
+func (v *TreeView) renderNode(b *strings.Builder, node *viewNode, prefix, indent string, root bool) {
+	b.WriteString(prefix)
+	b.WriteString(v.icon(node.status))
+	b.WriteString(" ")
+	b.WriteString(node.label)
+	if !root && node.flow != "" && node.flow != node.label {
+		b.WriteString(color.Dim + " (" + node.flow + ")" + color.Reset)
+	}
+	elapsed := node.elapsed
+	if node.status == nodeRunning {
+		elapsed = time.Since(node.start)
+	}
+	if node.status != nodePending {
+		b.WriteString(color.Dim + " " + formatElapsed(elapsed) + color.Reset)
+	}
+	b.WriteString("\n")
+
+	for i, child := range node.children {
+		last := i == len(node.children)-1
+		branch, next := "├─ ", "│  "
+		if last {
+			branch, next = "└─ ", "   "
+		}
+		v.renderNode(b, child, indent+branch, indent+next, false)
+	}
+}
+
+// icon returns the status icon of a node.
+func (v *TreeView) icon(status nodeStatus) string {
+	switch status {
+	case nodeSucceeded:
+		return color.Green.Apply("✓")
+	case nodeFailed:
+		return color.Red.Apply("✗")
+	case nodeCanceled:
+		return color.Yellow.Apply("⊘")
+	case nodeSkipped:
+		return color.Dim + "↷" + color.Reset
+	case nodePending:
+		return color.Dim + "○" + color.Reset
+	default:
+		return color.Cyan.Apply(spinnerFrames[v.frame%len(spinnerFrames)])
+	}
+}
+
+// formatElapsed renders a duration compactly, e.g. 850ms or 1.2s.
+func formatElapsed(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// Tick implements runfx.Visual by advancing the spinner.
+func (v *TreeView) Tick(now time.Time) {
+	v.mu.Lock()
+	v.frame++
+	v.mu.Unlock()
+}
+
+// OnResize implements runfx.Visual.
+func (v *TreeView) OnResize(cols, rows int) {}
@@ -0,0 +1,101 @@
+package flowfx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/garaekz/tfx/color"
+)
+
+// flushBuffer is a writer.Writer over a bytes.Buffer.
+type flushBuffer struct{ bytes.Buffer }
+
+func (b *flushBuffer) Flush() error { return nil }
+
+// elapsedPattern matches the durations rendered by a TreeView.
+var elapsedPattern = regexp.MustCompile(` \d+(ms|\.\ds)`)
+
+// renderTree renders view without colors and with durations replaced by
+// " 0s".
+func renderTree(view *TreeView) string {
+	var buf flushBuffer
+	view.Render(&buf)
+	return elapsedPattern.ReplaceAllString(color.StripANSI(buf.String()), " 0s")
+}
+
+func TestTreeView(t *testing.T) {
+	errTest := errors.New("tests failed")
+	var during string
+	var view *TreeView
+	checks := NewParallelBuilder().Name("verify").
+		Task(once("lint", fail(nil))).
+		Task(once("test", fail(errTest))).
+		Build()
+	seq := NewSequenceBuilder().Name("ci").
+		Task(once("build", func(context.Context) error {
+			during = renderTree(view)
+			return nil
+		})).
+		Step(Subflow("checks", checks)).
+		Task(once("deploy", fail(nil))).
+		Build()
+	view = NewTreeView(seq)
+
+	before := `○ ci
+├─ ○ build
+├─ ○ checks (verify)
+│  ├─ ○ lint
+│  └─ ○ test
+└─ ○ deploy
+`
+	if got := renderTree(view); got != before {
+		t.Errorf("before the run got\n%s\nwant\n%s", got, before)
+	}
+
+	if err := seq.Use(view.Middleware()).Run(context.Background()); !errors.Is(err, errTest) {
+		t.Fatalf("expected %v, got %v", errTest, err)
+	}
+
+	running := `⠋ ci 0s
+├─ ⠋ build 0s
+├─ ○ checks (verify)
+│  ├─ ○ lint
+│  └─ ○ test
+└─ ○ deploy
+`
+	if during != running {
+		t.Errorf("while building got\n%s\nwant\n%s", during, running)
+	}
+	after := `✗ ci 0s
+├─ ✓ build 0s
+├─ ✗ checks (verify) 0s
+│  ├─ ✓ lint 0s
+│  └─ ✗ test 0s
+└─ ○ deploy
+`
+	if got := renderTree(view); got != after {
+		t.Errorf("after the run got\n%s\nwant\n%s", got, after)
+	}
+}
+
+func TestTreeViewWithoutSeed(t *testing.T) {
+	view := NewTreeView()
+	err := NewSequenceBuilder().Name("ci").
+		Use(view.Middleware()).
+		Task(once("build", fail(nil))).
+		Task(NewTask("lint", fail(nil), WithSkipIf(func(context.Context) bool { return true }))).
+		Run(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `✓ ci 0s
+├─ ✓ build 0s
+└─ ↷ lint 0s
+`
+	if got := renderTree(view); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -2,9 +2,11 @@ package flowfx
 
 import (
 	"context"
+	"errors"
 	"maps"
 
 	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/runfx"
 )
 
 // Wizard represents a step-by-step interactive flow with structured input/output.
@@ -16,6 +18,7 @@ type Wizard struct {
 	onComplete Hook
 	onError    Hook
 	state      map[string]any // Shared state between steps
+	prompter   Prompter       // Answers questions; defaults when non-interactive
 }
 
 // WizardConfig provides configuration for a Wizard flow.
@@ -25,6 +28,12 @@ type WizardConfig struct {
 	OnComplete   Hook
 	OnError      Hook
 	InitialState map[string]any // Initial state for the wizard
+
+	// Prompter asks the user for input, e.g. formfx.NewFlowPrompter().
+	// Without a Prompter, or when no TTY is detected, defaults are used.
+	Prompter       Prompter
+	NonInteractive bool                 // Always answer with defaults
+	DetectTTY      func() runfx.TTYInfo // TTY detection used to pick the prompter
 }
 
 // DefaultWizardConfig returns the default configuration for a Wizard flow.
@@ -32,6 +41,7 @@ func DefaultWizardConfig() WizardConfig {
 	return WizardConfig{
 		Name:         "wizard",
 		InitialState: make(map[string]any),
+		DetectTTY:    runfx.DetectTTY,
 	}
 }
 
@@ -49,9 +59,25 @@ func newWizard(cfg WizardConfig) *Wizard {
 		onComplete: cfg.OnComplete,
 		onError:    cfg.OnError,
 		state:      state,
+		prompter:   selectPrompter(cfg),
 	}
 }
 
+// selectPrompter falls back to defaults when the wizard cannot interact.
+func selectPrompter(cfg WizardConfig) Prompter {
+	if cfg.Prompter == nil || cfg.NonInteractive {
+		return DefaultsPrompter{}
+	}
+	detect := cfg.DetectTTY
+	if detect == nil {
+		detect = runfx.DetectTTY
+	}
+	if !detect().IsTTY {
+		return DefaultsPrompter{}
+	}
+	return cfg.Prompter
+}
+
 // --- MULTIPATH API FUNCTIONS ---
 
 // NewWizard creates a new wizard flow with multipath configuration support.
@@ -81,7 +107,26 @@ func (w *Wizard) AddFunc(label string, fn func(ctx context.Context, input map[st
 	return w
 }
 
-// Run executes all steps sequentially, maintaining shared state.
+// Ask adds a step that prompts for text and stores the answer under key.
+// A previous answer is offered as the default when navigating back.
+func (w *Wizard) Ask(key, label, defaultValue string) *Wizard {
+	return w.AddStep(inputStep{key: key, label: label, def: defaultValue})
+}
+
+// Confirm adds a yes/no step that stores the answer under key.
+func (w *Wizard) Confirm(key, label string, defaultValue bool) *Wizard {
+	return w.AddStep(confirmStep{key: key, label: label, def: defaultValue})
+}
+
+// Choose adds a step that selects one of options and stores it under key.
+func (w *Wizard) Choose(key, label string, options []string, defaultIndex int) *Wizard {
+	return w.AddStep(selectStep{key: key, label: label, options: options, def: defaultIndex})
+}
+
+// Run executes all steps sequentially, maintaining shared state. A step
+// returning ErrBack moves the wizard to the previous step; answers already
+// given stay in the state and become the new defaults. ErrBack on the first
+// step cancels the wizard.
 // It implements the Flow interface.
 func (w *Wizard) Run(ctx context.Context) error {
 	if len(w.steps) == 0 {
@@ -93,8 +138,11 @@ func (w *Wizard) Run(ctx context.Context) error {
 		w.onStart(ctx, w.name, nil)
 	}
 
+	ctx = context.WithValue(ctx, prompterKey{}, w.prompter)
+
 	// Execute each step sequentially
-	for _, step := range w.steps {
+	for i := 0; i < len(w.steps); i++ {
+		step := w.steps[i]
 		select {
 		case <-ctx.Done():
 			err := NewFlowError(w.name, step.Label(), ErrCanceled)
@@ -107,6 +155,14 @@ func (w *Wizard) Run(ctx context.Context) error {
 
 		// Execute the step with current state
 		output, err := step.Execute(ctx, w.state)
+		if errors.Is(err, ErrBack) && i > 0 {
+			i -= 2 // the loop increment lands on the previous step
+			continue
+		}
+		if errors.Is(err, ErrBack) {
+			// Going back from the first step leaves the wizard.
+			err = ErrCanceled
+		}
 		if err != nil {
 			flowErr := NewFlowError(w.name, step.Label(), err)
 			if w.onError != nil {
@@ -190,6 +246,33 @@ func (wb *WizardBuilder) InitialState(state map[string]any) *WizardBuilder {
 	return wb
 }
 
+// Prompter sets the prompter used to ask questions.
+func (wb *WizardBuilder) Prompter(p Prompter) *WizardBuilder {
+	wb.config.Prompter = p
+	return wb
+}
+
+// NonInteractive answers every question with its default value.
+func (wb *WizardBuilder) NonInteractive(enabled bool) *WizardBuilder {
+	wb.config.NonInteractive = enabled
+	return wb
+}
+
+// Ask adds a step that prompts for text and stores the answer under key.
+func (wb *WizardBuilder) Ask(key, label, defaultValue string) *WizardBuilder {
+	return wb.Step(inputStep{key: key, label: label, def: defaultValue})
+}
+
+// Confirm adds a yes/no step that stores the answer under key.
+func (wb *WizardBuilder) Confirm(key, label string, defaultValue bool) *WizardBuilder {
+	return wb.Step(confirmStep{key: key, label: label, def: defaultValue})
+}
+
+// Choose adds a step that selects one of options and stores it under key.
+func (wb *WizardBuilder) Choose(key, label string, options []string, defaultIndex int) *WizardBuilder {
+	return wb.Step(selectStep{key: key, label: label, options: options, def: defaultIndex})
+}
+
 // Step adds a step to the wizard flow.
 func (wb *WizardBuilder) Step(step WizardStep) *WizardBuilder {
 	wb.steps = append(wb.steps, step)
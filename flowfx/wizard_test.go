@@ -0,0 +1,153 @@
+package flowfx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/garaekz/tfx/runfx"
+)
+
+// answer is one scripted reply of a scriptedPrompter.
+type answer struct {
+	value any // string, bool or int, by question kind
+	err   error
+}
+
+// scriptedPrompter replies with a script and records the defaults it was
+// offered, one per question.
+type scriptedPrompter struct {
+	script   []answer
+	defaults []any
+}
+
+func (p *scriptedPrompter) next(def any) answer {
+	p.defaults = append(p.defaults, def)
+	if len(p.script) == 0 {
+		return answer{err: errors.New("script exhausted")}
+	}
+	a := p.script[0]
+	p.script = p.script[1:]
+	return a
+}
+
+func (p *scriptedPrompter) Input(ctx context.Context, label, def string) (string, error) {
+	a := p.next(def)
+	s, _ := a.value.(string)
+	return s, a.err
+}
+
+func (p *scriptedPrompter) Confirm(ctx context.Context, label string, def bool) (bool, error) {
+	a := p.next(def)
+	b, _ := a.value.(bool)
+	return b, a.err
+}
+
+func (p *scriptedPrompter) Select(ctx context.Context, label string, options []string, def int) (int, error) {
+	a := p.next(def)
+	i, _ := a.value.(int)
+	return i, a.err
+}
+
+func newTestWizard(p Prompter, tty bool) *Wizard {
+	return NewWizard(WizardConfig{
+		Name:      "setup",
+		Prompter:  p,
+		DetectTTY: func() runfx.TTYInfo { return runfx.TTYInfo{IsTTY: tty} },
+	}).
+		Ask("name", "Project name", "demo").
+		Choose("lang", "Language", []string{"go", "rust"}, 0).
+		Confirm("git", "Init git?", true)
+}
+
+func TestWizardPrompts(t *testing.T) {
+	tests := []struct {
+		name         string
+		script       []answer
+		tty          bool
+		wantErr      error
+		wantState    map[string]any
+		wantDefaults []any
+	}{
+		{
+			name:         "answers",
+			script:       []answer{{value: "app"}, {value: 1}, {value: false}},
+			tty:          true,
+			wantState:    map[string]any{"name": "app", "lang": "rust", "git": false},
+			wantDefaults: []any{"demo", 0, true},
+		},
+		{
+			name: "back navigation offers previous answers as defaults",
+			script: []answer{
+				{value: "app"}, {value: 1}, {err: ErrBack}, // Back from git to lang
+				{err: ErrBack}, // Back from lang to name
+				{value: "app2"}, {value: 1}, {value: true},
+			},
+			tty:          true,
+			wantState:    map[string]any{"name": "app2", "lang": "rust", "git": true},
+			wantDefaults: []any{"demo", 0, true, 1, "app", 1, true},
+		},
+		{
+			name:         "back on the first step cancels",
+			script:       []answer{{err: ErrBack}},
+			tty:          true,
+			wantErr:      ErrCanceled,
+			wantDefaults: []any{"demo"},
+		},
+		{
+			name:         "prompt failure",
+			script:       []answer{{value: "app"}, {err: ErrNonInteractive}},
+			tty:          true,
+			wantErr:      ErrNonInteractive,
+			wantDefaults: []any{"demo", 0},
+		},
+		{
+			name:      "defaults without a TTY",
+			script:    []answer{{value: "ignored"}},
+			wantState: map[string]any{"name": "demo", "lang": "go", "git": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &scriptedPrompter{script: tt.script}
+			w := newTestWizard(p, tt.tty)
+			err := w.Run(context.Background())
+			if tt.wantErr != nil {
+				var flowErr *FlowError
+				if !errors.Is(err, tt.wantErr) || !errors.As(err, &flowErr) {
+					t.Fatalf("expected a FlowError wrapping %v, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantState != nil {
+				state := w.GetState()
+				for k, v := range tt.wantState {
+					if state[k] != v {
+						t.Errorf("state[%q] = %v, want %v", k, state[k], v)
+					}
+				}
+			}
+			if len(p.defaults) != len(tt.wantDefaults) {
+				t.Fatalf("expected %d questions, got defaults %v", len(tt.wantDefaults), p.defaults)
+			}
+			for i, def := range tt.wantDefaults {
+				if p.defaults[i] != def {
+					t.Errorf("question %d offered default %v, want %v", i, p.defaults[i], def)
+				}
+			}
+		})
+	}
+}
+
+func TestWizardCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := &scriptedPrompter{}
+	if err := newTestWizard(p, true).Run(ctx); !errors.Is(err, ErrCanceled) {
+		t.Errorf("expected ErrCanceled, got %v", err)
+	}
+	if len(p.defaults) != 0 {
+		t.Errorf("expected no questions after cancel, got %v", p.defaults)
+	}
+}
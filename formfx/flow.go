@@ -0,0 +1,141 @@
+package formfx
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/runfx"
+	"github.com/garaekz/tfx/writer"
+)
+
+// FlowPrompterConfig configures a FlowPrompter.
+type FlowPrompterConfig struct {
+	Output io.Writer // Where prompts are drawn
+	// CancelErr is returned when the user cancels a prompt (Esc or Ctrl+C).
+	// Set it to flowfx.ErrBack to let users step back through a wizard.
+	CancelErr error
+}
+
+// DefaultFlowPrompterConfig returns the default FlowPrompter configuration.
+func DefaultFlowPrompterConfig() FlowPrompterConfig {
+	return FlowPrompterConfig{
+		Output:    os.Stdout,
+		CancelErr: ErrCanceled,
+	}
+}
+
+// WithPromptOutput sets where prompts are drawn.
+func WithPromptOutput(w io.Writer) share.Option[FlowPrompterConfig] {
+	return func(cfg *FlowPrompterConfig) {
+		cfg.Output = w
+	}
+}
+
+// WithCancelError sets the error returned when the user cancels a prompt.
+func WithCancelError(err error) share.Option[FlowPrompterConfig] {
+	return func(cfg *FlowPrompterConfig) {
+		cfg.CancelErr = err
+	}
+}
+
+// FlowPrompter runs formfx prompts on a RunFX loop. It satisfies the
+// flowfx.Prompter interface, so it can drive interactive wizards:
+//
+//	wizard := flowfx.New().
+//		Prompter(formfx.NewFlowPrompter(formfx.WithCancelError(flowfx.ErrBack))).
+//		Ask("name", "Project name", "demo").
+//		Build()
+type FlowPrompter struct {
+	cfg FlowPrompterConfig
+}
+
+// NewFlowPrompter creates a FlowPrompter with multipath configuration support.
+// opts Type: any = Option[FlowPrompterConfig] | FlowPrompterConfig
+func NewFlowPrompter(opts ...any) *FlowPrompter {
+	cfg := share.OverloadWithOptions(opts, DefaultFlowPrompterConfig())
+	if cfg.Output == nil {
+		cfg.Output = os.Stdout
+	}
+	if cfg.CancelErr == nil {
+		cfg.CancelErr = ErrCanceled
+	}
+	return &FlowPrompter{cfg: cfg}
+}
+
+// Input asks for free text.
+func (f *FlowPrompter) Input(ctx context.Context, label, defaultValue string) (string, error) {
+	p := NewInputPrompt(defaultValue)
+	p.Label = label
+	return awaitPrompt(ctx, f, &inputVisual{prompt: p}, p.Canceled, p.Done)
+}
+
+// Confirm asks a yes/no question.
+func (f *FlowPrompter) Confirm(ctx context.Context, label string, defaultValue bool) (bool, error) {
+	cfg := DefaultConfirmConfig()
+	cfg.Label = label
+	cfg.DefaultValue = defaultValue
+	c, err := NewConfirmPrompt(cfg)
+	if err != nil {
+		return false, err
+	}
+	idx, err := awaitPrompt(ctx, f, c, c.Canceled(), c.Done())
+	return idx == 0 && err == nil, err
+}
+
+// Select asks to pick one of options and returns its index.
+func (f *FlowPrompter) Select(ctx context.Context, label string, options []string, defaultIndex int) (int, error) {
+	cfg := DefaultSelectConfig()
+	cfg.Label = label
+	cfg.Options = options
+	cfg.SelectedIndex = defaultIndex
+	s, err := NewSelectPrompt(cfg)
+	if err != nil {
+		return 0, err
+	}
+	return awaitPrompt(ctx, f, &selectVisual{prompt: s}, s.Canceled(), s.Done())
+}
+
+// awaitPrompt mounts v on a loop until the prompt is answered or canceled.
+func awaitPrompt[T any](ctx context.Context, f *FlowPrompter, v runfx.Visual, canceled <-chan struct{}, done <-chan T) (T, error) {
+	var zero T
+	loop := runfx.Start(runfx.WithOutput(f.cfg.Output))
+	if _, err := loop.Mount(v); err != nil {
+		return zero, err
+	}
+	if err := loop.Run(ctx); err != nil {
+		return zero, err
+	}
+
+	select {
+	case answer := <-done:
+		return answer, nil
+	case <-canceled:
+		return zero, f.cfg.CancelErr
+	default:
+		// The loop stopped without an answer, e.g. on a read error.
+		return zero, ErrCanceled
+	}
+}
+
+// inputVisual renders an InputPrompt on a RunFX loop.
+type inputVisual struct {
+	prompt *InputPrompt
+}
+
+func (v *inputVisual) Render(w writer.Writer)   { w.Write(v.prompt.Render()) }
+func (v *inputVisual) OnKey(key runfx.Key) bool { return v.prompt.OnKey(key) }
+func (v *inputVisual) Tick(now time.Time)       {}
+func (v *inputVisual) OnResize(cols, rows int)  {}
+
+// selectVisual renders a SelectPrompt on a RunFX loop.
+type selectVisual struct {
+	prompt *SelectPrompt
+}
+
+func (v *selectVisual) Render(w writer.Writer)   { w.Write(v.prompt.Render()) }
+func (v *selectVisual) OnKey(key runfx.Key) bool { return v.prompt.OnKey(key) }
+func (v *selectVisual) Tick(now time.Time)       {}
+func (v *selectVisual) OnResize(cols, rows int)  {}
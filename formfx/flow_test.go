@@ -0,0 +1,44 @@
+package formfx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/garaekz/tfx/runfx"
+)
+
+// flushBuffer is a writer.Writer over a bytes.Buffer.
+type flushBuffer struct{ bytes.Buffer }
+
+func (b *flushBuffer) Flush() error { return nil }
+
+func TestInputVisualRendersPrompt(t *testing.T) {
+	p := NewInputPrompt("demo")
+	p.Label = "Project name"
+	v := &inputVisual{prompt: p}
+
+	var buf flushBuffer
+	v.Render(&buf)
+	if got := buf.String(); got != "Project name: demo\n" {
+		t.Errorf("unexpected render %q", got)
+	}
+
+	v.OnKey(runfx.Key{Code: runfx.KeyX, Rune: 'x'})
+	buf.Reset()
+	v.Render(&buf)
+	if got, want := buf.String(), string(p.Render()); got != want || got != "Project name: demox\n" {
+		t.Errorf("expected the visual to draw the prompt's render %q, got %q", want, got)
+	}
+}
+
+func TestFlowPrompterCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f := NewFlowPrompter(WithPromptOutput(io.Discard))
+	if _, err := f.Input(ctx, "name", "demo"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled context to stop the prompt, got %v", err)
+	}
+}
@@ -1,9 +1,14 @@
 package formfx
 
-import "github.com/garaekz/tfx/runfx"
+import (
+	"fmt"
+
+	"github.com/garaekz/tfx/runfx"
+)
 
 // InputPrompt stores the state for a text input primitive.
 type InputPrompt struct {
+	Label      string // Drawn before the value when set
 	Value      []rune
 	CursorPos  int
 	keyHandler TextKeyHandlerFunc
@@ -73,5 +78,12 @@ func (p *InputPrompt) OnKey(key runfx.Key) bool {
 	return TextInputKeyHandler(p, key)
 }
 
-func (p *InputPrompt) Render() []byte          { return nil }
+// Render draws the label, when set, followed by the current value.
+func (p *InputPrompt) Render() []byte {
+	if p.Label == "" {
+		return fmt.Appendf(nil, "%s\n", string(p.Value))
+	}
+	return fmt.Appendf(nil, "%s: %s\n", p.Label, string(p.Value))
+}
+
 func (p *InputPrompt) OnResize(cols, rows int) {}
@@ -1,5 +1,7 @@
 package share
 
+import "bytes"
+
 // ANSI Escape Codes and Control Sequences
 // These are the raw ANSI escape sequences for direct terminal control
 
@@ -77,3 +79,68 @@ func (a *ANSIColors) Style(text, colorSeq string) string {
 func (a *ANSIColors) Wrap(text, fg, bg string) string {
 	return fg + bg + text + Reset
 }
+
+// OSC 8 hyperlink sequences (https://gist.github.com/egmontkob/eb114294efbcd5adb1944c9f3cb5feda)
+const (
+	HyperlinkStart = "\033]8;;"
+	HyperlinkEnd   = "\033]8;;\033\\"
+	stringTerm     = "\033\\"
+)
+
+// Hyperlink wraps text in an OSC 8 sequence pointing at url.
+func Hyperlink(url, text string) string {
+	return HyperlinkStart + url + stringTerm + text + HyperlinkEnd
+}
+
+// hyperlinkPrefix opens every OSC 8 sequence.
+const hyperlinkPrefix = "\033]8;"
+
+// StripHyperlinks removes OSC 8 sequences from p, keeping the link text.
+// Both BEL and ST terminated sequences are recognized.
+func StripHyperlinks(p []byte) []byte {
+	const prefix = hyperlinkPrefix
+	idx := bytes.Index(p, []byte(prefix))
+	if idx < 0 {
+		return p
+	}
+
+	out := make([]byte, 0, len(p))
+	for idx >= 0 {
+		out = append(out, p[:idx]...)
+		p = p[idx:]
+
+		end := len(p)
+		for i := len(prefix); i < len(p); i++ {
+			if p[i] == '\a' {
+				end = i + 1
+				break
+			}
+			if p[i] == '\033' && i+1 < len(p) && p[i+1] == '\\' {
+				end = i + 2
+				break
+			}
+		}
+		p = p[end:]
+		idx = bytes.Index(p, []byte(prefix))
+	}
+	return append(out, p...)
+}
+
+// IncompleteHyperlink returns the offset of an OSC 8 sequence at the end of
+// p that is cut short, or of a trailing partial "\033]8;" opener, so that
+// the caller can hold it back until the rest arrives. It returns len(p)
+// when p ends outside of a sequence.
+func IncompleteHyperlink(p []byte) int {
+	if idx := bytes.LastIndex(p, []byte(hyperlinkPrefix)); idx >= 0 {
+		rest := p[idx+len(hyperlinkPrefix):]
+		if bytes.IndexByte(rest, '\a') < 0 && !bytes.Contains(rest, []byte(stringTerm)) {
+			return idx
+		}
+	}
+	for n := len(hyperlinkPrefix) - 1; n > 0; n-- {
+		if bytes.HasSuffix(p, []byte(hyperlinkPrefix[:n])) {
+			return len(p) - n
+		}
+	}
+	return len(p)
+}
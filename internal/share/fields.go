@@ -2,3 +2,19 @@ package share
 
 // Fields represents key-value pairs for structured logging
 type Fields map[string]any
+
+// Flatten calls fn for every field, expanding nested Fields, such as the
+// groups of logfx WithGroup, into dotted keys like "http.status".
+func (f Fields) Flatten(fn func(key string, value any)) {
+	f.flatten("", fn)
+}
+
+func (f Fields) flatten(prefix string, fn func(key string, value any)) {
+	for key, value := range f {
+		if nested, ok := value.(Fields); ok {
+			nested.flatten(prefix+key+".", fn)
+			continue
+		}
+		fn(prefix+key, value)
+	}
+}
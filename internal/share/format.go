@@ -8,6 +8,7 @@ const (
 	FormatJSON
 	FormatText
 	FormatCustom
+	FormatPretty // Badge line with fields in an indented block below, for development
 )
 
 // Formatter defines the interface for custom formatters
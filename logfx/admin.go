@@ -0,0 +1,121 @@
+package logfx
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// adminLevels is the body of the levels endpoints of AdminHandler.
+type adminLevels struct {
+	Level  string            `json:"level,omitempty"`  // Application level
+	Levels map[string]string `json:"levels,omitempty"` // Overrides by logger name
+}
+
+// AdminHandler returns an HTTP handler for controlling the logger of a
+// running service. Mount it on an existing mux under a prefix:
+//
+//	mux.Handle("/debug/log/", http.StripPrefix("/debug/log", logger.AdminHandler()))
+//
+// It serves:
+//
+//	GET    /levels         application level and overrides by logger name
+//	PUT    /levels         set them from {"level": "info", "levels": {"db": "debug"}};
+//	                       the overrides replace all previous ones
+//	GET    /levels/{name}  effective level of the named loggers
+//	PUT    /levels/{name}  override it from {"level": "debug"}
+//	DELETE /levels/{name}  remove the override
+//	GET    /health         writes and errors of every writer, see WriterHealth
+//
+// Responses are JSON; invalid levels are rejected with 400 Bad Request and
+// change nothing. The handler does no authentication, so mount it only on
+// an internal listener or behind one.
+func (l *Logger) AdminHandler() http.Handler {
+	root := l.root()
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /levels", func(w http.ResponseWriter, r *http.Request) {
+		writeAdminJSON(w, http.StatusOK, root.adminLevels())
+	})
+	mux.HandleFunc("PUT /levels", func(w http.ResponseWriter, r *http.Request) {
+		var body adminLevels
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeAdminError(w, http.StatusBadRequest, err)
+			return
+		}
+		spec := body.Level
+		for name, level := range body.Levels {
+			spec += fmt.Sprintf(",%s=%s", name, level)
+		}
+		if err := root.SetLevels(spec); err != nil {
+			writeAdminError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeAdminJSON(w, http.StatusOK, root.adminLevels())
+	})
+	mux.HandleFunc("GET /levels/{name}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		level := (&Logger{name: name, parent: root}).level()
+		writeAdminJSON(w, http.StatusOK, adminLevels{Level: level.String()})
+	})
+	mux.HandleFunc("PUT /levels/{name}", func(w http.ResponseWriter, r *http.Request) {
+		var body adminLevels
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeAdminError(w, http.StatusBadRequest, err)
+			return
+		}
+		level, err := ParseLevel(body.Level)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, err)
+			return
+		}
+		root.SetLevelFor(r.PathValue("name"), level)
+		writeAdminJSON(w, http.StatusOK, adminLevels{Level: level.String()})
+	})
+	mux.HandleFunc("DELETE /levels/{name}", func(w http.ResponseWriter, r *http.Request) {
+		root.ClearLevelFor(r.PathValue("name"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		health := root.WriterHealth()
+		status := http.StatusOK
+		for _, h := range health {
+			if !h.Healthy() {
+				status = http.StatusServiceUnavailable
+			}
+		}
+		writeAdminJSON(w, status, map[string]any{"writers": health})
+	})
+	return mux
+}
+
+// AdminHandler returns an HTTP handler controlling the global logger, see
+// (*Logger).AdminHandler.
+func AdminHandler() http.Handler { return GetLogger().AdminHandler() }
+
+// adminLevels returns the levels of the root logger l.
+func (l *Logger) adminLevels() adminLevels {
+	l.mu.RLock()
+	level := l.options.Level
+	overrides := maps.Clone(l.levels)
+	l.mu.RUnlock()
+
+	levels := make(map[string]string, len(overrides))
+	for name, level := range overrides {
+		levels[name] = level.String()
+	}
+	return adminLevels{Level: level.String(), Levels: levels}
+}
+
+// writeAdminJSON writes v as the JSON response.
+func writeAdminJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeAdminError writes err as a JSON error response.
+func writeAdminError(w http.ResponseWriter, status int, err error) {
+	writeAdminJSON(w, status, map[string]string{"error": err.Error()})
+}
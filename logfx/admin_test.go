@@ -0,0 +1,99 @@
+package logfx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(*share.Entry) error { return errors.New("disk full") }
+func (failingWriter) Close() error             { return nil }
+
+func adminRequest(t *testing.T, h http.Handler, method, path, body string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	var got map[string]any
+	if rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s %s: invalid JSON %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec, got
+}
+
+func TestAdminHandlerLevels(t *testing.T) {
+	logger := newTextLogger(&testutil.SafeBuffer{})
+	logger.SetLevel(share.LevelInfo)
+	h := logger.AdminHandler()
+
+	rec, got := adminRequest(t, h, http.MethodPut, "/levels", `{"level":"warn","levels":{"db":"debug"}}`)
+	if rec.Code != http.StatusOK || got["level"] != "WARN" {
+		t.Fatalf("PUT /levels: %d %v", rec.Code, got)
+	}
+	if logger.Named("db.pool").level() != share.LevelDebug {
+		t.Errorf("expected db override to apply to db.pool")
+	}
+
+	rec, got = adminRequest(t, h, http.MethodPut, "/levels/http", `{"level":"error"}`)
+	if rec.Code != http.StatusOK || logger.Levels()["http"] != share.LevelError {
+		t.Fatalf("PUT /levels/http: %d %v", rec.Code, got)
+	}
+
+	_, got = adminRequest(t, h, http.MethodGet, "/levels/db.pool", "")
+	if got["level"] != "DEBUG" {
+		t.Errorf("GET /levels/db.pool: %v", got)
+	}
+
+	rec, _ = adminRequest(t, h, http.MethodDelete, "/levels/db", "")
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE /levels/db: %d", rec.Code)
+	}
+	_, got = adminRequest(t, h, http.MethodGet, "/levels", "")
+	levels, _ := got["levels"].(map[string]any)
+	if got["level"] != "WARN" || len(levels) != 1 || levels["http"] != "ERROR" {
+		t.Errorf("GET /levels: %v", got)
+	}
+
+	rec, _ = adminRequest(t, h, http.MethodPut, "/levels", `{"levels":{"db":"loud"}}`)
+	if rec.Code != http.StatusBadRequest || len(logger.Levels()) != 1 {
+		t.Errorf("expected invalid level to be rejected, got %d and %v", rec.Code, logger.Levels())
+	}
+}
+
+func TestAdminHandlerHealth(t *testing.T) {
+	logger := newTextLogger(&testutil.SafeBuffer{})
+	h := logger.AdminHandler()
+	logger.Info("ok")
+
+	rec, got := adminRequest(t, h, http.MethodGet, "/health", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected healthy writers, got %d %v", rec.Code, got)
+	}
+
+	logger.AddWriter(failingWriter{})
+	logger.Info("fails")
+	rec, got = adminRequest(t, h, http.MethodGet, "/health", "")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+	writers, _ := got["writers"].([]any)
+	if len(writers) != 2 {
+		t.Fatalf("expected 2 writers, got %v", got)
+	}
+	failing, _ := writers[1].(map[string]any)
+	if failing["last_error"] != "disk full" || failing["errors"] != float64(1) {
+		t.Errorf("unexpected health %v", failing)
+	}
+	if first, _ := writers[0].(map[string]any); first["writes"] != float64(2) {
+		t.Errorf("expected 2 writes to the console, got %v", first)
+	}
+}
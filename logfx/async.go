@@ -0,0 +1,43 @@
+package logfx
+
+import (
+	"fmt"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// Dropped returns how many entries the asynchronous writers of the logger,
+// such as AsyncWriter and the shipping writers, dropped because their
+// queues were full or they could not be delivered.
+func (l *Logger) Dropped() uint64 {
+	var dropped uint64
+	for _, wr := range l.allWriters() {
+		if d, ok := wr.(interface{ Dropped() uint64 }); ok {
+			dropped += d.Dropped()
+		}
+	}
+	return dropped
+}
+
+// reportDropped logs how many entries were dropped since the last report
+// and reports whether it logged.
+func (l *Logger) reportDropped() bool {
+	dropped := l.Dropped()
+	root := l.root()
+	root.mu.Lock()
+	n := dropped - root.droppedReported
+	if dropped < root.droppedReported {
+		n = 0 // A writer was replaced; start over
+	}
+	root.droppedReported = dropped
+	root.mu.Unlock()
+	if n == 0 {
+		return false
+	}
+
+	entry := l.createEntry(share.LevelWarn, fmt.Sprintf("async queue dropped %d log entries", n), share.Fields{
+		"dropped": n,
+	})
+	l.write(entry)
+	return true
+}
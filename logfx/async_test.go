@@ -0,0 +1,76 @@
+package logfx
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// blockingWriter blocks every write until its gate is closed.
+type blockingWriter struct {
+	gate    chan struct{}
+	started sync.Once
+	first   chan struct{}
+}
+
+func (w *blockingWriter) Write(*share.Entry) error {
+	w.started.Do(func() { close(w.first) })
+	<-w.gate
+	return nil
+}
+
+func (w *blockingWriter) Close() error { return nil }
+
+func TestFlushReportsDroppedEntries(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	slow := &blockingWriter{gate: make(chan struct{}), first: make(chan struct{})}
+	aw := writerpkg.NewAsyncWriterWithOptions(slow, writerpkg.AsyncOptions{
+		BufferSize: 2,
+		Overflow:   writerpkg.OverflowDropNewest,
+	})
+	logger.AddWriter(aw)
+
+	logger.Info("held")
+	<-slow.first
+	for range 5 {
+		logger.Info("burst")
+	}
+	if got := logger.Dropped(); got != 3 {
+		t.Fatalf("expected 3 dropped entries, got %d", got)
+	}
+
+	close(slow.gate)
+	logger.Flush()
+	if !strings.Contains(buf.String(), "async queue dropped 3 log entries") {
+		t.Fatalf("expected a dropped summary, got %q", buf.String())
+	}
+
+	logger.Flush()
+	if got := strings.Count(buf.String(), "async queue dropped"); got != 1 {
+		t.Fatalf("expected the drops to be reported once, got %d", got)
+	}
+}
+
+func TestAsyncLoggerWritesEverythingOnFlush(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	opts := DefaultOptions()
+	opts.Output = buf
+	opts.Format = share.FormatText
+	opts.Async = true
+	opts.AsyncBuffer = 4
+	logger := New(opts)
+
+	for range 50 {
+		logger.Info("entry")
+	}
+	logger.Flush()
+
+	if got := strings.Count(buf.String(), "entry"); got != 50 {
+		t.Fatalf("expected 50 entries after Flush, got %d", got)
+	}
+}
@@ -0,0 +1,67 @@
+package logfx
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// AuditOptions configures the audit log, a tamper-evident JSON file in which
+// every entry carries a hash chaining it to the previous one. See
+// writerpkg.AuditWriter and VerifyAudit.
+type AuditOptions struct {
+	Filename string // Appended to, continuing its chain
+	Key      []byte // HMAC key; nil hashes with plain SHA-256
+}
+
+// WithAudit writes every logged entry to the audit file filename, hashed
+// with HMAC-SHA256 under key, or SHA-256 when key is nil. Without a key the
+// chain detects accidental damage but not a forger who rewrites it whole.
+//
+// When the file cannot be opened or its existing chain does not verify, the
+// logger reports it on its error output (os.Stderr by default) and every
+// entry fails to reach the audit log, which shows in WriterHealth; auditing
+// is never turned off silently.
+func WithAudit(filename string, key []byte) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Audit = &AuditOptions{Filename: filename, Key: key}
+	}
+}
+
+// VerifyAudit checks the hash chain of an audit log written with key and
+// returns its number of entries and last hash. Keep the last hash elsewhere
+// to also detect a truncated file.
+func VerifyAudit(r io.Reader, key []byte) (writerpkg.AuditReport, error) {
+	return writerpkg.VerifyAudit(r, key)
+}
+
+// newAuditWriter opens the audit file of opts, or returns nil when there is
+// none. A file that cannot be opened or does not verify is reported on the
+// error output of opts and yields a writer failing every entry.
+func newAuditWriter(opts LogOptions) share.Writer {
+	if opts.Audit == nil || opts.Audit.Filename == "" {
+		return nil
+	}
+	aw, err := writerpkg.OpenAuditFile(opts.Audit.Filename, opts.Audit.Key)
+	if err != nil {
+		errOut := opts.ErrorOutput
+		if errOut == nil {
+			errOut = os.Stderr
+		}
+		fmt.Fprintf(errOut, "logfx: audit log unavailable: %v\n", err)
+		return &failedAudit{err: fmt.Errorf("audit log unavailable: %w", err)}
+	}
+	return aw
+}
+
+// failedAudit stands in for an audit log that could not be opened, so that
+// every entry fails visibly instead of going unaudited.
+type failedAudit struct {
+	err error
+}
+
+func (w *failedAudit) Write(entry *share.Entry) error { return w.err }
+func (w *failedAudit) Close() error                   { return nil }
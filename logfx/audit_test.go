@@ -0,0 +1,75 @@
+package logfx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestWithAudit(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "audit.log")
+	key := []byte("secret")
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}), WithAudit(filename, key))
+
+	logger.WithFields(map[string]any{"user": "ana"}).Info("user created")
+	logger.Warn("role changed")
+	logger.Close()
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := VerifyAudit(strings.NewReader(string(data)), key)
+	if err != nil {
+		t.Fatalf("VerifyAudit: %v", err)
+	}
+	if report.Entries != 2 {
+		t.Errorf("Entries = %d, want 2", report.Entries)
+	}
+	if !strings.Contains(string(data), `"user":"ana"`) {
+		t.Errorf("audit log lacks the entry fields:\n%s", data)
+	}
+}
+
+func TestWithAuditTamperedFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "audit.log")
+	key := []byte("secret")
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}), WithAudit(filename, key))
+	logger.Info("user created")
+	logger.Info("role changed")
+	logger.Close()
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), "role changed", "role granted", 1)
+	if err := os.WriteFile(filename, []byte(tampered), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var errOut testutil.SafeBuffer
+	logger = LogWith(WithSplitOutput(&testutil.SafeBuffer{}, &errOut, share.LevelError), WithAudit(filename, key))
+	defer logger.Close()
+	if !strings.Contains(errOut.String(), "audit log unavailable") {
+		t.Errorf("tampered audit file not reported, error output: %q", errOut.String())
+	}
+
+	logger.Info("after tampering")
+	failed := false
+	for _, h := range logger.WriterHealth() {
+		if strings.Contains(h.LastError, "audit log unavailable") {
+			failed = true
+		}
+	}
+	if !failed {
+		t.Errorf("entries should fail to reach the tampered audit log, health: %+v", logger.WriterHealth())
+	}
+	if after, _ := os.ReadFile(filename); string(after) != tampered {
+		t.Error("the tampered audit file was appended to")
+	}
+}
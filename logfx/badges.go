@@ -0,0 +1,124 @@
+package logfx
+
+import (
+	"sync"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// BadgeRole names a color of the logger theme. A badge with a role takes its
+// background from the theme when it is logged, so registered badges follow
+// SetTheme.
+type BadgeRole string
+
+const (
+	BadgeRoleSuccess   BadgeRole = "success"
+	BadgeRoleError     BadgeRole = "error"
+	BadgeRoleWarning   BadgeRole = "warning"
+	BadgeRoleInfo      BadgeRole = "info"
+	BadgeRoleDebug     BadgeRole = "debug"
+	BadgeRolePrimary   BadgeRole = "primary"
+	BadgeRoleSecondary BadgeRole = "secondary"
+	BadgeRoleAccent    BadgeRole = "accent"
+)
+
+// color returns the color of the role in theme.
+func (r BadgeRole) color(theme color.ColorTheme) (color.Color, bool) {
+	switch r {
+	case BadgeRoleSuccess:
+		return theme.Success, true
+	case BadgeRoleError:
+		return theme.Error, true
+	case BadgeRoleWarning:
+		return theme.Warning, true
+	case BadgeRoleInfo:
+		return theme.Info, true
+	case BadgeRoleDebug:
+		return theme.Debug, true
+	case BadgeRolePrimary:
+		return theme.Primary, true
+	case BadgeRoleSecondary:
+		return theme.Secondary, true
+	case BadgeRoleAccent:
+		return theme.Accent, true
+	default:
+		return color.Color{}, false
+	}
+}
+
+// WithBadgeRole takes the badge background from the theme color of role,
+// overriding WithBadgeBackground.
+func WithBadgeRole(role BadgeRole) BadgeOption {
+	return func(cfg *BadgeConfig) {
+		cfg.role = role
+	}
+}
+
+var (
+	badgeMu   sync.RWMutex
+	badgeDefs = make(map[string]BadgeConfig)
+)
+
+// RegisterBadge registers the options of the badge tag, so it can be logged
+// with BadgeLog without repeating them. Registering a tag again replaces it.
+func RegisterBadge(tag string, opts ...BadgeOption) {
+	cfg := defaultBadgeConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	badgeMu.Lock()
+	defer badgeMu.Unlock()
+	badgeDefs[tag] = cfg
+}
+
+// UnregisterBadge removes the badge tag from the registry.
+func UnregisterBadge(tag string) {
+	badgeMu.Lock()
+	defer badgeMu.Unlock()
+	delete(badgeDefs, tag)
+}
+
+// registeredBadge returns the options of the badge tag, or the defaults of
+// ModernBadge when it is not registered.
+func registeredBadge(tag string) BadgeConfig {
+	badgeMu.RLock()
+	defer badgeMu.RUnlock()
+	if cfg, ok := badgeDefs[tag]; ok {
+		return cfg
+	}
+	return defaultBadgeConfig()
+}
+
+// BadgeLog logs msg with the registered badge tag, see RegisterBadge.
+func (l *Logger) BadgeLog(tag, msg string) {
+	cfg := registeredBadge(tag)
+	l.log(cfg.level, msg, l.badgeFields(tag, cfg))
+}
+
+// badgeFields returns the fields rendering the badge tag configured by cfg,
+// resolving its role against the logger theme.
+func (l *Logger) badgeFields(tag string, cfg BadgeConfig) share.Fields {
+	if cfg.role != "" {
+		l.mu.RLock()
+		theme := l.options.Theme
+		l.mu.RUnlock()
+		if c, ok := cfg.role.color(theme); ok {
+			cfg.bgColor = c
+		}
+	}
+
+	return share.Fields{
+		"badge":       tag,
+		"badge_style": cfg.style,
+		"badge_color": cfg.color,
+		"bg_color":    cfg.bgColor,
+		"bold":        cfg.bold,
+		"italic":      cfg.italic,
+		"underline":   cfg.underline,
+	}
+}
+
+// BadgeLog logs msg with the registered badge tag using the global logger.
+func BadgeLog(tag, msg string) { GetLogger().BadgeLog(tag, msg) }
@@ -0,0 +1,69 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+// captureEntries returns a logger whose entries are appended to the
+// returned slice.
+func captureEntries(buf *testutil.SafeBuffer) (*Logger, *[]*share.Entry) {
+	logger := newTextLogger(buf)
+	var entries []*share.Entry
+	logger.AddHook(func(e *share.Entry) *share.Entry {
+		entries = append(entries, e)
+		return e
+	})
+	return logger, &entries
+}
+
+func TestBadgeLogUsesRegisteredOptions(t *testing.T) {
+	RegisterBadge("API", WithBadgeBackground(MaterialGreen), WithBadgeLevel(share.LevelSuccess))
+	defer UnregisterBadge("API")
+
+	buf := &testutil.SafeBuffer{}
+	logger, entries := captureEntries(buf)
+	logger.BadgeLog("API", "request served")
+
+	if len(*entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(*entries))
+	}
+	e := (*entries)[0]
+	if e.Level != share.LevelSuccess || e.Fields["badge"] != "API" || e.Fields["bg_color"] != MaterialGreen {
+		t.Errorf("entry = level %v, fields %v", e.Level, e.Fields)
+	}
+	if !strings.Contains(buf.String(), "request served") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestBadgeLogFollowsTheme(t *testing.T) {
+	RegisterBadge("DB", WithBadgeRole(BadgeRoleError), WithBadgeBackground(Blue))
+	defer UnregisterBadge("DB")
+
+	logger, entries := captureEntries(&testutil.SafeBuffer{})
+	logger.BadgeLog("DB", "default theme")
+	logger.SetTheme(color.DraculaTheme)
+	logger.BadgeLog("DB", "dracula theme")
+
+	want := []color.Color{color.DefaultTheme.Error, color.DraculaTheme.Error}
+	for i, e := range *entries {
+		if e.Fields["bg_color"] != want[i] {
+			t.Errorf("entry %d: bg_color = %v, want %v", i, e.Fields["bg_color"], want[i])
+		}
+	}
+}
+
+func TestBadgeLogUnregisteredTag(t *testing.T) {
+	logger, entries := captureEntries(&testutil.SafeBuffer{})
+	logger.BadgeLog("NEW", "message")
+
+	e := (*entries)[0]
+	if e.Level != share.LevelInfo || e.Fields["badge"] != "NEW" || e.Fields["bg_color"] != Blue {
+		t.Errorf("entry = level %v, fields %v", e.Level, e.Fields)
+	}
+}
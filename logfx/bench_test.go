@@ -0,0 +1,91 @@
+package logfx
+
+import (
+	"io"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// newBenchLogger returns a logger writing format to io.Discard.
+func newBenchLogger(format share.Format, forceColor bool) *Logger {
+	opts := DefaultOptions()
+	opts.Output = io.Discard
+	opts.Format = format
+	opts.ForceColor = forceColor
+	opts.DisableColor = !forceColor
+	return New(opts)
+}
+
+func BenchmarkInfoBadge(b *testing.B) {
+	logger := newBenchLogger(share.FormatBadge, true)
+	b.ReportAllocs()
+	for b.Loop() {
+		logger.Info("request served")
+	}
+}
+
+func BenchmarkInfoText(b *testing.B) {
+	logger := newBenchLogger(share.FormatText, false)
+	b.ReportAllocs()
+	for b.Loop() {
+		logger.Info("request served")
+	}
+}
+
+func BenchmarkInfoJSON(b *testing.B) {
+	logger := newBenchLogger(share.FormatJSON, false)
+	b.ReportAllocs()
+	for b.Loop() {
+		logger.Info("request served")
+	}
+}
+
+func BenchmarkInfoJSONFields(b *testing.B) {
+	logger := newBenchLogger(share.FormatJSON, false)
+	fields := map[string]any{"status": 200, "path": "/api", "bytes": 512}
+	b.ReportAllocs()
+	for b.Loop() {
+		logger.WithFields(fields).Info("request served")
+	}
+}
+
+func BenchmarkDebugFiltered(b *testing.B) {
+	logger := newBenchLogger(share.FormatBadge, false)
+	b.ReportAllocs()
+	for b.Loop() {
+		logger.Debug("request served")
+	}
+}
+
+func TestInfoDoesNotAllocate(t *testing.T) {
+	for _, format := range []share.Format{share.FormatBadge, share.FormatText, share.FormatJSON} {
+		logger := newBenchLogger(format, true)
+		allocs := testing.AllocsPerRun(100, func() { logger.Info("request served") })
+		if allocs >= 1 {
+			t.Errorf("format %v: %.1f allocations per entry, want 0", format, allocs)
+		}
+	}
+}
+
+// retainingWriter keeps the entries it is given.
+type retainingWriter struct{ entries []*share.Entry }
+
+func (w *retainingWriter) Write(e *share.Entry) error {
+	w.entries = append(w.entries, e)
+	return nil
+}
+
+func (w *retainingWriter) Close() error { return nil }
+
+func TestEntriesNotRecycledWhileRetained(t *testing.T) {
+	logger := newBenchLogger(share.FormatText, false)
+	retained := &retainingWriter{}
+	logger.AddWriter(retained)
+
+	logger.Info("first")
+	logger.Info("second")
+	if len(retained.entries) != 2 || retained.entries[0].Message != "first" {
+		t.Fatalf("retained entry was recycled: %+v", retained.entries)
+	}
+}
@@ -0,0 +1,92 @@
+package logfx
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// pkgPrefix prefixes the names of the functions of this package.
+var pkgPrefix = reflect.TypeFor[Logger]().PkgPath() + "."
+
+// maxCallerFrames bounds the stack walked to find the caller.
+const maxCallerFrames = 32
+
+// callerAt returns the caller of the logging call, skipping skip more
+// frames plus those of CallerSkip and WithCallerSkip. With a fixed
+// CallerDepth, frames are counted from the function calling callerAt
+// instead.
+func (l *Logger) callerAt(skip int) *share.CallerInfo {
+	if l.options.CallerDepth > 0 {
+		pc, file, line, ok := runtime.Caller(l.options.CallerDepth + 1)
+		if !ok {
+			return nil
+		}
+		fn := runtime.FuncForPC(pc)
+		if fn == nil {
+			return nil
+		}
+		return &share.CallerInfo{File: file, Function: fn.Name(), Line: line}
+	}
+
+	skip += l.options.CallerSkip + l.callerSkip
+	var pcs [maxCallerFrames]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	inside := true
+	for {
+		frame, more := frames.Next()
+		if inside && isLogfxFrame(frame) {
+			if !more {
+				return nil
+			}
+			continue
+		}
+		inside = false
+		if skip == 0 {
+			return &share.CallerInfo{File: frame.File, Function: frame.Function, Line: frame.Line}
+		}
+		skip--
+		if !more {
+			return nil
+		}
+	}
+}
+
+// isLogfxFrame reports whether frame belongs to logfx itself rather than to
+// code calling it. Tests of the package count as callers.
+func isLogfxFrame(frame runtime.Frame) bool {
+	return strings.HasPrefix(frame.Function, pkgPrefix) && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// WithCallerSkip reports callers n frames further up the stack, so packages
+// wrapping logfx report the call sites of their users instead of their own:
+//
+//	func Warn(msg string) { logger.Warn(msg) } // in package mylog
+//	logger := logfx.LogWith(logfx.WithCaller(true), logfx.WithCallerSkip(1))
+func WithCallerSkip(n int) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.CallerSkip = n
+	}
+}
+
+// WithCallerSkip returns a child logger that reports callers n more frames
+// up the stack than l, for wrappers that only some call sites go through.
+// The child shares the name, fields, writers and hooks of l.
+func (l *Logger) WithCallerSkip(n int) *Logger {
+	child := l.With(nil)
+	child.callerSkip = l.callerSkip + n
+	return child
+}
+
+// WithCallerSkip returns a context whose entries report callers n more
+// frames up the stack, for a single wrapped call.
+func (c *Context) WithCallerSkip(n int) *Context {
+	return &Context{
+		logger: c.logger,
+		fields: c.fields,
+		ctx:    c.ctx,
+		skip:   c.skip + n,
+	}
+}
@@ -0,0 +1,73 @@
+package logfx
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+// nextLine returns the file:line suffix of the line after its caller.
+func nextLine() string {
+	_, _, line, _ := runtime.Caller(1)
+	return "caller_test.go:" + strconv.Itoa(line+1)
+}
+
+// wrappedWarn stands for a helper of a package wrapping logfx.
+func wrappedWarn(logger *Logger, msg string) {
+	logger.Warn(msg)
+}
+
+func newCallerLogger(buf *testutil.SafeBuffer, opts ...LogOption) *Logger {
+	logger := newTextLogger(buf)
+	logger.options.ShowCaller = true
+	for _, opt := range opts {
+		opt(&logger.options)
+	}
+	logger.refreshConsoles()
+	return logger
+}
+
+func TestCallerIsTheCallSite(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newCallerLogger(buf)
+
+	direct := nextLine()
+	logger.Info("direct")
+	viaContext := nextLine()
+	logger.WithFields(share.Fields{"k": 1}).Info("context")
+	viaTemplate := nextLine()
+	logger.Named("db").Infow("template {k}", share.Fields{"k": 2})
+	viaPrintf := nextLine()
+	logger.Infof("printf %d", 3)
+
+	out := buf.String()
+	for _, want := range []string{direct, viaContext, viaTemplate, viaPrintf} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected caller %s in %q", want, out)
+		}
+	}
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newCallerLogger(buf, WithCallerSkip(1))
+	viaOption := nextLine()
+	wrappedWarn(logger, "option")
+
+	plain := newCallerLogger(buf)
+	viaChild := nextLine()
+	wrappedWarn(plain.WithCallerSkip(1), "child")
+	viaEntry := nextLine()
+	func() { plain.WithFields(nil).WithCallerSkip(1).Info("entry") }()
+
+	out := buf.String()
+	for _, want := range []string{viaOption, viaChild, viaEntry} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected caller %s in %q", want, out)
+		}
+	}
+}
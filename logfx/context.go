@@ -14,6 +14,7 @@ type Context struct {
 	logger *Logger
 	fields map[string]any
 	ctx    context.Context
+	skip   int // Extra caller frames, see WithCallerSkip
 }
 
 // WithField adds a single field to the context
@@ -26,6 +27,7 @@ func (c *Context) WithField(key string, value any) *Context {
 		logger: c.logger,
 		fields: newFields,
 		ctx:    c.ctx,
+		skip:   c.skip,
 	}
 }
 
@@ -39,6 +41,7 @@ func (c *Context) WithFields(fields share.Fields) *Context {
 		logger: c.logger,
 		fields: newFields,
 		ctx:    c.ctx,
+		skip:   c.skip,
 	}
 }
 
@@ -48,6 +51,7 @@ func (c *Context) WithContext(ctx context.Context) *Context {
 		logger: c.logger,
 		fields: c.fields,
 		ctx:    ctx,
+		skip:   c.skip,
 	}
 }
 
@@ -82,10 +86,25 @@ func (c *Context) WithTraceID(traceID string) *Context {
 // log is the internal method that creates entries with fields
 func (c *Context) log(level share.Level, msg string) {
 	if !c.logger.shouldLog(level) {
+		if c.logger.recording(level) {
+			c.logger.record(level, msg, c.entryFields(), c.ctx)
+		}
+		return
+	}
+	if !c.logger.sample(level, msg) {
 		return
 	}
 
-	// Merge context fields with any fields from context.Context
+	entry := c.logger.createEntrySkip(level, msg, c.entryFields(), c.skip)
+	entry.Context = c.ctx
+
+	c.logger.emit(entry)
+	c.logger.release(entry)
+}
+
+// entryFields merges the context fields with any fields from the
+// context.Context.
+func (c *Context) entryFields() share.Fields {
 	allFields := make(share.Fields)
 
 	// Add fields from the logging context
@@ -96,26 +115,9 @@ func (c *Context) log(level share.Level, msg string) {
 		if ctxFields := extractContextFields(c.ctx); ctxFields != nil {
 			maps.Copy(allFields, ctxFields)
 		}
+		c.logger.addTrace(c.ctx, allFields)
 	}
-
-	entry := c.logger.createEntry(level, msg, allFields)
-	entry.Context = c.ctx
-
-	c.logger.mu.RLock()
-	writers := c.logger.writers
-	c.logger.mu.RUnlock()
-
-	for _, writer := range writers {
-		if c.logger.options.Async {
-			c.logger.wg.Add(1)
-			go func(w share.Writer, e *share.Entry) {
-				defer c.logger.wg.Done()
-				w.Write(e)
-			}(writer, entry)
-		} else {
-			writer.Write(entry)
-		}
-	}
+	return allFields
 }
 
 // Logging methods for Context
@@ -141,12 +143,13 @@ func (c *Context) Error(msg string, args ...any) {
 
 func (c *Context) Fatal(msg string, args ...any) {
 	c.log(share.LevelFatal, fmt.Sprintf(msg, args...))
-	osExit(1)
+	c.logger.exit(1)
 }
 
 func (c *Context) Panic(msg string, args ...any) {
 	msg = fmt.Sprintf(msg, args...)
 	c.log(share.LevelPanic, msg)
+	c.logger.root().Flush()
 	panic(msg)
 }
 
@@ -163,7 +166,7 @@ func (c *Context) FatalIf(err error, msg string, args ...any) {
 		errorFields["error"] = err.Error()
 
 		c.logger.log(share.LevelFatal, fmt.Sprintf("%s: %v", formattedMsg, err), errorFields)
-		osExit(1)
+		c.logger.exit(1)
 	}
 }
 
@@ -229,24 +232,10 @@ func (c *Context) Badge(tag, msg string, color color.Color, args ...any) {
 	badgeFields["badge"] = tag
 	badgeFields["badge_color"] = color
 
-	entry := c.logger.createEntry(share.LevelInfo, fmt.Sprintf(msg, args...), badgeFields)
+	entry := c.logger.createEntrySkip(share.LevelInfo, fmt.Sprintf(msg, args...), badgeFields, c.skip)
 	entry.Context = c.ctx
 
-	c.logger.mu.RLock()
-	writers := c.logger.writers
-	c.logger.mu.RUnlock()
-
-	for _, writer := range writers {
-		if c.logger.options.Async {
-			c.logger.wg.Add(1)
-			go func(w share.Writer, e *share.Entry) {
-				defer c.logger.wg.Done()
-				w.Write(e)
-			}(writer, entry)
-		} else {
-			writer.Write(entry)
-		}
-	}
+	c.logger.write(entry)
 }
 
 // GetFields returns a copy of all fields in the context
@@ -303,11 +292,39 @@ func extractContextFields(ctx context.Context) share.Fields {
 	return fields
 }
 
-// Convenience functions for creating contexts from context.Context
+// loggerKey is the context key of the logger stored by NewContext.
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx carrying logger, so code deep in a call
+// stack logs through the request- or flow-scoped logger instead of the
+// global one:
+//
+//	ctx = logfx.NewContext(ctx, logger.With(map[string]any{"request_id": id}))
+//	...
+//	logfx.FromContext(ctx).Info("cache miss")
+func NewContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger stored in ctx by NewContext, or the
+// global logger when there is none or ctx is nil.
+func LoggerFromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	return GetLogger()
+}
+
+// FromContext returns a logging context for ctx on the logger stored in it
+// by NewContext, falling back to the global logger. Entries carry the
+// fields and trace IDs found in ctx.
 func FromContext(ctx context.Context) *Context {
-	return GetLogger().WithContext(ctx)
+	return LoggerFromContext(ctx).WithContext(ctx)
 }
 
+// FromContextWithFields is FromContext with extra fields.
 func FromContextWithFields(ctx context.Context, fields share.Fields) *Context {
-	return GetLogger().WithContext(ctx).WithFields(fields)
+	return FromContext(ctx).WithFields(fields)
 }
@@ -0,0 +1,38 @@
+package logfx
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestNewContextCarriesLogger(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf).With(share.Fields{"request_id": "r9"})
+
+	ctx := NewContext(ContextWithTrace(context.Background(), "t1", "s1"), logger)
+	if LoggerFromContext(ctx) != logger {
+		t.Fatal("expected the stored logger")
+	}
+	FromContext(ctx).Info("deep call")
+	FromContextWithFields(ctx, share.Fields{"cache": "miss"}).Warn("deeper call")
+
+	out := buf.String()
+	for _, want := range []string{"deep call", "deeper call", "request_id=r9", "trace_id=t1", "cache=miss"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+}
+
+func TestFromContextFallsBackToGlobal(t *testing.T) {
+	if LoggerFromContext(context.Background()) != GetLogger() {
+		t.Error("expected the global logger without a stored one")
+	}
+	if LoggerFromContext(nil) != GetLogger() {
+		t.Error("expected the global logger for a nil context")
+	}
+}
@@ -0,0 +1,143 @@
+package logfx
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// WithDedup collapses identical consecutive entries, with the same level,
+// message and fields, logged within window of the first one. The first
+// entry is logged right away; the duplicates are reported as one entry such
+// as "connection refused (repeated 57 times in 10s)" when a different entry
+// is logged, the window expires or the logger is flushed.
+func WithDedup(window time.Duration) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Dedup = window
+	}
+}
+
+// SetDedup changes the duplicate suppression window of this logger. Zero
+// disables it. Named children keep their own setting.
+func (l *Logger) SetDedup(window time.Duration) {
+	l.flushDedup()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.options.Dedup = window
+	l.dedup = newDeduper(window)
+}
+
+// deduper tracks the last entry of a logger and how often it repeated. A nil
+// deduper passes every entry through.
+type deduper struct {
+	mu      sync.Mutex
+	window  time.Duration
+	first   *share.Entry // First entry of the current run
+	last    time.Time    // Time of the latest duplicate
+	repeats int
+}
+
+// newDeduper creates a deduper for window, or returns nil when it is zero.
+func newDeduper(window time.Duration) *deduper {
+	if window <= 0 {
+		return nil
+	}
+	return &deduper{window: window}
+}
+
+// observe records entry and reports whether it duplicates the current run.
+// It returns the summary of the previous run when entry ends it.
+func (d *deduper) observe(entry *share.Entry) (duplicate bool, summary *share.Entry) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.first != nil && sameEntry(d.first, entry) && entry.Timestamp.Sub(d.first.Timestamp) <= d.window {
+		d.repeats++
+		d.last = entry.Timestamp
+		return true, nil
+	}
+	summary = d.takeSummary()
+	d.first = entry
+	return false, summary
+}
+
+// takeSummary returns the entry reporting the repeats of the current run, or
+// nil when there were none, and resets the count. Callers hold d.mu.
+func (d *deduper) takeSummary() *share.Entry {
+	if d.first == nil || d.repeats == 0 {
+		return nil
+	}
+	first := d.first
+	fields := make(share.Fields, len(first.Fields)+1)
+	maps.Copy(fields, first.Fields)
+	fields["repeated"] = d.repeats
+
+	summary := *first
+	summary.Message = fmt.Sprintf("%s (repeated %d times in %s)",
+		first.Message, d.repeats, formatRepeatWindow(d.last.Sub(first.Timestamp)))
+	summary.Fields = fields
+	summary.Timestamp = d.last
+	d.repeats = 0
+	return &summary
+}
+
+// formatRepeatWindow renders how long a run of duplicates lasted.
+func formatRepeatWindow(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+// sameEntry reports whether b repeats a.
+func sameEntry(a, b *share.Entry) bool {
+	if a.Level != b.Level || a.Message != b.Message || len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	return len(a.Fields) == 0 || reflect.DeepEqual(a.Fields, b.Fields)
+}
+
+// emit writes entry unless it duplicates the previous entry of the logger,
+// writing the summary of the previous run first when it ends one. An entry
+// that triggers the flight recorder is preceded by the recorded entries.
+func (l *Logger) emit(entry *share.Entry) {
+	l.dumpOnTrigger(entry.Level)
+
+	l.mu.RLock()
+	d := l.dedup
+	l.mu.RUnlock()
+	if d == nil {
+		l.write(entry)
+		return
+	}
+
+	duplicate, summary := d.observe(entry)
+	if summary != nil {
+		l.write(summary)
+	}
+	if !duplicate {
+		l.write(entry)
+	}
+}
+
+// flushDedup writes the summary of a pending run of duplicates.
+func (l *Logger) flushDedup() {
+	l.mu.RLock()
+	d := l.dedup
+	l.mu.RUnlock()
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	summary := d.takeSummary()
+	d.mu.Unlock()
+	if summary != nil {
+		l.write(summary)
+	}
+}
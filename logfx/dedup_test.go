@@ -0,0 +1,79 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestDedupCollapsesConsecutiveEntries(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetDedup(time.Minute)
+
+	for range 5 {
+		logger.WithFields(share.Fields{"host": "db1"}).Warn("connection refused")
+	}
+	if got := strings.Count(buf.String(), "connection refused"); got != 1 {
+		t.Fatalf("expected duplicates to be held back, got %d in %q", got, buf.String())
+	}
+
+	logger.Info("reconnected")
+	out := buf.String()
+	if !strings.Contains(out, "connection refused (repeated 4 times in") {
+		t.Fatalf("expected repeat summary, got %q", out)
+	}
+	if strings.Index(out, "repeated 4 times") > strings.Index(out, "reconnected") {
+		t.Fatalf("summary must precede the next entry, got %q", out)
+	}
+}
+
+func TestDedupComparesLevelAndFields(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetDedup(time.Minute)
+
+	logger.WithFields(share.Fields{"id": 1}).Info("tick")
+	logger.WithFields(share.Fields{"id": 2}).Info("tick")
+	logger.Warn("tick")
+	logger.Flush()
+
+	if got := strings.Count(buf.String(), "tick"); got != 3 {
+		t.Fatalf("entries differing in fields or level must all be logged, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "repeated") {
+		t.Fatalf("unexpected summary in %q", buf.String())
+	}
+}
+
+func TestDedupWindowAndFlush(t *testing.T) {
+	d := newDeduper(time.Second)
+	start := time.Now()
+	entry := func(at time.Duration) *share.Entry {
+		return &share.Entry{Level: share.LevelInfo, Message: "poll", Timestamp: start.Add(at)}
+	}
+
+	if dup, _ := d.observe(entry(0)); dup {
+		t.Fatal("first entry is not a duplicate")
+	}
+	if dup, _ := d.observe(entry(500 * time.Millisecond)); !dup {
+		t.Fatal("entry within the window is a duplicate")
+	}
+	dup, summary := d.observe(entry(2 * time.Second))
+	if dup || summary == nil || summary.Fields["repeated"] != 1 {
+		t.Fatalf("entry after the window starts a new run, got dup=%v summary=%v", dup, summary)
+	}
+
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetDedup(time.Minute)
+	logger.Info("same")
+	logger.Info("same")
+	logger.Flush()
+	if !strings.Contains(buf.String(), "same (repeated 1 times in") {
+		t.Fatalf("expected Flush to report pending repeats, got %q", buf.String())
+	}
+}
@@ -0,0 +1,108 @@
+package logfx
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// ErrorFielder is implemented by errors that carry structured context, such
+// as the query that failed. Err merges their fields into the entry.
+type ErrorFielder interface {
+	Fields() share.Fields
+}
+
+// Err returns a context that logs err as structured data instead of a
+// flattened string:
+//
+//	logger.Err(err).Error("migration failed")
+//
+// The entry gets the full message in "error", the type of the innermost
+// cause in "error_type" and every layer of the chain in "error_chain", each
+// with its type and message. Wrapped errors are walked through Unwrap,
+// including errors joined with errors.Join, and the fields of every error
+// implementing ErrorFielder are added to the entry; an outer error wins
+// when two errors set the same field. A nil err adds nothing.
+func (l *Logger) Err(err error) *Context {
+	return l.WithFields(nil).Err(err)
+}
+
+// Err adds err to the context as structured data, see Logger.Err.
+func (c *Context) Err(err error) *Context {
+	if err == nil {
+		return c
+	}
+	return c.WithFields(errorFields(err))
+}
+
+// Err returns a context of the global logger carrying err, see Logger.Err.
+func Err(err error) *Context { return GetLogger().Err(err) }
+
+// errorFields describes the chain of err as fields.
+func errorFields(err error) share.Fields {
+	chain := errorChain(err)
+
+	fields := make(share.Fields)
+	for i := len(chain) - 1; i >= 0; i-- {
+		if fielder, ok := chain[i].(ErrorFielder); ok {
+			maps.Copy(fields, fielder.Fields())
+		}
+	}
+
+	layers := make([]map[string]any, len(chain))
+	for i, e := range chain {
+		layers[i] = map[string]any{
+			"type":    fmt.Sprintf("%T", e),
+			"message": e.Error(),
+		}
+	}
+	fields["error"] = err.Error()
+	fields["error_type"] = fmt.Sprintf("%T", rootCause(err))
+	fields["error_chain"] = layers
+	return fields
+}
+
+// errorChain returns err and the errors it wraps, depth first, outermost
+// first.
+func errorChain(err error) []error {
+	var chain []error
+	var walk func(error)
+	walk = func(e error) {
+		if e == nil {
+			return
+		}
+		chain = append(chain, e)
+		switch u := e.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range u.Unwrap() {
+				walk(inner)
+			}
+		default:
+			walk(errors.Unwrap(e))
+		}
+	}
+	walk(err)
+	return chain
+}
+
+// rootCause follows the first wrapped error of each layer down to the
+// innermost one.
+func rootCause(err error) error {
+	for {
+		var next error
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			if inner := u.Unwrap(); len(inner) > 0 {
+				next = inner[0]
+			}
+		default:
+			next = errors.Unwrap(err)
+		}
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
@@ -0,0 +1,67 @@
+package logfx
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+type queryError struct {
+	query string
+	err   error
+}
+
+func (e *queryError) Error() string        { return "query failed: " + e.err.Error() }
+func (e *queryError) Unwrap() error        { return e.err }
+func (e *queryError) Fields() share.Fields { return share.Fields{"query": e.query, "table": "inner"} }
+
+type tableError struct{ error }
+
+func (e tableError) Unwrap() error        { return e.error }
+func (e tableError) Fields() share.Fields { return share.Fields{"table": "users"} }
+
+func TestErrRecordsChain(t *testing.T) {
+	cause := &queryError{query: "SELECT 1", err: fs.ErrNotExist}
+	err := fmt.Errorf("migrate: %w", tableError{cause})
+
+	fields := New(DefaultOptions()).Err(err).GetFields()
+
+	if fields["error"] != err.Error() {
+		t.Errorf("expected full message, got %v", fields["error"])
+	}
+	if fields["query"] != "SELECT 1" {
+		t.Errorf("expected fields of wrapped errors, got %v", fields)
+	}
+	if fields["table"] != "users" {
+		t.Errorf("outer error fields must win, got %v", fields["table"])
+	}
+	if fields["error_type"] != "*errors.errorString" {
+		t.Errorf("expected root cause type, got %v", fields["error_type"])
+	}
+	chain, ok := fields["error_chain"].([]map[string]any)
+	if !ok || len(chain) != 4 {
+		t.Fatalf("expected 4 layers, got %#v", fields["error_chain"])
+	}
+	if chain[0]["type"] != "*fmt.wrapError" || chain[2]["type"] != "*logfx.queryError" {
+		t.Errorf("unexpected chain %v", chain)
+	}
+}
+
+func TestErrWalksJoinedErrors(t *testing.T) {
+	err := errors.Join(errors.New("first"), &queryError{query: "q", err: errors.New("second")})
+
+	fields := Err(err).GetFields()
+	chain := fields["error_chain"].([]map[string]any)
+	if len(chain) != 4 || fields["query"] != "q" {
+		t.Fatalf("expected every branch of the join, got %v", fields)
+	}
+}
+
+func TestErrNil(t *testing.T) {
+	if fields := New(DefaultOptions()).Err(nil).GetFields(); len(fields) != 0 {
+		t.Fatalf("nil error must add no fields, got %v", fields)
+	}
+}
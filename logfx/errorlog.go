@@ -0,0 +1,88 @@
+package logfx
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// ErrorLogOptions configures the error log file, which receives a copy of
+// the error entries of a logger so they can be triaged without searching
+// the full log.
+type ErrorLogOptions struct {
+	Filename   string      // Defaults to LogFile with an ".error.log" extension
+	Level      share.Level // Minimum level of the entries copied
+	MaxSize    int64       // Size in bytes that triggers a rotation
+	MaxBackups int         // Rotated files kept
+	MaxAge     int         // Days rotated files are kept
+}
+
+// DefaultErrorLogOptions copies Error and above to <name>.error.log,
+// rotating it at 10 MB and keeping 5 backups for 90 days.
+func DefaultErrorLogOptions() ErrorLogOptions {
+	return ErrorLogOptions{
+		Level:      share.LevelError,
+		MaxSize:    10 * 1024 * 1024,
+		MaxBackups: 5,
+		MaxAge:     90,
+	}
+}
+
+// WithErrorLog copies Error and above entries to a separate file next to
+// the log file: "app.log" gets "app.error.log". It needs WithFileOutput.
+func WithErrorLog() LogOption {
+	return WithErrorLogOptions(DefaultErrorLogOptions())
+}
+
+// WithErrorLogOptions copies error entries to a separate file configured by
+// opts, see WithErrorLog.
+func WithErrorLogOptions(opts ErrorLogOptions) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.ErrorLog = &opts
+	}
+}
+
+// errorLogName returns the error log file name derived from logFile.
+func errorLogName(logFile string) string {
+	ext := filepath.Ext(logFile)
+	return strings.TrimSuffix(logFile, ext) + ".error.log"
+}
+
+// newErrorLogWriter creates the writer of the error log of opts, or returns
+// nil when there is none or it cannot be opened.
+func newErrorLogWriter(opts LogOptions) share.Writer {
+	el := opts.ErrorLog
+	if el == nil {
+		return nil
+	}
+	filename := el.Filename
+	if filename == "" {
+		if opts.LogFile == "" {
+			return nil
+		}
+		filename = errorLogName(opts.LogFile)
+	}
+
+	fwOpts := writerpkg.DefaultFileOptions()
+	fwOpts.Level = el.Level
+	fwOpts.Format = opts.Format
+	fwOpts.JSONKeys = opts.JSONKeys
+	fwOpts.EncryptionKey = opts.FileKey
+	if el.MaxSize > 0 {
+		fwOpts.MaxSize = el.MaxSize
+	}
+	if el.MaxBackups > 0 {
+		fwOpts.MaxBackups = el.MaxBackups
+	}
+	if el.MaxAge > 0 {
+		fwOpts.MaxAge = el.MaxAge
+	}
+
+	fw, err := writerpkg.NewFileWriter(filename, fwOpts)
+	if err != nil {
+		return nil
+	}
+	return fw
+}
@@ -0,0 +1,74 @@
+package logfx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestErrorLogName(t *testing.T) {
+	for in, want := range map[string]string{
+		"app.log":          "app.error.log",
+		"/var/log/job":     "/var/log/job.error.log",
+		"logs/svc.v2.json": "logs/svc.v2.error.log",
+	} {
+		if got := errorLogName(in); got != want {
+			t.Errorf("errorLogName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestErrorLogReceivesErrorsOnly(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "job.log")
+	logger := LogWith(
+		WithOutput(&testutil.SafeBuffer{}),
+		WithFileOutput(logFile),
+		WithFileRotation(1<<20, 1, 1),
+		WithErrorLog(),
+	)
+
+	logger.Info("progress")
+	logger.Warn("slow")
+	logger.Error("failed")
+	logger.Close()
+
+	all, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := os.ReadFile(filepath.Join(dir, "job.error.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(all), "progress") || !strings.Contains(string(all), "failed") {
+		t.Errorf("expected every entry in the main log, got %q", all)
+	}
+	if !strings.Contains(string(errs), "failed") || strings.Contains(string(errs), "progress") || strings.Contains(string(errs), "slow") {
+		t.Errorf("expected only errors in the error log, got %q", errs)
+	}
+}
+
+func TestErrorLogCustomFileAndLevel(t *testing.T) {
+	dir := t.TempDir()
+	opts := DefaultErrorLogOptions()
+	opts.Filename = filepath.Join(dir, "problems.log")
+	opts.Level = share.LevelWarn
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}), WithErrorLogOptions(opts))
+
+	logger.Info("fine")
+	logger.Warn("careful")
+	logger.Close()
+
+	data, err := os.ReadFile(opts.Filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "careful") || strings.Contains(string(data), "fine") {
+		t.Errorf("expected Warn and above, got %q", data)
+	}
+}
@@ -0,0 +1,69 @@
+package logfx
+
+import (
+	"slices"
+	"sync"
+)
+
+// exitHooks are the callbacks registered with OnExit, keyed by an id so
+// they can be removed.
+var (
+	exitMu     sync.Mutex
+	exitHooks  []exitHook
+	nextExitID int
+)
+
+type exitHook struct {
+	id int
+	fn func()
+}
+
+// OnExit registers fn to run when a Fatal entry is about to terminate the
+// process, e.g. to close files, restore the terminal or stop a runfx loop:
+//
+//	remove := logfx.OnExit(func() { loop.Stop() })
+//	defer remove()
+//
+// Hooks run in reverse order of registration, like deferred calls, after
+// the Fatal entry is logged and before the loggers flush. A panicking hook
+// does not keep the others from running. The returned function removes
+// the hook.
+func OnExit(fn func()) (remove func()) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+
+	nextExitID++
+	id := nextExitID
+	exitHooks = append(exitHooks, exitHook{id: id, fn: fn})
+	return func() {
+		exitMu.Lock()
+		defer exitMu.Unlock()
+		exitHooks = slices.DeleteFunc(exitHooks, func(h exitHook) bool { return h.id == id })
+	}
+}
+
+// runExitHooks runs the registered hooks, newest first.
+func runExitHooks() {
+	exitMu.Lock()
+	hooks := slices.Clone(exitHooks)
+	exitMu.Unlock()
+
+	for _, h := range slices.Backward(hooks) {
+		func() {
+			defer func() { recover() }()
+			h.fn()
+		}()
+	}
+}
+
+// exit ends the process after a Fatal entry: it runs the exit hooks, then
+// flushes the logger, including its asynchronous writers and those of its
+// parents, and the global logger, so no entry is lost.
+func (l *Logger) exit(code int) {
+	runExitHooks()
+	l.root().Flush()
+	if global := GetLogger(); global != l.root() {
+		global.Flush()
+	}
+	osExit(code)
+}
@@ -0,0 +1,47 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestFatalRunsExitHooksAndFlushes(t *testing.T) {
+	oldOsExit := osExit
+	defer func() { osExit = oldOsExit }()
+
+	buf := &testutil.SafeBuffer{}
+	opts := DefaultOptions()
+	opts.Output = buf
+	opts.Format = share.FormatText
+	opts.Async = true
+	opts.AsyncBuffer = 64
+	logger := New(opts)
+
+	var order []string
+	defer OnExit(func() { order = append(order, "first") })()
+	defer OnExit(func() { panic("broken hook") })()
+	defer OnExit(func() { order = append(order, "last") })()
+	removed := OnExit(func() { order = append(order, "removed") })
+	removed()
+
+	var exitOutput string
+	osExit = func(code int) {
+		order = append(order, "exit")
+		exitOutput = buf.String()
+	}
+
+	for range 20 {
+		logger.Info("queued")
+	}
+	logger.Fatal("fatal message")
+
+	if got := strings.Join(order, ","); got != "last,first,exit" {
+		t.Fatalf("expected hooks newest first then exit, got %s", got)
+	}
+	if strings.Count(exitOutput, "queued") != 20 || !strings.Contains(exitOutput, "fatal message") {
+		t.Fatalf("expected every async entry written before exit, got %q", exitOutput)
+	}
+}
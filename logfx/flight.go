@@ -0,0 +1,147 @@
+package logfx
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// FlightRecorderOptions configures the flight recorder.
+type FlightRecorderOptions struct {
+	Size    int         // Number of entries kept
+	Trigger share.Level // Entries at this level or above dump the recording
+}
+
+// DefaultFlightRecorderOptions keeps 100 entries and dumps them on errors.
+func DefaultFlightRecorderOptions() FlightRecorderOptions {
+	return FlightRecorderOptions{Size: 100, Trigger: share.LevelError}
+}
+
+// WithFlightRecorder keeps the latest size entries filtered out by the
+// level of the logger, such as Debug and Trace entries of a logger at Info,
+// in memory. When an Error or Fatal entry is logged they are written first,
+// oldest first and marked with a "flight_recorder" field, giving the
+// context of the failure without logging at debug level all the time.
+func WithFlightRecorder(size int) LogOption {
+	opts := DefaultFlightRecorderOptions()
+	opts.Size = size
+	return WithFlightRecorderOptions(opts)
+}
+
+// WithFlightRecorderOptions enables the flight recorder with opts, see
+// WithFlightRecorder.
+func WithFlightRecorderOptions(opts FlightRecorderOptions) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.FlightRecorder = &opts
+	}
+}
+
+// recordedEntry is an entry kept by the flight recorder. It is turned into
+// a share.Entry, running hooks and redaction, only when dumped.
+type recordedEntry struct {
+	logger *Logger
+	level  share.Level
+	msg    string
+	fields share.Fields
+	ctx    context.Context
+	time   time.Time
+}
+
+// flightRecorder is a ring buffer of filtered entries. A nil flightRecorder
+// records nothing.
+type flightRecorder struct {
+	mu      sync.Mutex
+	trigger share.Level
+	entries []recordedEntry
+	next    int // Slot of the next entry
+	full    bool
+}
+
+// newFlightRecorder creates a recorder for opts, or returns nil when opts is
+// nil or keeps no entries.
+func newFlightRecorder(opts *FlightRecorderOptions) *flightRecorder {
+	if opts == nil || opts.Size <= 0 {
+		return nil
+	}
+	return &flightRecorder{trigger: opts.Trigger, entries: make([]recordedEntry, opts.Size)}
+}
+
+// add records e, overwriting the oldest entry when the buffer is full.
+func (r *flightRecorder) add(e recordedEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// take returns the recorded entries, oldest first, and empties the buffer.
+func (r *flightRecorder) take() []recordedEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var taken []recordedEntry
+	if r.full {
+		taken = append(taken, r.entries[r.next:]...)
+	}
+	taken = append(taken, r.entries[:r.next]...)
+	clear(r.entries)
+	r.next, r.full = 0, false
+	return taken
+}
+
+// flightRecorder returns the recorder of the root logger.
+func (l *Logger) flightRecorder() *flightRecorder {
+	root := l.root()
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	return root.recorder
+}
+
+// recording reports whether a filtered entry at level should be recorded.
+func (l *Logger) recording(level share.Level) bool {
+	r := l.flightRecorder()
+	return r != nil && level < r.trigger
+}
+
+// record keeps a filtered entry in the flight recorder.
+func (l *Logger) record(level share.Level, msg string, fields share.Fields, ctx context.Context) {
+	if r := l.flightRecorder(); r != nil {
+		r.add(recordedEntry{logger: l, level: level, msg: msg, fields: fields, ctx: ctx, time: time.Now()})
+	}
+}
+
+// dumpOnTrigger writes the recorded entries when level triggers the flight
+// recorder.
+func (l *Logger) dumpOnTrigger(level share.Level) {
+	if r := l.flightRecorder(); r != nil && level >= r.trigger {
+		l.DumpFlightRecorder()
+	}
+}
+
+// DumpFlightRecorder writes the entries kept by the flight recorder, oldest
+// first, and empties it. It does nothing unless the logger was created with
+// WithFlightRecorder.
+func (l *Logger) DumpFlightRecorder() {
+	r := l.flightRecorder()
+	if r == nil {
+		return
+	}
+	for _, rec := range r.take() {
+		fields := make(share.Fields, len(rec.fields)+1)
+		maps.Copy(fields, rec.fields)
+		fields["flight_recorder"] = true
+
+		entry := rec.logger.createEntry(rec.level, rec.msg, fields)
+		entry.Timestamp = rec.time
+		if rec.ctx != nil {
+			entry.Context = rec.ctx
+		}
+		rec.logger.write(entry)
+	}
+}
@@ -0,0 +1,70 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func newRecordingLogger(buf *testutil.SafeBuffer, size int) *Logger {
+	opts := DefaultOptions()
+	opts.Output = buf
+	opts.Format = share.FormatText
+	opts.Timestamp = false
+	opts.DisableColor = true
+	opts.Level = share.LevelInfo
+	WithFlightRecorder(size)(&opts)
+	return New(opts)
+}
+
+func TestFlightRecorderDumpsOnError(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newRecordingLogger(buf, 3)
+
+	logger.Debug("step 1")
+	logger.Named("db").Debug("step 2")
+	logger.WithFields(share.Fields{"id": 7}).Trace("step 3")
+	logger.Debug("step 4")
+	logger.Info("visible")
+	if strings.Contains(buf.String(), "step") {
+		t.Fatalf("recorded entries must stay hidden until an error, got %q", buf.String())
+	}
+
+	logger.Error("boom")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected info, 3 recorded entries and the error, got %q", lines)
+	}
+	for i, want := range []string{"step 2", "step 3", "step 4"} {
+		line := lines[i+1]
+		if !strings.Contains(line, want) || !strings.Contains(line, "flight_recorder=true") {
+			t.Errorf("expected recorded %q, got %q", want, line)
+		}
+	}
+	if !strings.Contains(lines[1], "logger=db") || !strings.Contains(lines[2], "id=7") {
+		t.Errorf("expected recorded fields to be kept, got %q", lines[1:3])
+	}
+	if !strings.Contains(lines[4], "boom") {
+		t.Errorf("expected the error last, got %q", lines[4])
+	}
+
+	logger.Error("again")
+	if got := strings.Count(buf.String(), "flight_recorder"); got != 3 {
+		t.Errorf("expected the recording to be emptied after a dump, got %d entries", got)
+	}
+}
+
+func TestFlightRecorderDisabled(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetLevel(share.LevelInfo)
+
+	logger.Debug("hidden")
+	logger.Error("boom")
+	logger.DumpFlightRecorder()
+	if strings.Contains(buf.String(), "hidden") {
+		t.Fatalf("expected no recording without WithFlightRecorder, got %q", buf.String())
+	}
+}
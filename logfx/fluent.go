@@ -3,7 +3,6 @@ package logfx
 import (
 	"fmt"
 	"maps"
-	"os"
 
 	"github.com/garaekz/tfx/color"
 	"github.com/garaekz/tfx/internal/share"
@@ -169,7 +168,7 @@ func (f *FluentLogger) Msg(msg string, args ...any) bool {
 
 	if f.level == share.LevelFatal {
 		f.logger.log(f.level, formattedMsg, f.fields)
-		os.Exit(1)
+		f.logger.exit(1)
 	}
 
 	f.logger.log(f.level, formattedMsg, f.fields)
@@ -219,17 +218,7 @@ func (l *Logger) ModernBadge(tag string, msg string, opts ...BadgeOption) {
 		opt(&cfg)
 	}
 
-	fields := share.Fields{
-		"badge":       tag,
-		"badge_style": cfg.style,
-		"badge_color": cfg.color,
-		"bg_color":    cfg.bgColor,
-		"bold":        cfg.bold,
-		"italic":      cfg.italic,
-		"underline":   cfg.underline,
-	}
-
-	l.log(cfg.level, msg, fields)
+	l.log(cfg.level, msg, l.badgeFields(tag, cfg))
 }
 
 // BadgeConfig holds configuration for modern badges
@@ -241,6 +230,7 @@ type BadgeConfig struct {
 	bold      bool
 	italic    bool
 	underline bool
+	role      BadgeRole
 }
 
 // BadgeOption is a functional option for badge configuration
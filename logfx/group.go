@@ -0,0 +1,97 @@
+package logfx
+
+import (
+	"slices"
+
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// WithGroup returns a child logger that nests the fields of later calls,
+// including those bound with With, under name, like slog.Logger.WithGroup:
+//
+//	httpLog := logger.With(map[string]any{"service": "api"}).WithGroup("http")
+//	httpLog.WithFields(map[string]any{"status": 200}).Info("served")
+//	// text: service=api • http.status=200
+//	// JSON: {"service":"api","http":{"status":200}}
+//
+// Fields bound before the group stay where they are. Groups nest, and an
+// entry whose group has no fields omits it. Unlike Group, which indents
+// the entries logged inside a function, it only changes field names.
+// Badge styling, trace and logger name fields are never nested. An empty
+// name returns l.
+func (l *Logger) WithGroup(name string) *Logger {
+	if name == "" {
+		return l
+	}
+	child := l.With(nil)
+	child.groups = append(slices.Clip(l.groups), name)
+	return child
+}
+
+// WithGroup returns a context whose later fields are nested under name,
+// see (*Logger).WithGroup. The fields of c stay outside the group.
+func (c *Context) WithGroup(name string) *Context {
+	if name == "" {
+		return c
+	}
+	return &Context{
+		logger: c.logger.With(c.fields).WithGroup(name),
+		ctx:    c.ctx,
+		skip:   c.skip,
+	}
+}
+
+// nest moves fields under the groups of l, leaving fields that are not data
+// at the top level.
+func (l *Logger) nest(fields share.Fields) share.Fields {
+	if len(l.groups) == 0 || len(fields) == 0 {
+		return fields
+	}
+
+	top := make(share.Fields)
+	inner := make(share.Fields, len(fields))
+	for key, value := range fields {
+		if ungrouped(key) {
+			top[key] = value
+		} else {
+			inner[key] = value
+		}
+	}
+	if len(inner) == 0 {
+		return top
+	}
+	for i := len(l.groups) - 1; i > 0; i-- {
+		inner = share.Fields{l.groups[i]: inner}
+	}
+	top[l.groups[0]] = inner
+	return top
+}
+
+// ungrouped reports whether the field key stays at the top level of
+// grouped entries.
+func ungrouped(key string) bool {
+	return writerpkg.IsPresentationField(key) ||
+		key == "type" || key == "logger" || key == TraceIDKey || key == SpanIDKey
+}
+
+// mergeFields copies src into dst, merging nested groups. Nested Fields are
+// copied before they are changed, so they can be shared between loggers.
+func mergeFields(dst, src share.Fields) {
+	for key, value := range src {
+		nested, ok := value.(share.Fields)
+		existing, exists := dst[key].(share.Fields)
+		if !ok || !exists {
+			dst[key] = value
+			continue
+		}
+		merged := make(share.Fields, len(existing)+len(nested))
+		mergeFields(merged, existing)
+		mergeFields(merged, nested)
+		dst[key] = merged
+	}
+}
+
+// WithGroup returns a child of the global logger that nests later fields
+// under name.
+func WithGroup(name string) *Logger { return GetLogger().WithGroup(name) }
@@ -0,0 +1,86 @@
+package logfx
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestWithGroupNestsLaterFields(t *testing.T) {
+	logger, entries := captureEntries(&testutil.SafeBuffer{})
+	httpLog := logger.With(map[string]any{"service": "api"}).WithGroup("http")
+	httpLog.With(map[string]any{"method": "GET"}).
+		WithFields(map[string]any{"status": 200}).Info("served")
+
+	got := (*entries)[0].Fields
+	http, ok := got["http"].(share.Fields)
+	if !ok || got["service"] != "api" || http["method"] != "GET" || http["status"] != 200 {
+		t.Fatalf("fields = %v", got)
+	}
+	if _, ok := got["status"]; ok {
+		t.Errorf("status also at the top level: %v", got)
+	}
+}
+
+func TestWithGroupNested(t *testing.T) {
+	logger, entries := captureEntries(&testutil.SafeBuffer{})
+	reqLog := logger.WithGroup("http").With(map[string]any{"path": "/"}).WithGroup("request")
+	reqLog.WithFields(map[string]any{"bytes": 12}).Info("read")
+	reqLog.Info("no fields")
+
+	want := "map[http:map[path:/ request:map[bytes:12]]]"
+	if got := fmtFields((*entries)[0].Fields); got != want {
+		t.Errorf("fields = %s, want %s", got, want)
+	}
+	if got := fmtFields((*entries)[1].Fields); got != "map[http:map[path:/]]" {
+		t.Errorf("empty group kept: %s", got)
+	}
+}
+
+func TestWithGroupKeepsMetadataTopLevel(t *testing.T) {
+	logger, entries := captureEntries(&testutil.SafeBuffer{})
+	logger.Named("db").WithGroup("sql").ModernBadge("DB", "query")
+
+	got := (*entries)[0].Fields
+	if got["badge"] != "DB" || got["logger"] != "db" {
+		t.Errorf("fields = %v", got)
+	}
+	if _, ok := got["sql"]; ok {
+		t.Errorf("group without data fields kept: %v", got)
+	}
+}
+
+func TestContextWithGroup(t *testing.T) {
+	logger, entries := captureEntries(&testutil.SafeBuffer{})
+	logger.WithFields(map[string]any{"user": "ana"}).WithGroup("auth").
+		WithField("method", "token").Info("login")
+
+	got := fmtFields((*entries)[0].Fields)
+	if got != "map[auth:map[method:token] user:ana]" {
+		t.Errorf("fields = %s", got)
+	}
+}
+
+func TestWithGroupRendering(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.WithGroup("http").WithFields(map[string]any{"status": 200}).Info("served")
+	if !strings.Contains(buf.String(), "http.status=200") {
+		t.Errorf("text output = %q", buf.String())
+	}
+
+	buf.Reset()
+	logger.SetFormat(share.FormatJSON)
+	logger.WithGroup("http").WithFields(map[string]any{"status": 200}).Info("served")
+	if !strings.Contains(buf.String(), `"http":{"status":200}`) {
+		t.Errorf("JSON output = %q", buf.String())
+	}
+}
+
+// fmtFields formats fields with sorted keys.
+func fmtFields(fields share.Fields) string {
+	return fmt.Sprint(map[string]any(fields))
+}
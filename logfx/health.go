@@ -0,0 +1,85 @@
+package logfx
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// WriterHealth reports how the writes to one writer went.
+type WriterHealth struct {
+	Writer      string    `json:"writer"` // Type of the writer, e.g. "*writer.FileWriter"
+	Writes      uint64    `json:"writes"`
+	Errors      uint64    `json:"errors"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at,omitzero"`
+}
+
+// Healthy reports whether the last write to the writer succeeded.
+func (h WriterHealth) Healthy() bool {
+	return h.LastError == ""
+}
+
+// writerHealth tracks the writes of the writers of a root logger.
+type writerHealth struct {
+	mu    sync.Mutex
+	stats map[share.Writer]*WriterHealth
+}
+
+// record notes the outcome of a write to w.
+func (h *writerHealth) record(w share.Writer, err error) {
+	if !reflect.TypeOf(w).Comparable() {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stats == nil {
+		h.stats = make(map[share.Writer]*WriterHealth)
+	}
+	s, ok := h.stats[w]
+	if !ok {
+		s = &WriterHealth{Writer: fmt.Sprintf("%T", w)}
+		h.stats[w] = s
+	}
+	s.Writes++
+	if err != nil {
+		s.Errors++
+		s.LastError = err.Error()
+		s.LastErrorAt = time.Now()
+	} else {
+		s.LastError = ""
+	}
+}
+
+// get returns the health of w.
+func (h *writerHealth) get(w share.Writer) WriterHealth {
+	if reflect.TypeOf(w).Comparable() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if s, ok := h.stats[w]; ok {
+			return *s
+		}
+	}
+	return WriterHealth{Writer: fmt.Sprintf("%T", w)}
+}
+
+// writeTo writes entry to w and records the outcome.
+func (l *Logger) writeTo(w share.Writer, entry *share.Entry) {
+	l.root().health.record(w, w.Write(entry))
+}
+
+// WriterHealth returns the health of the writers of l, including the ones
+// inherited from its parents, in the order entries reach them.
+func (l *Logger) WriterHealth() []WriterHealth {
+	root := l.root()
+	writers := l.allWriters()
+	health := make([]WriterHealth, len(writers))
+	for i, w := range writers {
+		health[i] = root.health.get(w)
+	}
+	return health
+}
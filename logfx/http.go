@@ -0,0 +1,47 @@
+package logfx
+
+import (
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// WithHTTPOutput ships entries in batches to url as JSON arrays, as
+// webhooks and the Datadog logs intake accept. See WithHTTPOptions for Loki,
+// headers, labels and compression. Dropped and Flush cover its queue.
+func WithHTTPOutput(url string) LogOption {
+	opts := writerpkg.DefaultHTTPOptions()
+	opts.URL = url
+	return WithHTTPOptions(opts)
+}
+
+// WithHTTPOptions ships entries over HTTP as configured by opts:
+//
+//	opts := writerpkg.DefaultHTTPOptions()
+//	opts.URL = "http://loki:3100/loki/api/v1/push"
+//	opts.Format = writerpkg.HTTPFormatLoki
+//	opts.Labels = map[string]string{"app": "api"}
+//	logger := logfx.LogWith(logfx.WithHTTPOptions(opts))
+func WithHTTPOptions(opts writerpkg.HTTPOptions) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.HTTP = &opts
+	}
+}
+
+// newHTTPWriter creates an HTTP writer configured by opts, or returns nil
+// when there is none or it has no URL. The logger filters the entries
+// itself.
+func newHTTPWriter(opts *writerpkg.HTTPOptions, keys writerpkg.JSONKeys) share.Writer {
+	if opts == nil {
+		return nil
+	}
+	hwOpts := *opts
+	hwOpts.Level = share.LevelTrace
+	if hwOpts.JSONKeys == (writerpkg.JSONKeys{}) {
+		hwOpts.JSONKeys = keys
+	}
+	hw, err := writerpkg.NewHTTPWriter(hwOpts)
+	if err != nil {
+		return nil
+	}
+	return hw
+}
@@ -0,0 +1,37 @@
+package logfx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestWithHTTPOutput(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(data))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}), WithHTTPOutput(srv.URL))
+	defer logger.Close()
+	logger.Info("shipped")
+	logger.Debug("filtered")
+	logger.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 1 || !strings.HasPrefix(bodies[0], "[") || !strings.Contains(bodies[0], `"msg":"shipped"`) ||
+		strings.Contains(bodies[0], "filtered") {
+		t.Errorf("unexpected requests %q", bodies)
+	}
+}
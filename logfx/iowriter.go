@@ -0,0 +1,88 @@
+package logfx
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// LineWriter is an io.Writer that logs every line written to it as one
+// entry. It lets code that only knows about io.Writer feed logfx, e.g.
+// exec.Cmd output or a standard library *log.Logger:
+//
+//	srv := &http.Server{
+//		ErrorLog: log.New(logger.Writer(logfx.LevelError), "", 0),
+//	}
+//
+// Writes may split or join lines freely; a line is logged once its newline
+// arrives, and Close logs a trailing line without one. Empty lines are
+// dropped.
+type LineWriter struct {
+	mu      sync.Mutex
+	log     func(msg string)
+	partial []byte
+}
+
+// newLineWriter creates a LineWriter that passes each line to log.
+func newLineWriter(log func(msg string)) *LineWriter {
+	return &LineWriter{log: log}
+}
+
+// Write implements io.Writer. It never fails.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(w.partial[:i])
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// Close logs any pending partial line.
+func (w *LineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.emit(w.partial)
+	w.partial = nil
+	return nil
+}
+
+// emit logs line without its trailing carriage return, unless it is empty.
+func (w *LineWriter) emit(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+	w.log(string(line))
+}
+
+var _ io.WriteCloser = (*LineWriter)(nil)
+
+// Writer returns an io.Writer that logs each line written to it at level.
+func (l *Logger) Writer(level share.Level) *LineWriter {
+	return newLineWriter(func(msg string) {
+		l.log(level, msg, nil)
+	})
+}
+
+// Writer returns an io.Writer that logs each line written to it at level,
+// with the fields of the context.
+func (c *Context) Writer(level share.Level) *LineWriter {
+	return newLineWriter(func(msg string) {
+		c.log(level, msg)
+	})
+}
+
+// Writer returns an io.Writer that logs each line written to it at level
+// through the global logger.
+func Writer(level share.Level) *LineWriter { return GetLogger().Writer(level) }
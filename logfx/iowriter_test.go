@@ -0,0 +1,74 @@
+package logfx
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func newTextLogger(buf *testutil.SafeBuffer) *Logger {
+	opts := DefaultOptions()
+	opts.Output = buf
+	opts.Format = share.FormatText
+	opts.Timestamp = false
+	opts.DisableColor = true
+	opts.Level = share.LevelTrace
+	return New(opts)
+}
+
+func TestLoggerWriterSplitsLines(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	w := logger.Writer(share.LevelWarn)
+	fmt.Fprint(w, "first li")
+	fmt.Fprint(w, "ne\r\nsecond line\n\n")
+	fmt.Fprint(w, "trailing")
+
+	out := buf.String()
+	if !strings.Contains(out, "first line") || !strings.Contains(out, "second line") {
+		t.Fatalf("expected both complete lines, got %q", out)
+	}
+	if strings.Contains(out, "trailing") {
+		t.Fatalf("partial line logged before Close: %q", out)
+	}
+	if got := strings.Count(out, "WARN"); got != 2 {
+		t.Fatalf("expected 2 WARN entries, got %d in %q", got, out)
+	}
+
+	w.Close()
+	if !strings.Contains(buf.String(), "trailing") {
+		t.Fatalf("expected trailing line after Close, got %q", buf.String())
+	}
+}
+
+func TestLoggerWriterWithStdlibLogger(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	std := log.New(logger.WithFields(share.Fields{"component": "http"}).Writer(share.LevelError), "", 0)
+	std.Printf("handler failed: %s", "boom")
+
+	out := buf.String()
+	if !strings.Contains(out, "ERROR") || !strings.Contains(out, "handler failed: boom") {
+		t.Fatalf("expected error entry, got %q", out)
+	}
+	if !strings.Contains(out, "component=http") {
+		t.Fatalf("expected context fields, got %q", out)
+	}
+}
+
+func TestLoggerWriterRespectsLevel(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetLevel(share.LevelInfo)
+
+	fmt.Fprintln(logger.Writer(share.LevelDebug), "hidden")
+	if strings.Contains(buf.String(), "hidden") {
+		t.Fatalf("debug line logged below level: %q", buf.String())
+	}
+}
@@ -0,0 +1,51 @@
+package logfx
+
+import (
+	"io"
+	"os"
+
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// JournaldMode selects when a logger sends its entries to systemd-journald.
+type JournaldMode int
+
+const (
+	// JournaldOff never uses the journal.
+	JournaldOff JournaldMode = iota
+	// JournaldAuto replaces the console writers whose output is the journal
+	// stream of a systemd service with a journald writer, so entries keep
+	// their priority and fields instead of becoming text lines.
+	JournaldAuto
+	// JournaldOn sends every entry to the journal in addition to the
+	// console.
+	JournaldOn
+)
+
+// WithJournald sets when entries are sent to systemd-journald, see
+// JournaldMode. DefaultOptions uses JournaldAuto.
+func WithJournald(mode JournaldMode) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Journald = mode
+	}
+}
+
+// journalStream reports whether out is the journal stream of a systemd
+// service under JournaldAuto.
+func journalStream(mode JournaldMode, out io.Writer) bool {
+	f, ok := out.(*os.File)
+	return ok && mode == JournaldAuto && writerpkg.UnderJournald(f)
+}
+
+// newJournaldWriter connects to journald, or returns nil when it is not
+// running. The logger filters the entries itself.
+func newJournaldWriter() share.Writer {
+	opts := writerpkg.DefaultJournaldOptions()
+	opts.Level = share.LevelTrace
+	jw, err := writerpkg.NewJournaldWriter(opts)
+	if err != nil {
+		return nil
+	}
+	return jw
+}
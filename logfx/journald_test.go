@@ -0,0 +1,44 @@
+package logfx
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+func TestJournaldAutoKeepsConsole(t *testing.T) {
+	out, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	t.Setenv("JOURNAL_STREAM", "")
+
+	opts := DefaultOptions()
+	opts.Output = out
+	opts.Format = share.FormatText
+	logger := New(opts)
+	logger.Info("to the console")
+
+	data, _ := os.ReadFile(out.Name())
+	if !strings.Contains(string(data), "to the console") {
+		t.Errorf("expected console output outside systemd, got %q", data)
+	}
+	for _, w := range logger.writers {
+		if _, ok := w.(*writerpkg.JournaldWriter); ok {
+			t.Error("unexpected journald writer outside systemd")
+		}
+	}
+}
+
+func TestJournalStream(t *testing.T) {
+	if journalStream(JournaldAuto, &strings.Builder{}) {
+		t.Error("expected false for a non-file output")
+	}
+	if journalStream(JournaldOff, os.Stderr) {
+		t.Error("expected false with JournaldOff")
+	}
+}
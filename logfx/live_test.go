@@ -0,0 +1,42 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/testutil"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+func TestConsoleFollowsLiveRoute(t *testing.T) {
+	screen := &testutil.SafeBuffer{}
+	region := &testutil.SafeBuffer{}
+	logger := newTextLogger(screen)
+
+	restore := writerpkg.RouteConsole(screen, region)
+	logger.Info("above the bar")
+	restore()
+	logger.Info("after the loop")
+
+	if !strings.Contains(region.String(), "above the bar") || strings.Contains(screen.String(), "above the bar") {
+		t.Errorf("routed line: screen %q, region %q", screen.String(), region.String())
+	}
+	if !strings.Contains(screen.String(), "after the loop") {
+		t.Errorf("line after restore missing from screen: %q", screen.String())
+	}
+}
+
+func TestConsoleRouteOnlyTakesTheScreen(t *testing.T) {
+	screen := &testutil.SafeBuffer{}
+	region := &testutil.SafeBuffer{}
+	other := &testutil.SafeBuffer{}
+	logger := newTextLogger(other)
+
+	restore := writerpkg.RouteConsole(screen, region)
+	logger.Info("to another output")
+	restore()
+
+	if region.String() != "" || !strings.Contains(other.String(), "to another output") {
+		t.Errorf("line for another output was routed: other %q, region %q", other.String(), region.String())
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"os"
-	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -34,9 +34,45 @@ type Logger struct {
 	hooks     []Hook
 	ctx       context.Context
 	mu        sync.RWMutex // Mutex for protecting options and writers
-	wg        sync.WaitGroup
 	indent    int
 	indentStr string
+
+	// name and parent are set on loggers created with Named; levels holds
+	// the per-name level overrides of a root logger.
+	name   string
+	parent *Logger
+	levels map[string]share.Level
+
+	// sampler drops repeated entries of a root logger, see WithSampling.
+	sampler *sampler
+
+	// dedup collapses identical consecutive entries, see WithDedup.
+	dedup *deduper
+
+	// redact masks sensitive data of a root logger, see WithRedaction.
+	redact *Redactor
+
+	// fields are attached to every entry, see With; groups nest the fields
+	// of later calls, see WithGroup.
+	fields share.Fields
+	groups []string
+
+	// health tracks the writes of a root logger, see WriterHealth.
+	health writerHealth
+	// metrics counts the entries of a root logger, see WithMetrics.
+	metrics *Metrics
+	// errConsole writes the entries routed to ErrorOutput.
+	errConsole *writerpkg.ConsoleWriter
+
+	// callerSkip is added to CallerSkip, see (*Logger).WithCallerSkip.
+	callerSkip int
+
+	// droppedReported is the number of dropped async entries Flush has
+	// already reported.
+	droppedReported uint64
+	// recorder keeps filtered entries of a root logger, see
+	// WithFlightRecorder.
+	recorder *flightRecorder
 }
 
 // LogOptions configures the logger
@@ -50,7 +86,8 @@ type LogOptions struct {
 	BadgeWidth      int
 	BadgeStyle      share.BadgeStyle // Changed to share.BadgeStyle
 	ShowCaller      bool
-	CallerDepth     int
+	CallerDepth     int // Frames above the caller lookup to report; zero finds the first frame outside logfx
+	CallerSkip      int // Frames skipped above the first frame outside logfx, for wrapper packages
 	ForceColor      bool
 	DisableColor    bool
 	LogFile         string
@@ -58,10 +95,70 @@ type LogOptions struct {
 	MaxFileSize     int64
 	MaxBackups      int
 	MaxAge          int
+	FileBuffer      int           // Bytes of file output buffered in memory; zero writes each entry
+	FileFlush       time.Duration // Longest time file output stays buffered
+	FileKey         []byte        // Encrypts the log and error log files, see WithFileEncryption
 	Async           bool
 	AsyncBuffer     int
+	AsyncWorkers    int                      // Goroutines per async writer; defaults to 1
+	AsyncOverflow   writerpkg.OverflowPolicy // What async writers do when their queue is full
 	ColorMode       color.Mode
 	CustomFormatter share.Formatter
+	JSONKeys        writerpkg.JSONKeys    // Key names of JSON entries
+	Labels          writerpkg.LevelLabels // Localized console level names and emoji
+
+	// Levels overrides Level for loggers created with Named, keyed by name,
+	// see SetLevels
+	Levels map[string]share.Level
+
+	// Sampling limits how often the same message is logged; nil logs every
+	// entry
+	Sampling *SamplingOptions
+
+	// Dedup collapses identical consecutive entries logged within this
+	// window into one "repeated" entry; zero disables it
+	Dedup time.Duration
+
+	// Redaction masks sensitive fields and message fragments before they
+	// reach any writer; nil disables it
+	Redaction *RedactOptions
+
+	// TraceExtractor reads trace and span IDs from the context.Context of
+	// entries logged through WithContext; nil uses TraceFromContext
+	TraceExtractor TraceExtractor
+	// Metrics counts entries by level and badge, see Counters
+	Metrics bool
+	// ErrorLog additionally writes error entries to a file of their own;
+	// nil disables it
+	ErrorLog *ErrorLogOptions
+	// NDJSONFile additionally receives every entry in the tfx NDJSON schema;
+	// empty disables it
+	NDJSONFile string
+	// Journald sends entries to systemd-journald, see JournaldMode
+	Journald JournaldMode
+	// Audit writes every entry to a hash-chained audit file; nil disables
+	// it
+	Audit *AuditOptions
+	// Network streams entries to a log collector; nil disables it
+	Network *writerpkg.NetworkOptions
+	// HTTP ships entries in batches over HTTP; nil disables it
+	HTTP *writerpkg.HTTPOptions
+	// OTLP exports entries to an OpenTelemetry collector; nil disables it
+	OTLP *writerpkg.HTTPOptions
+	// Publisher ships entries to a message bus with PublisherOptions; nil
+	// disables it
+	Publisher        writerpkg.PublishFunc
+	PublisherOptions writerpkg.PublishOptions
+
+	// ErrorOutput receives the console entries at SplitLevel or above
+	// instead of Output, see WithStderrSplit; nil writes everything to
+	// Output
+	ErrorOutput io.Writer
+	SplitLevel  share.Level
+
+	// FlightRecorder keeps the latest entries filtered out by Level and
+	// writes them before an error; nil disables it
+	FlightRecorder *FlightRecorderOptions
 }
 
 // DefaultOptions returns default logger options
@@ -76,7 +173,6 @@ func DefaultOptions() LogOptions {
 		BadgeWidth:  8,
 		BadgeStyle:  share.BadgeStyleDefault, // Changed to share.BadgeStyle
 		ShowCaller:  false,
-		CallerDepth: 3,
 		LogFile:     "",
 		FileLevel:   share.LevelInfo,
 		MaxFileSize: 100, // MB
@@ -85,6 +181,7 @@ func DefaultOptions() LogOptions {
 		Async:       false,
 		AsyncBuffer: 1000,
 		ColorMode:   color.ModeTrueColor,
+		Journald:    JournaldAuto,
 	}
 }
 
@@ -102,23 +199,42 @@ func New(opts LogOptions) *Logger {
 		writers: []share.Writer{},
 		hooks:   []Hook{},
 		ctx:     context.Background(),
+		levels:  maps.Clone(opts.Levels),
+		sampler: newSampler(opts.Sampling),
+		dedup:   newDeduper(opts.Dedup),
+		redact:  NewRedactor(opts.Redaction),
+	}
+	logger.recorder = newFlightRecorder(opts.FlightRecorder)
+	if opts.Metrics {
+		logger.metrics = NewMetrics()
+		logger.hooks = append(logger.hooks, logger.metrics.Hook())
+	}
+
+	// Send entries to journald natively rather than as text lines when
+	// the console output is its stream, keeping the console if journald
+	// cannot be reached
+	outJournal := journalStream(opts.Journald, opts.Output)
+	errJournal := opts.ErrorOutput != nil && journalStream(opts.Journald, opts.ErrorOutput)
+	var journald share.Writer
+	if outJournal || errJournal || opts.Journald == JournaldOn {
+		journald = newJournaldWriter()
+	}
+	if journald == nil {
+		outJournal, errJournal = false, false
 	}
 
 	// Add default console writer
-	cwOpts := writerpkg.ConsoleOptions{
-		Level:        opts.Level,
-		Format:       opts.Format,
-		Timestamp:    opts.Timestamp,
-		TimeFormat:   opts.TimeFormat,
-		Theme:        opts.Theme,
-		BadgeWidth:   opts.BadgeWidth,
-		BadgeStyle:   opts.BadgeStyle,
-		ShowCaller:   opts.ShowCaller,
-		ForceColor:   opts.ForceColor,
-		DisableColor: opts.DisableColor,
-	}
-	consoleWriter := writerpkg.NewConsoleWriter(opts.Output, cwOpts)
-	logger.AddWriter(consoleWriter)
+	if !outJournal {
+		consoleWriter := writerpkg.NewConsoleWriter(opts.Output, logger.consoleOptionsFor(false))
+		logger.AddWriter(consoleWriter)
+	}
+	if opts.ErrorOutput != nil && !errJournal {
+		logger.errConsole = writerpkg.NewConsoleWriter(opts.ErrorOutput, logger.consoleOptionsFor(true))
+		logger.AddWriter(logger.errConsole)
+	}
+	if journald != nil {
+		logger.writers = append(logger.writers, journald)
+	}
 
 	// Add file writer if specified
 	if opts.LogFile != "" {
@@ -128,6 +244,10 @@ func New(opts LogOptions) *Logger {
 		fwOpts.MaxSize = opts.MaxFileSize
 		fwOpts.MaxBackups = opts.MaxBackups
 		fwOpts.MaxAge = opts.MaxAge
+		fwOpts.BufferSize = opts.FileBuffer
+		fwOpts.FlushInterval = opts.FileFlush
+		fwOpts.EncryptionKey = opts.FileKey
+		fwOpts.JSONKeys = opts.JSONKeys
 
 		fileWriter, err := writerpkg.NewFileWriter(opts.LogFile, fwOpts)
 		if err == nil {
@@ -135,12 +255,46 @@ func New(opts LogOptions) *Logger {
 		}
 	}
 
+	// Add the error log file if specified
+	if errorLog := newErrorLogWriter(opts); errorLog != nil {
+		logger.writers = append(logger.writers, errorLog)
+	}
+
+	// Add the audit log if specified
+	if audit := newAuditWriter(opts); audit != nil {
+		logger.writers = append(logger.writers, audit)
+	}
+
+	// Add the NDJSON file if specified
+	if ndjson := newNDJSONWriter(opts); ndjson != nil {
+		logger.writers = append(logger.writers, ndjson)
+	}
+
 	if opts.Async {
+		asyncOpts := writerpkg.AsyncOptions{
+			BufferSize: opts.AsyncBuffer,
+			Workers:    opts.AsyncWorkers,
+			Overflow:   opts.AsyncOverflow,
+		}
 		for i, w := range logger.writers {
-			logger.writers[i] = writerpkg.NewAsyncWriter(w, opts.AsyncBuffer)
+			logger.writers[i] = writerpkg.NewAsyncWriterWithOptions(w, asyncOpts)
 		}
 	}
 
+	// Add the shipping writers, which queue in the background on their own
+	if network := newNetworkWriter(opts); network != nil {
+		logger.writers = append(logger.writers, network)
+	}
+	if httpWriter := newHTTPWriter(opts.HTTP, opts.JSONKeys); httpWriter != nil {
+		logger.writers = append(logger.writers, httpWriter)
+	}
+	if otlp := newHTTPWriter(opts.OTLP, opts.JSONKeys); otlp != nil {
+		logger.writers = append(logger.writers, otlp)
+	}
+	if publisher := newPublishWriter(opts); publisher != nil {
+		logger.writers = append(logger.writers, publisher)
+	}
+
 	return logger
 }
 
@@ -158,29 +312,22 @@ func GetLogger() *Logger {
 	return globalLogger
 }
 
-// SetLevel sets the minimum logging level
+// SetLevel sets the minimum logging level. On a logger created with Named
+// it sets the level override of its name, see SetLevelFor.
 func (l *Logger) SetLevel(level share.Level) {
+	if l.parent != nil {
+		if l.name == "" {
+			l.root().SetLevel(level)
+		} else {
+			l.root().SetLevelFor(l.name, level)
+		}
+		return
+	}
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.options.Level = level
-	// Update console writers
-	for _, wr := range l.writers {
-		if cw, ok := wr.(*writerpkg.ConsoleWriter); ok {
-			cwOpts := writerpkg.ConsoleOptions{
-				Level:        l.options.Level,
-				Format:       l.options.Format,
-				Timestamp:    l.options.Timestamp,
-				TimeFormat:   l.options.TimeFormat,
-				Theme:        l.options.Theme,
-				BadgeWidth:   l.options.BadgeWidth,
-				BadgeStyle:   l.options.BadgeStyle,
-				ShowCaller:   l.options.ShowCaller,
-				ForceColor:   l.options.ForceColor,
-				DisableColor: l.options.DisableColor,
-			}
-			cw.UpdateOptions(l.options.Output, cwOpts)
-		}
-	}
+	l.refreshConsoles()
 }
 
 // SetOutput sets the output writer
@@ -190,41 +337,35 @@ func (l *Logger) SetOutput(w io.Writer) {
 	l.options.Output = w
 	// Update console writer
 	for _, wr := range l.writers {
-		if cw, ok := wr.(*writerpkg.ConsoleWriter); ok {
-			cwOpts := writerpkg.ConsoleOptions{
-				Level:        l.options.Level,
-				Format:       l.options.Format,
-				Timestamp:    l.options.Timestamp,
-				TimeFormat:   l.options.TimeFormat,
-				Theme:        l.options.Theme,
-				BadgeWidth:   l.options.BadgeWidth,
-				BadgeStyle:   l.options.BadgeStyle,
-				ShowCaller:   l.options.ShowCaller,
-				ForceColor:   l.options.ForceColor,
-				DisableColor: l.options.DisableColor,
-			}
-			cw.UpdateOptions(w, cwOpts)
+		if cw, ok := wr.(*writerpkg.ConsoleWriter); ok && cw != l.errConsole {
+			cw.UpdateOptions(w, l.consoleOptionsFor(false))
 			break
 		}
 	}
 }
 
 func (l *Logger) Flush() {
-	l.wg.Wait() // Wait for any direct (non-async) writes
+	l.flushSampling()
+	l.flushDedup()
+	l.flushAsync()
+	if l.reportDropped() {
+		l.flushAsync()
+	}
+}
 
-	// Flush asynchronous writers
+// flushAsync waits for the asynchronous writers, such as AsyncWriter and
+// the shipping writers, to write their queues.
+func (l *Logger) flushAsync() {
 	var asyncWg sync.WaitGroup
-	l.mu.RLock()
-	for _, wr := range l.writers {
-		if asyncWriter, ok := wr.(*writerpkg.AsyncWriter); ok {
+	for _, wr := range l.allWriters() {
+		if flusher, ok := wr.(interface{ Flush() }); ok {
 			asyncWg.Add(1)
-			go func(aw *writerpkg.AsyncWriter) {
+			go func() {
 				defer asyncWg.Done()
-				aw.Flush()
-			}(asyncWriter)
+				flusher.Flush()
+			}()
 		}
 	}
-	l.mu.RUnlock()
 	asyncWg.Wait()
 }
 
@@ -233,24 +374,7 @@ func (l *Logger) SetFormat(format share.Format) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.options.Format = format
-	// Update console writers
-	for _, wr := range l.writers {
-		if cw, ok := wr.(*writerpkg.ConsoleWriter); ok {
-			cwOpts := writerpkg.ConsoleOptions{
-				Level:        l.options.Level,
-				Format:       l.options.Format,
-				Timestamp:    l.options.Timestamp,
-				TimeFormat:   l.options.TimeFormat,
-				Theme:        l.options.Theme,
-				BadgeWidth:   l.options.BadgeWidth,
-				BadgeStyle:   l.options.BadgeStyle,
-				ShowCaller:   l.options.ShowCaller,
-				ForceColor:   l.options.ForceColor,
-				DisableColor: l.options.DisableColor,
-			}
-			cw.UpdateOptions(l.options.Output, cwOpts)
-		}
-	}
+	l.refreshConsoles()
 }
 
 // EnableTimestamp enables timestamp in logs
@@ -258,24 +382,7 @@ func (l *Logger) EnableTimestamp() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.options.Timestamp = true
-	// Update console writers
-	for _, wr := range l.writers {
-		if cw, ok := wr.(*writerpkg.ConsoleWriter); ok {
-			cwOpts := writerpkg.ConsoleOptions{
-				Level:        l.options.Level,
-				Format:       l.options.Format,
-				Timestamp:    l.options.Timestamp,
-				TimeFormat:   l.options.TimeFormat,
-				Theme:        l.options.Theme,
-				BadgeWidth:   l.options.BadgeWidth,
-				BadgeStyle:   l.options.BadgeStyle,
-				ShowCaller:   l.options.ShowCaller,
-				ForceColor:   l.options.ForceColor,
-				DisableColor: l.options.DisableColor,
-			}
-			cw.UpdateOptions(l.options.Output, cwOpts)
-		}
-	}
+	l.refreshConsoles()
 }
 
 // DisableTimestamp disables timestamp in logs
@@ -283,24 +390,16 @@ func (l *Logger) DisableTimestamp() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.options.Timestamp = false
-	// Update console writers
-	for _, wr := range l.writers {
-		if cw, ok := wr.(*writerpkg.ConsoleWriter); ok {
-			cwOpts := writerpkg.ConsoleOptions{
-				Level:        l.options.Level,
-				Format:       l.options.Format,
-				Timestamp:    l.options.Timestamp,
-				TimeFormat:   l.options.TimeFormat,
-				Theme:        l.options.Theme,
-				BadgeWidth:   l.options.BadgeWidth,
-				BadgeStyle:   l.options.BadgeStyle,
-				ShowCaller:   l.options.ShowCaller,
-				ForceColor:   l.options.ForceColor,
-				DisableColor: l.options.DisableColor,
-			}
-			cw.UpdateOptions(l.options.Output, cwOpts)
-		}
-	}
+	l.refreshConsoles()
+}
+
+// SetLevelLabels replaces the localized console level labels; nil restores
+// the defaults
+func (l *Logger) SetLevelLabels(labels writerpkg.LevelLabels) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.options.Labels = labels
+	l.refreshConsoles()
 }
 
 // SetTheme sets the color theme
@@ -350,14 +449,27 @@ func (l *Logger) WithContext(ctx context.Context) *Context {
 
 // shouldLog checks if the level should be logged
 func (l *Logger) shouldLog(level share.Level) bool {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
-	return level >= l.options.Level
+	return level >= l.level()
 }
 
 // createEntry creates a log entry
 func (l *Logger) createEntry(level share.Level, msg string, fields share.Fields) *share.Entry {
-	entry := &share.Entry{
+	return l.createEntrySkip(level, msg, fields, 0)
+}
+
+// createEntrySkip creates a log entry whose caller is skip frames above the
+// usual one.
+func (l *Logger) createEntrySkip(level share.Level, msg string, fields share.Fields, skip int) *share.Entry {
+	fields = l.withBound(fields)
+	if l.name != "" {
+		named := make(share.Fields, len(fields)+1)
+		maps.Copy(named, fields)
+		named["logger"] = l.name
+		fields = named
+	}
+
+	entry := newEntry()
+	*entry = share.Entry{
 		Level:     level,
 		Message:   msg,
 		Fields:    fields,
@@ -368,13 +480,11 @@ func (l *Logger) createEntry(level share.Level, msg string, fields share.Fields)
 
 	// Add caller info if enabled
 	if l.options.ShowCaller {
-		entry.Caller = l.getCaller()
+		entry.Caller = l.callerAt(skip)
 	}
 
-	// Apply hooks
-	l.mu.RLock()
-	hooks := l.hooks
-	l.mu.RUnlock()
+	// Apply hooks, including those of parent loggers
+	hooks := l.allHooks()
 
 	for _, hook := range hooks {
 		if entry != nil {
@@ -384,50 +494,41 @@ func (l *Logger) createEntry(level share.Level, msg string, fields share.Fields)
 		}
 	}
 
+	// Mask secrets last, so nothing a hook adds reaches the writers
+	if r := l.redactor(); r != nil {
+		r.redact(entry)
+	}
+
 	return entry
 }
 
 // getCaller gets caller information
 func (l *Logger) getCaller() *share.CallerInfo {
-	pc, file, line, ok := runtime.Caller(l.options.CallerDepth)
-	if !ok {
-		return nil
-	}
-
-	fn := runtime.FuncForPC(pc)
-	if fn == nil {
-		return nil
-	}
-
-	return &share.CallerInfo{
-		File:     file,
-		Function: fn.Name(),
-		Line:     line,
-	}
+	return l.callerAt(0)
 }
 
 // log writes a log entry
 func (l *Logger) log(level share.Level, msg string, fields share.Fields) {
 	if !l.shouldLog(level) {
+		if l.recording(level) {
+			l.record(level, msg, fields, nil)
+		}
+		return
+	}
+
+	if !l.sample(level, msg) {
 		return
 	}
 
 	entry := l.createEntry(level, msg, fields)
+	l.emit(entry)
+	l.release(entry)
+}
 
-	l.mu.RLock()
-	writers := l.writers
-	l.mu.RUnlock()
-
-	for _, wr := range writers {
-		if l.options.Async {
-			l.wg.Add(1)
-			go func(w share.Writer, e *share.Entry) {
-				defer l.wg.Done()
-				w.Write(e)
-			}(wr, entry)
-		} else {
-			wr.Write(entry)
-		}
+// write sends entry to every writer of the logger and its parents.
+func (l *Logger) write(entry *share.Entry) {
+	for _, wr := range l.allWriters() {
+		l.writeTo(wr, entry)
 	}
 }
 
@@ -454,12 +555,13 @@ func (l *Logger) Error(msg string) {
 
 func (l *Logger) Fatal(msg string, args ...any) {
 	l.log(share.LevelFatal, fmt.Sprintf(msg, args...), nil)
-	osExit(1)
+	l.exit(1)
 }
 
 func (l *Logger) Panic(msg string, args ...any) {
 	msg = fmt.Sprintf(msg, args...)
 	l.log(share.LevelPanic, msg, nil)
+	l.root().Flush()
 	panic(msg)
 }
 
@@ -477,7 +579,7 @@ func (l *Logger) FatalIf(err error, msg string, args ...any) {
 			fmt.Sprintf("%s: %v", formattedMsg, err),
 			share.Fields{"error": err.Error()},
 		)
-		osExit(1)
+		l.exit(1)
 	}
 }
 
@@ -585,6 +687,7 @@ func AddWriter(writer share.Writer)            { GetLogger().AddWriter(writer) }
 func AddHook(hook Hook)                        { GetLogger().AddHook(hook) }
 func WithFields(fields share.Fields) *Context  { return GetLogger().WithFields(fields) }
 func WithContext(ctx context.Context) *Context { return GetLogger().WithContext(ctx) }
+func Named(name string) *Logger                { return GetLogger().Named(name) }
 
 func Trace(msg string)                { GetLogger().Trace(msg) }
 func Debug(msg string)                { GetLogger().Debug(msg) }
@@ -828,6 +931,23 @@ func WithOutput(w io.Writer) LogOption {
 	}
 }
 
+// WithStderrSplit writes Warn and above to stderr and the rest to stdout,
+// as Unix tools do, so piping stdout keeps warnings and errors on the
+// terminal. Each stream detects color support on its own.
+func WithStderrSplit() LogOption {
+	return WithSplitOutput(os.Stdout, os.Stderr, share.LevelWarn)
+}
+
+// WithSplitOutput writes console entries at level or above to errOut and
+// the rest to out.
+func WithSplitOutput(out, errOut io.Writer, level share.Level) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Output = out
+		cfg.ErrorOutput = errOut
+		cfg.SplitLevel = level
+	}
+}
+
 // WithLevel sets the minimum logging level
 func WithLevel(level share.Level) LogOption {
 	return func(cfg *LogOptions) {
@@ -905,7 +1025,9 @@ func WithCaller(enabled bool) LogOption {
 	}
 }
 
-// WithCallerDepth sets the caller depth
+// WithCallerDepth sets a fixed number of frames between the caller lookup
+// and the reported caller. Prefer WithCallerSkip, which does not depend on
+// the internals of logfx.
 func WithCallerDepth(depth int) LogOption {
 	return func(cfg *LogOptions) {
 		cfg.CallerDepth = depth
@@ -935,6 +1057,28 @@ func WithFileRotation(maxSize int64, maxBackups, maxAge int) LogOption {
 	}
 }
 
+// WithFileBuffer buffers up to size bytes of file output, writing it out
+// at least every interval and on Flush or Close, instead of writing and
+// syncing the file on every entry. Entries still buffered are lost if the
+// process dies, so Flush before exiting.
+func WithFileBuffer(size int, interval time.Duration) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.FileBuffer = size
+		cfg.FileFlush = interval
+	}
+}
+
+// WithFileEncryption encrypts the log file, and the error log file, with
+// AES-GCM under key, a 16, 24 or 32-byte key such as one from
+// writerpkg.NewLogKey, for logs holding sensitive data. Read them back with
+// writerpkg.DecryptLog. Combine it with WithFileBuffer to encrypt entries
+// in larger chunks. An invalid key disables file output.
+func WithFileEncryption(key []byte) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.FileKey = key
+	}
+}
+
 // WithCustomFormatter sets a custom formatter
 func WithCustomFormatter(formatter share.Formatter) LogOption {
 	return func(cfg *LogOptions) {
@@ -942,6 +1086,22 @@ func WithCustomFormatter(formatter share.Formatter) LogOption {
 	}
 }
 
+// WithJSONKeys renames the level, message, time and caller keys of JSON
+// output, e.g. to match a log shipper's schema
+func WithJSONKeys(keys writerpkg.JSONKeys) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.JSONKeys = keys
+	}
+}
+
+// WithLevelLabels localizes the level names and emoji of console output,
+// e.g. to show "ERREUR" badges; JSON output keeps the canonical names
+func WithLevelLabels(labels writerpkg.LevelLabels) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Labels = labels
+	}
+}
+
 // WithAsync enables asynchronous logging
 func WithAsync(bufferSize int) LogOption {
 	return func(cfg *LogOptions) {
@@ -950,6 +1110,17 @@ func WithAsync(bufferSize int) LogOption {
 	}
 }
 
+// WithAsyncOverflow enables asynchronous logging with a queue of bufferSize
+// entries per writer that follows policy when full. Dropped entries are
+// reported by Flush.
+func WithAsyncOverflow(bufferSize int, policy writerpkg.OverflowPolicy) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Async = true
+		cfg.AsyncBuffer = bufferSize
+		cfg.AsyncOverflow = policy
+	}
+}
+
 // --- CONVENIENCE OPTIONS ---
 
 // WithJSON enables JSON format
@@ -967,6 +1138,11 @@ func WithText() LogOption {
 	return WithFormat(share.FormatText)
 }
 
+// WithPretty enables the multi-line development format
+func WithPretty() LogOption {
+	return WithFormat(share.FormatPretty)
+}
+
 // WithDebugLevel sets level to Debug
 func WithDebugLevel() LogOption {
 	return WithLevel(share.LevelDebug)
@@ -987,11 +1163,12 @@ func WithErrorLevel() LogOption {
 	return WithLevel(share.LevelError)
 }
 
-// WithDevelopment configures logger for development
+// WithDevelopment configures logger for development, with fields rendered
+// as an indented block under each message (share.FormatPretty)
 func WithDevelopment() LogOption {
 	return func(cfg *LogOptions) {
 		cfg.Level = share.LevelDebug
-		cfg.Format = share.FormatBadge
+		cfg.Format = share.FormatPretty
 		cfg.Timestamp = true
 		cfg.ShowCaller = true
 		cfg.ForceColor = true
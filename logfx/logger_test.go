@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -359,7 +360,7 @@ func TestCreateEntry(t *testing.T) {
 	if entry.Timestamp.IsZero() {
 		t.Error("Timestamp should not be zero")
 	}
-	if entry.Caller == nil || !strings.Contains(entry.Caller.File, "logx.go") {
+	if entry.Caller == nil || !strings.Contains(entry.Caller.File, "logger.go") {
 		t.Errorf("Expected caller info, got %v", entry.Caller)
 	}
 
@@ -388,8 +389,8 @@ func TestGetCaller(t *testing.T) {
 	if caller == nil {
 		t.Fatal("getCaller() returned nil")
 	}
-	if !strings.Contains(caller.File, "logx_test.go") {
-		t.Errorf("Expected file to contain logx_test.go, got %q", caller.File)
+	if !strings.Contains(caller.File, "logger_test.go") {
+		t.Errorf("Expected file to contain logger_test.go, got %q", caller.File)
 	}
 	if !strings.Contains(caller.Function, "TestGetCaller") {
 		t.Errorf("Expected function to contain TestGetCaller, got %q", caller.Function)
@@ -665,3 +666,139 @@ func (m *MockCloserWriter) Close() error {
 	}
 	return nil
 }
+
+func TestJSONOutputKeepsTypesAndCustomKeys(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := LogWith(
+		WithOutput(buf),
+		WithJSON(),
+		WithJSONKeys(writerpkg.JSONKeys{Message: "message"}),
+	)
+
+	logger.WithFields(share.Fields{"attempt": 3, "path": `C:\tmp "x"`}).Info("line one\nline two")
+	logger.Flush()
+
+	out := buf.String()
+	for _, want := range []string{`"message":"line one\nline two"`, `"attempt":3`, `"path":"C:\\tmp \"x\""`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in %q", want, out)
+		}
+	}
+}
+
+func TestLoggerLevelLabels(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetLevelLabels(writerpkg.LevelLabels{share.LevelWarn: {Name: "AVISO"}})
+
+	logger.Warn("espacio bajo")
+	if out := buf.String(); !strings.Contains(out, "AVISO") {
+		t.Errorf("expected localized label, got %q", out)
+	}
+
+	buf.Reset()
+	logger.SetLevelLabels(nil)
+	logger.Warn("espacio bajo")
+	if out := buf.String(); !strings.Contains(out, "WARN") {
+		t.Errorf("expected default label after reset, got %q", out)
+	}
+}
+
+func TestLoggerCaptureWriter(t *testing.T) {
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}))
+	capture := writerpkg.NewCaptureWriter()
+	logger.AddWriter(capture)
+
+	logger.WithFields(share.Fields{"port": 5432}).Error("connection refused")
+	logger.Info("retrying") // Reuses the pooled entry of the error
+
+	errs := capture.Entries(share.LevelError)
+	if len(errs) != 1 || errs[0].Message != "connection refused" || errs[0].Fields["port"] != 5432 {
+		t.Errorf("unexpected captured errors %+v", errs)
+	}
+	if !capture.ContainsMessage("retrying") {
+		t.Errorf("expected the info entry captured, got %q", capture.Messages())
+	}
+}
+
+func TestLoggerFileBuffer(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := LogWith(
+		WithOutput(&testutil.SafeBuffer{}),
+		WithFileOutput(logFile),
+		WithFileRotation(1<<20, 1, 1),
+		WithFileBuffer(1<<10, time.Hour),
+	)
+	defer logger.Close()
+
+	logger.Info("buffered")
+	if data, _ := os.ReadFile(logFile); len(data) != 0 {
+		t.Fatalf("expected the entry buffered, got %q", data)
+	}
+	logger.Flush()
+	if data, _ := os.ReadFile(logFile); !strings.Contains(string(data), "buffered") {
+		t.Errorf("expected Flush to write the entry, got %q", data)
+	}
+}
+
+func TestLoggerRouteWriter(t *testing.T) {
+	low, high := writerpkg.NewCaptureWriter(), writerpkg.NewCaptureWriter()
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}), WithLevel(share.LevelTrace))
+	logger.AddWriter(writerpkg.NewRouteWriter(writerpkg.RouteOptions{Routes: []writerpkg.Route{
+		{Writer: low, Below: share.LevelError},
+		{Writer: high, Level: share.LevelError},
+	}}))
+	if !logger.recyclable() {
+		t.Error("expected routing to recyclable writers to keep entries pooled")
+	}
+
+	logger.Debug("details")
+	logger.Error("failed")
+	if low.Messages()[0] != "details" || low.Len() != 1 || high.Messages()[0] != "failed" || high.Len() != 1 {
+		t.Errorf("unexpected routing: low %q, high %q", low.Messages(), high.Messages())
+	}
+}
+
+func TestLoggerNDJSONFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.ndjson")
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}), WithNDJSONFile(name))
+	logger.Warn("disk almost full")
+	logger.Close()
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"level":"warn","msg":"disk almost full"`) {
+		t.Errorf("unexpected NDJSON file %q", data)
+	}
+}
+
+func TestLoggerFileEncryption(t *testing.T) {
+	key, err := writerpkg.NewLogKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := LogWith(
+		WithOutput(&testutil.SafeBuffer{}),
+		WithFileOutput(logFile),
+		WithFileRotation(1<<20, 1, 1),
+		WithFileEncryption(key),
+	)
+	logger.Info("token abc123 issued")
+	logger.Close()
+
+	f, err := os.Open(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var out strings.Builder
+	if err := writerpkg.DecryptLog(&out, f, key); err != nil {
+		t.Fatalf("DecryptLog: %v", err)
+	}
+	if !strings.Contains(out.String(), "token abc123 issued") {
+		t.Errorf("unexpected decrypted log %q", out.String())
+	}
+}
@@ -0,0 +1,142 @@
+package logfx
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// Counters is a snapshot of the entries counted by Metrics.
+type Counters struct {
+	Levels map[share.Level]uint64 // Entries by level
+	Badges map[string]uint64      // Entries by badge, for entries logged with one
+}
+
+// Total returns the number of entries counted.
+func (c Counters) Total() uint64 {
+	var total uint64
+	for _, n := range c.Levels {
+		total += n
+	}
+	return total
+}
+
+// Metrics counts log entries by level and badge. Its counters are atomic,
+// so the hook adds little to the cost of an entry.
+type Metrics struct {
+	levels [share.LevelPanic + 1]atomic.Uint64
+	badges sync.Map // string -> *atomic.Uint64
+}
+
+// NewMetrics creates a Metrics with zero counters.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// Hook returns a hook that counts the entries passing through it. Loggers
+// created with WithMetrics install one themselves.
+func (m *Metrics) Hook() Hook {
+	return func(entry *share.Entry) *share.Entry {
+		m.count(entry)
+		return entry
+	}
+}
+
+// count counts entry.
+func (m *Metrics) count(entry *share.Entry) {
+	if int(entry.Level) < len(m.levels) {
+		m.levels[entry.Level].Add(1)
+	}
+	badge, _ := entry.Fields["badge"].(string)
+	if badge == "" {
+		return
+	}
+	counter, ok := m.badges.Load(badge)
+	if !ok {
+		counter, _ = m.badges.LoadOrStore(badge, new(atomic.Uint64))
+	}
+	counter.(*atomic.Uint64).Add(1)
+}
+
+// Counters returns a snapshot of the counters.
+func (m *Metrics) Counters() Counters {
+	c := Counters{Levels: make(map[share.Level]uint64), Badges: make(map[string]uint64)}
+	for level := range m.levels {
+		if n := m.levels[level].Load(); n > 0 {
+			c.Levels[share.Level(level)] = n
+		}
+	}
+	m.badges.Range(func(key, value any) bool {
+		c.Badges[key.(string)] = value.(*atomic.Uint64).Load()
+		return true
+	})
+	return c
+}
+
+// WritePrometheus writes the counters in the Prometheus text exposition
+// format, as the counters logfx_entries_total{level} and
+// logfx_badge_entries_total{badge}. Every level is reported, including
+// those without entries, so rates are defined from the first scrape.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# HELP logfx_entries_total Log entries by level.\n")
+	b.WriteString("# TYPE logfx_entries_total counter\n")
+	for level := range m.levels {
+		fmt.Fprintf(&b, "logfx_entries_total{level=%q} %d\n",
+			strings.ToLower(share.Level(level).String()), m.levels[level].Load())
+	}
+
+	badges := m.Counters().Badges
+	if len(badges) > 0 {
+		b.WriteString("# HELP logfx_badge_entries_total Log entries by badge.\n")
+		b.WriteString("# TYPE logfx_badge_entries_total counter\n")
+		for _, badge := range slices.Sorted(maps.Keys(badges)) {
+			fmt.Fprintf(&b, "logfx_badge_entries_total{badge=%q} %d\n", badge, badges[badge])
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Handler returns an HTTP handler serving WritePrometheus, to be scraped by
+// Prometheus without linking its client library:
+//
+//	mux.Handle("/metrics/log", logger.Metrics().Handler())
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		m.WritePrometheus(w)
+	})
+}
+
+// WithMetrics counts the entries of the logger by level and badge, see
+// (*Logger).Counters.
+func WithMetrics() LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Metrics = true
+	}
+}
+
+// Metrics returns the metrics of the logger, or nil unless it was created
+// with WithMetrics. Named and With children share the metrics of their
+// root.
+func (l *Logger) Metrics() *Metrics {
+	return l.root().metrics
+}
+
+// Counters returns a snapshot of the entries counted so far, empty unless
+// the logger was created with WithMetrics.
+func (l *Logger) Counters() Counters {
+	if m := l.Metrics(); m != nil {
+		return m.Counters()
+	}
+	return NewMetrics().Counters()
+}
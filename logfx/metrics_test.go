@@ -0,0 +1,61 @@
+package logfx
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestCountersByLevelAndBadge(t *testing.T) {
+	logger := LogWith(WithMetrics(), WithOutput(&testutil.SafeBuffer{}), WithLevel(share.LevelInfo))
+
+	logger.Debug("filtered")
+	logger.Info("one")
+	logger.Error("two")
+	logger.Named("db").Error("three")
+	logger.Badge("DEPLOY", "shipped", color.ModernGreen)
+
+	c := logger.Counters()
+	if c.Levels[share.LevelInfo] != 2 || c.Levels[share.LevelError] != 2 {
+		t.Errorf("unexpected level counters %v", c.Levels)
+	}
+	if _, ok := c.Levels[share.LevelDebug]; ok {
+		t.Errorf("filtered entries must not be counted, got %v", c.Levels)
+	}
+	if c.Badges["DEPLOY"] != 1 || c.Total() != 4 {
+		t.Errorf("unexpected counters %+v", c)
+	}
+}
+
+func TestCountersDisabled(t *testing.T) {
+	logger := newTextLogger(&testutil.SafeBuffer{})
+	logger.Error("ignored")
+	if c := logger.Counters(); c.Total() != 0 || logger.Metrics() != nil {
+		t.Fatalf("expected no counters without WithMetrics, got %+v", c)
+	}
+}
+
+func TestMetricsPrometheusHandler(t *testing.T) {
+	logger := LogWith(WithMetrics(), WithOutput(&testutil.SafeBuffer{}))
+	logger.Warn("careful")
+	logger.Badge("API", "called", color.ModernBlue)
+
+	rec := httptest.NewRecorder()
+	logger.Metrics().Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	out := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE logfx_entries_total counter",
+		`logfx_entries_total{level="warn"} 1`,
+		`logfx_entries_total{level="debug"} 0`,
+		`logfx_badge_entries_total{badge="API"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package logfx
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// Named returns a child logger for a subsystem. Entries of the child carry a
+// "logger" field with its name, and its level can be overridden separately
+// from the application level, so a noisy subsystem can be silenced or
+// debugged on its own:
+//
+//	db := logger.Named("db")
+//	logger.SetLevels("info,db=debug,http=warn")
+//
+// Names of nested children are joined with dots ("db.pool"), and an
+// override for "db" also applies to "db.pool" unless it has its own. The
+// child shares the writers and hooks of its parent, including ones added
+// later; writers and hooks added to the child apply to it alone.
+func (l *Logger) Named(name string) *Logger {
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return &Logger{
+		options:    l.options,
+		ctx:        l.ctx,
+		indent:     l.indent,
+		indentStr:  l.indentStr,
+		name:       name,
+		parent:     l,
+		fields:     l.fields,
+		groups:     l.groups,
+		dedup:      newDeduper(l.options.Dedup),
+		callerSkip: l.callerSkip,
+	}
+}
+
+// Name returns the name of a logger created with Named, or "" for a root
+// logger.
+func (l *Logger) Name() string {
+	return l.name
+}
+
+// root returns the logger at the top of the Named chain.
+func (l *Logger) root() *Logger {
+	for l.parent != nil {
+		l = l.parent
+	}
+	return l
+}
+
+// level returns the minimum level of l, honoring the override of its name
+// or of the closest enclosing name.
+func (l *Logger) level() share.Level {
+	root := l.root()
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+
+	for name := l.name; name != ""; {
+		if level, ok := root.levels[name]; ok {
+			return level
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return root.options.Level
+}
+
+// SetLevelFor overrides the level of the loggers named name and of their
+// children. It may be called while logging.
+func (l *Logger) SetLevelFor(name string, level share.Level) {
+	root := l.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	if root.levels == nil {
+		root.levels = make(map[string]share.Level)
+	}
+	root.levels[name] = level
+	root.refreshConsoles()
+}
+
+// ClearLevelFor removes the level override of name, so its loggers follow
+// the application level again.
+func (l *Logger) ClearLevelFor(name string) {
+	root := l.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	delete(root.levels, name)
+	root.refreshConsoles()
+}
+
+// Levels returns a copy of the level overrides, keyed by logger name.
+func (l *Logger) Levels() map[string]share.Level {
+	root := l.root()
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	return maps.Clone(root.levels)
+}
+
+// SetLevels applies a level spec such as "info,db=debug,http=warn". Each
+// comma-separated item is either name=level, overriding the level of the
+// named loggers, or a bare level, setting the application level. The
+// overrides replace all previous ones. On error nothing is changed.
+func (l *Logger) SetLevels(spec string) error {
+	base, overrides, err := parseLevelSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	root := l.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	if base != nil {
+		root.options.Level = *base
+	}
+	root.levels = overrides
+	root.refreshConsoles()
+	return nil
+}
+
+// parseLevelSpec parses the spec accepted by SetLevels.
+func parseLevelSpec(spec string) (*share.Level, map[string]share.Level, error) {
+	var base *share.Level
+	overrides := make(map[string]share.Level)
+	for item := range strings.SplitSeq(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, levelName, named := strings.Cut(item, "=")
+		if !named {
+			level, err := ParseLevel(item)
+			if err != nil {
+				return nil, nil, err
+			}
+			base = &level
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, nil, fmt.Errorf("logfx: missing logger name in %q", item)
+		}
+		level, err := ParseLevel(levelName)
+		if err != nil {
+			return nil, nil, err
+		}
+		overrides[name] = level
+	}
+	return base, overrides, nil
+}
+
+// ParseLevel parses a level name such as "debug", "WARN" or "wrn". It
+// accepts the names returned by Level.String and Level.ShortString, in any
+// case, and "warning".
+func ParseLevel(s string) (share.Level, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if name == "WARNING" {
+		return share.LevelWarn, nil
+	}
+	for level := share.LevelTrace; level <= share.LevelPanic; level++ {
+		if name == level.String() || name == level.ShortString() {
+			return level, nil
+		}
+	}
+	return share.LevelInfo, fmt.Errorf("logfx: unknown level %q", s)
+}
+
+// consoleOptions returns the options of the logger's console writers. Their
+// level is the lowest one any named logger may log at, since the logger
+// filters entries itself. Callers hold l.mu.
+func (l *Logger) consoleOptions() writerpkg.ConsoleOptions {
+	level := l.options.Level
+	for _, override := range l.levels {
+		level = min(level, override)
+	}
+	if l.recorder != nil {
+		level = share.LevelTrace // Recorded entries are dumped below the level
+	}
+	return writerpkg.ConsoleOptions{
+		Level:        level,
+		Format:       l.options.Format,
+		Timestamp:    l.options.Timestamp,
+		TimeFormat:   l.options.TimeFormat,
+		Theme:        l.options.Theme,
+		BadgeWidth:   l.options.BadgeWidth,
+		BadgeStyle:   l.options.BadgeStyle,
+		ShowCaller:   l.options.ShowCaller,
+		ForceColor:   l.options.ForceColor,
+		DisableColor: l.options.DisableColor,
+		JSONKeys:     l.options.JSONKeys,
+		Labels:       l.options.Labels,
+	}
+}
+
+// consoleOptionsFor returns the options of the console writer of Output,
+// or of ErrorOutput when errors is true. They differ from consoleOptions
+// when entries are split between the two. Callers hold l.mu.
+func (l *Logger) consoleOptionsFor(errors bool) writerpkg.ConsoleOptions {
+	opts := l.consoleOptions()
+	switch {
+	case l.options.ErrorOutput == nil:
+	case errors:
+		opts.Level = max(opts.Level, l.options.SplitLevel)
+	default:
+		opts.Below = l.options.SplitLevel
+	}
+	return opts
+}
+
+// refreshConsoles updates the console writers after an option change,
+// keeping the output of each. Callers hold l.mu.
+func (l *Logger) refreshConsoles() {
+	for _, wr := range l.writers {
+		if cw, ok := wr.(*writerpkg.ConsoleWriter); ok {
+			cw.UpdateOptions(cw.Output(), l.consoleOptionsFor(cw == l.errConsole))
+		}
+	}
+}
+
+// allWriters returns the writers of l and of its parents.
+func (l *Logger) allWriters() []share.Writer {
+	l.mu.RLock()
+	writers, parent := l.writers, l.parent
+	l.mu.RUnlock()
+	if parent == nil {
+		return writers
+	}
+	return append(slices.Clip(parent.allWriters()), writers...)
+}
+
+// allHooks returns the hooks of l's parents followed by its own.
+func (l *Logger) allHooks() []Hook {
+	l.mu.RLock()
+	hooks, parent := l.hooks, l.parent
+	l.mu.RUnlock()
+	if parent == nil {
+		return hooks
+	}
+	return append(slices.Clip(parent.allHooks()), hooks...)
+}
+
+// WithNamedLevel overrides the level of the loggers created with Named(name).
+func WithNamedLevel(name string, level share.Level) LogOption {
+	return func(cfg *LogOptions) {
+		if cfg.Levels == nil {
+			cfg.Levels = make(map[string]share.Level)
+		}
+		cfg.Levels[name] = level
+	}
+}
+
+// SetLevels applies a level spec to the global logger, see Logger.SetLevels.
+func SetLevels(spec string) error { return GetLogger().SetLevels(spec) }
@@ -0,0 +1,100 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestNamedLoggerOverrides(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetLevel(share.LevelInfo)
+
+	db := logger.Named("db")
+	pool := db.Named("pool")
+	http := logger.Named("http")
+
+	if err := logger.SetLevels("db=debug,http=warn"); err != nil {
+		t.Fatalf("SetLevels: %v", err)
+	}
+
+	db.Debug("db debug")
+	pool.Debug("pool debug")
+	http.Info("http info")
+	http.Warn("http warn")
+	logger.Debug("app debug")
+	logger.Info("app info")
+
+	out := buf.String()
+	for _, want := range []string{"db debug", "pool debug", "http warn", "app info", "logger=db.pool"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got %q", want, out)
+		}
+	}
+	for _, unwanted := range []string{"http info", "app debug"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("did not expect %q in output, got %q", unwanted, out)
+		}
+	}
+}
+
+func TestNamedLoggerRuntimeChanges(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetLevel(share.LevelInfo)
+	db := logger.Named("db")
+
+	db.SetLevel(share.LevelError)
+	db.Warn("silenced")
+	if strings.Contains(buf.String(), "silenced") {
+		t.Fatalf("expected db warn to be silenced, got %q", buf.String())
+	}
+	if got := logger.Levels()["db"]; got != share.LevelError {
+		t.Fatalf("expected db override Error, got %v", got)
+	}
+
+	logger.ClearLevelFor("db")
+	db.Warn("restored")
+	if !strings.Contains(buf.String(), "restored") {
+		t.Fatalf("expected db to follow the application level, got %q", buf.String())
+	}
+}
+
+func TestSetLevelsSpec(t *testing.T) {
+	logger := New(DefaultOptions())
+
+	if err := logger.SetLevels("warn, db=DBG ,http=warning"); err != nil {
+		t.Fatalf("SetLevels: %v", err)
+	}
+	if logger.options.Level != share.LevelWarn {
+		t.Errorf("expected base level Warn, got %v", logger.options.Level)
+	}
+	levels := logger.Levels()
+	if levels["db"] != share.LevelDebug || levels["http"] != share.LevelWarn {
+		t.Errorf("unexpected overrides %v", levels)
+	}
+
+	if err := logger.SetLevels("db=loud"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+	if len(logger.Levels()) != 2 {
+		t.Errorf("failed spec must not change overrides, got %v", logger.Levels())
+	}
+}
+
+func TestNamedLoggerSharesParentWriters(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	other := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	child := logger.Named("worker")
+
+	logger.AddWriter(newTextLogger(other).writers[0])
+	child.Info("from child")
+
+	if !strings.Contains(buf.String(), "from child") || !strings.Contains(other.String(), "from child") {
+		t.Fatalf("expected child entries in every parent writer, got %q and %q", buf.String(), other.String())
+	}
+}
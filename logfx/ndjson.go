@@ -0,0 +1,30 @@
+package logfx
+
+import (
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// WithNDJSONFile also appends every logged entry to filename in the tfx
+// NDJSON schema, see writerpkg.NDJSONEncoder, for log shippers. It is
+// buffered like the log file when WithFileBuffer is set.
+func WithNDJSONFile(filename string) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.NDJSONFile = filename
+	}
+}
+
+// newNDJSONWriter opens the NDJSON file of opts, or returns nil when there
+// is none or it cannot be opened.
+func newNDJSONWriter(opts LogOptions) *writerpkg.NDJSONWriter {
+	if opts.NDJSONFile == "" {
+		return nil
+	}
+	ndOpts := writerpkg.DefaultNDJSONOptions()
+	ndOpts.BufferSize = opts.FileBuffer
+	ndOpts.FlushInterval = opts.FileFlush
+	w, err := writerpkg.NewNDJSONFile(opts.NDJSONFile, ndOpts)
+	if err != nil {
+		return nil
+	}
+	return w
+}
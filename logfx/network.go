@@ -0,0 +1,44 @@
+package logfx
+
+import (
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// WithNetworkOutput streams every entry as newline-delimited JSON to the
+// collector at address over network ("tcp" or "udp"), reconnecting in the
+// background and buffering entries while it is unreachable. Dropped and
+// Flush cover its buffer.
+func WithNetworkOutput(network, address string) LogOption {
+	opts := writerpkg.DefaultNetworkOptions()
+	opts.Network = network
+	opts.Address = address
+	return WithNetworkOptions(opts)
+}
+
+// WithNetworkOptions streams entries to a collector configured by opts, see
+// WithNetworkOutput.
+func WithNetworkOptions(opts writerpkg.NetworkOptions) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Network = &opts
+	}
+}
+
+// newNetworkWriter creates the network writer of opts, or returns nil when
+// there is none or its network is unsupported. The logger filters the
+// entries itself.
+func newNetworkWriter(opts LogOptions) share.Writer {
+	if opts.Network == nil {
+		return nil
+	}
+	nwOpts := *opts.Network
+	nwOpts.Level = share.LevelTrace
+	if nwOpts.JSONKeys == (writerpkg.JSONKeys{}) {
+		nwOpts.JSONKeys = opts.JSONKeys
+	}
+	nw, err := writerpkg.NewNetworkWriter(nwOpts)
+	if err != nil {
+		return nil
+	}
+	return nw
+}
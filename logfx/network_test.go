@@ -0,0 +1,57 @@
+package logfx
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/testutil"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+func TestWithNetworkOutput(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("tcp unavailable: %v", err)
+	}
+	defer ln.Close()
+
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}), WithNetworkOutput("tcp", ln.Addr().String()))
+	defer logger.Close()
+	logger.WithFields(map[string]any{"user": "ana"}).Info("user created")
+
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(line, `"msg":"user created"`) || !strings.Contains(line, `"user":"ana"`) {
+		t.Errorf("unexpected line %q", line)
+	}
+}
+
+func TestNetworkDroppedReported(t *testing.T) {
+	opts := writerpkg.DefaultNetworkOptions()
+	opts.Network = "unix"
+	opts.Address = filepath.Join(t.TempDir(), "none.sock")
+	opts.BufferSize = 1
+	buf := &testutil.SafeBuffer{}
+	logger := LogWith(WithOutput(buf), WithNetworkOptions(opts))
+	defer logger.Close()
+
+	for range 4 {
+		logger.Info("lost")
+	}
+	logger.Flush()
+	if logger.Dropped() == 0 || !strings.Contains(buf.String(), "dropped") {
+		t.Errorf("expected dropped network entries reported, got %d: %q", logger.Dropped(), buf.String())
+	}
+}
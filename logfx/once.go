@@ -0,0 +1,108 @@
+package logfx
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// occurrences counts the calls of LogOnce and LogEveryN by key, for the
+// whole process.
+var occurrences sync.Map // string -> *atomic.Uint64
+
+// occurrence counts one more call for key and returns the count.
+func occurrence(key string) uint64 {
+	counter, ok := occurrences.Load(key)
+	if !ok {
+		counter, _ = occurrences.LoadOrStore(key, new(atomic.Uint64))
+	}
+	return counter.(*atomic.Uint64).Add(1)
+}
+
+// callSite returns the file:line of the caller skip frames above the caller
+// of callSite, used as the key of LogOnce and LogEveryN.
+func callSite(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 2)
+	if !ok {
+		return "unknown"
+	}
+	return file + ":" + strconv.Itoa(line)
+}
+
+// LogOnce logs msg the first time this call site runs in the process and
+// drops it afterwards, e.g. for deprecation warnings:
+//
+//	logger.LogOnce(logfx.LevelWarn, "--legacy is deprecated, use --mode")
+func (l *Logger) LogOnce(level share.Level, msg string) {
+	l.LogOnceKey(callSite(0), level, msg)
+}
+
+// LogOnceKey logs msg the first time key is used in the process, so
+// several call sites can share one message.
+func (l *Logger) LogOnceKey(key string, level share.Level, msg string) {
+	if occurrence(key) == 1 {
+		l.log(level, msg, nil)
+	}
+}
+
+// LogEveryN logs msg on the first and then every nth run of this call site,
+// with an "occurrences" field counting all runs so far. It keeps tight loops
+// from flooding the output.
+func (l *Logger) LogEveryN(n int, level share.Level, msg string) {
+	l.LogEveryNKey(callSite(0), n, level, msg)
+}
+
+// LogEveryNKey is LogEveryN keyed by key instead of the call site.
+func (l *Logger) LogEveryNKey(key string, n int, level share.Level, msg string) {
+	if count := occurrence(key); everyN(count, n) {
+		l.log(level, msg, share.Fields{"occurrences": count})
+	}
+}
+
+// LogOnce logs msg with the fields of the context the first time this call
+// site runs, see (*Logger).LogOnce.
+func (c *Context) LogOnce(level share.Level, msg string) {
+	c.LogOnceKey(callSite(0), level, msg)
+}
+
+// LogOnceKey logs msg with the fields of the context the first time key is
+// used.
+func (c *Context) LogOnceKey(key string, level share.Level, msg string) {
+	if occurrence(key) == 1 {
+		c.log(level, msg)
+	}
+}
+
+// LogEveryN logs msg with the fields of the context on the first and every
+// nth run of this call site, see (*Logger).LogEveryN.
+func (c *Context) LogEveryN(n int, level share.Level, msg string) {
+	c.LogEveryNKey(callSite(0), n, level, msg)
+}
+
+// LogEveryNKey is LogEveryN keyed by key instead of the call site.
+func (c *Context) LogEveryNKey(key string, n int, level share.Level, msg string) {
+	if count := occurrence(key); everyN(count, n) {
+		c.WithField("occurrences", count).log(level, msg)
+	}
+}
+
+// everyN reports whether the count-th occurrence is logged when logging
+// every nth one. n below 1 logs every occurrence.
+func everyN(count uint64, n int) bool {
+	return n <= 1 || count%uint64(n) == 1
+}
+
+// LogOnce logs msg through the global logger the first time this call site
+// runs.
+func LogOnce(level share.Level, msg string) {
+	GetLogger().LogOnceKey(callSite(0), level, msg)
+}
+
+// LogEveryN logs msg through the global logger on the first and every nth
+// run of this call site.
+func LogEveryN(n int, level share.Level, msg string) {
+	GetLogger().LogEveryNKey(callSite(0), n, level, msg)
+}
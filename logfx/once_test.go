@@ -0,0 +1,57 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestLogOnceByCallSite(t *testing.T) {
+	occurrences.Clear()
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	for range 3 {
+		logger.LogOnce(share.LevelWarn, "deprecated flag")
+	}
+	logger.LogOnce(share.LevelWarn, "deprecated flag")
+
+	if got := strings.Count(buf.String(), "deprecated flag"); got != 2 {
+		t.Fatalf("expected one entry per call site, got %d in %q", got, buf.String())
+	}
+}
+
+func TestLogOnceKeySharedAcrossLoggers(t *testing.T) {
+	occurrences.Clear()
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	logger.LogOnceKey(t.Name(), share.LevelInfo, "first")
+	logger.Named("other").WithFields(share.Fields{"k": 1}).LogOnceKey(t.Name(), share.LevelInfo, "second")
+
+	if out := buf.String(); !strings.Contains(out, "first") || strings.Contains(out, "second") {
+		t.Fatalf("expected key to be logged once per process, got %q", out)
+	}
+}
+
+func TestLogEveryN(t *testing.T) {
+	occurrences.Clear()
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	for range 7 {
+		logger.LogEveryN(3, share.LevelInfo, "tick")
+	}
+
+	out := buf.String()
+	if got := strings.Count(out, "tick"); got != 3 {
+		t.Fatalf("expected runs 1, 4 and 7, got %d in %q", got, out)
+	}
+	for _, want := range []string{"occurrences=1", "occurrences=4", "occurrences=7"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package logfx
+
+import writerpkg "github.com/garaekz/tfx/writer"
+
+// WithOTLP exports entries over OTLP/HTTP to the logs endpoint of an
+// OpenTelemetry collector, e.g. "http://otel-collector:4318/v1/logs", as
+// serviceName; an empty endpoint uses writerpkg.OTLPEndpoint. The trace and
+// span IDs added by WithContext become the trace context of the records.
+func WithOTLP(endpoint, serviceName string) LogOption {
+	opts := writerpkg.DefaultOTLPOptions()
+	if endpoint != "" {
+		opts.URL = endpoint
+	}
+	if serviceName != "" {
+		opts.Labels["service.name"] = serviceName
+	}
+	return WithOTLPOptions(opts)
+}
+
+// WithOTLPOptions exports entries over OTLP/HTTP as configured by opts,
+// usually writerpkg.DefaultOTLPOptions with headers or compression added.
+func WithOTLPOptions(opts writerpkg.HTTPOptions) LogOption {
+	return func(cfg *LogOptions) {
+		opts.Format = writerpkg.HTTPFormatOTLP
+		cfg.OTLP = &opts
+	}
+}
@@ -0,0 +1,42 @@
+package logfx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestWithOTLP(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(data))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}), WithOTLP(srv.URL, "checkout"))
+	defer logger.Close()
+	ctx := ContextWithTrace(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+	logger.WithContext(ctx).Error("charge failed")
+	logger.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 1 {
+		t.Fatalf("expected one export request, got %d", len(bodies))
+	}
+	for _, want := range []string{`"stringValue":"checkout"`, `"severityNumber":17`, `"traceId":"4bf92f3577b34da6a3ce929d0e0e4736"`, `"stringValue":"charge failed"`} {
+		if !strings.Contains(bodies[0], want) {
+			t.Errorf("expected %s in %s", want, bodies[0])
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package logfx
+
+import (
+	"sync"
+
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// entryPool recycles entries once they are written, see release.
+var entryPool = sync.Pool{New: func() any { return new(share.Entry) }}
+
+// newEntry returns a zeroed entry from the pool.
+func newEntry() *share.Entry {
+	return entryPool.Get().(*share.Entry)
+}
+
+// release returns entry to the pool when nothing can still refer to it.
+func (l *Logger) release(entry *share.Entry) {
+	if !l.recyclable() {
+		return
+	}
+	*entry = share.Entry{}
+	entryPool.Put(entry)
+}
+
+// recyclable reports whether the entries of l are done with once written:
+// no hook or deduplication can keep them, and every writer formats them
+// before Write returns.
+func (l *Logger) recyclable() bool {
+	l.mu.RLock()
+	d := l.dedup
+	l.mu.RUnlock()
+	if d != nil || len(l.allHooks()) > 0 {
+		return false
+	}
+	for _, w := range l.allWriters() {
+		if !recyclableWriter(w) {
+			return false
+		}
+	}
+	return true
+}
+
+// recyclableWriter reports whether w is done with entries once Write
+// returns.
+func recyclableWriter(w share.Writer) bool {
+	switch w := w.(type) {
+	case *writerpkg.ConsoleWriter, *writerpkg.FileWriter, *writerpkg.AuditWriter,
+		*writerpkg.JournaldWriter, *writerpkg.NetworkWriter, *writerpkg.HTTPWriter,
+		*writerpkg.PublishWriter, *writerpkg.CaptureWriter, *writerpkg.NDJSONWriter,
+		*writerpkg.CSVWriter:
+		return true
+	case interface{ Writers() []share.Writer }: // RouteWriter, DedupWriter, RateLimitWriter
+		for _, inner := range w.Writers() {
+			if !recyclableWriter(inner) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
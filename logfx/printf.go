@@ -0,0 +1,77 @@
+package logfx
+
+import (
+	"fmt"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// logf formats and logs a message, skipping the formatting when level is
+// disabled and not recorded.
+func (l *Logger) logf(level share.Level, format string, args []any) {
+	if !l.shouldLog(level) && !l.recording(level) {
+		return
+	}
+	l.log(level, fmt.Sprintf(format, args...), nil)
+}
+
+// Tracef logs a printf-style message at Trace.
+func (l *Logger) Tracef(format string, args ...any) { l.logf(share.LevelTrace, format, args) }
+
+// Debugf logs a printf-style message at Debug.
+func (l *Logger) Debugf(format string, args ...any) { l.logf(share.LevelDebug, format, args) }
+
+// Infof logs a printf-style message at Info.
+func (l *Logger) Infof(format string, args ...any) { l.logf(share.LevelInfo, format, args) }
+
+// Successf logs a printf-style message at Success.
+func (l *Logger) Successf(format string, args ...any) { l.logf(share.LevelSuccess, format, args) }
+
+// Warnf logs a printf-style message at Warn.
+func (l *Logger) Warnf(format string, args ...any) { l.logf(share.LevelWarn, format, args) }
+
+// Errorf logs a printf-style message at Error.
+func (l *Logger) Errorf(format string, args ...any) { l.logf(share.LevelError, format, args) }
+
+// Fatalf logs a printf-style message at Fatal, then exits, see Fatal.
+func (l *Logger) Fatalf(format string, args ...any) { l.Fatal(format, args...) }
+
+// Panicf logs a printf-style message at Panic, then panics, see Panic.
+func (l *Logger) Panicf(format string, args ...any) { l.Panic(format, args...) }
+
+// The level methods of Context already take printf-style arguments; the
+// f-suffixed variants keep call sites uniform between Logger and Context.
+
+// Tracef logs a printf-style message at Trace.
+func (c *Context) Tracef(format string, args ...any) { c.Trace(format, args...) }
+
+// Debugf logs a printf-style message at Debug.
+func (c *Context) Debugf(format string, args ...any) { c.Debug(format, args...) }
+
+// Infof logs a printf-style message at Info.
+func (c *Context) Infof(format string, args ...any) { c.Info(format, args...) }
+
+// Successf logs a printf-style message at Success.
+func (c *Context) Successf(format string, args ...any) { c.Success(format, args...) }
+
+// Warnf logs a printf-style message at Warn.
+func (c *Context) Warnf(format string, args ...any) { c.Warn(format, args...) }
+
+// Errorf logs a printf-style message at Error.
+func (c *Context) Errorf(format string, args ...any) { c.Error(format, args...) }
+
+// Fatalf logs a printf-style message at Fatal, then exits.
+func (c *Context) Fatalf(format string, args ...any) { c.Fatal(format, args...) }
+
+// Panicf logs a printf-style message at Panic, then panics.
+func (c *Context) Panicf(format string, args ...any) { c.Panic(format, args...) }
+
+// Global printf-style functions that use the global logger
+func Tracef(format string, args ...any)   { GetLogger().Tracef(format, args...) }
+func Debugf(format string, args ...any)   { GetLogger().Debugf(format, args...) }
+func Infof(format string, args ...any)    { GetLogger().Infof(format, args...) }
+func Successf(format string, args ...any) { GetLogger().Successf(format, args...) }
+func Warnf(format string, args ...any)    { GetLogger().Warnf(format, args...) }
+func Errorf(format string, args ...any)   { GetLogger().Errorf(format, args...) }
+func Fatalf(format string, args ...any)   { GetLogger().Fatalf(format, args...) }
+func Panicf(format string, args ...any)   { GetLogger().Panicf(format, args...) }
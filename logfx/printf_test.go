@@ -0,0 +1,48 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+// countingStringer counts how often it is formatted.
+type countingStringer struct{ calls *int }
+
+func (s countingStringer) String() string {
+	*s.calls++
+	return "formatted"
+}
+
+func TestPrintfVariants(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	logger.Infof("served %d requests", 3)
+	logger.Warnf("retry %s", "db")
+	logger.WithFields(share.Fields{"k": 1}).Errorf("failed after %dms", 20)
+
+	out := buf.String()
+	for _, want := range []string{"INFO served 3 requests", "WARN retry db", "ERROR failed after 20ms"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+}
+
+func TestPrintfSkipsDisabledLevels(t *testing.T) {
+	logger := newTextLogger(&testutil.SafeBuffer{})
+	logger.SetLevel(share.LevelInfo)
+
+	calls := 0
+	logger.Debugf("value %v", countingStringer{&calls})
+	if calls != 0 {
+		t.Errorf("disabled Debugf formatted its arguments %d times", calls)
+	}
+	logger.Infof("value %v", countingStringer{&calls})
+	if calls != 1 {
+		t.Errorf("Infof formatted its arguments %d times, want 1", calls)
+	}
+}
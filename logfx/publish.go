@@ -0,0 +1,40 @@
+package logfx
+
+import (
+	"github.com/garaekz/tfx/internal/share"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+// WithPublisher ships every entry to a message bus such as Kafka or NATS
+// through publish, in JSON batches with the defaults of
+// writerpkg.DefaultPublishOptions. Dropped and Flush cover its queue.
+func WithPublisher(publish writerpkg.PublishFunc) LogOption {
+	return WithPublisherOptions(publish, writerpkg.DefaultPublishOptions())
+}
+
+// WithPublisherOptions ships entries through publish as configured by opts,
+// see WithPublisher and writerpkg.PublishWriter.
+func WithPublisherOptions(publish writerpkg.PublishFunc, opts writerpkg.PublishOptions) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Publisher = publish
+		cfg.PublisherOptions = opts
+	}
+}
+
+// newPublishWriter creates the publish writer of opts, or returns nil when
+// there is none. The logger filters the entries itself.
+func newPublishWriter(opts LogOptions) share.Writer {
+	if opts.Publisher == nil {
+		return nil
+	}
+	pwOpts := opts.PublisherOptions
+	pwOpts.Level = share.LevelTrace
+	if pwOpts.JSONKeys == (writerpkg.JSONKeys{}) {
+		pwOpts.JSONKeys = opts.JSONKeys
+	}
+	pw, err := writerpkg.NewPublishWriter(opts.Publisher, pwOpts)
+	if err != nil {
+		return nil
+	}
+	return pw
+}
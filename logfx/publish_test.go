@@ -0,0 +1,37 @@
+package logfx
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/testutil"
+	writerpkg "github.com/garaekz/tfx/writer"
+)
+
+func TestWithPublisher(t *testing.T) {
+	var mu sync.Mutex
+	var published []string
+	publish := func(ctx context.Context, batch []writerpkg.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, m := range batch {
+			published = append(published, string(m.Key)+" "+string(m.Value))
+		}
+		return nil
+	}
+	opts := writerpkg.DefaultPublishOptions()
+	opts.KeyField = "order"
+	logger := LogWith(WithOutput(&testutil.SafeBuffer{}), WithPublisherOptions(publish, opts))
+	defer logger.Close()
+
+	logger.WithFields(map[string]any{"order": 42}).Info("order placed")
+	logger.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(published) != 1 || !strings.HasPrefix(published[0], "42 {") || !strings.Contains(published[0], `"msg":"order placed"`) {
+		t.Errorf("unexpected messages %q", published)
+	}
+}
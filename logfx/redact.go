@@ -0,0 +1,222 @@
+package logfx
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// DefaultRedactKeys are the field key fragments treated as sensitive by
+// default. Keys match when they contain a fragment, ignoring case.
+var DefaultRedactKeys = []string{
+	"password", "passwd", "secret", "token", "authorization", "api_key", "apikey", "cookie",
+}
+
+// RedactedValue replaces sensitive values when no Scrubber is set.
+const RedactedValue = "[REDACTED]"
+
+// Scrubber returns the masked form of the value of a sensitive field.
+type Scrubber func(key string, value any) any
+
+// MaskScrubber replaces every sensitive value with RedactedValue.
+func MaskScrubber(key string, value any) any {
+	return RedactedValue
+}
+
+// PartialScrubber keeps the last visible characters of a sensitive value and
+// masks the rest, e.g. "****1234", so values stay distinguishable.
+func PartialScrubber(visible int) Scrubber {
+	return func(key string, value any) any {
+		s := fmt.Sprint(value)
+		if len(s) <= visible {
+			return strings.Repeat("*", len(s))
+		}
+		return strings.Repeat("*", len(s)-visible) + s[len(s)-visible:]
+	}
+}
+
+// RedactOptions configures redaction of sensitive data.
+type RedactOptions struct {
+	// Keys are the sensitive field key fragments, matched case-insensitively
+	// as substrings. Nil uses DefaultRedactKeys.
+	Keys []string
+
+	// Scrub masks sensitive values; nil uses MaskScrubber.
+	Scrub Scrubber
+
+	// Patterns match additional secrets inside messages, such as card
+	// numbers; every match is replaced with RedactedValue.
+	Patterns []*regexp.Regexp
+}
+
+// DefaultRedactOptions returns redaction of DefaultRedactKeys with
+// MaskScrubber.
+func DefaultRedactOptions() RedactOptions {
+	return RedactOptions{Keys: DefaultRedactKeys}
+}
+
+// WithRedaction masks sensitive data before it reaches any writer. See
+// Redactor.
+func WithRedaction(opts RedactOptions) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Redaction = &opts
+	}
+}
+
+// SetRedaction replaces the redaction of the logger and its named children.
+// Nil disables it.
+func (l *Logger) SetRedaction(opts *RedactOptions) {
+	root := l.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	root.options.Redaction = opts
+	root.redact = NewRedactor(opts)
+}
+
+// redactor returns the redactor of the root logger.
+func (l *Logger) redactor() *Redactor {
+	root := l.root()
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	return root.redact
+}
+
+// Redactor masks sensitive data in entries. Fields whose key matches one of
+// the sensitive fragments, including keys of nested maps, are replaced with
+// their scrubbed value. Messages are cleaned too: "key=value" and
+// "key: value" pairs with a sensitive key, bearer credentials, the literal
+// values of sensitive fields and matches of the extra patterns are masked.
+type Redactor struct {
+	keys     []string
+	scrub    Scrubber
+	inline   *regexp.Regexp
+	patterns []*regexp.Regexp
+}
+
+// bearerPattern matches credentials of HTTP Authorization headers.
+var bearerPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/]+=*`)
+
+// NewRedactor compiles opts into a Redactor, or returns nil when opts is nil.
+func NewRedactor(opts *RedactOptions) *Redactor {
+	if opts == nil {
+		return nil
+	}
+	keys := opts.Keys
+	if keys == nil {
+		keys = DefaultRedactKeys
+	}
+	r := &Redactor{
+		scrub:    opts.Scrub,
+		patterns: append([]*regexp.Regexp{bearerPattern}, opts.Patterns...),
+	}
+	if r.scrub == nil {
+		r.scrub = MaskScrubber
+	}
+
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		r.keys = append(r.keys, strings.ToLower(key))
+		quoted = append(quoted, regexp.QuoteMeta(key))
+	}
+	if len(quoted) > 0 {
+		// A sensitive key, possibly prefixed (db_password), then = or :
+		// and a quoted or bare value with an optional auth scheme
+		r.inline = regexp.MustCompile(`(?i)([\w.-]*(?:` + strings.Join(quoted, "|") +
+			`)[\w.-]*["']?\s*[:=]\s*)((?:(?:bearer|basic)\s+)?(?:"[^"]*"|'[^']*'|[^\s,;&]+))`)
+	}
+	return r
+}
+
+// Sensitive reports whether a field key names sensitive data.
+func (r *Redactor) Sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range r.keys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactMessage masks secrets embedded in msg.
+func (r *Redactor) RedactMessage(msg string) string {
+	if r.inline != nil {
+		msg = r.inline.ReplaceAllString(msg, "${1}"+RedactedValue)
+	}
+	for _, pattern := range r.patterns {
+		msg = pattern.ReplaceAllStringFunc(msg, func(match string) string {
+			if pattern == bearerPattern {
+				scheme, _, _ := strings.Cut(match, " ")
+				return scheme + " " + RedactedValue
+			}
+			return RedactedValue
+		})
+	}
+	return msg
+}
+
+// redact masks the sensitive data of entry in place. Fields are copied
+// before they are changed, since callers may share the map.
+func (r *Redactor) redact(entry *share.Entry) {
+	var secrets []string
+	if fields, changed := r.redactFields(entry.Fields, &secrets); changed {
+		entry.Fields = fields
+	}
+
+	msg := entry.Message
+	for _, secret := range secrets {
+		if len(secret) >= 4 {
+			msg = strings.ReplaceAll(msg, secret, RedactedValue)
+		}
+	}
+	entry.Message = r.RedactMessage(msg)
+}
+
+// redactFields returns fields with sensitive values scrubbed, recursing into
+// nested maps, and collects the original values as strings in secrets.
+func (r *Redactor) redactFields(fields map[string]any, secrets *[]string) (map[string]any, bool) {
+	var out map[string]any
+	for key, value := range fields {
+		var redacted any
+		switch {
+		case r.Sensitive(key):
+			redacted = r.scrub(key, value)
+			*secrets = append(*secrets, fmt.Sprint(value))
+		default:
+			nested, ok := asFieldMap(value)
+			if !ok {
+				continue
+			}
+			cleaned, changed := r.redactFields(nested, secrets)
+			if !changed {
+				continue
+			}
+			redacted = cleaned
+		}
+		if out == nil {
+			out = maps.Clone(fields)
+		}
+		out[key] = redacted
+	}
+	if out == nil {
+		return fields, false
+	}
+	return out, true
+}
+
+// asFieldMap returns value as a map of fields when it is one.
+func asFieldMap(value any) (map[string]any, bool) {
+	switch m := value.(type) {
+	case share.Fields:
+		return m, true
+	case map[string]any:
+		return m, true
+	}
+	return nil, false
+}
@@ -0,0 +1,73 @@
+package logfx
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestRedactionMasksSensitiveFields(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetRedaction(&RedactOptions{})
+
+	fields := share.Fields{
+		"user":          "alice",
+		"password":      "hunter2",
+		"Authorization": "Bearer abc.def",
+		"db":            map[string]any{"db_password": "s3cret!"},
+	}
+	logger.WithFields(fields).Info("login attempt")
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "abc.def", "s3cret!"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("secret %q leaked: %q", secret, out)
+		}
+	}
+	if !strings.Contains(out, "user=alice") || !strings.Contains(out, "password="+RedactedValue) {
+		t.Errorf("expected non-sensitive fields untouched and sensitive masked, got %q", out)
+	}
+	if fields["password"] != "hunter2" {
+		t.Error("redaction must not modify the caller's fields")
+	}
+}
+
+func TestRedactionCleansMessages(t *testing.T) {
+	r := NewRedactor(&RedactOptions{
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`\b\d{4}-\d{4}-\d{4}-\d{4}\b`)},
+	})
+
+	cases := map[string]string{
+		"connecting with password=hunter2 to db":  "connecting with password=" + RedactedValue + " to db",
+		`config {"api_key": "k-123"} loaded`:      `config {"api_key": ` + RedactedValue + `} loaded`,
+		"header Authorization: Bearer eyJhbGci.x": "header Authorization: " + RedactedValue,
+		"sent Bearer eyJhbGci.x upstream":         "sent Bearer " + RedactedValue + " upstream",
+		"card 1234-5678-9012-3456 charged":        "card " + RedactedValue + " charged",
+		"nothing to hide":                         "nothing to hide",
+	}
+	for in, want := range cases {
+		if got := r.RedactMessage(in); got != want {
+			t.Errorf("RedactMessage(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRedactionMasksFieldValuesInMessage(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetRedaction(&RedactOptions{Keys: []string{"token"}, Scrub: PartialScrubber(4)})
+
+	logger.WithFields(share.Fields{"token": "tok-abcdef-9876"}).Info("refreshing tok-abcdef-9876")
+
+	out := buf.String()
+	if strings.Contains(out, "tok-abcdef-9876") {
+		t.Fatalf("token leaked: %q", out)
+	}
+	if !strings.Contains(out, "token=***********9876") {
+		t.Fatalf("expected partially masked token, got %q", out)
+	}
+}
@@ -0,0 +1,213 @@
+package logfx
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// SamplingOptions configures log sampling. Within each Tick, the first
+// First entries with the same level and message are logged, then only every
+// Thereafter-th one. Suppressed entries are counted and reported in a
+// summary entry at most once per SummaryInterval, so hot paths cannot flood
+// the writers while their volume stays visible. The summary is logged when
+// the interval ends even if nothing else is logged, and right away by
+// Flush. Fatal and Panic entries are never sampled.
+type SamplingOptions struct {
+	Tick            time.Duration
+	First           int
+	Thereafter      int // Zero drops every entry after First
+	SummaryInterval time.Duration
+}
+
+// DefaultSamplingOptions returns the default sampling configuration: 100
+// entries per message and second, then 1 in 100, summarized every 10s.
+func DefaultSamplingOptions() SamplingOptions {
+	return SamplingOptions{
+		Tick:            time.Second,
+		First:           100,
+		Thereafter:      100,
+		SummaryInterval: 10 * time.Second,
+	}
+}
+
+// WithSampling enables sampling that logs the first entries per message and
+// second, then 1 in thereafter. See SamplingOptions.
+func WithSampling(first, thereafter int) LogOption {
+	return func(cfg *LogOptions) {
+		sampling := DefaultSamplingOptions()
+		sampling.First = first
+		sampling.Thereafter = thereafter
+		cfg.Sampling = &sampling
+	}
+}
+
+// WithSamplingOptions enables sampling with full control over its options.
+func WithSamplingOptions(opts SamplingOptions) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.Sampling = &opts
+	}
+}
+
+// SetSampling replaces the sampling configuration of the logger and its
+// named children. Nil disables sampling.
+func (l *Logger) SetSampling(opts *SamplingOptions) {
+	root := l.root()
+	root.flushSampling()
+
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	root.options.Sampling = opts
+	root.sampler = newSampler(opts)
+}
+
+// samplingKey identifies entries that are sampled together.
+type samplingKey struct {
+	level share.Level
+	msg   string
+}
+
+// sampler counts entries per key and tick. A nil sampler keeps every entry.
+type sampler struct {
+	mu          sync.Mutex
+	opts        SamplingOptions
+	tickStart   time.Time
+	counts      map[samplingKey]int
+	suppressed  map[string]int // By message, since the last summary
+	lastSummary time.Time
+	summary     *time.Timer // Logs the pending summary when it is due
+}
+
+// newSampler creates a sampler for opts, or returns nil when opts is nil.
+func newSampler(opts *SamplingOptions) *sampler {
+	if opts == nil {
+		return nil
+	}
+	cfg := *opts
+	if cfg.Tick <= 0 {
+		cfg.Tick = time.Second
+	}
+	now := time.Now()
+	return &sampler{
+		opts:        cfg,
+		tickStart:   now,
+		counts:      make(map[samplingKey]int),
+		suppressed:  make(map[string]int),
+		lastSummary: now,
+	}
+}
+
+// allow counts an entry and reports whether it should be logged. It returns
+// the suppressed counts to report when a summary is due.
+func (s *sampler) allow(level share.Level, msg string, now time.Time) (bool, map[string]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.tickStart) >= s.opts.Tick {
+		clear(s.counts)
+		s.tickStart = now
+	}
+	key := samplingKey{level: level, msg: msg}
+	s.counts[key]++
+	n := s.counts[key]
+	allowed := n <= s.opts.First ||
+		(s.opts.Thereafter > 0 && (n-s.opts.First)%s.opts.Thereafter == 0)
+	if !allowed {
+		s.suppressed[msg]++
+	}
+
+	if len(s.suppressed) == 0 || now.Sub(s.lastSummary) < s.opts.SummaryInterval {
+		return allowed, nil
+	}
+	return allowed, s.takeSuppressed(now)
+}
+
+// scheduleSummary arranges for summarize to run when the summary of the
+// suppressed entries is due, unless it is already scheduled.
+func (s *sampler) scheduleSummary(summarize func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.summary != nil || len(s.suppressed) == 0 {
+		return
+	}
+	s.summary = time.AfterFunc(time.Until(s.lastSummary.Add(s.opts.SummaryInterval)), summarize)
+}
+
+// takeSuppressed returns and resets the suppressed counts, canceling the
+// scheduled summary. Callers hold s.mu.
+func (s *sampler) takeSuppressed(now time.Time) map[string]int {
+	if s.summary != nil {
+		s.summary.Stop()
+		s.summary = nil
+	}
+	suppressed := s.suppressed
+	s.suppressed = make(map[string]int)
+	s.lastSummary = now
+	return suppressed
+}
+
+// sample applies the sampling of the root logger to an entry, logging a
+// summary of suppressed entries when one is due. A suppressed entry
+// schedules the summary, so a burst followed by silence is still reported.
+func (l *Logger) sample(level share.Level, msg string) bool {
+	if level >= share.LevelFatal {
+		return true
+	}
+	root := l.root()
+	root.mu.RLock()
+	s := root.sampler
+	root.mu.RUnlock()
+	if s == nil {
+		return true
+	}
+
+	allowed, suppressed := s.allow(level, msg, time.Now())
+	if suppressed != nil {
+		root.logSamplingSummary(suppressed)
+	} else if !allowed {
+		s.scheduleSummary(func() { root.summarize(s) })
+	}
+	return allowed
+}
+
+// flushSampling logs the summary of entries suppressed since the last one.
+func (l *Logger) flushSampling() {
+	root := l.root()
+	root.mu.RLock()
+	s := root.sampler
+	root.mu.RUnlock()
+	root.summarize(s)
+}
+
+// summarize logs the summary of entries s suppressed since the last one.
+func (l *Logger) summarize(s *sampler) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	var suppressed map[string]int
+	if len(s.suppressed) > 0 {
+		suppressed = s.takeSuppressed(time.Now())
+	}
+	s.mu.Unlock()
+	if suppressed != nil {
+		l.logSamplingSummary(suppressed)
+	}
+}
+
+// logSamplingSummary logs how many entries sampling suppressed. The summary
+// itself is never sampled.
+func (l *Logger) logSamplingSummary(suppressed map[string]int) {
+	total := 0
+	for _, n := range suppressed {
+		total += n
+	}
+	entry := l.createEntry(share.LevelWarn, fmt.Sprintf("sampling suppressed %d log entries", total), share.Fields{
+		"suppressed":          total,
+		"suppressed_messages": suppressed,
+	})
+	l.write(entry)
+}
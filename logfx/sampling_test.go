@@ -0,0 +1,90 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestSamplingFirstThenEveryM(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetSampling(&SamplingOptions{Tick: time.Hour, First: 3, Thereafter: 5, SummaryInterval: time.Hour})
+
+	for range 20 {
+		logger.Info("hot path")
+	}
+	logger.Info("other message")
+
+	// 3 first entries, then the 8th, 13th and 18th
+	if got := strings.Count(buf.String(), "hot path"); got != 6 {
+		t.Fatalf("expected 6 sampled entries, got %d in %q", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), "other message") {
+		t.Fatal("sampling must be per message")
+	}
+
+	logger.Flush()
+	if !strings.Contains(buf.String(), "sampling suppressed 14 log entries") {
+		t.Fatalf("expected summary on Flush, got %q", buf.String())
+	}
+}
+
+func TestSamplingResetsEachTick(t *testing.T) {
+	s := newSampler(&SamplingOptions{Tick: time.Second, First: 1, SummaryInterval: time.Minute})
+	start := time.Now()
+
+	if ok, _ := s.allow(share.LevelInfo, "msg", start); !ok {
+		t.Fatal("first entry must be logged")
+	}
+	if ok, _ := s.allow(share.LevelInfo, "msg", start.Add(100*time.Millisecond)); ok {
+		t.Fatal("second entry in the same tick must be dropped")
+	}
+	if ok, _ := s.allow(share.LevelInfo, "msg", start.Add(1100*time.Millisecond)); !ok {
+		t.Fatal("first entry of a new tick must be logged")
+	}
+
+	s.allow(share.LevelInfo, "msg", start.Add(1200*time.Millisecond))
+	_, summary := s.allow(share.LevelInfo, "other", start.Add(time.Minute+time.Second))
+	if summary["msg"] != 2 {
+		t.Fatalf("expected summary of 2 suppressed entries, got %v", summary)
+	}
+}
+
+func TestSamplingNeverDropsFatal(t *testing.T) {
+	logger := newTextLogger(&testutil.SafeBuffer{})
+	logger.SetSampling(&SamplingOptions{Tick: time.Hour, First: 0, SummaryInterval: time.Hour})
+	if !logger.sample(share.LevelFatal, "boom") {
+		t.Fatal("fatal entries must not be sampled")
+	}
+	if logger.sample(share.LevelError, "boom") {
+		t.Fatal("error entries are sampled")
+	}
+}
+
+func TestSamplingSummaryAfterQuietPeriod(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetSampling(&SamplingOptions{Tick: time.Hour, First: 1, SummaryInterval: 20 * time.Millisecond})
+
+	for range 5 {
+		logger.Info("burst")
+	}
+	// Nothing else is logged; the summary must arrive on its own
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(buf.String(), "sampling suppressed") && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !strings.Contains(buf.String(), "sampling suppressed 4 log entries") {
+		t.Fatalf("expected a summary after the burst, got %q", buf.String())
+	}
+
+	logger.Flush()
+	time.Sleep(50 * time.Millisecond)
+	if got := strings.Count(buf.String(), "sampling suppressed"); got != 1 {
+		t.Fatalf("expected a single summary, got %d in %q", got, buf.String())
+	}
+}
@@ -0,0 +1,113 @@
+package logfx
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// LevelSignalOptions configures HandleLevelSignals.
+type LevelSignalOptions struct {
+	Level  share.Level // Level set on SIGUSR1
+	Toggle bool        // SIGUSR1 also restores the level when it is raised
+}
+
+// DefaultLevelSignalOptions raises the level to Debug on SIGUSR1.
+func DefaultLevelSignalOptions() LevelSignalOptions {
+	return LevelSignalOptions{Level: share.LevelDebug}
+}
+
+// HandleLevelSignals lets operators change the level of a running process
+// without restarting it: SIGUSR1 sets the level of the root logger to
+// opts.Level and SIGUSR2 restores the level it had before. With
+// opts.Toggle, SIGUSR1 alternates between the two. The handler runs until
+// ctx is done or the returned stop function is called.
+//
+//	stop := logger.HandleLevelSignals(ctx, logfx.DefaultLevelSignalOptions())
+//	defer stop()
+//	// kill -USR1 <pid> to debug, kill -USR2 <pid> to restore
+//
+// Platforms without these signals, such as Windows, ignore the call.
+func (l *Logger) HandleLevelSignals(ctx context.Context, opts LevelSignalOptions) (stop func()) {
+	if len(levelSignals) == 0 {
+		return func() {}
+	}
+
+	ls := &levelSignaler{logger: l.root(), opts: opts}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, levelSignals...)
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-sigCh:
+				ls.handle(levelSignalAction(sig))
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(sigCh)
+			cancel()
+			<-done
+		})
+	}
+}
+
+// HandleLevelSignals changes the level of the global logger on SIGUSR1 and
+// SIGUSR2, see (*Logger).HandleLevelSignals.
+func HandleLevelSignals(ctx context.Context, opts LevelSignalOptions) (stop func()) {
+	return GetLogger().HandleLevelSignals(ctx, opts)
+}
+
+// signalAction is what a level signal asks for.
+type signalAction int
+
+const (
+	signalIgnore  signalAction = iota
+	signalRaise                // SIGUSR1
+	signalRestore              // SIGUSR2
+)
+
+// levelSignaler applies level signals to a root logger.
+type levelSignaler struct {
+	logger *Logger
+	opts   LevelSignalOptions
+	raised bool
+	saved  share.Level
+}
+
+// handle applies action.
+func (ls *levelSignaler) handle(action signalAction) {
+	if action == signalRaise && ls.raised && ls.opts.Toggle {
+		action = signalRestore
+	}
+
+	switch action {
+	case signalRaise:
+		if !ls.raised {
+			ls.logger.mu.RLock()
+			ls.saved = ls.logger.options.Level
+			ls.logger.mu.RUnlock()
+			ls.raised = true
+		}
+		ls.logger.SetLevel(ls.opts.Level)
+		ls.logger.log(share.LevelInfo, "log level raised to "+ls.opts.Level.String()+" by signal", nil)
+	case signalRestore:
+		if !ls.raised {
+			return
+		}
+		ls.logger.log(share.LevelInfo, "log level restored to "+ls.saved.String()+" by signal", nil)
+		ls.logger.SetLevel(ls.saved)
+		ls.raised = false
+	}
+}
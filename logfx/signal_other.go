@@ -0,0 +1,11 @@
+//go:build !unix
+
+package logfx
+
+import "os"
+
+// levelSignals is empty: the platform has no SIGUSR1 and SIGUSR2.
+var levelSignals []os.Signal
+
+// levelSignalAction ignores every signal.
+func levelSignalAction(os.Signal) signalAction { return signalIgnore }
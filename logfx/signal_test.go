@@ -0,0 +1,43 @@
+package logfx
+
+import (
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestLevelSignalerRaiseAndRestore(t *testing.T) {
+	logger := newTextLogger(&testutil.SafeBuffer{})
+	logger.SetLevel(share.LevelWarn)
+	ls := &levelSignaler{logger: logger, opts: DefaultLevelSignalOptions()}
+
+	ls.handle(signalRestore)
+	if logger.options.Level != share.LevelWarn {
+		t.Fatalf("restore without raise must be a no-op, got %v", logger.options.Level)
+	}
+	ls.handle(signalRaise)
+	ls.handle(signalRaise)
+	if logger.options.Level != share.LevelDebug {
+		t.Fatalf("expected Debug after raise, got %v", logger.options.Level)
+	}
+	ls.handle(signalRestore)
+	if logger.options.Level != share.LevelWarn {
+		t.Fatalf("expected the original level after restore, got %v", logger.options.Level)
+	}
+}
+
+func TestLevelSignalerToggle(t *testing.T) {
+	logger := newTextLogger(&testutil.SafeBuffer{})
+	logger.SetLevel(share.LevelInfo)
+	ls := &levelSignaler{logger: logger, opts: LevelSignalOptions{Level: share.LevelTrace, Toggle: true}}
+
+	ls.handle(signalRaise)
+	if logger.options.Level != share.LevelTrace {
+		t.Fatalf("expected Trace after first signal, got %v", logger.options.Level)
+	}
+	ls.handle(signalRaise)
+	if logger.options.Level != share.LevelInfo {
+		t.Fatalf("expected Info after second signal, got %v", logger.options.Level)
+	}
+}
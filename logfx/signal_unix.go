@@ -0,0 +1,22 @@
+//go:build unix
+
+package logfx
+
+import (
+	"os"
+	"syscall"
+)
+
+// levelSignals are the signals handled by HandleLevelSignals.
+var levelSignals = []os.Signal{syscall.SIGUSR1, syscall.SIGUSR2}
+
+// levelSignalAction maps a level signal to its action.
+func levelSignalAction(sig os.Signal) signalAction {
+	switch sig {
+	case syscall.SIGUSR1:
+		return signalRaise
+	case syscall.SIGUSR2:
+		return signalRestore
+	}
+	return signalIgnore
+}
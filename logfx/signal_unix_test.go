@@ -0,0 +1,36 @@
+//go:build unix
+
+package logfx
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestHandleLevelSignals(t *testing.T) {
+	logger := newTextLogger(&testutil.SafeBuffer{})
+	logger.SetLevel(share.LevelInfo)
+	stop := logger.HandleLevelSignals(context.Background(), DefaultLevelSignalOptions())
+	defer stop()
+
+	waitLevel := func(want share.Level) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for logger.level() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected level %v, got %v", want, logger.level())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	waitLevel(share.LevelDebug)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	waitLevel(share.LevelInfo)
+}
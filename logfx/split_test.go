@@ -0,0 +1,55 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestSplitOutputByLevel(t *testing.T) {
+	out, errOut := &testutil.SafeBuffer{}, &testutil.SafeBuffer{}
+	logger := LogWith(
+		WithSplitOutput(out, errOut, share.LevelWarn),
+		WithFormat(share.FormatText),
+		WithLevel(share.LevelDebug),
+	)
+
+	logger.Debug("debug line")
+	logger.Info("info line")
+	logger.Warn("warn line")
+	logger.Named("db").Error("error line")
+
+	if got := out.String(); !strings.Contains(got, "debug line") || !strings.Contains(got, "info line") ||
+		strings.Contains(got, "warn line") || strings.Contains(got, "error line") {
+		t.Errorf("unexpected stdout %q", got)
+	}
+	if got := errOut.String(); !strings.Contains(got, "warn line") || !strings.Contains(got, "error line") ||
+		strings.Contains(got, "info line") {
+		t.Errorf("unexpected stderr %q", got)
+	}
+}
+
+func TestSplitOutputSurvivesOptionChanges(t *testing.T) {
+	out, errOut, moved := &testutil.SafeBuffer{}, &testutil.SafeBuffer{}, &testutil.SafeBuffer{}
+	logger := LogWith(WithSplitOutput(out, errOut, share.LevelWarn), WithFormat(share.FormatText))
+
+	logger.SetLevel(share.LevelError)
+	logger.SetFormat(share.FormatJSON)
+	logger.SetOutput(moved)
+	logger.Warn("filtered")
+	logger.Error("to stderr")
+	logger.SetLevel(share.LevelInfo)
+	logger.Info("to moved")
+
+	if got := errOut.String(); !strings.Contains(got, `"msg":"to stderr"`) || strings.Contains(got, "filtered") {
+		t.Errorf("unexpected stderr %q", got)
+	}
+	if got := moved.String(); !strings.Contains(got, "to moved") || strings.Contains(got, "to stderr") {
+		t.Errorf("unexpected stdout %q", got)
+	}
+	if out.String() != "" {
+		t.Errorf("expected nothing on the replaced stdout, got %q", out.String())
+	}
+}
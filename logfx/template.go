@@ -0,0 +1,118 @@
+package logfx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// expandTemplate replaces each {name} in tmpl with the value of the field
+// name. Placeholders without a field are kept as they are, and "{{" and
+// "}}" stand for literal braces.
+func expandTemplate(tmpl string, fields share.Fields) string {
+	if !strings.ContainsAny(tmpl, "{}") {
+		return tmpl
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(tmpl); i++ {
+		c := tmpl[i]
+		switch {
+		case c == '{' && i+1 < len(tmpl) && tmpl[i+1] == '{',
+			c == '}' && i+1 < len(tmpl) && tmpl[i+1] == '}':
+			b.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(tmpl[i:], '}')
+			if end < 0 {
+				b.WriteString(tmpl[i:])
+				return b.String()
+			}
+			name := tmpl[i+1 : i+end]
+			if value, ok := fields[name]; ok {
+				fmt.Fprint(&b, value)
+			} else {
+				b.WriteString(tmpl[i : i+end+1])
+			}
+			i += end
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// logw logs tmpl expanded with fields, keeping the fields on the entry.
+func (l *Logger) logw(level share.Level, tmpl string, fields share.Fields) {
+	if !l.shouldLog(level) && !l.recording(level) {
+		return
+	}
+	l.log(level, expandTemplate(tmpl, fields), fields)
+}
+
+// Tracew logs a message template at Trace, see Infow.
+func (l *Logger) Tracew(tmpl string, fields share.Fields) { l.logw(share.LevelTrace, tmpl, fields) }
+
+// Debugw logs a message template at Debug, see Infow.
+func (l *Logger) Debugw(tmpl string, fields share.Fields) { l.logw(share.LevelDebug, tmpl, fields) }
+
+// Infow logs a message whose {name} placeholders are replaced by the
+// values of fields, which are attached to the entry as well. Text output
+// stays readable and greppable while JSON writers still get every value as
+// a separate key:
+//
+//	logger.Infow("user {user} logged in from {ip}", map[string]any{"user": "ann", "ip": ip})
+//
+// Placeholders without a field are left as they are; write "{{" and "}}"
+// for literal braces.
+func (l *Logger) Infow(tmpl string, fields share.Fields) { l.logw(share.LevelInfo, tmpl, fields) }
+
+// Successw logs a message template at Success, see Infow.
+func (l *Logger) Successw(tmpl string, fields share.Fields) {
+	l.logw(share.LevelSuccess, tmpl, fields)
+}
+
+// Warnw logs a message template at Warn, see Infow.
+func (l *Logger) Warnw(tmpl string, fields share.Fields) { l.logw(share.LevelWarn, tmpl, fields) }
+
+// Errorw logs a message template at Error, see Infow.
+func (l *Logger) Errorw(tmpl string, fields share.Fields) { l.logw(share.LevelError, tmpl, fields) }
+
+// logw logs tmpl expanded with the context fields and fields.
+func (c *Context) logw(level share.Level, tmpl string, fields share.Fields) {
+	if !c.logger.shouldLog(level) && !c.logger.recording(level) {
+		return
+	}
+	c = c.WithFields(fields)
+	c.log(level, expandTemplate(tmpl, c.fields))
+}
+
+// Tracew logs a message template at Trace, see (*Logger).Infow.
+func (c *Context) Tracew(tmpl string, fields share.Fields) { c.logw(share.LevelTrace, tmpl, fields) }
+
+// Debugw logs a message template at Debug, see (*Logger).Infow.
+func (c *Context) Debugw(tmpl string, fields share.Fields) { c.logw(share.LevelDebug, tmpl, fields) }
+
+// Infow logs a message template at Info. Placeholders may name context
+// fields too, see (*Logger).Infow.
+func (c *Context) Infow(tmpl string, fields share.Fields) { c.logw(share.LevelInfo, tmpl, fields) }
+
+// Successw logs a message template at Success, see (*Logger).Infow.
+func (c *Context) Successw(tmpl string, fields share.Fields) {
+	c.logw(share.LevelSuccess, tmpl, fields)
+}
+
+// Warnw logs a message template at Warn, see (*Logger).Infow.
+func (c *Context) Warnw(tmpl string, fields share.Fields) { c.logw(share.LevelWarn, tmpl, fields) }
+
+// Errorw logs a message template at Error, see (*Logger).Infow.
+func (c *Context) Errorw(tmpl string, fields share.Fields) { c.logw(share.LevelError, tmpl, fields) }
+
+// Global template functions that use the global logger
+func Tracew(tmpl string, fields share.Fields)   { GetLogger().Tracew(tmpl, fields) }
+func Debugw(tmpl string, fields share.Fields)   { GetLogger().Debugw(tmpl, fields) }
+func Infow(tmpl string, fields share.Fields)    { GetLogger().Infow(tmpl, fields) }
+func Successw(tmpl string, fields share.Fields) { GetLogger().Successw(tmpl, fields) }
+func Warnw(tmpl string, fields share.Fields)    { GetLogger().Warnw(tmpl, fields) }
+func Errorw(tmpl string, fields share.Fields)   { GetLogger().Errorw(tmpl, fields) }
@@ -0,0 +1,43 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	fields := share.Fields{"user": "ann", "n": 3}
+	for tmpl, want := range map[string]string{
+		"user {user} did {n} things": "user ann did 3 things",
+		"missing {ip} stays":         "missing {ip} stays",
+		"literal {{user}} braces }}": "literal {user} braces }",
+		"unterminated {user":         "unterminated {user",
+		"no placeholders":            "no placeholders",
+	} {
+		if got := expandTemplate(tmpl, fields); got != want {
+			t.Errorf("expandTemplate(%q) = %q, want %q", tmpl, got, want)
+		}
+	}
+}
+
+func TestInfowKeepsFields(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	logger.Infow("user {user} logged in from {ip}", share.Fields{"user": "ann", "ip": "10.0.0.1"})
+	logger.WithFields(share.Fields{"job": "sync"}).Warnw("{job} retry {attempt}", share.Fields{"attempt": 2})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 entries, got %q", lines)
+	}
+	if !strings.Contains(lines[0], "user ann logged in from 10.0.0.1") || !strings.Contains(lines[0], "ip=10.0.0.1") {
+		t.Errorf("expected expanded message and fields, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "WARN") || !strings.Contains(lines[1], "sync retry 2") || !strings.Contains(lines[1], "attempt=2") {
+		t.Errorf("expected context fields in the template, got %q", lines[1])
+	}
+}
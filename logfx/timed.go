@@ -0,0 +1,82 @@
+package logfx
+
+import (
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// TimerOptions configures Timed.
+type TimerOptions struct {
+	// Threshold skips the completion entry of successful operations faster
+	// than it, and the start entry of every operation; failures are always
+	// logged
+	Threshold time.Duration
+}
+
+// TimerOption configures Timed.
+type TimerOption func(*TimerOptions)
+
+// WithTimerThreshold only logs successful operations that take at least d.
+func WithTimerThreshold(d time.Duration) TimerOption {
+	return func(opts *TimerOptions) {
+		opts.Threshold = d
+	}
+}
+
+// Timed logs the start of op at Debug and returns a function that logs its
+// completion, with the duration and outcome, at Info or, when err is not
+// nil, at Error:
+//
+//	done := logger.Timed("migrate db")
+//	err := migrate()
+//	done(err)
+//
+// Only the first call of the returned function logs.
+func (l *Logger) Timed(op string, opts ...TimerOption) (done func(err error)) {
+	return timed(l.WithFields(nil), op, opts)
+}
+
+// Timed logs the start and completion of op with the fields of the
+// context, see (*Logger).Timed.
+func (c *Context) Timed(op string, opts ...TimerOption) (done func(err error)) {
+	return timed(c, op, opts)
+}
+
+// Timed times op with the global logger, see (*Logger).Timed.
+func Timed(op string, opts ...TimerOption) (done func(err error)) {
+	return GetLogger().Timed(op, opts...)
+}
+
+// timed implements Timed on c.
+func timed(c *Context, op string, opts []TimerOption) func(error) {
+	var cfg TimerOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c = c.WithField("operation", op)
+	if cfg.Threshold == 0 {
+		c.log(share.LevelDebug, op+" started")
+	}
+	start := time.Now()
+
+	finished := false
+	return func(err error) {
+		if finished {
+			return
+		}
+		finished = true
+
+		elapsed := time.Since(start)
+		if err == nil && elapsed < cfg.Threshold {
+			return
+		}
+		c := c.WithField("duration", elapsed.Round(time.Microsecond).String())
+		if err != nil {
+			c.WithFields(share.Fields{"outcome": "failed", "error": err.Error()}).log(share.LevelError, op+" failed")
+			return
+		}
+		c.WithField("outcome", "ok").log(share.LevelInfo, op+" completed")
+	}
+}
@@ -0,0 +1,61 @@
+package logfx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestTimedLogsStartAndOutcome(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	done := logger.Timed("migrate db")
+	done(nil)
+	done(errors.New("ignored"))
+	logger.WithFields(share.Fields{"table": "users"}).Timed("backfill")(errors.New("lock timeout"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 entries, got %q", lines)
+	}
+	for i, want := range [][]string{
+		{"DEBUG", "migrate db started", "operation=migrate db"},
+		{"INFO", "migrate db completed", "outcome=ok", "duration="},
+		{"DEBUG", "backfill started", "table=users"},
+		{"ERROR", "backfill failed", "outcome=failed", "error=lock timeout", "table=users"},
+	} {
+		for _, w := range want {
+			if !strings.Contains(lines[i], w) {
+				t.Errorf("entry %d: expected %q in %q", i, w, lines[i])
+			}
+		}
+	}
+}
+
+func TestTimedThreshold(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	logger.Timed("fast", WithTimerThreshold(time.Hour))(nil)
+	if buf.String() != "" {
+		t.Fatalf("expected fast operation to be skipped, got %q", buf.String())
+	}
+
+	logger.Timed("fast but failing", WithTimerThreshold(time.Hour))(errors.New("boom"))
+	slow := logger.Timed("slow", WithTimerThreshold(time.Millisecond))
+	time.Sleep(2 * time.Millisecond)
+	slow(nil)
+
+	out := buf.String()
+	if !strings.Contains(out, "fast but failing failed") || !strings.Contains(out, "slow completed") {
+		t.Fatalf("expected failure and slow operation, got %q", out)
+	}
+	if strings.Contains(out, "started") {
+		t.Fatalf("expected no start entries with a threshold, got %q", out)
+	}
+}
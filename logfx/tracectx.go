@@ -0,0 +1,83 @@
+package logfx
+
+import (
+	"context"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// Field keys of the trace identifiers added to entries.
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// TraceExtractor returns the trace and span IDs of the span active in ctx,
+// or empty strings when there is none. Plug in the tracing library of the
+// application with WithTraceExtractor; for OpenTelemetry:
+//
+//	logfx.WithTraceExtractor(func(ctx context.Context) (string, string) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		if !sc.IsValid() {
+//			return "", ""
+//		}
+//		return sc.TraceID().String(), sc.SpanID().String()
+//	})
+type TraceExtractor func(ctx context.Context) (traceID, spanID string)
+
+// traceKey is the context key of the IDs stored by ContextWithTrace.
+type traceKey struct{}
+
+// traceIDs are the IDs stored by ContextWithTrace.
+type traceIDs struct {
+	traceID, spanID string
+}
+
+// ContextWithTrace returns a context carrying trace and span IDs, for
+// applications that propagate IDs without a tracing library.
+func ContextWithTrace(ctx context.Context, traceID, spanID string) context.Context {
+	return context.WithValue(ctx, traceKey{}, traceIDs{traceID, spanID})
+}
+
+// TraceFromContext is the default TraceExtractor. It returns the IDs stored
+// by ContextWithTrace.
+func TraceFromContext(ctx context.Context) (traceID, spanID string) {
+	ids, _ := ctx.Value(traceKey{}).(traceIDs)
+	return ids.traceID, ids.spanID
+}
+
+// WithTraceExtractor sets how trace and span IDs are read from the
+// context.Context of entries logged through WithContext.
+func WithTraceExtractor(extract TraceExtractor) LogOption {
+	return func(cfg *LogOptions) {
+		cfg.TraceExtractor = extract
+	}
+}
+
+// SetTraceExtractor replaces the trace extractor of the logger and its
+// children. Nil restores TraceFromContext.
+func (l *Logger) SetTraceExtractor(extract TraceExtractor) {
+	root := l.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	root.options.TraceExtractor = extract
+}
+
+// addTrace adds the trace and span IDs found in ctx to fields.
+func (l *Logger) addTrace(ctx context.Context, fields share.Fields) {
+	root := l.root()
+	root.mu.RLock()
+	extract := root.options.TraceExtractor
+	root.mu.RUnlock()
+	if extract == nil {
+		extract = TraceFromContext
+	}
+
+	traceID, spanID := extract(ctx)
+	if traceID != "" {
+		fields[TraceIDKey] = traceID
+	}
+	if spanID != "" {
+		fields[SpanIDKey] = spanID
+	}
+}
@@ -0,0 +1,46 @@
+package logfx
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestWithContextAddsTraceIDs(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	ctx := ContextWithTrace(context.Background(), "4bf92f35", "00f067aa")
+	logger.WithContext(ctx).Info("traced")
+	logger.WithContext(context.Background()).Info("untraced")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 entries, got %q", lines)
+	}
+	if !strings.Contains(lines[0], "trace_id=4bf92f35") || !strings.Contains(lines[0], "span_id=00f067aa") {
+		t.Errorf("expected trace fields, got %q", lines[0])
+	}
+	if strings.Contains(lines[1], "trace_id") || strings.Contains(lines[1], "span_id") {
+		t.Errorf("expected no trace fields, got %q", lines[1])
+	}
+}
+
+func TestCustomTraceExtractor(t *testing.T) {
+	type spanKey struct{}
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.Named("rpc").SetTraceExtractor(func(ctx context.Context) (string, string) {
+		id, _ := ctx.Value(spanKey{}).(string)
+		return "t-" + id, "s-" + id
+	})
+
+	ctx := context.WithValue(context.Background(), spanKey{}, "42")
+	logger.WithContext(ctx).Warn("custom")
+
+	if out := buf.String(); !strings.Contains(out, "trace_id=t-42") || !strings.Contains(out, "span_id=s-42") {
+		t.Fatalf("expected extracted IDs, got %q", out)
+	}
+}
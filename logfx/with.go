@@ -0,0 +1,62 @@
+package logfx
+
+import (
+	"maps"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// With returns a child logger that attaches fields to every entry, for
+// request- or component-scoped logging:
+//
+//	reqLog := logger.With(map[string]any{"request_id": id})
+//	reqLog.Info("started")
+//	reqLog.WithFields(map[string]any{"status": 200}).Info("done")
+//
+// Unlike WithFields, which returns a Context for a single chain of calls,
+// the result is a full *Logger that can be passed around, named with Named
+// or bound further with With. Fields of nested With calls accumulate, the
+// innermost winning on conflicts, and fields given to a single call win
+// over bound ones. The child keeps the name and level of l and shares its
+// writers and hooks, including ones added later.
+func (l *Logger) With(fields share.Fields) *Logger {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	bound := make(share.Fields, len(l.fields)+len(fields))
+	mergeFields(bound, l.fields)
+	mergeFields(bound, l.nest(fields))
+	return &Logger{
+		options:    l.options,
+		ctx:        l.ctx,
+		indent:     l.indent,
+		indentStr:  l.indentStr,
+		name:       l.name,
+		parent:     l,
+		fields:     bound,
+		groups:     l.groups,
+		dedup:      newDeduper(l.options.Dedup),
+		callerSkip: l.callerSkip,
+	}
+}
+
+// Fields returns a copy of the fields bound to l with With.
+func (l *Logger) Fields() share.Fields {
+	return maps.Clone(l.fields)
+}
+
+// withBound returns fields, nested under the groups of l, on top of the
+// bound fields of l.
+func (l *Logger) withBound(fields share.Fields) share.Fields {
+	fields = l.nest(fields)
+	if len(l.fields) == 0 {
+		return fields
+	}
+	merged := make(share.Fields, len(l.fields)+len(fields))
+	mergeFields(merged, l.fields)
+	mergeFields(merged, fields)
+	return merged
+}
+
+// With returns a child of the global logger with bound fields.
+func With(fields share.Fields) *Logger { return GetLogger().With(fields) }
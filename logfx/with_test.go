@@ -0,0 +1,68 @@
+package logfx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+func TestWithBindsFields(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+
+	req := logger.With(share.Fields{"request_id": "r1", "user": "ann"})
+	req.Info("started")
+	req.WithFields(share.Fields{"user": "bob"}).Info("switched")
+	logger.Info("plain")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 entries, got %q", lines)
+	}
+	if !strings.Contains(lines[0], "request_id=r1") || !strings.Contains(lines[0], "user=ann") {
+		t.Errorf("expected bound fields, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "request_id=r1") || !strings.Contains(lines[1], "user=bob") {
+		t.Errorf("expected call fields to win over bound ones, got %q", lines[1])
+	}
+	if strings.Contains(lines[2], "request_id") {
+		t.Errorf("parent must not get bound fields, got %q", lines[2])
+	}
+}
+
+func TestWithNestsAndInherits(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	logger.SetLevel(share.LevelInfo)
+
+	db := logger.With(share.Fields{"component": "db"}).Named("db")
+	pool := db.With(share.Fields{"pool": 1})
+	if got := pool.Fields(); got["component"] != "db" || got["pool"] != 1 {
+		t.Fatalf("expected accumulated fields, got %v", got)
+	}
+
+	logger.SetLevelFor("db", share.LevelDebug)
+	pool.Debug("query")
+
+	out := buf.String()
+	for _, want := range []string{"query", "component=db", "pool=1", "logger=db"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+}
+
+func TestWithSetLevelFollowsRoot(t *testing.T) {
+	buf := &testutil.SafeBuffer{}
+	logger := newTextLogger(buf)
+	child := logger.With(share.Fields{"k": "v"})
+
+	child.SetLevel(share.LevelWarn)
+	logger.Info("hidden")
+	child.Info("hidden too")
+	if buf.String() != "" {
+		t.Fatalf("expected SetLevel on an unnamed child to change the root, got %q", buf.String())
+	}
+}
@@ -2,6 +2,7 @@ package progress
 
 import (
 	"io"
+	"time"
 
 	"github.com/garaekz/tfx/internal/share"
 	"github.com/garaekz/tfx/runfx"
@@ -15,9 +16,57 @@ type ProgressConfig struct {
 	Theme     ProgressTheme
 	Style     ProgressStyle
 	Effect    ProgressEffect
-	Writer    io.Writer // Used only for TTY detection, not direct writes.
+	Writer    io.Writer // Used for TTY detection, Println and events; os.Stdout when nil
 	ShowETA   bool
 	DetectTTY func() runfx.TTYInfo
+
+	// Units counts bytes, drawing "12.3 MiB / 100 MiB @ 4.2 MiB/s" after
+	// the percentage; UnitsNone counts plain units
+	Units ByteUnits
+	// Smoothing weighs each throughput sample against the previous rate,
+	// from 0 (never changes) to 1 (latest sample only), in the default
+	// EWMAEstimator; zero uses 0.3
+	Smoothing float64
+	// Estimator creates the estimator of the throughput the rate and ETA
+	// are drawn from; nil uses an EWMAEstimator
+	Estimator func() Estimator
+
+	// Indeterminate animates the bar while Total is zero or less, until
+	// SetTotal gives it one
+	Indeterminate IndeterminateStyle
+
+	// Glyphs draws the bar, overriding those of Style when Fill is set;
+	// ProgressStyleAscii suits terminals without Unicode
+	Glyphs BarGlyphs
+	// Brackets frames the bar; BracketsSquare when zero
+	Brackets BracketStyle
+	// Percent places the percentage; PercentRight when zero
+	Percent PercentPlacement
+
+	// Template lays out the line of the bar in terminals, e.g.
+	// "{label} {bar:30} {percent} {rate} ETA {eta}". Empty draws the
+	// label, bar and percentage. Placeholders:
+	//
+	//	{label}    the label
+	//	{bar}      the bar, {bar:30} 30 cells wide
+	//	{percent}  the percentage; "" while the total is unknown
+	//	{current}  the count, in Units
+	//	{total}    the total, in Units; "" while unknown
+	//	{rate}     the estimated throughput, e.g. "4.2 MiB/s"; "" until known
+	//	{elapsed}  the time since the bar started
+	//	{eta}      the estimated time left; "" until known
+	//	{paused}   "(paused)" while the bar is paused
+	//
+	// "{{" draws a brace and unknown placeholders are drawn as written
+	Template string
+	// Placeholders adds placeholders to Template, or replaces built-in ones
+	Placeholders map[string]PlaceholderFunc
+
+	// Events reports progress as ProgressEvent JSON lines written to
+	// Writer, for CI systems and wrappers to parse, instead of drawing
+	Events EventMode
+	// EventInterval is the shortest time between events; zero uses 1s
+	EventInterval time.Duration
 }
 
 // DefaultProgressConfig returns sensible defaults.
@@ -46,7 +95,7 @@ func NewProgressBuilder() *ProgressBuilder {
 	return &ProgressBuilder{config: DefaultProgressConfig()}
 }
 
-// Total sets the total amount of work.
+// Total sets the total amount of work; zero or less when it is unknown.
 func (b *ProgressBuilder) Total(total int) *ProgressBuilder {
 	b.config.Total = total
 	return b
@@ -64,6 +113,76 @@ func (b *ProgressBuilder) Width(width int) *ProgressBuilder {
 	return b
 }
 
+// Bytes counts bytes, written in units.
+func (b *ProgressBuilder) Bytes(units ByteUnits) *ProgressBuilder {
+	b.config.Units = units
+	return b
+}
+
+// Smoothing weighs throughput samples with alpha in the default estimator.
+func (b *ProgressBuilder) Smoothing(alpha float64) *ProgressBuilder {
+	b.config.Smoothing = alpha
+	return b
+}
+
+// Estimator estimates the throughput with estimators created by fn.
+func (b *ProgressBuilder) Estimator(fn func() Estimator) *ProgressBuilder {
+	b.config.Estimator = fn
+	return b
+}
+
+// Indeterminate animates the bar in style while the total is unknown.
+func (b *ProgressBuilder) Indeterminate(style IndeterminateStyle) *ProgressBuilder {
+	b.config.Indeterminate = style
+	return b
+}
+
+// Style draws the bar with the glyphs of style.
+func (b *ProgressBuilder) Style(style ProgressStyle) *ProgressBuilder {
+	b.config.Style = style
+	return b
+}
+
+// Glyphs draws the bar with glyphs instead of those of its style.
+func (b *ProgressBuilder) Glyphs(glyphs BarGlyphs) *ProgressBuilder {
+	b.config.Glyphs = glyphs
+	return b
+}
+
+// Brackets frames the bar with brackets.
+func (b *ProgressBuilder) Brackets(brackets BracketStyle) *ProgressBuilder {
+	b.config.Brackets = brackets
+	return b
+}
+
+// Percent places the percentage.
+func (b *ProgressBuilder) Percent(placement PercentPlacement) *ProgressBuilder {
+	b.config.Percent = placement
+	return b
+}
+
+// Template lays out the line of the bar with tmpl.
+func (b *ProgressBuilder) Template(tmpl string) *ProgressBuilder {
+	b.config.Template = tmpl
+	return b
+}
+
+// Placeholder adds the placeholder {name} to the template, rendered by fn.
+func (b *ProgressBuilder) Placeholder(name string, fn PlaceholderFunc) *ProgressBuilder {
+	if b.config.Placeholders == nil {
+		b.config.Placeholders = make(map[string]PlaceholderFunc)
+	}
+	b.config.Placeholders[name] = fn
+	return b
+}
+
+// Events reports progress as JSON lines in mode, at most interval apart.
+func (b *ProgressBuilder) Events(mode EventMode, interval time.Duration) *ProgressBuilder {
+	b.config.Events = mode
+	b.config.EventInterval = interval
+	return b
+}
+
 // DetectTTY allows providing a custom TTY detection function.
 func (b *ProgressBuilder) DetectTTY(fn func() runfx.TTYInfo) *ProgressBuilder {
 	b.config.DetectTTY = fn
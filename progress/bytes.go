@@ -0,0 +1,59 @@
+package progress
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ByteUnits selects whether a bar counts bytes and how it writes them.
+type ByteUnits int
+
+const (
+	UnitsNone ByteUnits = iota // Plain counts
+	UnitsIEC                   // Powers of 1024: KiB, MiB, GiB
+	UnitsSI                    // Powers of 1000: kB, MB, GB
+)
+
+var (
+	iecSuffixes = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	siSuffixes  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+)
+
+// FormatBytes writes n bytes in units, e.g. "12.3 MiB" or "100 MB", with
+// one decimal when it matters. UnitsNone writes the plain number.
+func FormatBytes(n float64, units ByteUnits) string {
+	base, suffixes := 1024.0, iecSuffixes
+	switch units {
+	case UnitsNone:
+		return strconv.FormatFloat(n, 'f', 0, 64)
+	case UnitsSI:
+		base, suffixes = 1000, siSuffixes
+	}
+	i := 0
+	for n >= base && i < len(suffixes)-1 {
+		n /= base
+		i++
+	}
+	text := strconv.FormatFloat(n, 'f', 1, 64)
+	if i == 0 || n >= 100 {
+		text = strconv.FormatFloat(n, 'f', 0, 64)
+	}
+	return strings.TrimSuffix(text, ".0") + " " + suffixes[i]
+}
+
+// byteText returns the "12.3 MiB / 100 MiB @ 4.2 MiB/s" text of a bar in
+// byte mode, without the total when it is unknown, or "" otherwise. Bars of
+// unknown total always count. Callers hold p.mu.
+func (p *Progress) byteText() string {
+	if p.units == UnitsNone && p.total > 0 {
+		return ""
+	}
+	text := FormatBytes(float64(p.current), p.units)
+	if p.total > 0 {
+		text += " / " + FormatBytes(float64(p.total), p.units)
+	}
+	if p.rate > 0 {
+		text += " @ " + FormatBytes(p.rate, p.units) + "/s"
+	}
+	return text
+}
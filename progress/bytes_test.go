@@ -0,0 +1,68 @@
+package progress
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n     float64
+		units ByteUnits
+		want  string
+	}{
+		{512, UnitsIEC, "512 B"},
+		{1536, UnitsIEC, "1.5 KiB"},
+		{12.3 * (1 << 20), UnitsIEC, "12.3 MiB"},
+		{100 * (1 << 20), UnitsIEC, "100 MiB"},
+		{4 * (1 << 20), UnitsIEC, "4 MiB"},
+		{1500, UnitsSI, "1.5 kB"},
+		{2.5e9, UnitsSI, "2.5 GB"},
+		{1234, UnitsNone, "1234"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n, tt.units); got != tt.want {
+			t.Errorf("FormatBytes(%v, %v) = %q, want %q", tt.n, tt.units, got, tt.want)
+		}
+	}
+}
+
+func TestProgressRateSmoothing(t *testing.T) {
+	p := newProgress(ProgressConfig{Total: 1000, Smoothing: 0.5, DetectTTY: noTTY})
+	start := time.Now()
+
+	p.sampleRate(start)
+	p.current = 100
+	p.sampleRate(start.Add(time.Second)) // 100/s
+	p.current = 400
+	p.sampleRate(start.Add(2 * time.Second)) // 300/s
+	if got := p.Rate(); math.Abs(got-200) > 1e-9 {
+		t.Errorf("expected the samples averaged to 200/s, got %v", got)
+	}
+
+	p.current = 1000
+	p.sampleRate(start.Add(2*time.Second + time.Millisecond))
+	if got := p.Rate(); math.Abs(got-200) > 1e-9 {
+		t.Errorf("expected samples closer than %v ignored, got %v", minRateSample, got)
+	}
+}
+
+func TestProgressByteText(t *testing.T) {
+	p := newProgress(ProgressConfig{Total: 100 << 20, Label: "download", Units: UnitsIEC, DetectTTY: noTTY})
+	p.Set(12 << 20)
+	if got := p.Render(); got != "download  12% 12 MiB / 100 MiB" {
+		t.Errorf("unexpected render %q", got)
+	}
+
+	p.rate = 4.2 * (1 << 20)
+	if got := p.Render(); got != "download  12% 12 MiB / 100 MiB @ 4.2 MiB/s" {
+		t.Errorf("unexpected render with a rate %q", got)
+	}
+
+	plain := newProgress(ProgressConfig{Total: 10, Label: "items", DetectTTY: noTTY})
+	plain.Set(5)
+	if got := plain.Render(); got != "items  50%" {
+		t.Errorf("expected plain bars unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,134 @@
+package progress
+
+import "time"
+
+// Estimator estimates the throughput of a bar, from which its rate and ETA
+// are drawn. A bar calls it with its lock held, so it needs no locking of
+// its own but must not call the bar. An estimator with a Skip(d
+// time.Duration) method is told of the time d a bar spent paused when it
+// resumes, so the pause does not count as time without progress.
+type Estimator interface {
+	// Observe records that the bar counted current at now.
+	Observe(now time.Time, current int)
+	// Rate returns the estimated units per second, or 0 while unknown.
+	Rate() float64
+}
+
+// minRateSample is the shortest time between throughput samples, so bursts
+// of small updates do not make the rate jump.
+const minRateSample = 100 * time.Millisecond
+
+// EWMAEstimator estimates the rate as an exponentially weighted moving
+// average of samples taken at least MinInterval apart, so it follows
+// changes in throughput without jumping with each update. It is the
+// default Estimator.
+type EWMAEstimator struct {
+	Alpha       float64       // Weight of each sample against the previous rate, from 0 to 1
+	MinInterval time.Duration // Shortest time between samples
+
+	sampleTime  time.Time
+	sampleValue int
+	rate        float64
+}
+
+// NewEWMAEstimator returns an EWMAEstimator weighing samples with alpha,
+// 0.3 when out of (0, 1].
+func NewEWMAEstimator(alpha float64) *EWMAEstimator {
+	if alpha <= 0 || alpha > 1 {
+		alpha = 0.3
+	}
+	return &EWMAEstimator{Alpha: alpha, MinInterval: minRateSample}
+}
+
+// Observe folds the progress made since the last sample into the rate.
+func (e *EWMAEstimator) Observe(now time.Time, current int) {
+	if e.sampleTime.IsZero() {
+		e.sampleTime, e.sampleValue = now, current
+		return
+	}
+	elapsed := now.Sub(e.sampleTime)
+	if elapsed < e.MinInterval || elapsed <= 0 {
+		return
+	}
+	rate := float64(current-e.sampleValue) / elapsed.Seconds()
+	if e.rate == 0 {
+		e.rate = rate
+	} else {
+		e.rate = e.Alpha*rate + (1-e.Alpha)*e.rate
+	}
+	e.sampleTime, e.sampleValue = now, current
+}
+
+// Rate implements Estimator.
+func (e *EWMAEstimator) Rate() float64 {
+	return e.rate
+}
+
+// Skip leaves the time d out of the next sample.
+func (e *EWMAEstimator) Skip(d time.Duration) {
+	if !e.sampleTime.IsZero() {
+		e.sampleTime = e.sampleTime.Add(d)
+	}
+}
+
+// AverageEstimator estimates the rate as the average since the first
+// observation, steady but slow to follow changes in throughput.
+type AverageEstimator struct {
+	start      time.Time
+	startValue int
+	rate       float64
+}
+
+// Observe implements Estimator.
+func (e *AverageEstimator) Observe(now time.Time, current int) {
+	if e.start.IsZero() {
+		e.start, e.startValue = now, current
+		return
+	}
+	if elapsed := now.Sub(e.start); elapsed > 0 {
+		e.rate = float64(current-e.startValue) / elapsed.Seconds()
+	}
+}
+
+// Rate implements Estimator.
+func (e *AverageEstimator) Rate() float64 {
+	return e.rate
+}
+
+// Skip leaves the time d out of the average.
+func (e *AverageEstimator) Skip(d time.Duration) {
+	if !e.start.IsZero() {
+		e.start = e.start.Add(d)
+	}
+}
+
+// sampleRate gives the progress to the estimator and caches its rate.
+// Callers hold p.mu.
+func (p *Progress) sampleRate(now time.Time) {
+	p.estimator.Observe(now, p.current)
+	p.rate = p.estimator.Rate()
+}
+
+// Rate returns the estimated throughput in units per second.
+func (p *Progress) Rate() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rate
+}
+
+// eta returns the estimated time left, or 0 while unknown. Until the
+// estimator knows the rate, the average since the start is used. Callers
+// hold p.mu.
+func (p *Progress) eta(now time.Time) time.Duration {
+	if p.total <= 0 || !p.isStarted {
+		return 0
+	}
+	rate := p.rate
+	if elapsed := p.elapsed(now); rate <= 0 && p.current > 0 && elapsed > 0 {
+		rate = float64(p.current) / elapsed.Seconds()
+	}
+	if rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(p.total-p.current) / rate * float64(time.Second))
+}
@@ -0,0 +1,57 @@
+package progress
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEstimators(t *testing.T) {
+	start := time.Now()
+	samples := []struct {
+		at      time.Duration
+		current int
+	}{
+		{0, 0},
+		{time.Second, 100},     // 100/s
+		{2 * time.Second, 200}, // 100/s
+		{3 * time.Second, 600}, // 400/s
+	}
+
+	ewma := NewEWMAEstimator(0.5)
+	avg := &AverageEstimator{}
+	for _, s := range samples {
+		ewma.Observe(start.Add(s.at), s.current)
+		avg.Observe(start.Add(s.at), s.current)
+	}
+	if got := ewma.Rate(); math.Abs(got-250) > 1e-9 {
+		t.Errorf("expected the EWMA to follow the speed-up to 250/s, got %v", got)
+	}
+	if got := avg.Rate(); math.Abs(got-200) > 1e-9 {
+		t.Errorf("expected the average of 200/s, got %v", got)
+	}
+}
+
+// fixedEstimator always estimates rate.
+type fixedEstimator struct{ rate float64 }
+
+func (e fixedEstimator) Observe(time.Time, int) {}
+func (e fixedEstimator) Rate() float64          { return e.rate }
+
+func TestProgressEstimator(t *testing.T) {
+	p := NewProgressBuilder().
+		Total(1000).
+		Estimator(func() Estimator { return fixedEstimator{rate: 50} }).
+		DetectTTY(noTTY).
+		Build()
+	p.Set(500)
+	if got := p.Rate(); got != 50 {
+		t.Errorf("expected the rate of the estimator, got %v", got)
+	}
+	p.mu.Lock()
+	eta := p.eta(time.Now())
+	p.mu.Unlock()
+	if eta != 10*time.Second {
+		t.Errorf("expected 500 left at 50/s to take 10s, got %v", eta)
+	}
+}
@@ -0,0 +1,66 @@
+package progress
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventMode selects when a bar reports its progress as JSON lines instead
+// of drawing itself.
+type EventMode int
+
+const (
+	EventsOff    EventMode = iota // Draw the bar
+	EventsNonTTY                  // Report events when the output is not a terminal, e.g. in CI
+	EventsAlways                  // Always report events
+)
+
+// ProgressEvent is a progress report of a bar in events mode, written as
+// one JSON line:
+//
+//	{"label":"build","current":42,"total":100,"percent":42,"rate":3.5,"elapsed_ms":12000,"eta_ms":16571,"done":false}
+//
+// Rate is in units per second; ETA is left out while unknown and Total
+// while the total is unknown.
+type ProgressEvent struct {
+	Label     string  `json:"label"`
+	Current   int     `json:"current"`
+	Total     int     `json:"total,omitempty"`
+	Percent   float64 `json:"percent"`
+	Rate      float64 `json:"rate"`
+	ElapsedMS int64   `json:"elapsed_ms"`
+	ETAMS     int64   `json:"eta_ms,omitempty"`
+	Paused    bool    `json:"paused,omitempty"`
+	Done      bool    `json:"done"`
+}
+
+// event returns the report of the bar by now. Callers hold p.mu.
+func (p *Progress) event(now time.Time) ProgressEvent {
+	return ProgressEvent{
+		Label:     p.label,
+		Current:   p.current,
+		Total:     max(p.total, 0),
+		Percent:   float64(int(p.fraction()*1000)) / 10,
+		Rate:      p.rate,
+		ElapsedMS: p.elapsed(now).Milliseconds(),
+		ETAMS:     p.eta(now).Milliseconds(),
+		Paused:    p.paused,
+		Done:      p.finished || p.total > 0 && p.current >= p.total,
+	}
+}
+
+// emitEvent writes the report of the bar, unless one was written less
+// than the event interval ago. The first report and the one of a done bar
+// are always written. Callers hold p.mu.
+func (p *Progress) emitEvent(now time.Time) {
+	e := p.event(now)
+	if !p.emitted.IsZero() && !e.Done && now.Sub(p.emitted) < p.eventInterval {
+		return
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	p.emitted = now
+	p.out.Write(append(line, '\n'))
+}
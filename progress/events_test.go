@@ -0,0 +1,60 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func decodeEvents(t *testing.T, out string) []ProgressEvent {
+	t.Helper()
+	var events []ProgressEvent
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e ProgressEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid event %q: %v", line, err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestProgressEvents(t *testing.T) {
+	var out bytes.Buffer
+	p := NewProgressBuilder().
+		Total(200).
+		Label("build").
+		Events(EventsNonTTY, time.Hour).
+		DetectTTY(noTTY).
+		Build()
+	p.out = &out
+
+	p.Set(50)
+	p.Add(50) // Within the interval
+	if got := p.Render(); got != "" {
+		t.Errorf("expected nothing drawn in events mode, got %q", got)
+	}
+	p.Finish()
+
+	events := decodeEvents(t, out.String())
+	if len(events) != 2 {
+		t.Fatalf("expected the first and the final event, got %+v", events)
+	}
+	if e := events[0]; e.Label != "build" || e.Current != 50 || e.Total != 200 || e.Percent != 25 || e.Done {
+		t.Errorf("unexpected first event %+v", e)
+	}
+	if e := events[1]; e.Current != 200 || e.Percent != 100 || !e.Done || e.ETAMS != 0 {
+		t.Errorf("unexpected final event %+v", e)
+	}
+}
+
+func TestProgressEventsOnTTY(t *testing.T) {
+	var out bytes.Buffer
+	p := newProgress(ProgressConfig{Total: 10, Label: "build", Writer: &out, Events: EventsNonTTY, DetectTTY: tty})
+	p.Set(5)
+	if out.Len() != 0 || p.Render() == "" {
+		t.Errorf("expected the bar drawn in a terminal, got events %q", out.String())
+	}
+}
@@ -0,0 +1,118 @@
+package progress
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/runfx"
+	"github.com/garaekz/tfx/writer"
+)
+
+// FlowBarsConfig defines options for FlowBars.
+type FlowBarsConfig struct {
+	Width     int
+	Theme     ProgressTheme
+	Effect    ProgressEffect
+	Writer    io.Writer // Used only for TTY detection, not direct writes.
+	ShowETA   bool
+	DetectTTY func() runfx.TTYInfo
+}
+
+// DefaultFlowBarsConfig returns sensible defaults.
+func DefaultFlowBarsConfig() FlowBarsConfig {
+	return FlowBarsConfig{
+		Width:     40,
+		DetectTTY: runfx.DetectTTY,
+	}
+}
+
+// FlowBars renders one progress bar per flow step. It implements
+// flowfx.FlowReporter, so it can be passed to a Sequence or Parallel, and
+// runfx.Visual, so it can be mounted on a loop:
+//
+//	bars := progress.NewFlowBars()
+//	unmount, _ := loop.Mount(bars)
+//	defer unmount()
+//	err := flowfx.NewParallelBuilder().Reporter(bars).Func("a", a).Func("b", b).Run(ctx)
+type FlowBars struct {
+	cfg   FlowBarsConfig
+	bars  map[string]*Progress
+	order []string
+	mu    sync.Mutex
+}
+
+// NewFlowBars creates an empty multi-bar view.
+// opts Type: any = Option[FlowBarsConfig] | FlowBarsConfig
+func NewFlowBars(opts ...any) *FlowBars {
+	cfg := share.OverloadWithOptions(opts, DefaultFlowBarsConfig())
+	return &FlowBars{cfg: cfg, bars: make(map[string]*Progress)}
+}
+
+// bar returns the bar for step, creating it on first use.
+func (f *FlowBars) bar(flow, step string) *Progress {
+	key := flow + "/" + step
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p, ok := f.bars[key]; ok {
+		return p
+	}
+	p := newProgress(ProgressConfig{
+		Total:     100,
+		Label:     step,
+		Width:     f.cfg.Width,
+		Theme:     f.cfg.Theme,
+		Effect:    f.cfg.Effect,
+		Writer:    f.cfg.Writer,
+		ShowETA:   f.cfg.ShowETA,
+		DetectTTY: f.cfg.DetectTTY,
+	})
+	f.bars[key] = p
+	f.order = append(f.order, key)
+	return p
+}
+
+// StepStarted implements flowfx.FlowReporter.
+func (f *FlowBars) StepStarted(flow, step string) {
+	p := f.bar(flow, step)
+	p.SetLabel(step)
+	p.Set(0)
+}
+
+// StepProgress implements flowfx.FlowReporter.
+func (f *FlowBars) StepProgress(flow, step string, percent float64) {
+	f.bar(flow, step).Set(int(percent * 100))
+}
+
+// StepDone implements flowfx.FlowReporter. Failed steps keep their progress
+// and are labeled as failed.
+func (f *FlowBars) StepDone(flow, step string, err error) {
+	p := f.bar(flow, step)
+	if err != nil {
+		p.SetLabel(step + " (failed)")
+		return
+	}
+	p.Finish()
+}
+
+// Render implements runfx.Visual, drawing one line per started step.
+func (f *FlowBars) Render(w writer.Writer) {
+	f.mu.Lock()
+	bars := make([]*Progress, len(f.order))
+	for i, key := range f.order {
+		bars[i] = f.bars[key]
+	}
+	f.mu.Unlock()
+
+	for _, p := range bars {
+		w.Write([]byte(p.draw(true) + "\n"))
+	}
+}
+
+// Tick implements runfx.Visual.
+func (f *FlowBars) Tick(now time.Time) {}
+
+// OnResize implements runfx.Visual.
+func (f *FlowBars) OnResize(cols, rows int) {}
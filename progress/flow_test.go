@@ -0,0 +1,51 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/flowfx"
+	"github.com/garaekz/tfx/runfx"
+)
+
+// flushBuffer adapts bytes.Buffer to writer.Writer.
+type flushBuffer struct{ bytes.Buffer }
+
+func (b *flushBuffer) Flush() error { return nil }
+
+func TestFlowBarsReportsSteps(t *testing.T) {
+	bars := NewFlowBars(FlowBarsConfig{
+		Width:     10,
+		DetectTTY: func() runfx.TTYInfo { return runfx.TTYInfo{} },
+	})
+
+	seq := flowfx.NewSequenceBuilder().
+		Reporter(bars).
+		Func("fetch", func(ctx context.Context) error {
+			flowfx.ReportProgress(ctx, 0.5)
+			return nil
+		}).
+		Task(flowfx.NewTask("build", func(ctx context.Context) error {
+			return errors.New("boom")
+		}, flowfx.WithRetry(flowfx.RetryConfig{MaxAttempts: 1}))).
+		Build()
+	if err := seq.Run(context.Background()); err == nil {
+		t.Fatal("expected the build step to fail")
+	}
+
+	var buf flushBuffer
+	bars.Render(&buf)
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 bars, got %q", buf.String())
+	}
+	if lines[0] != "fetch 100%" {
+		t.Errorf("expected finished fetch bar, got %q", lines[0])
+	}
+	if lines[1] != "build (failed)   0%" {
+		t.Errorf("expected failed build bar, got %q", lines[1])
+	}
+}
@@ -0,0 +1,77 @@
+package progress
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpinnerFrames is a set of spinner frames and the time each is shown.
+type SpinnerFrames struct {
+	Frames   []string
+	Interval time.Duration // Zero advances a frame on every tick
+}
+
+// Names of the built-in frame sets, see SpinnerConfig.Preset.
+const (
+	FramesDots        = "dots"
+	FramesDots2       = "dots2"
+	FramesDots3       = "dots3"
+	FramesLine        = "line"
+	FramesMoon        = "moon"
+	FramesClock       = "clock"
+	FramesBouncingBar = "bouncingBar"
+	FramesArrows      = "arrows"
+)
+
+var (
+	framesMu  sync.RWMutex
+	frameSets = map[string]SpinnerFrames{
+		FramesDots:  {strings.Split("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏", ""), 80 * time.Millisecond},
+		FramesDots2: {strings.Split("⣾⣽⣻⢿⡿⣟⣯⣷", ""), 80 * time.Millisecond},
+		FramesDots3: {strings.Split("⠋⠙⠚⠞⠖⠦⠴⠲⠳⠓", ""), 80 * time.Millisecond},
+		FramesLine:  {[]string{"|", "/", "-", "\\"}, 130 * time.Millisecond},
+		FramesMoon:  {strings.Split("🌑🌒🌓🌔🌕🌖🌗🌘", ""), 80 * time.Millisecond},
+		FramesClock: {strings.Split("🕛🕐🕑🕒🕓🕔🕕🕖🕗🕘🕙🕚", ""), 100 * time.Millisecond},
+		FramesBouncingBar: {[]string{
+			"[    ]", "[=   ]", "[==  ]", "[=== ]", "[ ===]", "[  ==]",
+			"[   =]", "[    ]", "[   =]", "[  ==]", "[ ===]", "[====]",
+			"[=== ]", "[==  ]", "[=   ]",
+		}, 80 * time.Millisecond},
+		FramesArrows: {strings.Split("←↖↑↗→↘↓↙", ""), 100 * time.Millisecond},
+	}
+)
+
+// RegisterFrames registers frames, shown interval apart, as the frame set
+// name for SpinnerConfig.Preset. Registering a name again replaces it,
+// built-in ones included. Empty frames are ignored.
+func RegisterFrames(name string, frames []string, interval time.Duration) {
+	if len(frames) == 0 {
+		return
+	}
+	framesMu.Lock()
+	defer framesMu.Unlock()
+	frameSets[name] = SpinnerFrames{Frames: slices.Clone(frames), Interval: interval}
+}
+
+// LookupFrames returns the frame set name.
+func LookupFrames(name string) (SpinnerFrames, bool) {
+	framesMu.RLock()
+	defer framesMu.RUnlock()
+	set, ok := frameSets[name]
+	set.Frames = slices.Clone(set.Frames)
+	return set, ok
+}
+
+// FrameNames returns the names of the registered frame sets, sorted.
+func FrameNames() []string {
+	framesMu.RLock()
+	defer framesMu.RUnlock()
+	names := make([]string, 0, len(frameSets))
+	for name := range frameSets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
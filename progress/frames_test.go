@@ -0,0 +1,47 @@
+package progress
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFrameCatalog(t *testing.T) {
+	names := FrameNames()
+	for _, name := range []string{FramesDots, FramesDots2, FramesDots3, FramesLine, FramesMoon, FramesClock, FramesBouncingBar, FramesArrows} {
+		set, ok := LookupFrames(name)
+		if !ok || len(set.Frames) < 2 || set.Interval <= 0 {
+			t.Errorf("expected the built-in frame set %q, got %+v", name, set)
+		}
+		if !slices.Contains(names, name) {
+			t.Errorf("expected %q among the frame names %v", name, names)
+		}
+	}
+	if set, _ := LookupFrames(FramesMoon); set.Frames[0] != "🌑" || len(set.Frames) != 8 {
+		t.Errorf("expected the moon phases split by rune, got %q", set.Frames)
+	}
+}
+
+func TestRegisterFrames(t *testing.T) {
+	RegisterFrames("test-pulse", []string{".", "o", "O"}, time.Hour)
+	defer func() {
+		framesMu.Lock()
+		delete(frameSets, "test-pulse")
+		framesMu.Unlock()
+	}()
+
+	s := NewSpinnerBuilder().Label("wait").Preset("test-pulse").DetectTTY(noTTY).Build()
+	if !slices.Equal(s.frames, []string{".", "o", "O"}) || s.interval != time.Hour {
+		t.Fatalf("expected the registered frames, got %q every %v", s.frames, s.interval)
+	}
+	s.Tick()
+	s.Tick() // Within the interval
+	if s.index != 1 {
+		t.Errorf("expected one frame advanced within the interval, got %d", s.index)
+	}
+
+	unknown := StartSpinner(SpinnerConfig{Preset: "missing", DetectTTY: noTTY})
+	if !slices.Equal(unknown.frames, DefaultSpinnerConfig().Frames) {
+		t.Errorf("expected an unknown preset to keep the default frames, got %q", unknown.frames)
+	}
+}
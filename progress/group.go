@@ -0,0 +1,177 @@
+package progress
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/runfx"
+	"github.com/garaekz/tfx/writer"
+)
+
+// GroupItem is a line of a Group, such as a *Progress or a *Spinner. Items
+// with a Done() bool method count as done when it returns true; others
+// stay active until removed. Items with a Tick() method are ticked with
+// the group.
+type GroupItem interface {
+	Render() string
+}
+
+// GroupConfig defines options for a Group.
+type GroupConfig struct {
+	Header      bool // Draw a "N done / M total" line above the items
+	ActiveFirst bool // Draw active items above done ones
+	HideDone    bool // Leave done items out, still counting them in the header
+}
+
+// DefaultGroupConfig returns sensible defaults.
+func DefaultGroupConfig() GroupConfig {
+	return GroupConfig{
+		Header:      true,
+		ActiveFirst: true,
+	}
+}
+
+// Group stacks many concurrent bars and spinners, added and removed while
+// it is mounted on a loop:
+//
+//	group := progress.NewGroup()
+//	unmount, _ := loop.Mount(group)
+//	defer unmount()
+//	for _, file := range files {
+//		bar := group.AddBar(progress.ProgressConfig{Total: file.Size, Label: file.Name})
+//		go download(file, bar)
+//	}
+type Group struct {
+	cfg   GroupConfig
+	items []GroupItem
+	mu    sync.Mutex
+}
+
+// NewGroup creates an empty Group.
+// opts Type: any = Option[GroupConfig] | GroupConfig
+func NewGroup(opts ...any) *Group {
+	cfg := share.OverloadWithOptions(opts, DefaultGroupConfig())
+	return &Group{cfg: cfg}
+}
+
+// Add appends item to the group.
+func (g *Group) Add(item GroupItem) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.items = append(g.items, item)
+}
+
+// AddBar creates a bar as Start does and adds it.
+func (g *Group) AddBar(opts ...any) *Progress {
+	p := Start(opts...)
+	g.Add(p)
+	return p
+}
+
+// AddSpinner creates a spinner as StartSpinner does and adds it.
+func (g *Group) AddSpinner(opts ...any) *Spinner {
+	s := StartSpinner(opts...)
+	g.Add(s)
+	return s
+}
+
+// Remove takes item out of the group.
+func (g *Group) Remove(item GroupItem) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.items = slices.DeleteFunc(g.items, func(i GroupItem) bool { return i == item })
+}
+
+// Len returns the number of items in the group.
+func (g *Group) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.items)
+}
+
+// drawer is implemented by the bars, spinners and parents of this package.
+type drawer interface {
+	draw(tty bool) string
+}
+
+// drawItem renders item for the loop the group is mounted on. The items of
+// this package draw for the TTY even though the loop owns it, since the
+// loop is what draws them.
+func drawItem(item GroupItem) string {
+	if d, ok := item.(drawer); ok {
+		return d.draw(true)
+	}
+	return item.Render()
+}
+
+// itemDone reports whether item is done.
+func itemDone(item GroupItem) bool {
+	d, ok := item.(interface{ Done() bool })
+	return ok && d.Done()
+}
+
+// Render implements runfx.Visual, drawing the header and one line per item.
+func (g *Group) Render(w writer.Writer) {
+	g.mu.Lock()
+	items := slices.Clone(g.items)
+	cfg := g.cfg
+	g.mu.Unlock()
+
+	type row struct {
+		item GroupItem
+		done bool
+	}
+	rows := make([]row, len(items))
+	n := 0
+	for i, item := range items {
+		rows[i] = row{item, itemDone(item)}
+		if rows[i].done {
+			n++
+		}
+	}
+	if cfg.ActiveFirst {
+		slices.SortStableFunc(rows, func(a, b row) int {
+			switch {
+			case a.done == b.done:
+				return 0
+			case b.done:
+				return -1
+			default:
+				return 1
+			}
+		})
+	}
+
+	if cfg.Header {
+		fmt.Fprintf(w, "%d done / %d total\n", n, len(items))
+	}
+	for _, r := range rows {
+		if cfg.HideDone && r.done {
+			continue
+		}
+		if line := drawItem(r.item); line != "" { // Bars draw nothing until started
+			w.Write([]byte(line + "\n"))
+		}
+	}
+}
+
+// Tick implements runfx.Visual, advancing the spinners and animated bars of
+// the group.
+func (g *Group) Tick(now time.Time) {
+	g.mu.Lock()
+	items := slices.Clone(g.items)
+	g.mu.Unlock()
+	for _, item := range items {
+		if t, ok := item.(interface{ Tick() }); ok {
+			t.Tick()
+		}
+	}
+}
+
+// OnResize implements runfx.Visual.
+func (g *Group) OnResize(cols, rows int) {}
+
+var _ runfx.Visual = (*Group)(nil)
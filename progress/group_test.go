@@ -0,0 +1,52 @@
+package progress
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/runfx"
+)
+
+func noTTY() runfx.TTYInfo { return runfx.TTYInfo{} }
+
+func renderGroup(g *Group) []string {
+	var buf flushBuffer
+	g.Render(&buf)
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
+
+func TestGroupStacksItemsActiveFirst(t *testing.T) {
+	g := NewGroup()
+	a := g.AddBar(ProgressConfig{Total: 10, Label: "a", Width: 10, DetectTTY: noTTY})
+	b := g.AddBar(ProgressConfig{Total: 10, Label: "b", Width: 10, DetectTTY: noTTY})
+	s := g.AddSpinner(SpinnerConfig{Label: "resolving", Frames: []string{"-"}, DetectTTY: noTTY})
+	a.Finish()
+	b.Set(5)
+
+	want := []string{"1 done / 3 total", "b  50%", "resolving", "a 100%"}
+	if got := renderGroup(g); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	g.Remove(s)
+	b.Finish()
+	want = []string{"2 done / 2 total", "a 100%", "b 100%"}
+	if got := renderGroup(g); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("after removal got %q, want %q", got, want)
+	}
+}
+
+func TestGroupHideDoneWithoutHeader(t *testing.T) {
+	g := NewGroup(GroupConfig{HideDone: true})
+	a := g.AddBar(ProgressConfig{Total: 2, Label: "a", Width: 10, DetectTTY: noTTY})
+	g.AddBar(ProgressConfig{Total: 2, Label: "b", Width: 10, DetectTTY: noTTY}).Set(1)
+	g.AddBar(ProgressConfig{Total: 2, Label: "pending", Width: 10, DetectTTY: noTTY})
+	a.Finish()
+
+	if got := renderGroup(g); len(got) != 1 || got[0] != "b  50%" {
+		t.Errorf("expected only the active bar, got %q", got)
+	}
+	if g.Len() != 3 {
+		t.Errorf("expected hidden items kept, got %d", g.Len())
+	}
+}
@@ -0,0 +1,107 @@
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/terminal"
+)
+
+// IndeterminateStyle selects the animation of a bar whose total is unknown.
+type IndeterminateStyle int
+
+const (
+	IndeterminateBounce  IndeterminateStyle = iota // A block sliding back and forth
+	IndeterminateMarquee                           // A block sliding right, wrapping around
+	IndeterminateBlocks                            // A wave of rising and falling blocks
+)
+
+// waveBlocks are the cells of IndeterminateBlocks, from empty to full.
+var waveBlocks = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃", "▂"}
+
+// indeterminateCells returns the glyphs of frame of the animation, one per
+// cell of a bar of width cells.
+func indeterminateCells(style IndeterminateStyle, frame, width int) []string {
+	cells := make([]string, width)
+	if width <= 0 {
+		return cells
+	}
+	if style == IndeterminateBlocks {
+		for i := range cells {
+			cells[i] = waveBlocks[(i+frame)%len(waveBlocks)]
+		}
+		return cells
+	}
+
+	size := max(1, width/4)
+	span := width - size // Positions the block can start at
+	for i := range cells {
+		cells[i] = "░"
+	}
+	switch style {
+	case IndeterminateMarquee:
+		for i := range size {
+			cells[(frame+i)%width] = "█"
+		}
+	default:
+		pos := 0
+		if span > 0 {
+			pos = frame % (2 * span)
+			if pos > span {
+				pos = 2*span - pos
+			}
+		}
+		for i := range size {
+			cells[pos+i] = "█"
+		}
+	}
+	return cells
+}
+
+// renderIndeterminate draws frame of the animation in theme colors, the
+// sliding block of the bounce and marquee styles with glyphs.
+func (pt ProgressTheme) renderIndeterminate(
+	style IndeterminateStyle,
+	frame, width int,
+	glyphs BarGlyphs,
+	detector *terminal.Detector,
+) string {
+	completeColor := pt.RenderColor(pt.CompleteColor, detector)
+	incompleteColor := pt.RenderColor(pt.IncompleteColor, detector)
+
+	var bar strings.Builder
+	for _, cell := range indeterminateCells(style, frame, width) {
+		switch {
+		case style == IndeterminateBlocks:
+			bar.WriteString(completeColor + cell + color.Reset)
+		case cell == "░":
+			bar.WriteString(incompleteColor + glyphs.Empty + color.Reset)
+		default:
+			bar.WriteString(completeColor + glyphs.Fill + color.Reset)
+		}
+	}
+	return bar.String()
+}
+
+// formatElapsed writes the time a bar has been running, e.g. "42s" or
+// "3m07s".
+func formatElapsed(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	if d < time.Minute {
+		return d.String()
+	}
+	minutes := strings.TrimSuffix(d.Truncate(time.Minute).String(), "0s")
+	return fmt.Sprintf("%s%02ds", minutes, int(d%time.Minute/time.Second))
+}
+
+// Tick advances the animation of a bar whose total is unknown, unless it
+// is paused.
+func (p *Progress) Tick() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.paused {
+		p.frame++
+	}
+}
@@ -0,0 +1,73 @@
+package progress
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/runfx"
+)
+
+func tty() runfx.TTYInfo { return runfx.TTYInfo{IsTTY: true, ANSI: true} }
+
+var ansiCodes = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func TestIndeterminateCells(t *testing.T) {
+	tests := []struct {
+		style IndeterminateStyle
+		frame int
+		want  string
+	}{
+		{IndeterminateBounce, 0, "██░░░░░░"},
+		{IndeterminateBounce, 6, "░░░░░░██"},
+		{IndeterminateBounce, 8, "░░░░██░░"},
+		{IndeterminateBounce, 12, "██░░░░░░"},
+		{IndeterminateMarquee, 7, "█░░░░░░█"},
+		{IndeterminateMarquee, 8, "██░░░░░░"},
+		{IndeterminateBlocks, 0, "▁▂▃▄▅▆▇█"},
+		{IndeterminateBlocks, 1, "▂▃▄▅▆▇█▇"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(indeterminateCells(tt.style, tt.frame, 8), ""); got != tt.want {
+			t.Errorf("style %d frame %d: got %q, want %q", tt.style, tt.frame, got, tt.want)
+		}
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	tests := map[time.Duration]string{
+		1500 * time.Millisecond:       "1s",
+		42 * time.Second:              "42s",
+		3*time.Minute + 7*time.Second: "3m07s",
+		time.Hour + 5*time.Second:     "1h0m05s",
+	}
+	for d, want := range tests {
+		if got := formatElapsed(d); got != want {
+			t.Errorf("formatElapsed(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestProgressIndeterminate(t *testing.T) {
+	p := newProgress(ProgressConfig{Label: "scan", Width: 8, DetectTTY: tty})
+	p.Add(3)
+	p.Tick()
+	if got := ansiCodes.ReplaceAllString(p.Render(), ""); got != "\rscan [░██░░░░░] 0s 3" {
+		t.Errorf("unexpected indeterminate render %q", got)
+	}
+	if p.Done() {
+		t.Error("expected a bar of unknown total not done")
+	}
+
+	p.SetTotal(6)
+	if got := ansiCodes.ReplaceAllString(p.Render(), ""); got != "\rscan [████░░░░]  50%" {
+		t.Errorf("expected a regular bar once the total is set, got %q", got)
+	}
+
+	empty := newProgress(ProgressConfig{Label: "empty", DetectTTY: noTTY})
+	empty.Finish()
+	if !empty.Done() {
+		t.Error("expected a finished bar of unknown total done")
+	}
+}
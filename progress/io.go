@@ -0,0 +1,105 @@
+package progress
+
+import (
+	"io"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// byteConfig returns the bar configuration of a Reader or Writer: opts over
+// the defaults, counting total bytes in IEC units unless set otherwise.
+func byteConfig(label string, total int64, opts []any) ProgressConfig {
+	def := DefaultProgressConfig()
+	def.Label = label
+	def.Total = int(total)
+	def.Units = UnitsIEC
+	cfg := share.OverloadWithOptions(opts, def)
+	if cfg.Units == UnitsNone {
+		cfg.Units = UnitsIEC
+	}
+	return cfg
+}
+
+// Reader reports the bytes read through it on a bar:
+//
+//	body := progress.NewReader(resp.Body, resp.ContentLength)
+//	defer body.Close()
+//	group.Add(body.Bar())
+//	_, err := io.Copy(file, body)
+//
+// The bar finishes when the reader reaches EOF.
+type Reader struct {
+	r   io.Reader
+	bar *Progress
+}
+
+// NewReader wraps r, expecting total bytes; zero or less for an unknown
+// size.
+// opts Type: any = Option[ProgressConfig] | ProgressConfig, whose Total is
+// replaced by total
+func NewReader(r io.Reader, total int64, opts ...any) *Reader {
+	cfg := byteConfig("Reading", total, opts)
+	cfg.Total = int(total)
+	return &Reader{r: r, bar: newProgress(cfg)}
+}
+
+// Read reads from the wrapped reader, advancing the bar.
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.bar.Add(n)
+	if err == io.EOF {
+		r.bar.Finish()
+	}
+	return n, err
+}
+
+// Close closes the wrapped reader if it is an io.Closer.
+func (r *Reader) Close() error {
+	if c, ok := r.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Bar returns the bar of the reader, to render or mount.
+func (r *Reader) Bar() *Progress {
+	return r.bar
+}
+
+// Writer reports the bytes written through it on a bar:
+//
+//	dst := progress.NewWriter(file, progress.ProgressConfig{Total: size, Label: "Copying"})
+//	_, err := io.Copy(dst, src)
+//	dst.Bar().Finish()
+type Writer struct {
+	w   io.Writer
+	bar *Progress
+}
+
+// NewWriter wraps w. The size is unknown unless opts sets Total.
+// opts Type: any = Option[ProgressConfig] | ProgressConfig
+func NewWriter(w io.Writer, opts ...any) *Writer {
+	return &Writer{w: w, bar: newProgress(byteConfig("Writing", 0, opts))}
+}
+
+// Write writes to the wrapped writer, advancing the bar.
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.bar.Add(n)
+	return n, err
+}
+
+// Close closes the wrapped writer if it is an io.Closer and finishes the
+// bar.
+func (w *Writer) Close() error {
+	w.bar.Finish()
+	if c, ok := w.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Bar returns the bar of the writer, to render or mount.
+func (w *Writer) Bar() *Progress {
+	return w.bar
+}
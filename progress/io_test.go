@@ -0,0 +1,58 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func TestReaderAdvancesBar(t *testing.T) {
+	src := strings.NewReader(strings.Repeat("x", 3000))
+	r := NewReader(src, 3000, ProgressConfig{Label: "download", DetectTTY: noTTY})
+
+	buf := make([]byte, 1000)
+	r.Read(buf)
+	if got := r.Bar().Render(); got != "download  33% 1000 B / 2.9 KiB" {
+		t.Errorf("unexpected render %q", got)
+	}
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Bar().Done() {
+		t.Error("expected the bar finished at EOF")
+	}
+}
+
+func TestReaderUnknownSize(t *testing.T) {
+	r := NewReader(strings.NewReader(strings.Repeat("x", 2048)), -1, ProgressConfig{Label: "stream", DetectTTY: noTTY})
+	io.Copy(io.Discard, r)
+	if got := r.Bar().Render(); got != "stream 100% 2 KiB / 2 KiB" || !r.Bar().Done() {
+		t.Errorf("expected the total taken at EOF, got %q", got)
+	}
+
+	partial := NewReader(strings.NewReader(strings.Repeat("x", 2048)), 0, ProgressConfig{Label: "stream", DetectTTY: noTTY})
+	partial.Read(make([]byte, 1024))
+	if got := partial.Bar().Render(); got != "stream 1 KiB" {
+		t.Errorf("expected only the count before EOF, got %q", got)
+	}
+}
+
+func TestWriterAdvancesBar(t *testing.T) {
+	var dst bytes.Buffer
+	w := NewWriter(&dst, share.Option[ProgressConfig](func(c *ProgressConfig) {
+		c.Total = 10
+		c.Label = "copy"
+		c.DetectTTY = noTTY
+	}))
+	w.Write([]byte("hello"))
+	if got := w.Bar().Render(); got != "copy  50% 5 B / 10 B" || dst.String() != "hello" {
+		t.Errorf("unexpected render %q of %q", got, dst.String())
+	}
+	w.Close()
+	if !w.Bar().Done() {
+		t.Error("expected Close to finish the bar")
+	}
+}
@@ -0,0 +1,50 @@
+package progress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressPause(t *testing.T) {
+	p := newProgress(ProgressConfig{Total: 10, Label: "copy", DetectTTY: noTTY})
+	p.Set(5)
+	p.Pause()
+	if !p.Paused() {
+		t.Fatal("expected the bar paused")
+	}
+	if got := p.Render(); got != "copy  50% (paused)" {
+		t.Errorf("unexpected paused render %q", got)
+	}
+
+	now := time.Now()
+	p.mu.Lock()
+	p.startTime = now.Add(-2 * time.Hour) // Ran an hour, paused an hour
+	p.pausedAt = now.Add(-time.Hour)
+	frozen := p.elapsed(now.Add(time.Hour))
+	p.mu.Unlock()
+	if frozen != time.Hour {
+		t.Errorf("expected the elapsed time frozen at 1h while paused, got %v", frozen)
+	}
+
+	p.Resume()
+	p.mu.Lock()
+	elapsed := p.elapsed(time.Now())
+	p.mu.Unlock()
+	if elapsed < time.Hour || elapsed > time.Hour+time.Minute {
+		t.Errorf("expected the pause not counted as elapsed, got %v", elapsed)
+	}
+	if got := p.Render(); got != "copy  50%" {
+		t.Errorf("unexpected resumed render %q", got)
+	}
+}
+
+func TestEstimatorSkip(t *testing.T) {
+	start := time.Now()
+	e := NewEWMAEstimator(1)
+	e.Observe(start, 0)
+	e.Skip(time.Minute)
+	e.Observe(start.Add(time.Minute+time.Second), 100)
+	if got := e.Rate(); got != 100 {
+		t.Errorf("expected the skipped minute left out of the rate, got %v", got)
+	}
+}
@@ -0,0 +1,103 @@
+package progress
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/garaekz/tfx/writer"
+)
+
+// clearLine erases the terminal line the cursor is on.
+const clearLine = "\r\x1b[2K"
+
+// Println prints a line above the bar, formatted as fmt.Println does. In a
+// terminal the bar line is cleared, the line printed and the bar redrawn
+// below it, so output and progress interleave without garbling each
+// other:
+//
+//	for _, file := range files {
+//		if err := copyFile(file); err != nil {
+//			bar.Println("skipped", file, err)
+//		}
+//		bar.Add(1)
+//		fmt.Print(bar.Render())
+//	}
+//
+// While a runfx loop with a log region draws on the output, the line goes
+// to that region instead.
+func (p *Progress) Println(args ...any) {
+	p.printLines(fmt.Sprintln(args...))
+}
+
+// Printf prints a line above the bar as Println does, formatted as
+// fmt.Printf does. A newline is added if missing.
+func (p *Progress) Printf(format string, args ...any) {
+	text := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	p.printLines(text)
+}
+
+// printLines writes text, whole lines, above the bar.
+func (p *Progress) printLines(text string) error {
+	if region := writer.Routed(p.out); region != p.out {
+		_, err := io.WriteString(region, text)
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.isTTY || p.events {
+		_, err := io.WriteString(p.out, text)
+		return err
+	}
+	var buf strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line != "" {
+			buf.WriteString(clearLine + line)
+		}
+	}
+	if p.isStarted {
+		buf.WriteString(RenderBar(p, p.detector))
+	}
+	_, err := io.WriteString(p.out, buf.String())
+	return err
+}
+
+// LogWriter returns a writer printing the lines written to it above the
+// bar as Println does, to point a logger at:
+//
+//	log := logfx.New(logfx.WithOutput(bar.LogWriter()))
+//
+// A trailing partial line is held until its newline arrives.
+func (p *Progress) LogWriter() io.Writer {
+	return &barLog{bar: p}
+}
+
+// barLog is the writer returned by Progress.LogWriter.
+type barLog struct {
+	bar     *Progress
+	mu      sync.Mutex
+	partial []byte
+}
+
+// Write prints the complete lines of partial and b above the bar.
+func (w *barLog) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.partial = append(w.partial, b...)
+	i := bytes.LastIndexByte(w.partial, '\n')
+	if i < 0 {
+		return len(b), nil
+	}
+	text := string(w.partial[:i+1])
+	w.partial = append(w.partial[:0], w.partial[i+1:]...)
+	if err := w.bar.printLines(text); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
@@ -0,0 +1,58 @@
+package progress
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/garaekz/tfx/writer"
+)
+
+func TestProgressPrintln(t *testing.T) {
+	var out bytes.Buffer
+	p := newProgress(ProgressConfig{Total: 4, Label: "sync", Width: 4, Style: ProgressStyleAscii, Writer: &out, DetectTTY: tty})
+	p.Set(2)
+	p.Println("skipped", "a.txt")
+
+	got := ansiCodes.ReplaceAllString(out.String(), "")
+	if want := "\r\x1b[2Kskipped a.txt\n\rsync [==--]  50%"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestProgressPrintlnPlain(t *testing.T) {
+	var out bytes.Buffer
+	p := newProgress(ProgressConfig{Total: 4, Label: "sync", Writer: &out, DetectTTY: noTTY})
+	p.Set(2)
+	p.Printf("done %d", 2)
+	if got := out.String(); got != "done 2\n" {
+		t.Errorf("expected the line alone without a terminal, got %q", got)
+	}
+}
+
+func TestProgressLogWriter(t *testing.T) {
+	var out bytes.Buffer
+	p := newProgress(ProgressConfig{Total: 4, Label: "sync", Writer: &out, DetectTTY: noTTY})
+	w := p.LogWriter()
+	fmt.Fprint(w, "first\nsec")
+	if got := out.String(); got != "first\n" {
+		t.Errorf("expected the partial line held, got %q", got)
+	}
+	fmt.Fprint(w, "ond\n")
+	if got := out.String(); got != "first\nsecond\n" {
+		t.Errorf("expected the line completed, got %q", got)
+	}
+}
+
+func TestProgressPrintlnRouted(t *testing.T) {
+	var out, region bytes.Buffer
+	restore := writer.RouteConsole(&out, &region)
+	defer restore()
+
+	p := newProgress(ProgressConfig{Total: 4, Label: "sync", Writer: &out, DetectTTY: tty})
+	p.Set(1)
+	p.Println("hello")
+	if out.Len() != 0 || region.String() != "hello\n" {
+		t.Errorf("expected the line in the log region of the live display, got %q and %q", out.String(), region.String())
+	}
+}
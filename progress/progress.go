@@ -2,6 +2,8 @@ package progress
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sync"
 	"time"
 
@@ -14,17 +16,38 @@ type Progress struct {
 	total     int
 	current   int
 	label     string
-	startTime time.Time
+	startTime time.Time // Moved forward by the time spent paused
 	isStarted bool
+	paused    bool
+	pausedAt  time.Time
 
 	width    int
 	theme    ProgressTheme
 	style    ProgressStyle
+	glyphs   BarGlyphs
+	brackets BracketStyle
+	percent  PercentPlacement
 	effect   ProgressEffect
 	detector *terminal.Detector
+	out      io.Writer // Where Println prints
 	ShowETA  bool
 	isTTY    bool
 
+	template     []templatePart
+	placeholders map[string]PlaceholderFunc
+
+	events        bool // Report JSON lines instead of drawing
+	eventInterval time.Duration
+	emitted       time.Time // When the last event was written
+
+	indeterminate IndeterminateStyle
+	frame         int  // Animation frame while the total is unknown
+	finished      bool // Finish was called
+
+	units     ByteUnits
+	estimator Estimator
+	rate      float64 // Estimated throughput per second
+
 	mu sync.Mutex
 }
 
@@ -35,6 +58,34 @@ func newProgress(cfg ProgressConfig) *Progress {
 		detect = runfx.DetectTTY
 	}
 	tty := detect()
+	out := cfg.Writer
+	if out == nil {
+		out = os.Stdout
+	}
+	glyphs := cfg.Glyphs
+	if glyphs.Fill == "" {
+		glyphs = cfg.Style.Glyphs()
+	}
+	if glyphs.Empty == "" {
+		glyphs.Empty = " "
+	}
+	var estimator Estimator
+	if cfg.Estimator != nil {
+		estimator = cfg.Estimator()
+	}
+	if estimator == nil {
+		estimator = NewEWMAEstimator(cfg.Smoothing)
+	}
+
+	eventInterval := cfg.EventInterval
+	if eventInterval <= 0 {
+		eventInterval = time.Second
+	}
+
+	var template []templatePart
+	if cfg.Template != "" {
+		template = parseTemplate(cfg.Template)
+	}
 
 	return &Progress{
 		total:    cfg.Total,
@@ -42,31 +93,74 @@ func newProgress(cfg ProgressConfig) *Progress {
 		width:    cfg.Width,
 		theme:    cfg.Theme,
 		style:    cfg.Style,
+		glyphs:   glyphs,
+		brackets: cfg.Brackets,
+		percent:  cfg.Percent,
 		effect:   cfg.Effect,
 		detector: terminal.NewDetector(cfg.Writer),
+		out:      out,
 		ShowETA:  cfg.ShowETA,
 		isTTY:    tty.IsTTY,
+
+		template:     template,
+		placeholders: cfg.Placeholders,
+
+		events:        cfg.Events == EventsAlways || cfg.Events == EventsNonTTY && !tty.IsTTY,
+		eventInterval: eventInterval,
+
+		indeterminate: cfg.Indeterminate,
+
+		units:     cfg.Units,
+		estimator: estimator,
 	}
 }
 
 // Render returns the current progress bar representation.
-// Falls back to plain text when not in a TTY.
+// Falls back to plain text when not in a TTY or while another component,
+// such as a runfx loop, owns the terminal, and returns "" in events mode,
+// see ProgressConfig.Events. Add the bar to a Group to draw it in a loop.
 func (p *Progress) Render() string {
+	return p.draw(!ttyOwned())
+}
+
+// draw renders the bar, as plain text unless tty is set and the bar runs
+// in a TTY.
+func (p *Progress) draw(tty bool) string {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if !p.isStarted {
+	if !p.isStarted || p.events {
 		return ""
 	}
 
-	if !p.isTTY {
-		percent := float64(p.current) / float64(p.total)
-		return fmt.Sprintf("%s %3d%%", p.label, int(percent*100))
+	if !p.isTTY || !tty {
+		var text string
+		if p.total <= 0 {
+			text = p.label + " " + p.byteText()
+		} else {
+			text = fmt.Sprintf("%s %3d%%", p.label, int(p.fraction()*100))
+			if bytes := p.byteText(); bytes != "" {
+				text += " " + bytes
+			}
+		}
+		if p.paused {
+			text += " " + pausedText
+		}
+		return text
 	}
 
 	return RenderBar(p, p.detector)
 }
 
+// fraction returns the completed share of the total, or 0 when the total
+// is unknown. Callers hold p.mu.
+func (p *Progress) fraction() float64 {
+	if p.total <= 0 {
+		return 0
+	}
+	return float64(p.current) / float64(p.total)
+}
+
 // Set updates the progress to the given value.
 func (p *Progress) Set(current int) {
 	p.mu.Lock()
@@ -76,7 +170,17 @@ func (p *Progress) Set(current int) {
 		p.isStarted = true
 		p.startTime = time.Now()
 	}
-	p.current = min(current, p.total)
+	p.current = current
+	if p.total > 0 {
+		p.current = min(current, p.total)
+	}
+	now := time.Now()
+	if !p.paused {
+		p.sampleRate(now)
+	}
+	if p.events {
+		p.emitEvent(now)
+	}
 }
 
 // Add increments progress by the provided amount.
@@ -88,7 +192,17 @@ func (p *Progress) Add(amount int) {
 		p.isStarted = true
 		p.startTime = time.Now()
 	}
-	p.current = min(p.current+amount, p.total)
+	p.current += amount
+	if p.total > 0 {
+		p.current = min(p.current, p.total)
+	}
+	now := time.Now()
+	if !p.paused {
+		p.sampleRate(now)
+	}
+	if p.events {
+		p.emitEvent(now)
+	}
 }
 
 // SetLabel changes the progress label.
@@ -98,7 +212,92 @@ func (p *Progress) SetLabel(label string) {
 	p.label = label
 }
 
-// Finish sets the progress to 100%.
+// SetTotal changes the total amount of work. Zero or less means it is
+// unknown: the bar then animates as set by ProgressConfig.Indeterminate
+// and counts, see ProgressConfig.Units. Setting a total switches it to a
+// regular bar.
+func (p *Progress) SetTotal(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+	if total > 0 {
+		p.current = min(p.current, total)
+	}
+}
+
+// Pause freezes the elapsed time, the ETA and the animation of the bar,
+// which shows it is paused, e.g. while a flow waits for user input. Updates
+// still count.
+func (p *Progress) Pause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.paused {
+		return
+	}
+	p.paused = true
+	p.pausedAt = time.Now()
+}
+
+// Resume continues a paused bar. The time spent paused counts neither as
+// elapsed nor against the rate.
+func (p *Progress) Resume() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.paused {
+		return
+	}
+	p.paused = false
+	d := time.Since(p.pausedAt)
+	p.startTime = p.startTime.Add(d)
+	if s, ok := p.estimator.(interface{ Skip(time.Duration) }); ok {
+		s.Skip(d)
+	}
+}
+
+// Paused reports whether the bar is paused.
+func (p *Progress) Paused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// elapsed returns the time the bar has been running by now, not counting
+// pauses. Callers hold p.mu.
+func (p *Progress) elapsed(now time.Time) time.Duration {
+	if !p.isStarted {
+		return 0
+	}
+	if p.paused {
+		now = p.pausedAt
+	}
+	return now.Sub(p.startTime)
+}
+
+// Finish sets the progress to 100%. A bar of unknown total takes the
+// current count as its total.
 func (p *Progress) Finish() {
-	p.Set(p.total)
+	p.mu.Lock()
+	if p.total <= 0 {
+		p.total = p.current
+	}
+	p.finished = true
+	total := p.total
+	p.mu.Unlock()
+	p.Set(total)
+}
+
+// Done reports whether the progress reached its total. A bar of unknown
+// total is done once finished.
+func (p *Progress) Done() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.isStarted && p.current >= p.total && (p.total > 0 || p.finished)
+}
+
+// ttyOwned reports whether a runfx loop or another component holds the
+// terminal, see runfx.AcquireTTY. Bars and spinners rendered outside of it
+// then draw plain text rather than over its frame.
+func ttyOwned() bool {
+	_, owned := runfx.TTYOwner()
+	return owned
 }
@@ -8,39 +8,93 @@ import (
 	"github.com/garaekz/tfx/terminal"
 )
 
-// RenderBar builds a progress bar string using theme colors.
+// pausedText marks a paused bar.
+const pausedText = "(paused)"
+
+// RenderBar builds a progress bar string using theme colors, laid out by
+// the template of the bar if it has one.
 func RenderBar(p *Progress, detector *terminal.Detector) string {
-	percent := float64(p.current) / float64(p.total)
+	if p.template != nil {
+		return renderTemplate(p, detector)
+	}
+	if p.total <= 0 {
+		return renderIndeterminateBar(p, detector)
+	}
+	percent := p.fraction()
 
 	labelColor := p.theme.RenderColor(p.theme.LabelColor, detector)
 	label := labelColor + p.label + color.Reset
 
-	var bar string
-	if p.theme.EffectEnabled && p.effect != EffectNone {
-		bar = p.theme.RenderProgress(percent, p.width, p.effect, detector)
-	} else {
-		bar = p.theme.renderSolidProgress(int(percent*float64(p.width)), p.width, detector)
-	}
+	bar := p.drawBar(p.width, detector)
 
 	percentColor := p.theme.RenderColor(p.theme.PercentColor, detector)
 	percentText := percentColor + fmt.Sprintf("%3d%%", int(percent*100)) + color.Reset
 
-	borderColor := p.theme.RenderColor(p.theme.BorderColor, detector)
-	leftBorder := borderColor + "[" + color.Reset
-	rightBorder := borderColor + "]" + color.Reset
-
-	result := fmt.Sprintf("\r%s %s%s%s %s", label, leftBorder, bar, rightBorder, percentText)
+	var result string
+	switch p.percent {
+	case PercentLeft:
+		result = fmt.Sprintf("\r%s %s %s", label, percentText, bar)
+	case PercentHidden:
+		result = fmt.Sprintf("\r%s %s", label, bar)
+	default:
+		result = fmt.Sprintf("\r%s %s %s", label, bar, percentText)
+	}
+	if bytes := p.byteText(); bytes != "" {
+		result += " " + percentColor + bytes + color.Reset
+	}
 
-	if p.ShowETA && p.isStarted && p.current > 0 {
-		elapsed := time.Since(p.startTime)
-		rate := float64(p.current) / elapsed.Seconds()
-		if rate > 0 {
-			remaining := float64(p.total-p.current) / rate
+	if p.paused {
+		result += " " + percentColor + pausedText + color.Reset
+	}
+	if p.ShowETA {
+		if eta := p.eta(time.Now()); eta > 0 {
 			etaColor := p.theme.RenderColor(p.theme.PercentColor, detector)
-			eta := etaColor + fmt.Sprintf("ETA: %ds", int(remaining)) + color.Reset
-			return result + " " + eta
+			return result + " " + etaColor + fmt.Sprintf("ETA: %ds", int(eta.Seconds())) + color.Reset
 		}
 	}
 
 	return result
 }
+
+// renderIndeterminateBar builds the animated bar of a progress whose total
+// is unknown, followed by the time it has been running.
+func renderIndeterminateBar(p *Progress, detector *terminal.Detector) string {
+	labelColor := p.theme.RenderColor(p.theme.LabelColor, detector)
+	label := labelColor + p.label + color.Reset
+
+	bar := p.drawBar(p.width, detector)
+
+	textColor := p.theme.RenderColor(p.theme.PercentColor, detector)
+	text := formatElapsed(p.elapsed(time.Now()))
+	if bytes := p.byteText(); bytes != "" {
+		text += " " + bytes
+	}
+
+	if p.paused {
+		text += " " + pausedText
+	}
+	return fmt.Sprintf("\r%s %s %s", label, bar, textColor+text+color.Reset)
+}
+
+// drawBar draws the bar, width cells wide within its brackets, animated
+// while the total is unknown. Callers hold p.mu.
+func (p *Progress) drawBar(width int, detector *terminal.Detector) string {
+	if p.total <= 0 {
+		return p.bracket(p.theme.renderIndeterminate(p.indeterminate, p.frame, width, p.glyphs, detector), detector)
+	}
+	effect := EffectNone
+	if p.theme.EffectEnabled {
+		effect = p.effect
+	}
+	return p.bracket(p.theme.RenderGlyphs(p.fraction(), width, effect, p.glyphs, detector), detector)
+}
+
+// bracket puts the brackets of the bar around bar.
+func (p *Progress) bracket(bar string, detector *terminal.Detector) string {
+	left, right := p.brackets.Pair()
+	if left == "" && right == "" {
+		return bar
+	}
+	borderColor := p.theme.RenderColor(p.theme.BorderColor, detector)
+	return borderColor + left + color.Reset + bar + borderColor + right + color.Reset
+}
@@ -3,6 +3,7 @@ package progress
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/garaekz/tfx/color"
 	"github.com/garaekz/tfx/runfx"
@@ -12,12 +13,20 @@ import (
 // Spinner is a simple animated indicator that cycles through frames.
 type Spinner struct {
 	frames   []string
+	interval time.Duration
 	index    int
+	advanced time.Time // When the frame last changed
 	label    string
 	theme    ProgressTheme
 	detector *terminal.Detector
 	isTTY    bool
 
+	started      time.Time
+	showElapsed  bool
+	stallAfter   time.Duration
+	stallColor   color.Color
+	stallMessage string
+
 	mu sync.Mutex
 }
 
@@ -28,40 +37,100 @@ func newSpinner(cfg SpinnerConfig) *Spinner {
 		detect = runfx.DetectTTY
 	}
 	tty := detect()
+	frames, interval := cfg.Frames, cfg.Interval
+	if set, ok := LookupFrames(cfg.Preset); ok && cfg.Preset != "" {
+		frames, interval = set.Frames, set.Interval
+	}
+	if len(frames) == 0 {
+		frames = DefaultSpinnerConfig().Frames
+	}
+	stallColor := cfg.StallColor
+	if stallColor == (color.Color{}) {
+		stallColor = color.ColorWarning
+	}
 
 	return &Spinner{
-		frames:   cfg.Frames,
+		frames:   frames,
+		interval: interval,
 		label:    cfg.Label,
 		theme:    cfg.Theme,
 		detector: terminal.NewDetector(cfg.Writer),
 		isTTY:    tty.IsTTY,
+
+		started:      time.Now(),
+		showElapsed:  cfg.ShowElapsed,
+		stallAfter:   cfg.StallAfter,
+		stallColor:   stallColor,
+		stallMessage: cfg.StallMessage,
 	}
 }
 
-// Render returns the current spinner frame with the label.
-// When not running in a TTY, only the label is returned.
+// Render returns the current spinner frame with the label, followed by the
+// elapsed time and stall message when configured. When not running in a
+// TTY, or while another component such as a runfx loop owns the terminal,
+// the frame is left out. Add the spinner to a Group to draw it in a loop.
 func (s *Spinner) Render() string {
+	return s.draw(!ttyOwned())
+}
+
+// draw renders the spinner, leaving the frame out unless tty is set and
+// the spinner runs in a TTY.
+func (s *Spinner) draw(tty bool) string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if !s.isTTY {
-		return s.label
+	elapsed := time.Since(s.started)
+	stalled := s.stallAfter > 0 && elapsed >= s.stallAfter
+	var suffix string
+	if s.showElapsed {
+		suffix += " " + formatElapsed(elapsed)
+	}
+	if stalled && s.stallMessage != "" {
+		suffix += " " + s.stallMessage
+	}
+
+	if !s.isTTY || !tty {
+		return s.label + suffix
 	}
 
 	frame := s.frames[s.index%len(s.frames)]
 	frameColor := s.theme.RenderColor(s.theme.CompleteColor, s.detector)
 	labelColor := s.theme.RenderColor(s.theme.LabelColor, s.detector)
+	suffixColor := s.theme.RenderColor(s.theme.PercentColor, s.detector)
+	if stalled {
+		frameColor = s.theme.RenderColor(s.stallColor, s.detector)
+		labelColor, suffixColor = frameColor, frameColor
+	}
 
 	styledFrame := frameColor + frame + color.Reset
 	styledLabel := labelColor + s.label + color.Reset
+	if suffix != "" {
+		styledLabel += suffixColor + suffix + color.Reset
+	}
 
 	return fmt.Sprintf("\r%s %s", styledFrame, styledLabel)
 }
 
-// Tick advances the spinner to the next frame.
+// Stalled reports whether the spinner has run longer than its stall
+// threshold.
+func (s *Spinner) Stalled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stallAfter > 0 && time.Since(s.started) >= s.stallAfter
+}
+
+// Tick advances the spinner to the next frame, unless the interval of the
+// current one has not passed.
 func (s *Spinner) Tick() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if s.interval > 0 {
+		now := time.Now()
+		if now.Sub(s.advanced) < s.interval {
+			return
+		}
+		s.advanced = now
+	}
 	s.index = (s.index + 1) % len(s.frames)
 }
 
@@ -2,7 +2,9 @@ package progress
 
 import (
 	"io"
+	"time"
 
+	"github.com/garaekz/tfx/color"
 	"github.com/garaekz/tfx/internal/share"
 	"github.com/garaekz/tfx/runfx"
 )
@@ -11,9 +13,21 @@ import (
 type SpinnerConfig struct {
 	Label     string
 	Frames    []string
+	Preset    string        // Name of a frame set replacing Frames and Interval, see RegisterFrames
+	Interval  time.Duration // Shortest time between frames; zero advances one per Tick
 	Theme     ProgressTheme
 	Writer    io.Writer // Used only for TTY detection.
 	DetectTTY func() runfx.TTYInfo
+
+	// ShowElapsed draws the time since the spinner started after the label
+	ShowElapsed bool
+	// StallAfter marks the spinner as stalled once it has run this long,
+	// drawing it in StallColor followed by StallMessage; zero never does
+	StallAfter time.Duration
+	// StallColor draws a stalled spinner; color.ColorWarning when zero
+	StallColor color.Color
+	// StallMessage follows the label of a stalled spinner
+	StallMessage string
 }
 
 // DefaultSpinnerConfig provides sensible defaults.
@@ -23,6 +37,9 @@ func DefaultSpinnerConfig() SpinnerConfig {
 		Frames:    []string{"|", "/", "-", "\\"},
 		Theme:     MaterialTheme,
 		DetectTTY: runfx.DetectTTY,
+
+		StallColor:   color.ColorWarning,
+		StallMessage: "(taking longer than expected)",
 	}
 }
 
@@ -54,6 +71,34 @@ func (b *SpinnerBuilder) Frames(frames []string) *SpinnerBuilder {
 	return b
 }
 
+// Preset uses the registered frame set name, see RegisterFrames.
+func (b *SpinnerBuilder) Preset(name string) *SpinnerBuilder {
+	b.config.Preset = name
+	return b
+}
+
+// Interval sets the shortest time between frames.
+func (b *SpinnerBuilder) Interval(interval time.Duration) *SpinnerBuilder {
+	b.config.Interval = interval
+	return b
+}
+
+// ShowElapsed draws the time since the spinner started.
+func (b *SpinnerBuilder) ShowElapsed() *SpinnerBuilder {
+	b.config.ShowElapsed = true
+	return b
+}
+
+// StallAfter marks the spinner as stalled once it has run for d, appending
+// message if not empty.
+func (b *SpinnerBuilder) StallAfter(d time.Duration, message string) *SpinnerBuilder {
+	b.config.StallAfter = d
+	if message != "" {
+		b.config.StallMessage = message
+	}
+	return b
+}
+
 // Theme sets the spinner theme.
 func (b *SpinnerBuilder) Theme(theme ProgressTheme) *SpinnerBuilder {
 	b.config.Theme = theme
@@ -0,0 +1,86 @@
+package progress
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/runfx"
+)
+
+func TestSpinnerElapsed(t *testing.T) {
+	s := NewSpinnerBuilder().Label("resolving").ShowElapsed().DetectTTY(noTTY).Build()
+	s.started = time.Now().Add(-42 * time.Second)
+	if got := s.Render(); got != "resolving 42s" {
+		t.Errorf("unexpected render %q", got)
+	}
+}
+
+func TestSpinnerStall(t *testing.T) {
+	s := NewSpinnerBuilder().
+		Label("fetching").
+		StallAfter(time.Minute, "").
+		DetectTTY(tty).
+		Build()
+	if s.Stalled() {
+		t.Fatal("expected a new spinner not stalled")
+	}
+	if got := ansiCodes.ReplaceAllString(s.Render(), ""); got != "\r| fetching" {
+		t.Errorf("unexpected render %q", got)
+	}
+
+	s.started = time.Now().Add(-2 * time.Minute)
+	if !s.Stalled() {
+		t.Fatal("expected the spinner stalled past its threshold")
+	}
+	if plain := ansiCodes.ReplaceAllString(s.Render(), ""); plain != "\r| fetching (taking longer than expected)" {
+		t.Errorf("unexpected stalled render %q", plain)
+	}
+	if s.stallColor != color.ColorWarning {
+		t.Errorf("expected stalled spinners drawn in the warning color, got %v", s.stallColor)
+	}
+}
+
+// TestSpinnerBesideLoop runs a standalone spinner while a loop owns the
+// terminal: it must fall back to plain text, while the spinner of a group
+// mounted on the loop keeps its frame.
+func TestSpinnerBesideLoop(t *testing.T) {
+	loop := runfx.StartWith(runfx.Config{Output: io.Discard, TickInterval: time.Millisecond})
+	// The loop only claims a real terminal, so stand in for it.
+	release, err := runfx.AcquireTTY("test loop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	group := NewGroup(GroupConfig{})
+	group.AddSpinner(SpinnerConfig{Label: "mounted", Frames: []string{"*"}, DetectTTY: tty})
+	if _, err := loop.Mount(group); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- loop.Run(ctx) }()
+
+	s := NewSpinnerBuilder().Label("standalone").Frames([]string{"*"}).DetectTTY(tty).Build()
+	for range 20 {
+		s.Tick()
+		if got := s.Render(); got != "standalone" {
+			t.Fatalf("expected plain text beside the loop, got %q", got)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := loop.SnapshotPlain(); !strings.Contains(got, "* mounted") {
+		t.Errorf("expected the mounted spinner to keep its frame, got %q", got)
+	}
+
+	cancel()
+	<-done
+	release()
+	if got := ansiCodes.ReplaceAllString(s.Render(), ""); got != "\r* standalone" {
+		t.Errorf("expected the frame once the terminal is free, got %q", got)
+	}
+}
@@ -7,17 +7,69 @@ const (
 	ProgressStyleDots
 	ProgressStyleArrows
 	ProgressStyleAscii
+	ProgressStyleBlocks  // Solid blocks with eighth-cell partial fills
+	ProgressStyleBraille // Braille dots filling up cell by cell
 )
 
-// styleChars maps each style to its filled and empty symbols.
-var styleChars = map[ProgressStyle]struct {
-	Filled, Empty string
-}{
-	ProgressStyleBar:    {"█", "░"},
-	ProgressStyleDots:   {"●", "○"},
-	ProgressStyleArrows: {">", "-"},
-	ProgressStyleAscii:  {"=", "-"},
+// BarGlyphs are the characters a bar is drawn with. Each should take one
+// terminal cell.
+type BarGlyphs struct {
+	Fill    string
+	Empty   string
+	Partial []string // Partly filled cells, from least to most filled; none rounds down to whole cells
 }
 
-func (s ProgressStyle) FilledChar() string { return styleChars[s].Filled }
-func (s ProgressStyle) EmptyChar() string  { return styleChars[s].Empty }
+// styleGlyphs maps each style to its glyphs.
+var styleGlyphs = map[ProgressStyle]BarGlyphs{
+	ProgressStyleBar:     {Fill: "█", Empty: "░"},
+	ProgressStyleDots:    {Fill: "●", Empty: "○"},
+	ProgressStyleArrows:  {Fill: ">", Empty: "-"},
+	ProgressStyleAscii:   {Fill: "=", Empty: "-"},
+	ProgressStyleBlocks:  {Fill: "█", Empty: " ", Partial: []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉"}},
+	ProgressStyleBraille: {Fill: "⣿", Empty: "⣀", Partial: []string{"⡀", "⡄", "⡆", "⡇", "⣇", "⣧", "⣷"}},
+}
+
+// Glyphs returns the glyphs of the style, those of ProgressStyleBar for an
+// unknown one.
+func (s ProgressStyle) Glyphs() BarGlyphs {
+	if g, ok := styleGlyphs[s]; ok {
+		return g
+	}
+	return styleGlyphs[ProgressStyleBar]
+}
+
+func (s ProgressStyle) FilledChar() string { return s.Glyphs().Fill }
+func (s ProgressStyle) EmptyChar() string  { return s.Glyphs().Empty }
+
+// BracketStyle selects the characters around a bar.
+type BracketStyle int
+
+const (
+	BracketsSquare BracketStyle = iota // [████░░░░]
+	BracketsRound                      // (████░░░░)
+	BracketsPipe                       // |████░░░░|
+	BracketsNone                       // ████░░░░
+)
+
+// Pair returns the left and right bracket.
+func (b BracketStyle) Pair() (string, string) {
+	switch b {
+	case BracketsRound:
+		return "(", ")"
+	case BracketsPipe:
+		return "|", "|"
+	case BracketsNone:
+		return "", ""
+	default:
+		return "[", "]"
+	}
+}
+
+// PercentPlacement selects where the percentage of a bar is drawn.
+type PercentPlacement int
+
+const (
+	PercentRight  PercentPlacement = iota // After the bar
+	PercentLeft                           // Between the label and the bar
+	PercentHidden                         // Not at all
+)
@@ -0,0 +1,68 @@
+package progress
+
+import "testing"
+
+func TestSplitCells(t *testing.T) {
+	tests := []struct {
+		percent         float64
+		width, steps    int
+		filled, partial int
+	}{
+		{0.5, 10, 0, 5, 0},
+		{0.55, 10, 0, 5, 0},
+		{0.55, 10, 7, 5, 4},
+		{0.51, 10, 7, 5, 0},
+		{1, 10, 7, 10, 0},
+		{1.5, 10, 7, 10, 0},
+		{-1, 10, 7, 0, 0},
+	}
+	for _, tt := range tests {
+		filled, partial := splitCells(tt.percent, tt.width, tt.steps)
+		if filled != tt.filled || partial != tt.partial {
+			t.Errorf("splitCells(%v, %d, %d) = %d, %d; want %d, %d",
+				tt.percent, tt.width, tt.steps, filled, partial, tt.filled, tt.partial)
+		}
+	}
+}
+
+func TestProgressGlyphs(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ProgressConfig
+		want string
+	}{
+		{"default", ProgressConfig{}, "\rjob [█████░░░░░]  55%"},
+		{"ascii", ProgressConfig{Style: ProgressStyleAscii}, "\rjob [=====-----]  55%"},
+		{"blocks", ProgressConfig{Style: ProgressStyleBlocks, Brackets: BracketsPipe}, "\rjob |█████▌    |  55%"},
+		{"braille", ProgressConfig{Style: ProgressStyleBraille, Brackets: BracketsNone}, "\rjob ⣿⣿⣿⣿⣿⡇⣀⣀⣀⣀  55%"},
+		{"custom", ProgressConfig{Glyphs: BarGlyphs{Fill: "#", Empty: "."}, Brackets: BracketsRound}, "\rjob (#####.....)  55%"},
+		{"percent left", ProgressConfig{Style: ProgressStyleAscii, Percent: PercentLeft}, "\rjob  55% [=====-----]"},
+		{"percent hidden", ProgressConfig{Style: ProgressStyleAscii, Percent: PercentHidden}, "\rjob [=====-----]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Total, cfg.Label, cfg.Width, cfg.DetectTTY = 100, "job", 10, tty
+			p := newProgress(cfg)
+			p.Set(55)
+			if got := ansiCodes.ReplaceAllString(p.Render(), ""); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressBuilderGlyphs(t *testing.T) {
+	p := NewProgressBuilder().
+		Label("job").
+		Width(4).
+		Style(ProgressStyleAscii).
+		Brackets(BracketsNone).
+		Percent(PercentHidden).
+		DetectTTY(tty).
+		Build()
+	p.Set(50)
+	if got := ansiCodes.ReplaceAllString(p.Render(), ""); got != "\rjob ==--" {
+		t.Errorf("unexpected render %q", got)
+	}
+}
@@ -0,0 +1,155 @@
+package progress
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/terminal"
+)
+
+// BarState is a snapshot of a bar, passed to placeholder funcs.
+type BarState struct {
+	Label   string
+	Current int
+	Total   int     // Zero or less when unknown
+	Percent float64 // From 0 to 1; 0 when the total is unknown
+	Rate    float64 // Estimated units per second
+	Elapsed time.Duration
+	ETA     time.Duration // Zero when unknown
+	Units   ByteUnits
+	Paused  bool
+}
+
+// PlaceholderFunc renders a placeholder of a bar template from the state of
+// the bar and the argument after the colon, "" without one. It must not
+// call methods of the bar.
+type PlaceholderFunc func(state BarState, arg string) string
+
+// templatePart is a literal run of a template or, when name is set, a
+// placeholder.
+type templatePart struct {
+	text      string
+	name, arg string
+}
+
+// parseTemplate splits tmpl into literals and {name} or {name:arg}
+// placeholders. "{{" stands for a literal brace; an unclosed brace is left
+// as is.
+func parseTemplate(tmpl string) []templatePart {
+	var parts []templatePart
+	var literal strings.Builder
+	for len(tmpl) > 0 {
+		i := strings.IndexByte(tmpl, '{')
+		if i < 0 {
+			literal.WriteString(tmpl)
+			break
+		}
+		literal.WriteString(tmpl[:i])
+		tmpl = tmpl[i:]
+		if strings.HasPrefix(tmpl, "{{") {
+			literal.WriteByte('{')
+			tmpl = tmpl[2:]
+			continue
+		}
+		end := strings.IndexByte(tmpl, '}')
+		if end < 0 {
+			literal.WriteString(tmpl)
+			break
+		}
+		if literal.Len() > 0 {
+			parts = append(parts, templatePart{text: literal.String()})
+			literal.Reset()
+		}
+		name, arg, _ := strings.Cut(tmpl[1:end], ":")
+		parts = append(parts, templatePart{text: tmpl[:end+1], name: name, arg: arg})
+		tmpl = tmpl[end+1:]
+	}
+	if literal.Len() > 0 {
+		parts = append(parts, templatePart{text: literal.String()})
+	}
+	return parts
+}
+
+// state returns the snapshot of the bar. Callers hold p.mu.
+func (p *Progress) state() BarState {
+	s := BarState{
+		Label:   p.label,
+		Current: p.current,
+		Total:   p.total,
+		Percent: p.fraction(),
+		Rate:    p.rate,
+		Units:   p.units,
+		Paused:  p.paused,
+	}
+	now := time.Now()
+	s.Elapsed = p.elapsed(now)
+	s.ETA = p.eta(now)
+	return s
+}
+
+// renderTemplate builds the line of a bar with its template, see
+// ProgressConfig.Template. Callers hold p.mu.
+func renderTemplate(p *Progress, detector *terminal.Detector) string {
+	state := p.state()
+	labelColor := p.theme.RenderColor(p.theme.LabelColor, detector)
+	textColor := p.theme.RenderColor(p.theme.PercentColor, detector)
+	colored := func(c, text string) string {
+		if text == "" {
+			return ""
+		}
+		return c + text + color.Reset
+	}
+
+	var line strings.Builder
+	line.WriteByte('\r')
+	for _, part := range p.template {
+		if part.name == "" {
+			line.WriteString(part.text)
+			continue
+		}
+		if fn, ok := p.placeholders[part.name]; ok {
+			line.WriteString(fn(state, part.arg))
+			continue
+		}
+		switch part.name {
+		case "label":
+			line.WriteString(colored(labelColor, state.Label))
+		case "bar":
+			width := p.width
+			if n, err := strconv.Atoi(part.arg); err == nil && n > 0 {
+				width = n
+			}
+			line.WriteString(p.drawBar(width, detector))
+		case "percent":
+			if state.Total > 0 {
+				line.WriteString(colored(textColor, fmt.Sprintf("%3d%%", int(state.Percent*100))))
+			}
+		case "current":
+			line.WriteString(colored(textColor, FormatBytes(float64(state.Current), state.Units)))
+		case "total":
+			if state.Total > 0 {
+				line.WriteString(colored(textColor, FormatBytes(float64(state.Total), state.Units)))
+			}
+		case "rate":
+			if state.Rate > 0 {
+				line.WriteString(colored(textColor, FormatBytes(state.Rate, state.Units)+"/s"))
+			}
+		case "elapsed":
+			line.WriteString(colored(textColor, formatElapsed(state.Elapsed)))
+		case "paused":
+			if state.Paused {
+				line.WriteString(colored(textColor, pausedText))
+			}
+		case "eta":
+			if state.ETA > 0 {
+				line.WriteString(colored(textColor, formatElapsed(state.ETA)))
+			}
+		default:
+			line.WriteString(part.text)
+		}
+	}
+	return line.String()
+}
@@ -0,0 +1,56 @@
+package progress
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseTemplate(t *testing.T) {
+	got := parseTemplate("{label} {bar:30} {{x} {unclosed")
+	want := []templatePart{
+		{text: "{label}", name: "label"},
+		{text: " "},
+		{text: "{bar:30}", name: "bar", arg: "30"},
+		{text: " {x} {unclosed"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestProgressTemplate(t *testing.T) {
+	p := newProgress(ProgressConfig{
+		Total:     4 << 20,
+		Label:     "fetch",
+		Width:     10,
+		Style:     ProgressStyleAscii,
+		Units:     UnitsIEC,
+		Template:  "{percent} {bar:4} {label} {current}/{total} {rate} {nope}",
+		DetectTTY: tty,
+	})
+	p.Set(1 << 20)
+	p.rate = 1 << 20
+	if got := ansiCodes.ReplaceAllString(p.Render(), ""); got != "\r 25% [=---] fetch 1 MiB/4 MiB 1 MiB/s {nope}" {
+		t.Errorf("unexpected render %q", got)
+	}
+}
+
+func TestProgressTemplatePlaceholders(t *testing.T) {
+	p := NewProgressBuilder().
+		Total(10).
+		Label("jobs").
+		Template("{label} {left:jobs} {percent}").
+		Placeholder("left", func(s BarState, arg string) string {
+			return strconv.Itoa(s.Total-s.Current) + " " + arg + " left"
+		}).
+		Placeholder("percent", func(s BarState, _ string) string {
+			return strconv.Itoa(int(s.Percent*100)) + "/100"
+		}).
+		DetectTTY(tty).
+		Build()
+	p.Set(3)
+	if got := ansiCodes.ReplaceAllString(p.Render(), ""); got != "\rjobs 7 jobs left 30/100" {
+		t.Errorf("unexpected render %q", got)
+	}
+}
@@ -1,6 +1,8 @@
 package progress
 
 import (
+	"strings"
+
 	"github.com/garaekz/tfx/color"
 	"github.com/garaekz/tfx/terminal"
 )
@@ -177,33 +179,100 @@ func (pt ProgressTheme) RenderProgress(
 	detector *terminal.Detector,
 ) string {
 	filled := int(percent * float64(width))
+	return pt.renderCells(filled, 0, width, effect, ProgressStyleBar.Glyphs(), detector)
+}
+
+// RenderGlyphs renders a bar like RenderProgress, drawn with glyphs. Bars
+// with partial glyphs show the fraction of the cell after the filled ones.
+func (pt ProgressTheme) RenderGlyphs(
+	percent float64,
+	width int,
+	effect ProgressEffect,
+	glyphs BarGlyphs,
+	detector *terminal.Detector,
+) string {
+	filled, partial := splitCells(percent, width, len(glyphs.Partial))
+	return pt.renderCells(filled, partial, width, effect, glyphs, detector)
+}
+
+// splitCells returns how many of width cells percent fills whole and which
+// of steps partial glyphs, counted from 1, draws the next; 0 for none.
+func splitCells(percent float64, width, steps int) (filled, partial int) {
+	percent = max(0, min(percent, 1))
+	cells := percent * float64(width)
+	filled = int(cells)
+	if filled < width && steps > 0 {
+		partial = int((cells - float64(filled)) * float64(steps+1))
+	}
+	return filled, partial
+}
+
+// renderCells draws width cells with glyphs: filled whole ones in the
+// colors of effect, then partial glyph number partial if not 0, then empty
+// ones.
+func (pt ProgressTheme) renderCells(
+	filled, partial, width int,
+	effect ProgressEffect,
+	glyphs BarGlyphs,
+	detector *terminal.Detector,
+) string {
+	incompleteColor := pt.RenderColor(pt.IncompleteColor, detector)
+
+	var bar strings.Builder
+	for i := range width {
+		switch {
+		case i < filled:
+			bar.WriteString(pt.RenderColor(pt.cellColor(effect, i, filled), detector) + glyphs.Fill + color.Reset)
+		case i == filled && partial > 0:
+			bar.WriteString(pt.RenderColor(pt.cellColor(effect, i, filled), detector) + glyphs.Partial[partial-1] + color.Reset)
+		default:
+			bar.WriteString(incompleteColor + glyphs.Empty + color.Reset)
+		}
+	}
+	return bar.String()
+}
+
+// rainbowColors are the colors EffectRainbow cycles through.
+var rainbowColors = []color.Color{
+	color.MaterialRed,
+	color.MaterialOrange,
+	color.MaterialYellow,
+	color.MaterialGreen,
+	color.MaterialBlue,
+	color.MaterialPurple,
+}
 
+// cellColor returns the color of filled cell i of a bar with filled cells.
+func (pt ProgressTheme) cellColor(effect ProgressEffect, i, filled int) color.Color {
 	switch effect {
 	case EffectRainbow:
-		return pt.renderRainbowProgress(filled, width, detector)
+		return rainbowColors[i%len(rainbowColors)]
 	case EffectGradient:
-		return pt.renderGradientProgress(filled, width, detector)
+		// Calculate interpolation (simplified)
+		ratio := float64(i) / float64(filled)
+		if ratio < 0.5 {
+			return pt.CompleteColor
+		}
+		return color.MaterialCyan // Could be configurable
 	case EffectGlow:
-		return pt.renderGlowProgress(filled, width, detector)
+		// Glow effect: brighter in center, dimmer at edges
+		distanceFromCenter := float64(abs(i-filled/2)) / float64(filled/2)
+		if distanceFromCenter < 0.3 {
+			return pt.CompleteColor
+		}
+		return color.RGB(
+			pt.CompleteColor.R/2,
+			pt.CompleteColor.G/2,
+			pt.CompleteColor.B/2,
+		) // Dimmed version
 	default:
-		return pt.renderSolidProgress(filled, width, detector)
+		return pt.CompleteColor
 	}
 }
 
 // Solid color progress (standard)
 func (pt ProgressTheme) renderSolidProgress(filled, width int, detector *terminal.Detector) string {
-	completeColor := pt.RenderColor(pt.CompleteColor, detector)
-	incompleteColor := pt.RenderColor(pt.IncompleteColor, detector)
-
-	bar := ""
-	for i := range width {
-		if i < filled {
-			bar += completeColor + "█" + color.Reset
-		} else {
-			bar += incompleteColor + "░" + color.Reset
-		}
-	}
-	return bar
+	return pt.renderCells(filled, 0, width, EffectNone, ProgressStyleBar.Glyphs(), detector)
 }
 
 // Rainbow progress effect
@@ -211,28 +280,7 @@ func (pt ProgressTheme) renderRainbowProgress(
 	filled, width int,
 	detector *terminal.Detector,
 ) string {
-	rainbowColors := []color.Color{
-		color.MaterialRed,
-		color.MaterialOrange,
-		color.MaterialYellow,
-		color.MaterialGreen,
-		color.MaterialBlue,
-		color.MaterialPurple,
-	}
-
-	incompleteColor := pt.RenderColor(pt.IncompleteColor, detector)
-
-	bar := ""
-	for i := range width {
-		if i < filled {
-			colorIndex := i % len(rainbowColors)
-			currentColor := pt.RenderColor(rainbowColors[colorIndex], detector)
-			bar += currentColor + "█" + color.Reset
-		} else {
-			bar += incompleteColor + "░" + color.Reset
-		}
-	}
-	return bar
+	return pt.renderCells(filled, 0, width, EffectRainbow, ProgressStyleBar.Glyphs(), detector)
 }
 
 // Gradient progress effect
@@ -240,56 +288,12 @@ func (pt ProgressTheme) renderGradientProgress(
 	filled, width int,
 	detector *terminal.Detector,
 ) string {
-	startColor := pt.CompleteColor
-	endColor := color.MaterialCyan // Could be configurable
-	incompleteColor := pt.RenderColor(pt.IncompleteColor, detector)
-
-	bar := ""
-	for i := range width {
-		if i < filled {
-			// Calculate interpolation (simplified)
-			ratio := float64(i) / float64(filled)
-			if ratio < 0.5 {
-				currentColor := pt.RenderColor(startColor, detector)
-				bar += currentColor + "█" + color.Reset
-			} else {
-				currentColor := pt.RenderColor(endColor, detector)
-				bar += currentColor + "█" + color.Reset
-			}
-		} else {
-			bar += incompleteColor + "░" + color.Reset
-		}
-	}
-	return bar
+	return pt.renderCells(filled, 0, width, EffectGradient, ProgressStyleBar.Glyphs(), detector)
 }
 
 // Glow effect (brightness variation)
 func (pt ProgressTheme) renderGlowProgress(filled, width int, detector *terminal.Detector) string {
-	centerColor := pt.CompleteColor
-	edgeColor := color.RGB(
-		pt.CompleteColor.R/2,
-		pt.CompleteColor.G/2,
-		pt.CompleteColor.B/2,
-	) // Dimmed version
-	incompleteColor := pt.RenderColor(pt.IncompleteColor, detector)
-
-	bar := ""
-	for i := range width {
-		if i < filled {
-			// Glow effect: brighter in center, dimmer at edges
-			distanceFromCenter := float64(abs(i-filled/2)) / float64(filled/2)
-			if distanceFromCenter < 0.3 {
-				currentColor := pt.RenderColor(centerColor, detector)
-				bar += currentColor + "█" + color.Reset
-			} else {
-				currentColor := pt.RenderColor(edgeColor, detector)
-				bar += currentColor + "█" + color.Reset
-			}
-		} else {
-			bar += incompleteColor + "░" + color.Reset
-		}
-	}
-	return bar
+	return pt.renderCells(filled, 0, width, EffectGlow, ProgressStyleBar.Glyphs(), detector)
 }
 
 // Helper function
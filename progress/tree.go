@@ -0,0 +1,243 @@
+package progress
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// parentScale is the total of the bar of a Parent, so its percentage keeps
+// a tenth of a percent of precision.
+const parentScale = 1000
+
+// treeNode is a child of a Parent.
+type treeNode interface {
+	GroupItem
+	Done() bool
+	started() bool
+	completion() float64
+	lines(tty bool) []string
+	Tick()
+}
+
+// treeChild is a child of a Parent with its weight.
+type treeChild struct {
+	node   treeNode
+	weight float64
+}
+
+// Parent is a bar whose progress is the weighted progress of its children,
+// drawn above them as an indented tree:
+//
+//	install := progress.NewParent(progress.ProgressConfig{Label: "Install"})
+//	download := install.AddBar(0.7, progress.ProgressConfig{Total: size, Label: "download", Units: progress.UnitsIEC})
+//	extract := install.AddBar(0.3, progress.ProgressConfig{Total: files, Label: "extract"})
+//	group.Add(install)
+//
+// Children are bars or other parents. Finished children are collapsed
+// into their parent's line. A Parent is a GroupItem.
+type Parent struct {
+	bar      *Progress
+	cfg      ProgressConfig
+	children []treeChild
+	mu       sync.Mutex
+}
+
+// NewParent creates a Parent without children. Its total is ignored.
+// opts Type: any = Option[ProgressConfig] | ProgressConfig
+func NewParent(opts ...any) *Parent {
+	cfg := share.OverloadWithOptions[ProgressConfig](opts, DefaultProgressConfig())
+	bar := cfg
+	bar.Total = parentScale
+	bar.Units = UnitsNone
+	return &Parent{bar: newProgress(bar), cfg: cfg}
+}
+
+// add appends node with weight, which counts as 1 when zero or less.
+func (t *Parent) add(node treeNode, weight float64) {
+	if weight <= 0 {
+		weight = 1
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.children = append(t.children, treeChild{node, weight})
+}
+
+// Add adds bar as a child making up weight of the parent's progress,
+// relative to the weights of the other children.
+func (t *Parent) Add(bar *Progress, weight float64) {
+	t.add(bar, weight)
+}
+
+// AddBar creates a child bar as Start does, defaulting to the width, theme
+// and terminal of the parent, and adds it with weight.
+func (t *Parent) AddBar(weight float64, opts ...any) *Progress {
+	def := t.cfg
+	def.Total = DefaultProgressConfig().Total
+	def.Label = ""
+	def.Units = UnitsNone
+	bar := newProgress(share.OverloadWithOptions[ProgressConfig](opts, def))
+	t.add(bar, weight)
+	return bar
+}
+
+// AddParent creates a nested parent labeled label, defaulting to the
+// configuration of t, and adds it with weight.
+func (t *Parent) AddParent(label string, weight float64) *Parent {
+	cfg := t.cfg
+	cfg.Label = label
+	child := NewParent(cfg)
+	t.add(child, weight)
+	return child
+}
+
+// Fraction returns the weighted progress of the children, from 0 to 1.
+func (t *Parent) Fraction() float64 {
+	return t.completion()
+}
+
+// completion returns the weighted progress of the children.
+func (t *Parent) completion() float64 {
+	t.mu.Lock()
+	children := t.children
+	t.mu.Unlock()
+
+	var done, total float64
+	for _, c := range children {
+		done += c.weight * c.node.completion()
+		total += c.weight
+	}
+	if total == 0 {
+		return 0
+	}
+	return min(done/total, 1)
+}
+
+// completion returns the progress of a child bar. Bars of unknown total
+// count as not started until they finish.
+func (p *Progress) completion() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.total <= 0 {
+		if p.finished {
+			return 1
+		}
+		return 0
+	}
+	return p.fraction()
+}
+
+// lines returns the line of a child bar, if it started.
+func (p *Progress) lines(tty bool) []string {
+	if line := p.draw(tty); line != "" {
+		return []string{line}
+	}
+	return nil
+}
+
+// started reports whether any child started.
+func (t *Parent) started() bool {
+	t.mu.Lock()
+	children := t.children
+	t.mu.Unlock()
+	for _, c := range children {
+		if c.node.started() {
+			return true
+		}
+	}
+	return false
+}
+
+// started reports whether the bar was set or advanced.
+func (p *Progress) started() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.isStarted
+}
+
+// Done reports whether every child is done.
+func (t *Parent) Done() bool {
+	t.mu.Lock()
+	children := t.children
+	t.mu.Unlock()
+	if len(children) == 0 {
+		return false
+	}
+	for _, c := range children {
+		if !c.node.Done() {
+			return false
+		}
+	}
+	return true
+}
+
+// Render returns the parent line and, below it, the lines of the children
+// still running, or "" until a child starts. Like Progress.Render, it
+// draws plain text while another component owns the terminal.
+func (t *Parent) Render() string {
+	return t.draw(!ttyOwned())
+}
+
+// draw renders the tree, as plain text unless tty is set.
+func (t *Parent) draw(tty bool) string {
+	return strings.Join(t.lines(tty), "\n")
+}
+
+// lines returns the parent line and the indented lines of the children
+// still running.
+func (t *Parent) lines(tty bool) []string {
+	if !t.started() {
+		return nil
+	}
+	t.bar.Set(int(t.completion() * parentScale))
+	lines := []string{t.bar.draw(tty)}
+
+	t.mu.Lock()
+	children := t.children
+	t.mu.Unlock()
+
+	var running [][]string
+	for _, c := range children {
+		if c.node.Done() {
+			continue
+		}
+		if child := c.node.lines(tty); len(child) > 0 {
+			running = append(running, child)
+		}
+	}
+	for i, child := range running {
+		branch, stem := "├─ ", "│  "
+		if i == len(running)-1 {
+			branch, stem = "└─ ", "   "
+		}
+		for j, line := range child {
+			prefix := stem
+			if j == 0 {
+				prefix = branch
+			}
+			lines = append(lines, indentLine(line, prefix))
+		}
+	}
+	return lines
+}
+
+// indentLine puts prefix before line, after the carriage return that
+// starts the lines of terminal bars.
+func indentLine(line, prefix string) string {
+	if rest, ok := strings.CutPrefix(line, "\r"); ok {
+		return "\r" + prefix + rest
+	}
+	return prefix + line
+}
+
+// Tick advances the animations of the parent and its children.
+func (t *Parent) Tick() {
+	t.bar.Tick()
+	t.mu.Lock()
+	children := t.children
+	t.mu.Unlock()
+	for _, c := range children {
+		c.node.Tick()
+	}
+}
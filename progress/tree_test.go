@@ -0,0 +1,69 @@
+package progress
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParentWeightsChildren(t *testing.T) {
+	install := NewParent(ProgressConfig{Label: "Install", DetectTTY: noTTY})
+	download := install.AddBar(0.7, ProgressConfig{Total: 100, Label: "download"})
+	extract := install.AddBar(0.3, ProgressConfig{Total: 10, Label: "extract"})
+
+	if got := install.Render(); got != "" {
+		t.Errorf("expected nothing drawn before a child starts, got %q", got)
+	}
+
+	download.Set(50)
+	if got := install.Fraction(); math.Abs(got-0.35) > 1e-9 {
+		t.Errorf("expected 0.7 * 50%%, got %v", got)
+	}
+	want := "Install  35%\n└─ download  50%"
+	if got := install.Render(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	download.Finish()
+	extract.Set(5)
+	want = "Install  85%\n└─ extract  50%"
+	if got := install.Render(); got != want {
+		t.Errorf("expected the finished child collapsed, got %q, want %q", got, want)
+	}
+	if install.Done() {
+		t.Error("expected the parent running while a child is")
+	}
+
+	extract.Finish()
+	if got := install.Render(); got != "Install 100%" || !install.Done() {
+		t.Errorf("expected the parent done alone, got %q", got)
+	}
+}
+
+func TestParentNested(t *testing.T) {
+	root := NewParent(ProgressConfig{Label: "deploy", DetectTTY: noTTY})
+	build := root.AddParent("build", 1)
+	compile := build.AddBar(1, ProgressConfig{Total: 4, Label: "compile"})
+	link := build.AddBar(1, ProgressConfig{Total: 4, Label: "link"})
+	upload := root.AddBar(1, ProgressConfig{Total: 2, Label: "upload"})
+
+	compile.Set(2)
+	link.Set(1)
+	upload.Set(1)
+	want := []string{
+		"deploy  43%",
+		"├─ build  37%",
+		"│  ├─ compile  50%",
+		"│  └─ link  25%",
+		"└─ upload  50%",
+	}
+	if got := strings.Split(root.Render(), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	group := NewGroup(GroupConfig{})
+	group.Add(root)
+	if lines := renderGroup(group); len(lines) != len(want) {
+		t.Errorf("expected the tree drawn in a group, got %q", lines)
+	}
+}
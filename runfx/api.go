@@ -1,6 +1,7 @@
 package runfx
 
 import (
+	"context"
 	"io"
 	"os"
 	"time"
@@ -18,22 +19,57 @@ func Start(opts ...any) Loop {
 	return newLoopWithConfig(share.OverloadWithOptions(opts, DefaultConfig()))
 }
 
+// Shared returns the loop that currently owns the terminal so callers can
+// mount their visuals into it. When no loop is running, a new one is created
+// with the given options.
+// opts Type: any = Option[Config] | Config
+func Shared(opts ...any) Loop {
+	if loop, ok := ActiveLoop(); ok {
+		return loop
+	}
+	return Start(opts...)
+}
+
 // newLoopWithConfig creates a new Loop with the given configuration
 func newLoopWithConfig(cfg Config) Loop {
 	ttyInfo := DetectTTYForOutput(cfg.Output)
 	tw := writer.NewTerminalWriter(cfg.Output, writer.TerminalOptions{
 		DoubleBuffer: true,
 		DisableColor: ttyInfo.NoColor,
+		Hyperlinks:   cfg.Hyperlinks,
 	})
 
-	return &MainLoop{
+	values := newLoopValues(cfg.Values)
+	if _, ok := values.get(TTYInfoKey); !ok {
+		values.set(TTYInfoKey, ttyInfo)
+	}
+
+	ml := &MainLoop{
 		mux:     NewMultiplexer(),
 		writer:  tw,
 		reader:  NewKeyReader(os.Stdin),
 		signals: terminal.NewSignalHandler(),
 		events:  make(chan any, 64), // buffered channel for events
 		ticker:  time.NewTicker(cfg.TickInterval),
+
+		resizePolicy:   cfg.ResizePolicy,
+		resizeDebounce: cfg.ResizeDebounce,
+
+		frameInterval: frameInterval(cfg.MaxFPS),
+
+		tickInterval: cfg.TickInterval,
+		idleAfter:    cfg.IdleAfter,
+		idleInterval: cfg.IdleTickInterval,
+
+		onStart: cfg.OnStart,
+		onStop:  cfg.OnStop,
+
+		values: values,
+	}
+	if cfg.LogLines > 0 {
+		ml.log = newLogRegion(cfg.LogLines, ml.requestRender)
 	}
+	return ml
 }
 
 // --- DSL BUILDER API (Hardcore Path) ---
@@ -75,6 +111,62 @@ func (b *LoopBuilder) FastAnimation() *LoopBuilder {
 	return b
 }
 
+// ResizePolicy sets how terminal resize events are delivered to visuals
+func (b *LoopBuilder) ResizePolicy(policy ResizePolicy) *LoopBuilder {
+	b.config.ResizePolicy = policy
+	return b
+}
+
+// ResizeDebounce sets the quiet period used by the debounced resize policy
+func (b *LoopBuilder) ResizeDebounce(d time.Duration) *LoopBuilder {
+	b.config.ResizeDebounce = d
+	return b
+}
+
+// IdleThrottle lowers the tick rate to interval after the given number of
+// unchanged ticks. Pass 0 ticks to disable throttling.
+func (b *LoopBuilder) IdleThrottle(ticks int, interval time.Duration) *LoopBuilder {
+	b.config.IdleAfter = ticks
+	b.config.IdleTickInterval = interval
+	return b
+}
+
+// OnStart sets a hook called once the loop is running, before the first render
+func (b *LoopBuilder) OnStart(fn func(ctx context.Context)) *LoopBuilder {
+	b.config.OnStart = fn
+	return b
+}
+
+// OnStop sets a hook called after the loop exits, with the error returned by Run
+func (b *LoopBuilder) OnStop(fn func(err error)) *LoopBuilder {
+	b.config.OnStop = fn
+	return b
+}
+
+// MaxFPS caps repaints per second independently of the tick rate (0 uncaps)
+func (b *LoopBuilder) MaxFPS(fps int) *LoopBuilder {
+	b.config.MaxFPS = fps
+	return b
+}
+
+// Hyperlinks sets how OSC 8 hyperlinks emitted by visuals are written
+func (b *LoopBuilder) Hyperlinks(mode writer.HyperlinkMode) *LoopBuilder {
+	b.config.Hyperlinks = mode
+	return b
+}
+
+// LogLines sets how many console log lines are shown above the visuals (0 disables)
+func (b *LoopBuilder) LogLines(lines int) *LoopBuilder {
+	b.config.LogLines = lines
+	return b
+}
+
+// Value attaches a value visuals can read via ValueFrom
+func (b *LoopBuilder) Value(key, val any) *LoopBuilder {
+	WithValue(key, val)(&b.config)
+	return b
+}
+
 // Start creates and returns the configured Loop instance
 func (b *LoopBuilder) Start() Loop {
 	return newLoopWithConfig(b.config)
@@ -124,6 +216,82 @@ func WithFastAnimation() share.Option[Config] {
 	}
 }
 
+// WithResizePolicy returns an Option to set how resize events are delivered to visuals.
+func WithResizePolicy(policy ResizePolicy) share.Option[Config] {
+	return func(cfg *Config) {
+		cfg.ResizePolicy = policy
+	}
+}
+
+// WithResizeDebounce returns an Option to set the debounce window for resize events.
+func WithResizeDebounce(d time.Duration) share.Option[Config] {
+	return func(cfg *Config) {
+		cfg.ResizePolicy = ResizeDebounced
+		cfg.ResizeDebounce = d
+	}
+}
+
+// WithIdleThrottle returns an Option to lower the tick rate after the given number of unchanged ticks.
+func WithIdleThrottle(ticks int, interval time.Duration) share.Option[Config] {
+	return func(cfg *Config) {
+		cfg.IdleAfter = ticks
+		cfg.IdleTickInterval = interval
+	}
+}
+
+// WithoutIdleThrottle returns an Option that keeps the tick rate constant.
+func WithoutIdleThrottle() share.Option[Config] {
+	return func(cfg *Config) {
+		cfg.IdleAfter = 0
+	}
+}
+
+// WithOnStart returns an Option to set a hook called when the loop starts.
+func WithOnStart(fn func(ctx context.Context)) share.Option[Config] {
+	return func(cfg *Config) {
+		cfg.OnStart = fn
+	}
+}
+
+// WithOnStop returns an Option to set a hook called when the loop stops.
+func WithOnStop(fn func(err error)) share.Option[Config] {
+	return func(cfg *Config) {
+		cfg.OnStop = fn
+	}
+}
+
+// WithMaxFPS returns an Option to cap repaints per second; 0 removes the cap.
+func WithMaxFPS(fps int) share.Option[Config] {
+	return func(cfg *Config) {
+		cfg.MaxFPS = fps
+	}
+}
+
+// WithHyperlinks returns an Option to control OSC 8 hyperlink passthrough.
+func WithHyperlinks(mode writer.HyperlinkMode) share.Option[Config] {
+	return func(cfg *Config) {
+		cfg.Hyperlinks = mode
+	}
+}
+
+// WithLogLines returns an Option to set how many console log lines are shown
+// above the visuals while the loop runs; 0 disables the log region.
+func WithLogLines(lines int) share.Option[Config] {
+	return func(cfg *Config) {
+		cfg.LogLines = lines
+	}
+}
+
+// WithValue returns an Option to attach a value visuals can read via ValueFrom.
+func WithValue(key, val any) share.Option[Config] {
+	return func(cfg *Config) {
+		if cfg.Values == nil {
+			cfg.Values = make(map[any]any)
+		}
+		cfg.Values[key] = val
+	}
+}
+
 // WithAutoTick returns an Option to set the tick interval based on detected TTY capabilities.
 func WithAutoTick() share.Option[Config] {
 	return func(cfg *Config) {
@@ -1,22 +1,47 @@
 package runfx
 
 import (
+	"context"
 	"io"
 	"os"
 	"time"
+
+	"github.com/garaekz/tfx/writer"
 )
 
 // Config provides structured configuration for RunFX Loop
 type Config struct {
-	TickInterval time.Duration
-	Output       io.Writer
+	TickInterval   time.Duration
+	Output         io.Writer
+	ResizePolicy   ResizePolicy  // How resize events reach visuals
+	ResizeDebounce time.Duration // Quiet period for ResizeDebounced
+
+	MaxFPS int // Cap on repaints per second, independent of TickInterval; 0 is uncapped
+
+	IdleAfter        int           // Unchanged ticks before throttling; 0 disables
+	IdleTickInterval time.Duration // Tick interval while idle
+
+	Hyperlinks writer.HyperlinkMode // OSC 8 passthrough; auto-detected by default
+
+	Values map[any]any // Values visuals can read via ValueFrom, e.g. a logger or theme
+
+	LogLines int // Console log lines shown above the visuals while running; 0 (the default) disables
+
+	OnStart func(ctx context.Context) // Called after terminal setup, before the first render
+	OnStop  func(err error)           // Called after the loop exits with Run's result
 }
 
 // DefaultConfig returns default configuration for RunFX
 func DefaultConfig() Config {
 	return Config{
-		TickInterval: 50 * time.Millisecond, // Default 50ms for smooth animation
-		Output:       os.Stdout,
+		TickInterval:   50 * time.Millisecond, // Default 50ms for smooth animation
+		Output:         os.Stdout,
+		ResizePolicy:   ResizeDebounced,
+		ResizeDebounce: DefaultResizeDebounce,
+		MaxFPS:         DefaultMaxFPS,
+
+		IdleAfter:        DefaultIdleAfter,
+		IdleTickInterval: DefaultIdleTickInterval,
 	}
 }
 
@@ -7,10 +7,12 @@
 // # Key Features
 //
 // - Explicit TTY ownership with advanced multiplexing
-// - Cross-platform signal handling (SIGWINCH on Unix, graceful fallback on Windows)
-// - Double-buffered, flicker-free rendering
+// - Cross-platform resize detection (SIGWINCH on Unix, console size polling on Windows)
+// - Double-buffered, flicker-free rendering with wide-character and emoji aware clearing
 // - Thread-safe visual mounting/unmounting
 // - Configurable tick rates for smooth animation (30-120ms)
+// - Adaptive tick throttling when the screen stops changing
+// - Frame rate cap that coalesces high-frequency updates into fewer repaints
 // - Intelligent fallback for non-TTY environments
 // - Zero reflection, global state, or hidden dependencies
 // - Multipath API with three entry points for different usage patterns
@@ -49,6 +51,12 @@
 //   - FastAnimation()        - 100ms ticks for less CPU usage
 //   - TestMode()             - Enable test mode for non-TTY environments
 //   - Output(writer)         - Custom output destination
+//   - ResizePolicy(policy)   - Immediate, Debounced (default) or OnNextTick resize delivery
+//   - ResizeDebounce(d)      - Quiet period before a debounced resize is delivered
+//   - IdleThrottle(n, d)     - Tick every d after n unchanged ticks (0 disables)
+//   - MaxFPS(n)              - Cap repaints per second independently of ticks (default 60)
+//   - Hyperlinks(mode)       - OSC 8 hyperlink passthrough: auto-detect, on or off
+//   - Value(key, val)        - Attach a value visuals can read via ValueFrom
 //
 // ## Experimental Path - Functional Options
 //
@@ -89,6 +97,85 @@
 //		OnResize(cols, rows int)   // Called when terminal is resized
 //	}
 //
+// # TTY Ownership
+//
+// Only one loop may drive a real terminal at a time. A second loop started
+// against the terminal fails with an error wrapping ErrTTYOwned that names the
+// current owner. Use runfx.Shared() to reuse the running loop instead:
+//
+//	loop := runfx.Shared()
+//	unmount, _ := loop.Mount(myVisual)
+//	defer unmount()
+//
+// Other full-screen components can take part via AcquireTTY.
+//
+// # Crash Safety
+//
+// A running loop registers a cleanup that shows the cursor, leaves the
+// alternate screen, disables mouse reporting and restores the terminal mode.
+// Defer runfx.Guard() in goroutines that may panic and use runfx.Exit instead
+// of os.Exit so the terminal is always left usable:
+//
+//	func main() {
+//		defer runfx.Guard()
+//		...
+//		runfx.Exit(1)
+//	}
+//
+// # Retained Rendering
+//
+// By default every visual is rendered on each frame. Visuals that implement
+// Invalidatable are rendered once and their output is reused until they ask
+// for a repaint, which keeps idle dashboards cheap:
+//
+//	func (s *Status) SetInvalidator(fn func()) { s.invalidate = fn }
+//	func (s *Status) Set(text string)           { s.text = text; s.invalidate() }
+//
+// Code that holds the loop can also call loop.Invalidate(visual).
+//
+// # Log Pane
+//
+// LogPane is a built-in visual with its own scrollback that other packages
+// can write into while the loop renders. It implements io.Writer, scrolls with
+// PgUp/PgDn/Home/End and composes with other mounted visuals:
+//
+//	pane := runfx.NewLogPane(runfx.WithPaneHeight(8), runfx.WithPaneTitle("Output"))
+//	loop.Mount(pane)
+//	fmt.Fprintln(pane, "build started")
+//
+// # Loop Values
+//
+// Values such as a logger, theme or capability info can be attached to the
+// loop with WithValue or SetValue. Mountable visuals read them from the
+// context passed to Mounted, during Render or Tick. The detected TTYInfo is
+// always available under TTYInfoKey:
+//
+//	func (v *myVisual) Mounted(ctx context.Context) { v.ctx = ctx }
+//
+//	func (v *myVisual) Render(w writer.Writer) {
+//		tty, _ := runfx.ValueFrom[runfx.TTYInfo](v.ctx, runfx.TTYInfoKey)
+//		...
+//	}
+//
+// # Snapshots
+//
+// Snapshot returns the last rendered frame with ANSI styling; SnapshotPlain
+// strips escape sequences so the final state of a dashboard can be logged or
+// attached to an error report:
+//
+//	err := loop.Run(ctx)
+//	log.Printf("final state:\n%s", loop.SnapshotPlain())
+//
+// # Lifecycle Hooks
+//
+// Loops accept OnStart and OnStop hooks through Config, the builder or
+// functional options. Visuals that also implement Mountable receive
+// Mounted(ctx) when mounted and Unmounted() when removed; ctx is canceled on
+// unmount so goroutines started by the visual can exit:
+//
+//	func (c *Clock) Mounted(ctx context.Context) { go c.poll(ctx) }
+//	func (c *Clock) Unmounted()                  { c.release() }
+//
 // # Graceful Degradation
 //
 // RunFX automatically detects TTY capabilities and falls back to minimal output
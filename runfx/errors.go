@@ -9,4 +9,5 @@ var (
 	ErrNotTTY             = errors.New("runfx: not a TTY environment")
 	ErrLoopAlreadyRunning = errors.New("runfx: loop is already running")
 	ErrLoopNotRunning     = errors.New("runfx: loop is not running")
+	ErrTTYOwned           = errors.New("runfx: terminal is already owned")
 )
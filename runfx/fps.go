@@ -0,0 +1,55 @@
+package runfx
+
+import "time"
+
+// DefaultMaxFPS caps repaints per second. Ticks and invalidations arriving
+// faster than this are coalesced into the next frame.
+const DefaultMaxFPS = 60
+
+// frameInterval returns the minimum time between two frames for fps, or 0
+// when rendering is uncapped.
+func frameInterval(fps int) time.Duration {
+	if fps <= 0 {
+		return 0
+	}
+	return time.Second / time.Duration(fps)
+}
+
+// renderCapped renders a frame unless the FPS cap was hit, in which case a
+// single deferred frame is scheduled. It reports whether the frame changed
+// and whether it was rendered now.
+func (ml *MainLoop) renderCapped() (changed, rendered bool) {
+	if ml.frameInterval > 0 {
+		if ml.frameTimer != nil {
+			// A deferred frame is already pending and will pick this up.
+			return false, false
+		}
+		if wait := ml.frameInterval - time.Since(ml.lastRender); wait > 0 {
+			ml.frameTimer = time.NewTimer(wait)
+			return false, false
+		}
+	}
+	return ml.renderNow(), true
+}
+
+// renderNow renders a frame and records when it happened.
+func (ml *MainLoop) renderNow() bool {
+	ml.lastRender = time.Now()
+	return ml.renderFrame()
+}
+
+// frameDue returns the channel of the pending deferred frame, or nil.
+func (ml *MainLoop) frameDue() <-chan time.Time {
+	if ml.frameTimer == nil {
+		return nil
+	}
+	return ml.frameTimer.C
+}
+
+// stopFrameTimer cancels any pending deferred frame.
+func (ml *MainLoop) stopFrameTimer() {
+	if ml.frameTimer != nil {
+		ml.frameTimer.Stop()
+		ml.frameTimer = nil
+	}
+}
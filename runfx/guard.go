@@ -0,0 +1,89 @@
+package runfx
+
+import (
+	"os"
+	"slices"
+	"sync"
+)
+
+// terminalResetSeq shows the cursor, leaves the alternate screen and disables
+// every mouse reporting mode a visual may have enabled.
+const terminalResetSeq = "\033[?25h\033[?1049l\033[?1000l\033[?1002l\033[?1003l\033[?1006l"
+
+// crashGuard keeps the cleanup functions that must run before the process
+// dies, whether it exits normally, via Exit, or through a panic.
+type crashGuard struct {
+	mu       sync.Mutex
+	nextID   uint64
+	cleanups map[uint64]func()
+	order    []uint64
+}
+
+var guard = &crashGuard{cleanups: make(map[uint64]func())}
+
+// osExit is swapped in tests.
+var osExit = os.Exit
+
+// RegisterCleanup registers fn to run when the terminal must be restored.
+// Cleanups run in reverse registration order. The returned function removes
+// fn from the registry without running it.
+func RegisterCleanup(fn func()) (unregister func()) {
+	if fn == nil {
+		return func() {}
+	}
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+
+	guard.nextID++
+	id := guard.nextID
+	guard.cleanups[id] = fn
+	guard.order = append(guard.order, id)
+
+	return func() {
+		guard.mu.Lock()
+		defer guard.mu.Unlock()
+		delete(guard.cleanups, id)
+		guard.order = slices.DeleteFunc(guard.order, func(v uint64) bool { return v == id })
+	}
+}
+
+// RestoreTerminal runs and clears every registered cleanup. It is safe to
+// call multiple times and from any goroutine; each cleanup runs at most once.
+func RestoreTerminal() {
+	guard.mu.Lock()
+	fns := make([]func(), 0, len(guard.order))
+	for i := len(guard.order) - 1; i >= 0; i-- {
+		fns = append(fns, guard.cleanups[guard.order[i]])
+	}
+	guard.cleanups = make(map[uint64]func())
+	guard.order = nil
+	guard.mu.Unlock()
+
+	for _, fn := range fns {
+		func() {
+			// A failing cleanup must not prevent the others from running.
+			defer func() { _ = recover() }()
+			fn()
+		}()
+	}
+}
+
+// Guard restores the terminal if the calling goroutine is panicking and then
+// re-panics with the original value. Defer it at the top of main and of any
+// goroutine that drives visuals:
+//
+//	defer runfx.Guard()
+func Guard() {
+	if r := recover(); r != nil {
+		RestoreTerminal()
+		panic(r)
+	}
+}
+
+// Exit restores the terminal and terminates the program with the given code.
+// Use it instead of os.Exit while a loop is running, since os.Exit skips
+// deferred cleanup.
+func Exit(code int) {
+	RestoreTerminal()
+	osExit(code)
+}
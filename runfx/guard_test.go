@@ -0,0 +1,56 @@
+package runfx
+
+import "testing"
+
+// TestRestoreTerminalRunsOnceInReverse checks cleanup order and idempotence
+func TestRestoreTerminalRunsOnceInReverse(t *testing.T) {
+	var calls []int
+	RegisterCleanup(func() { calls = append(calls, 1) })
+	RegisterCleanup(func() { calls = append(calls, 2) })
+	unregister := RegisterCleanup(func() { calls = append(calls, 3) })
+	unregister()
+
+	RestoreTerminal()
+	RestoreTerminal()
+
+	if len(calls) != 2 || calls[0] != 2 || calls[1] != 1 {
+		t.Fatalf("expected cleanups [2 1], got %v", calls)
+	}
+}
+
+// TestGuardRestoresAndRepanics verifies Guard runs cleanups before re-panicking
+func TestGuardRestoresAndRepanics(t *testing.T) {
+	restored := false
+	RegisterCleanup(func() { restored = true })
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("expected re-panic with boom, got %v", r)
+		}
+		if !restored {
+			t.Fatal("expected terminal cleanup to run before re-panic")
+		}
+	}()
+
+	func() {
+		defer Guard()
+		panic("boom")
+	}()
+}
+
+// TestExitRestoresTerminal verifies Exit runs cleanups before exiting
+func TestExitRestoresTerminal(t *testing.T) {
+	orig := osExit
+	defer func() { osExit = orig }()
+
+	code := -1
+	osExit = func(c int) { code = c }
+
+	restored := false
+	RegisterCleanup(func() { restored = true })
+	Exit(3)
+
+	if !restored || code != 3 {
+		t.Fatalf("expected restore and exit code 3, got restored=%v code=%d", restored, code)
+	}
+}
@@ -0,0 +1,57 @@
+package runfx
+
+import "time"
+
+// Idle throttling defaults.
+const (
+	// DefaultIdleAfter is the number of consecutive unchanged ticks after
+	// which the loop considers itself idle.
+	DefaultIdleAfter = 20
+	// DefaultIdleTickInterval is the tick interval used while idle.
+	DefaultIdleTickInterval = 500 * time.Millisecond
+)
+
+// trackIdle updates idle bookkeeping after an event has been handled.
+// Input, resizes and frames that differ from the previous one restore the
+// configured tick rate; a run of unchanged ticks lowers it.
+func (ml *MainLoop) trackIdle(e any, changed bool) {
+	if ml.idleAfter <= 0 || ml.idleInterval <= ml.tickInterval {
+		return
+	}
+
+	switch e.(type) {
+	case keyEvent, resizeEvent, invalidateEvent:
+		changed = true
+	case frameEvent:
+		// Deferred frames only matter when they changed the screen.
+		if !changed {
+			return
+		}
+	case tickEvent:
+	default:
+		return
+	}
+
+	if changed {
+		ml.idleTicks = 0
+		if ml.idle.Load() {
+			ml.idle.Store(false)
+			ml.ticker.Reset(ml.tickInterval)
+			DebugLog("Leaving idle mode, tick interval %s", ml.tickInterval)
+		}
+		return
+	}
+
+	ml.idleTicks++
+	if !ml.idle.Load() && ml.idleTicks >= ml.idleAfter {
+		ml.idle.Store(true)
+		ml.ticker.Reset(ml.idleInterval)
+		DebugLog("Entering idle mode, tick interval %s", ml.idleInterval)
+	}
+}
+
+// IsIdle reports whether the loop is currently throttled due to inactivity.
+// It is safe to call from any goroutine.
+func (ml *MainLoop) IsIdle() bool {
+	return ml.idle.Load()
+}
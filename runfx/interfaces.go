@@ -24,9 +24,33 @@ type Interactive interface {
 	OnKey(key Key) bool // Returns true to stop the loop.
 }
 
+// Mountable is an optional interface for visuals that tie resources such as
+// tickers or goroutines to their mount lifetime.
+//
+// Mounted is called when the visual is mounted; ctx is canceled when it is
+// unmounted and carries the loop's values (see ValueFrom). Unmounted is called once after the visual has been removed.
+type Mountable interface {
+	Mounted(ctx context.Context)
+	Unmounted()
+}
+
+// Invalidatable is an optional interface for visuals that repaint only when
+// their state changes. The loop caches their last render and reuses it until
+// the visual is invalidated, either by calling the function received through
+// SetInvalidator or via Loop.Invalidate.
+type Invalidatable interface {
+	Visual
+	SetInvalidator(requestRender func())
+}
+
 // Loop defines the runtime loop for mounting and managing visuals.
 type Loop interface {
 	Mount(v Visual) (unmount func(), err error)
+	Invalidate(v Visual)
+	Snapshot() string
+	SnapshotPlain() string
+	SetValue(key, val any)
+	Value(key any) any
 	Run(ctx context.Context) error
 	Stop() error
 	IsRunning() bool
@@ -68,6 +68,12 @@ const (
 	KeyCtrlC
 	KeyCtrlD
 	KeyCtrlZ
+
+	// Navigation keys, appended so the values of the keys above are stable
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
 )
 
 // String returns a readable name for debugging/logging.
@@ -93,6 +99,14 @@ func (k KeyCode) String() string {
 		return "←"
 	case KeyArrowRight:
 		return "→"
+	case KeyPageUp:
+		return "PgUp"
+	case KeyPageDown:
+		return "PgDn"
+	case KeyHome:
+		return "Home"
+	case KeyEnd:
+		return "End"
 	case KeyCtrlC:
 		return "Ctrl+C"
 	case KeyCtrlD:
@@ -0,0 +1,23 @@
+package runfx
+
+import "testing"
+
+// TestKeyCodeValuesStable pins key codes that existed before the navigation
+// keys, which callers may have persisted or compared numerically
+func TestKeyCodeValuesStable(t *testing.T) {
+	cases := []struct {
+		code KeyCode
+		want int
+	}{
+		{KeyArrowRight, 10},
+		{KeyA, 11},
+		{Key0, 37},
+		{KeyCtrlZ, 49},
+		{KeyPageUp, 50},
+	}
+	for _, c := range cases {
+		if int(c.code) != c.want {
+			t.Errorf("%v = %d, want %d", c.code, int(c.code), c.want)
+		}
+	}
+}
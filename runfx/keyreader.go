@@ -103,6 +103,14 @@ func (kr *KeyReader) decodeCSI(seq []byte) (Key, error) {
 		return Key{Code: KeyArrowLeft}, nil
 	case "3~":
 		return Key{Code: KeyDelete}, nil
+	case "5~":
+		return Key{Code: KeyPageUp}, nil
+	case "6~":
+		return Key{Code: KeyPageDown}, nil
+	case "H", "1~", "7~":
+		return Key{Code: KeyHome}, nil
+	case "F", "4~", "8~":
+		return Key{Code: KeyEnd}, nil
 	}
 
 	if strings.Contains(s, ";") {
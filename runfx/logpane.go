@@ -0,0 +1,272 @@
+package runfx
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/writer"
+)
+
+// DefaultLogPaneScrollback is the number of lines a LogPane keeps by default.
+const DefaultLogPaneScrollback = 1000
+
+// LogPaneConfig configures a LogPane.
+type LogPaneConfig struct {
+	// Height is the number of visible rows. Zero uses the full terminal height.
+	Height int
+	// Scrollback caps the number of lines kept; older lines are dropped.
+	Scrollback int
+	// Title is rendered as a header line when non-empty.
+	Title string
+}
+
+// DefaultLogPaneConfig returns the default LogPane configuration.
+func DefaultLogPaneConfig() LogPaneConfig {
+	return LogPaneConfig{
+		Height:     10,
+		Scrollback: DefaultLogPaneScrollback,
+	}
+}
+
+// WithPaneHeight sets the number of visible rows of a LogPane.
+func WithPaneHeight(rows int) share.Option[LogPaneConfig] {
+	return func(cfg *LogPaneConfig) {
+		cfg.Height = rows
+	}
+}
+
+// WithScrollback sets the number of lines a LogPane keeps.
+func WithScrollback(lines int) share.Option[LogPaneConfig] {
+	return func(cfg *LogPaneConfig) {
+		cfg.Scrollback = lines
+	}
+}
+
+// WithPaneTitle sets the header line of a LogPane.
+func WithPaneTitle(title string) share.Option[LogPaneConfig] {
+	return func(cfg *LogPaneConfig) {
+		cfg.Title = title
+	}
+}
+
+// LogPane is a scrollable region of log lines that composes with other
+// visuals. It implements io.Writer so loggers and flows can write into it
+// while the loop is running; PgUp/PgDn scroll through the scrollback and
+// Home/End jump to the oldest or newest lines.
+type LogPane struct {
+	mu         sync.Mutex
+	cfg        LogPaneConfig
+	lines      []string
+	partial    strings.Builder
+	offset     int // lines scrolled up from the bottom
+	cols, rows int
+	invalidate func()
+}
+
+// NewLogPane creates a LogPane with multipath configuration support.
+// opts Type: any = Option[LogPaneConfig] | LogPaneConfig
+func NewLogPane(opts ...any) *LogPane {
+	cfg := share.OverloadWithOptions(opts, DefaultLogPaneConfig())
+	if cfg.Scrollback <= 0 {
+		cfg.Scrollback = DefaultLogPaneScrollback
+	}
+	return &LogPane{cfg: cfg}
+}
+
+// Write appends p to the pane, splitting it into lines. A trailing partial
+// line is held until its newline arrives.
+func (p *LogPane) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	text := p.partial.String() + string(b)
+	p.partial.Reset()
+	parts := strings.Split(text, "\n")
+	p.partial.WriteString(parts[len(parts)-1])
+	p.appendLocked(parts[:len(parts)-1]...)
+	p.mu.Unlock()
+
+	p.requestRender()
+	return len(b), nil
+}
+
+// Println appends a single formatted line to the pane.
+func (p *LogPane) Println(args ...any) {
+	p.AppendLine(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+// AppendLine appends one or more lines to the pane. Embedded newlines start
+// new lines.
+func (p *LogPane) AppendLine(lines ...string) {
+	p.mu.Lock()
+	for _, line := range lines {
+		p.appendLocked(strings.Split(line, "\n")...)
+	}
+	p.mu.Unlock()
+
+	p.requestRender()
+}
+
+func (p *LogPane) appendLocked(lines ...string) {
+	for _, line := range lines {
+		p.lines = append(p.lines, strings.TrimRight(line, "\r"))
+		// Keep the view anchored while the user is reading older lines.
+		if p.offset > 0 {
+			p.offset++
+		}
+	}
+	if over := len(p.lines) - p.cfg.Scrollback; over > 0 {
+		p.lines = append(p.lines[:0], p.lines[over:]...)
+	}
+	p.clampLocked()
+}
+
+// Lines returns a copy of the lines currently held in the scrollback.
+func (p *LogPane) Lines() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.lines...)
+}
+
+// Clear removes all lines from the pane.
+func (p *LogPane) Clear() {
+	p.mu.Lock()
+	p.lines = nil
+	p.partial.Reset()
+	p.offset = 0
+	p.mu.Unlock()
+
+	p.requestRender()
+}
+
+// ScrollUp moves the view n lines towards older output.
+func (p *LogPane) ScrollUp(n int) {
+	p.scroll(n)
+}
+
+// ScrollDown moves the view n lines towards newer output.
+func (p *LogPane) ScrollDown(n int) {
+	p.scroll(-n)
+}
+
+// Offset reports how many lines the view is scrolled up from the bottom.
+func (p *LogPane) Offset() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.offset
+}
+
+func (p *LogPane) scroll(n int) {
+	p.mu.Lock()
+	p.offset += n
+	p.clampLocked()
+	p.mu.Unlock()
+
+	p.requestRender()
+}
+
+func (p *LogPane) clampLocked() {
+	maxOffset := len(p.lines) - p.heightLocked()
+	if p.offset > maxOffset {
+		p.offset = maxOffset
+	}
+	if p.offset < 0 {
+		p.offset = 0
+	}
+}
+
+// heightLocked returns the number of rows available for log lines.
+func (p *LogPane) heightLocked() int {
+	h := p.cfg.Height
+	if h <= 0 {
+		h = p.rows
+	}
+	if p.cfg.Title != "" {
+		h--
+	}
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// Render implements the Visual interface.
+func (p *LogPane) Render(w writer.Writer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	height := p.heightLocked()
+	end := len(p.lines) - p.offset
+	start := max(end-height, 0)
+
+	if p.cfg.Title != "" {
+		title := p.cfg.Title
+		if p.offset > 0 {
+			title = fmt.Sprintf("%s (↑%d)", title, p.offset)
+		}
+		fmt.Fprintln(w, p.fit(title))
+	}
+	for _, line := range p.lines[start:end] {
+		fmt.Fprintln(w, p.fit(line))
+	}
+	// Keep the pane's footprint stable so visuals below it do not jump.
+	for i := end - start; i < height; i++ {
+		fmt.Fprintln(w)
+	}
+}
+
+func (p *LogPane) fit(line string) string {
+	if p.cols <= 0 {
+		return line
+	}
+	return TruncateWidth(line, p.cols)
+}
+
+// Tick implements the Visual interface (no-op).
+func (p *LogPane) Tick(now time.Time) {}
+
+// OnResize implements the Visual interface.
+func (p *LogPane) OnResize(cols, rows int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cols, p.rows = cols, rows
+	p.clampLocked()
+}
+
+// OnKey implements the Interactive interface, handling PgUp, PgDn, Home and
+// End. It never stops the loop.
+func (p *LogPane) OnKey(key Key) bool {
+	p.mu.Lock()
+	page := p.heightLocked()
+	p.mu.Unlock()
+
+	switch key.Code {
+	case KeyPageUp:
+		p.ScrollUp(page)
+	case KeyPageDown:
+		p.ScrollDown(page)
+	case KeyHome:
+		p.ScrollUp(len(p.Lines()))
+	case KeyEnd:
+		p.ScrollDown(p.Offset())
+	}
+	return false
+}
+
+// SetInvalidator implements the Invalidatable interface so the pane is only
+// repainted when its content or scroll position changes.
+func (p *LogPane) SetInvalidator(requestRender func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.invalidate = requestRender
+}
+
+func (p *LogPane) requestRender() {
+	p.mu.Lock()
+	fn := p.invalidate
+	p.mu.Unlock()
+	if fn != nil {
+		fn()
+	}
+}
@@ -0,0 +1,58 @@
+package runfx
+
+import (
+	"strings"
+	"testing"
+)
+
+func renderPane(p *LogPane) []string {
+	bw := &bufferWriter{}
+	p.Render(bw)
+	return strings.Split(strings.TrimSuffix(string(bw.Bytes()), "\n"), "\n")
+}
+
+// TestLogPaneWriteAndScrollback checks line splitting and the scrollback cap
+func TestLogPaneWriteAndScrollback(t *testing.T) {
+	p := NewLogPane(WithPaneHeight(2), WithScrollback(3))
+	p.Write([]byte("one\ntwo\nthr"))
+	p.Write([]byte("ee\nfour\n"))
+
+	if got := p.Lines(); strings.Join(got, ",") != "two,three,four" {
+		t.Fatalf("unexpected scrollback %v", got)
+	}
+	if got := renderPane(p); strings.Join(got, ",") != "three,four" {
+		t.Fatalf("unexpected render %v", got)
+	}
+}
+
+// TestLogPaneScrollKeys verifies PgUp/PgDn/Home/End navigation
+func TestLogPaneScrollKeys(t *testing.T) {
+	p := NewLogPane(WithPaneHeight(2), WithPaneTitle("logs"))
+	invalidated := 0
+	p.SetInvalidator(func() { invalidated++ })
+	p.AppendLine("a", "b", "c", "d")
+
+	p.OnKey(Key{Code: KeyPageUp})
+	if got := renderPane(p); strings.Join(got, ",") != "logs (↑1),c" {
+		t.Fatalf("unexpected render after PgUp %v", got)
+	}
+
+	p.OnKey(Key{Code: KeyHome})
+	if p.Offset() != 3 {
+		t.Fatalf("expected offset 3 after Home, got %d", p.Offset())
+	}
+
+	// New lines keep the view anchored while scrolled up.
+	p.AppendLine("e")
+	if got := renderPane(p); got[1] != "a" {
+		t.Fatalf("expected view to stay on oldest line, got %v", got)
+	}
+
+	p.OnKey(Key{Code: KeyEnd})
+	if got := renderPane(p); strings.Join(got, ",") != "logs,e" {
+		t.Fatalf("unexpected render after End %v", got)
+	}
+	if invalidated == 0 {
+		t.Fatal("expected pane to request repaints")
+	}
+}
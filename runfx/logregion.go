@@ -0,0 +1,151 @@
+package runfx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/garaekz/tfx/writer"
+)
+
+// DefaultLogLines is a typical size for a log region, for loops that enable
+// one with Config.LogLines.
+const DefaultLogLines = 10
+
+// logRegion holds the latest lines printed while a loop runs. They are drawn
+// above the visuals, so log output appears over progress bars instead of
+// clobbering them. Lines pushed out of the region wait in scrolled until the
+// loop prints them into the terminal's scrollback.
+type logRegion struct {
+	mu       sync.Mutex
+	max      int
+	lines    []string
+	scrolled []string
+	partial  strings.Builder
+	changed  func()
+}
+
+func newLogRegion(max int, changed func()) *logRegion {
+	return &logRegion{max: max, changed: changed}
+}
+
+// Write appends p to the region, splitting it into lines. A trailing partial
+// line is held until its newline arrives.
+func (r *logRegion) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	text := r.partial.String() + string(p)
+	r.partial.Reset()
+	parts := strings.Split(text, "\n")
+	r.partial.WriteString(parts[len(parts)-1])
+	for _, line := range parts[:len(parts)-1] {
+		r.lines = append(r.lines, strings.TrimRight(line, "\r"))
+	}
+	if over := len(r.lines) - r.max; over > 0 {
+		r.scrolled = append(r.scrolled, r.lines[:over]...)
+		r.lines = append(r.lines[:0], r.lines[over:]...)
+	}
+	r.mu.Unlock()
+
+	if len(parts) > 1 && r.changed != nil {
+		r.changed()
+	}
+	return len(p), nil
+}
+
+// Lines returns a copy of the lines in the region.
+func (r *logRegion) Lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.lines...)
+}
+
+// takeScrolled returns and forgets the lines pushed out of the region.
+func (r *logRegion) takeScrolled() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lines := r.scrolled
+	r.scrolled = nil
+	return lines
+}
+
+// render draws the lines of the region, truncated to cols when positive.
+func (r *logRegion) render(w writer.Writer, cols int) {
+	for _, line := range r.Lines() {
+		if cols > 0 {
+			line = TruncateWidth(line, cols)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// LogWriter returns a writer whose lines the loop shows above its visuals
+// while it runs. While the loop runs, console log writers targeting its
+// output write there automatically, see writer.RouteConsole. Lines pushed out
+// of the region are printed above the frame, into the terminal's scrollback.
+// It returns nil when the loop has no log region (Config.LogLines is zero,
+// the default).
+func (ml *MainLoop) LogWriter() io.Writer {
+	if ml.log == nil {
+		return nil
+	}
+	return ml.log
+}
+
+// composeFrame renders the log region and the mounted visuals into w.
+func (ml *MainLoop) composeFrame(w writer.Writer) {
+	if ml.log != nil {
+		cols := 0
+		if c, _, err := ml.writer.GetSize(); err == nil {
+			cols = c
+		}
+		ml.log.render(w, cols)
+	}
+	ml.mux.Render(w)
+}
+
+// printLogRegion prints the lines of the log region after the loop cleared
+// the screen, so the latest log output outlives the frame.
+func (ml *MainLoop) printLogRegion() {
+	if ml.log == nil {
+		return
+	}
+	for _, line := range append(ml.log.takeScrolled(), ml.log.Lines()...) {
+		ml.writer.Write([]byte(line + "\r\n"))
+	}
+	ml.writer.Flush()
+}
+
+// scrollOut prints the lines pushed out of the log region before the next
+// frame. On a terminal they are drawn at the top of the screen and scrolled
+// off it by newlines written on its last row, so they land in the
+// scrollback above the frame; elsewhere they are written in sequence.
+func (ml *MainLoop) scrollOut() {
+	if ml.log == nil {
+		return
+	}
+	lines := ml.log.takeScrolled()
+	if len(lines) == 0 {
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line + "\x1b[K\r\n")
+	}
+	_, rows, err := ml.writer.GetSize()
+	if !ml.writer.IsTerminal() || err != nil || rows <= 0 {
+		ml.writer.Write(buf.Bytes())
+		return
+	}
+	ml.writer.MoveCursor(1, 1)
+	ml.writer.Write(buf.Bytes())
+	ml.writer.MoveCursor(rows, 1)
+	ml.writer.Write(bytes.Repeat([]byte("\n"), min(len(lines), rows)))
+
+	// The old frame moved up with the lines; draw the next one from scratch
+	ml.writer.MoveCursor(1, 1)
+	ml.writer.Write([]byte("\x1b[J"))
+	ml.lastWidths = nil
+}
@@ -0,0 +1,118 @@
+package runfx
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+	"github.com/garaekz/tfx/writer"
+)
+
+// TestLogRegionAboveVisuals checks that the latest log lines are drawn above
+// the visuals
+func TestLogRegionAboveVisuals(t *testing.T) {
+	loop := StartWith(Config{Output: io.Discard, TickInterval: time.Millisecond, LogLines: 2})
+	if _, err := loop.Mount(&textVisual{text: "[=====]\n"}); err != nil {
+		t.Fatalf("mount failed: %v", err)
+	}
+
+	lw := loop.(*MainLoop).LogWriter()
+	io.WriteString(lw, "one\ntwo\nthr")
+	io.WriteString(lw, "ee\n")
+
+	if got, want := loop.Snapshot(), "two\nthree\n[=====]\n"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+// TestLogRegionDisabled checks that LogLines zero draws no region
+func TestLogRegionDisabled(t *testing.T) {
+	loop := StartWith(Config{Output: io.Discard, TickInterval: time.Millisecond})
+	if lw := loop.(*MainLoop).LogWriter(); lw != nil {
+		t.Fatalf("expected no log writer, got %v", lw)
+	}
+}
+
+// TestConsoleRoutedWhileRunning checks that console log lines written to the
+// loop output go through the log region while the loop runs, and are
+// printed once it stops
+func TestConsoleRoutedWhileRunning(t *testing.T) {
+	screen := &testutil.SafeBuffer{}
+	started := make(chan struct{})
+	loop := StartWith(Config{
+		Output:       screen,
+		TickInterval: time.Millisecond,
+		LogLines:     5,
+		OnStart:      func(context.Context) { close(started) },
+	})
+	ml := loop.(*MainLoop)
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	ml.reader = NewKeyReader(pr)
+
+	console := writer.NewConsoleWriter(screen, writer.ConsoleOptions{
+		Format:       share.FormatText,
+		DisableColor: true,
+	})
+	done := make(chan error, 1)
+	go func() { done <- ml.Run(context.Background()) }()
+	<-started
+
+	screen.Reset()
+	console.Write(&share.Entry{Level: share.LevelInfo, Message: "while running"})
+	if got := ml.log.Lines(); len(got) != 1 || !strings.Contains(got[0], "while running") {
+		t.Fatalf("expected the line in the log region, got %q", got)
+	}
+
+	ml.Stop()
+	<-done
+	if !strings.Contains(screen.String(), "while running\r\n") {
+		t.Fatalf("expected the region printed on stop, got %q", screen.String())
+	}
+
+	screen.Reset()
+	console.Write(&share.Entry{Level: share.LevelInfo, Message: "after"})
+	if !strings.Contains(screen.String(), "after") || len(ml.log.Lines()) != 1 {
+		t.Fatalf("expected direct output after stop, got %q", screen.String())
+	}
+}
+
+// TestLogRegionOptIn checks that loops draw no log region by default
+func TestLogRegionOptIn(t *testing.T) {
+	if n := DefaultConfig().LogLines; n != 0 {
+		t.Fatalf("expected the log region disabled by default, got %d lines", n)
+	}
+}
+
+// TestLogRegionScrollsOut checks that lines pushed out of the log region are
+// printed before the next frame instead of being dropped
+func TestLogRegionScrollsOut(t *testing.T) {
+	screen := &testutil.SafeBuffer{}
+	loop := StartWith(Config{Output: screen, TickInterval: time.Millisecond, LogLines: 2})
+	ml := loop.(*MainLoop)
+	if _, err := loop.Mount(&textVisual{text: "[=====]\n"}); err != nil {
+		t.Fatalf("mount failed: %v", err)
+	}
+
+	io.WriteString(ml.LogWriter(), "one\ntwo\nthree\n")
+	ml.renderFrame()
+	out := screen.String()
+	if !strings.HasPrefix(out, "one\x1b[K\r\n") {
+		t.Fatalf("expected the scrolled line before the frame, got %q", out)
+	}
+	if !strings.Contains(out, "two") || !strings.Contains(out, "three") {
+		t.Fatalf("expected the region in the frame, got %q", out)
+	}
+
+	// Lines scrolled out while no frame was drawn are printed on stop
+	screen.Reset()
+	io.WriteString(ml.LogWriter(), "four\nfive\n")
+	ml.printLogRegion()
+	if got, want := screen.String(), "two\r\nthree\r\nfour\r\nfive\r\n"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -24,10 +26,12 @@ func (b *bufferWriter) Flush() error { return nil }
 
 // --- Event Types ---
 type (
-	keyEvent    Key
-	tickEvent   struct{ time time.Time }
-	resizeEvent struct{ cols, rows int }
-	errorEvent  error
+	keyEvent        Key
+	tickEvent       struct{ time time.Time }
+	invalidateEvent struct{}
+	frameEvent      struct{} // a deferred frame drawn after the FPS cap
+	resizeEvent     struct{ cols, rows int }
+	errorEvent      error
 )
 
 // --- Loop Definition ---
@@ -48,7 +52,38 @@ type MainLoop struct {
 	rawState *term.State
 	running  atomic.Bool
 
-	lastLen int // track previous frame length for clearing
+	renderRequested atomic.Bool // coalesces Invalidate calls into one render
+
+	// Resize propagation
+	resizePolicy   ResizePolicy
+	resizeDebounce time.Duration
+	resizeMu       sync.Mutex
+	resizeTimer    *time.Timer
+	pendingResize  *resizeEvent // coalesced resize for ResizeOnNextTick
+
+	// Lifecycle hooks
+	onStart func(ctx context.Context)
+	onStop  func(err error)
+
+	// Idle throttling
+	tickInterval time.Duration
+	idleAfter    int
+	idleInterval time.Duration
+	idleTicks    int
+	idle         atomic.Bool // Written by the loop goroutine, read by IsIdle
+
+	frameInterval time.Duration // minimum time between frames; 0 is uncapped
+	lastRender    time.Time
+	frameTimer    *time.Timer // pending deferred frame, owned by the loop goroutine
+
+	values *loopValues // values visuals read through their mount context
+
+	log *logRegion // console lines shown above the visuals; nil disables
+
+	lastWidths []int // display width of each line of the previous frame
+
+	frameMu   sync.Mutex // guards lastFrame for Snapshot
+	lastFrame []byte     // previous composed frame for change detection
 }
 
 // --- Public API Methods ---
@@ -80,12 +115,32 @@ func (ml *MainLoop) Mount(v Visual) (unmount func(), err error) {
 		return nil, ErrTooManyVisuals
 	}
 
+	// Retained visuals get a callback to request their own repaint.
+	if iv, ok := v.(Invalidatable); ok {
+		iv.SetInvalidator(func() { ml.invalidateID(id) })
+	}
+
+	// Give lifecycle-aware visuals a context bound to their mount lifetime.
+	mountCtx, cancel := context.WithCancel(ml.withValues(context.Background()))
+	if m, ok := v.(Mountable); ok {
+		m.Mounted(mountCtx)
+	}
+
 	// Return a closure that captures the ID to unmount the visual later.
-	return func() { ml.mux.Unmount(id) }, nil
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ml.mux.Unmount(id)
+			cancel()
+			if m, ok := v.(Mountable); ok {
+				m.Unmounted()
+			}
+		})
+	}, nil
 }
 
 // Run starts the main loop and blocks until the context is canceled or Stop() is called.
-func (ml *MainLoop) Run(ctx context.Context) error {
+func (ml *MainLoop) Run(ctx context.Context) (err error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -96,6 +151,22 @@ func (ml *MainLoop) Run(ctx context.Context) error {
 		return ErrLoopAlreadyRunning
 	}
 	defer ml.running.Store(false)
+	// Only one loop may drive a real terminal at a time.
+	if ml.writer.IsTerminal() {
+		owner := "runfx loop"
+		if _, file, line, ok := runtime.Caller(1); ok {
+			owner = fmt.Sprintf("runfx loop started at %s:%d", filepath.Base(file), line)
+		}
+		release, err := acquireTTY(owner, ml)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+	if ml.onStop != nil {
+		// Registered first so it runs after the terminal has been restored.
+		defer func() { ml.onStop(err) }()
+	}
 
 	// Setup terminal
 	if state, err := ml.writer.EnableRawMode(); err == nil {
@@ -104,23 +175,39 @@ func (ml *MainLoop) Run(ctx context.Context) error {
 	}
 	ml.writer.HideCursor()
 	defer ml.writer.ShowCursor()
+	defer ml.printLogRegion()
 	defer ml.writer.Clear()
 
+	// Route console log lines into the log region while the loop draws.
+	if ml.log != nil {
+		defer writer.RouteConsole(ml.writer.Output(), ml.log)()
+	}
+
+	// Make sure a panic elsewhere or runfx.Exit never leaves the terminal broken.
+	unguard := RegisterCleanup(ml.restoreTerminal)
+	defer unguard()
+
 	// Create a cancellable context for the loop's goroutines
-	loopCtx, cancel := context.WithCancel(ctx)
+	loopCtx, cancel := context.WithCancel(ml.withValues(ctx))
 	ml.cancelMu.Lock()
 	ml.cancel = cancel
 	ml.cancelMu.Unlock()
 	defer ml.ticker.Stop()
+	defer ml.stopResizeTimer()
 
 	// Start event producers
 	go ml.produceKeyEvents(loopCtx)
 	go ml.produceTickEvents(loopCtx)
 	go ml.produceSignalEvents(loopCtx)
 
+	if ml.onStart != nil {
+		ml.onStart(loopCtx)
+	}
+
 	// Initial render on a clean screen
 	ml.writer.Clear()
-	ml.renderFrame()
+	ml.renderNow()
+	defer ml.stopFrameTimer()
 
 	// Main event processing loop
 	for {
@@ -132,9 +219,17 @@ func (ml *MainLoop) Run(ctx context.Context) error {
 			if shouldStop {
 				return nil
 			}
-			if shouldRender {
-				ml.renderFrame()
+			if !shouldRender {
+				ml.trackIdle(e, false)
+				continue
 			}
+			// Deferred frames are tracked when they are actually drawn.
+			if changed, rendered := ml.renderCapped(); rendered {
+				ml.trackIdle(e, changed)
+			}
+		case <-ml.frameDue():
+			ml.frameTimer = nil
+			ml.trackIdle(frameEvent{}, ml.renderNow())
 		}
 	}
 }
@@ -153,11 +248,69 @@ func (ml *MainLoop) Stop() error {
 	return ErrLoopClosed
 }
 
+// Invalidate marks a mounted visual as changed and schedules a render.
+// It is safe to call from any goroutine, including from Render or Tick.
+func (ml *MainLoop) Invalidate(v Visual) {
+	if id, ok := ml.mux.Find(v); ok {
+		ml.invalidateID(id)
+	}
+}
+
+// invalidateID marks the visual dirty and wakes the loop without blocking.
+func (ml *MainLoop) invalidateID(id VisualID) {
+	ml.mux.Invalidate(id)
+	ml.requestRender()
+}
+
+// requestRender queues a render, coalescing requests until it happens.
+func (ml *MainLoop) requestRender() {
+	if ml.renderRequested.CompareAndSwap(false, true) {
+		select {
+		case ml.events <- invalidateEvent{}:
+		default:
+			// Queue is full; the pending events will trigger a render anyway.
+			ml.renderRequested.Store(false)
+		}
+	}
+}
+
+// Snapshot returns the most recently rendered frame, including ANSI styling.
+// Before the first render it composes a frame from the mounted visuals.
+func (ml *MainLoop) Snapshot() string {
+	ml.frameMu.Lock()
+	frame := string(ml.lastFrame)
+	rendered := ml.lastFrame != nil
+	ml.frameMu.Unlock()
+
+	if rendered {
+		return frame
+	}
+	bw := &bufferWriter{}
+	ml.composeFrame(bw)
+	return string(bw.Bytes())
+}
+
+// SnapshotPlain returns Snapshot with all escape sequences removed, suitable
+// for log files and error reports.
+func (ml *MainLoop) SnapshotPlain() string {
+	return stripANSI(ml.Snapshot())
+}
+
 // IsRunning checks if the loop is currently active.
 func (ml *MainLoop) IsRunning() bool {
 	return ml.running.Load()
 }
 
+// restoreTerminal resets terminal modes the loop (or its visuals) may have changed.
+func (ml *MainLoop) restoreTerminal() {
+	if ml.writer.IsTerminal() {
+		ml.writer.Write([]byte(terminalResetSeq))
+	}
+	if ml.rawState != nil {
+		ml.writer.RestoreMode(ml.rawState)
+	}
+}
+
 // --- Internal Event Producers ---
 
 func (ml *MainLoop) produceKeyEvents(ctx context.Context) {
@@ -196,13 +349,7 @@ func (ml *MainLoop) produceTickEvents(ctx context.Context) {
 
 func (ml *MainLoop) produceSignalEvents(ctx context.Context) {
 	ml.signals.OnResize(func() {
-		cols, rows, err := ml.writer.GetSize()
-		if err == nil {
-			select {
-			case ml.events <- resizeEvent{cols: cols, rows: rows}:
-			case <-ctx.Done():
-			}
-		}
+		ml.scheduleResize(ctx)
 	})
 	ml.signals.OnStop(func() {
 		ml.Stop()
@@ -231,6 +378,11 @@ func (ml *MainLoop) handleEvent(e any) (bool, bool) {
 		// If no component stopped the loop, we assume a state change and re-render.
 		return false, true
 	case tickEvent:
+		// Deliver any resize that was deferred to this tick.
+		if ml.pendingResize != nil {
+			ml.mux.OnResize(ml.pendingResize.cols, ml.pendingResize.rows)
+			ml.pendingResize = nil
+		}
 		// Dispatch tick to all visuals.
 		for _, id := range ml.mux.ListVisuals() {
 			if v, ok := ml.mux.GetVisual(id); ok {
@@ -240,10 +392,18 @@ func (ml *MainLoop) handleEvent(e any) (bool, bool) {
 		// A tick always implies a potential visual change.
 		return false, true
 	case resizeEvent:
+		if ml.resizePolicy == ResizeOnNextTick {
+			// Keep only the latest size; the next tick delivers it.
+			ml.pendingResize = &event
+			return false, false
+		}
 		// Dispatch resize to all visuals.
 		ml.mux.OnResize(event.cols, event.rows)
 		// A resize always requires a full re-render.
 		return false, true
+	case invalidateEvent:
+		ml.renderRequested.Store(false)
+		return false, true
 	case errorEvent:
 		// Log or handle error, for now we stop.
 		fmt.Fprintf(os.Stderr, "runfx error: %v\n", event)
@@ -254,21 +414,26 @@ func (ml *MainLoop) handleEvent(e any) (bool, bool) {
 }
 
 // renderFrame clears the screen and renders all mounted visuals.
-func (ml *MainLoop) renderFrame() {
+// It reports whether the composed frame differs from the previous one.
+func (ml *MainLoop) renderFrame() bool {
+	ml.scrollOut()
+
 	bw := &bufferWriter{}
-	ml.mux.Render(bw)
+	ml.composeFrame(bw)
 
 	cur := bw.Bytes()
+	changed := !bytes.Equal(cur, ml.lastFrame)
+	ml.frameMu.Lock()
+	ml.lastFrame = append(ml.lastFrame[:0], cur...)
+	ml.frameMu.Unlock()
+
+	// Pad by display cells, not bytes, so wide characters and emoji from
+	// the previous frame are fully cleared.
+	widths := frameLineWidths(cur)
 	ml.writer.MoveCursor(1, 1)
-	ml.writer.Write(cur)
-
-	// Clear any leftover content from previous frame
-	if ml.lastLen > len(cur) {
-		pad := bytes.Repeat([]byte(" "), ml.lastLen-len(cur))
-		ml.writer.Write(pad)
-		ml.writer.Write([]byte("\r"))
-	}
+	ml.writer.Write(padFrame(cur, widths, ml.lastWidths))
 
 	ml.writer.Flush()
-	ml.lastLen = len(cur)
+	ml.lastWidths = widths
+	return changed
 }
@@ -95,3 +95,350 @@ func TestRunAlreadyRunning(t *testing.T) {
 	pw.Close()
 	<-done
 }
+
+type resizeRecorder struct {
+	dummyVisual
+	sizes [][2]int
+}
+
+func (r *resizeRecorder) OnResize(cols, rows int) {
+	r.sizes = append(r.sizes, [2]int{cols, rows})
+}
+
+// TestResizeOnNextTick ensures resizes are coalesced and delivered on the next tick
+func TestResizeOnNextTick(t *testing.T) {
+	loop := StartWith(Config{
+		Output:       io.Discard,
+		TickInterval: time.Millisecond,
+		ResizePolicy: ResizeOnNextTick,
+	})
+	ml := loop.(*MainLoop)
+
+	rec := &resizeRecorder{}
+	ml.mux.Mount(rec)
+
+	if _, render := ml.handleEvent(resizeEvent{cols: 80, rows: 24}); render {
+		t.Fatal("resize should not render before the next tick")
+	}
+	ml.handleEvent(resizeEvent{cols: 100, rows: 30})
+	if len(rec.sizes) != 0 {
+		t.Fatalf("expected no resize before tick, got %v", rec.sizes)
+	}
+
+	ml.handleEvent(tickEvent{time: time.Now()})
+	if len(rec.sizes) != 1 || rec.sizes[0] != [2]int{100, 30} {
+		t.Fatalf("expected single coalesced resize to 100x30, got %v", rec.sizes)
+	}
+}
+
+// TestResizeImmediate ensures the immediate policy delivers resizes right away
+func TestResizeImmediate(t *testing.T) {
+	loop := New().Output(io.Discard).ResizePolicy(ResizeImmediate).Start()
+	ml := loop.(*MainLoop)
+
+	rec := &resizeRecorder{}
+	ml.mux.Mount(rec)
+
+	if _, render := ml.handleEvent(resizeEvent{cols: 80, rows: 24}); !render {
+		t.Fatal("immediate resize should trigger a render")
+	}
+	if len(rec.sizes) != 1 {
+		t.Fatalf("expected one resize, got %v", rec.sizes)
+	}
+}
+
+type lifecycleVisual struct {
+	dummyVisual
+	ctx       context.Context
+	unmounted int
+}
+
+func (l *lifecycleVisual) Mounted(ctx context.Context) { l.ctx = ctx }
+func (l *lifecycleVisual) Unmounted()                  { l.unmounted++ }
+
+// TestMountableLifecycle verifies Mounted/Unmounted callbacks and context cancelation
+func TestMountableLifecycle(t *testing.T) {
+	loop := Start()
+	v := &lifecycleVisual{}
+
+	unmount, err := loop.Mount(v)
+	if err != nil {
+		t.Fatalf("mount failed: %v", err)
+	}
+	if v.ctx == nil || v.ctx.Err() != nil {
+		t.Fatal("expected a live mount context")
+	}
+
+	unmount()
+	unmount()
+	if v.ctx.Err() == nil {
+		t.Fatal("expected mount context to be canceled on unmount")
+	}
+	if v.unmounted != 1 {
+		t.Fatalf("expected Unmounted once, got %d", v.unmounted)
+	}
+}
+
+// TestLoopHooks verifies OnStart and OnStop are invoked around Run
+func TestLoopHooks(t *testing.T) {
+	started := make(chan struct{})
+	var stopErr error
+	loop := StartWith(Config{
+		Output:       io.Discard,
+		TickInterval: time.Millisecond,
+		OnStart:      func(context.Context) { close(started) },
+		OnStop:       func(err error) { stopErr = err },
+	})
+	ml := loop.(*MainLoop)
+
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	ml.reader = NewKeyReader(pr)
+
+	done := make(chan error, 1)
+	go func() { done <- ml.Run(context.Background()) }()
+
+	<-started
+	ml.Stop()
+	err := <-done
+	if stopErr != err || err != context.Canceled {
+		t.Fatalf("expected OnStop to receive %v, got %v", err, stopErr)
+	}
+}
+
+// TestIdleThrottle verifies the loop slows down when idle and recovers on input
+func TestIdleThrottle(t *testing.T) {
+	loop := StartWith(Config{
+		Output:           io.Discard,
+		TickInterval:     time.Millisecond,
+		IdleAfter:        3,
+		IdleTickInterval: time.Second,
+	})
+	ml := loop.(*MainLoop)
+	defer ml.ticker.Stop()
+
+	tick := tickEvent{time: time.Now()}
+	for i := 0; i < 3; i++ {
+		ml.trackIdle(tick, false)
+	}
+	if !ml.IsIdle() {
+		t.Fatal("expected loop to be idle after 3 unchanged ticks")
+	}
+
+	ml.trackIdle(keyEvent(Key{Code: KeyA}), false)
+	if ml.IsIdle() {
+		t.Fatal("expected key input to leave idle mode")
+	}
+
+	ml.trackIdle(tick, false)
+	ml.trackIdle(tick, true)
+	if ml.idleTicks != 0 {
+		t.Fatalf("expected changed frame to reset idle counter, got %d", ml.idleTicks)
+	}
+}
+
+// TestIsIdleConcurrent reads the idle flag while the loop updates it; run
+// with -race.
+func TestIsIdleConcurrent(t *testing.T) {
+	loop := StartWith(Config{
+		Output:           io.Discard,
+		TickInterval:     time.Millisecond,
+		IdleAfter:        1,
+		IdleTickInterval: time.Second,
+	})
+	ml := loop.(*MainLoop)
+	defer ml.ticker.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			ml.IsIdle()
+		}
+	}()
+	tick := tickEvent{time: time.Now()}
+	for i := 0; i < 100; i++ {
+		ml.trackIdle(tick, i%2 == 0)
+	}
+	<-done
+}
+
+type retainedVisual struct {
+	dummyVisual
+	text       string
+	renders    int
+	invalidate func()
+}
+
+func (r *retainedVisual) Render(w writer.Writer)           { r.renders++; w.Write([]byte(r.text)) }
+func (r *retainedVisual) SetInvalidator(invalidate func()) { r.invalidate = invalidate }
+
+// TestInvalidateRetainedVisual verifies cached output is reused until invalidated
+func TestInvalidateRetainedVisual(t *testing.T) {
+	loop := StartWith(Config{Output: io.Discard, TickInterval: time.Millisecond})
+	ml := loop.(*MainLoop)
+
+	v := &retainedVisual{text: "a"}
+	if _, err := ml.Mount(v); err != nil {
+		t.Fatalf("mount failed: %v", err)
+	}
+
+	ml.renderFrame()
+	ml.renderFrame()
+	if v.renders != 1 {
+		t.Fatalf("expected a single render before invalidation, got %d", v.renders)
+	}
+
+	v.text = "b"
+	v.invalidate()
+	if changed := ml.renderFrame(); !changed || string(ml.lastFrame) != "b" {
+		t.Fatalf("expected repaint with new text, got %q", ml.lastFrame)
+	}
+
+	ml.Invalidate(v)
+	ml.renderFrame()
+	if v.renders != 3 {
+		t.Fatalf("expected Loop.Invalidate to force a render, got %d renders", v.renders)
+	}
+}
+
+// selfInvalidating invalidates itself through the loop from Render and Tick.
+type selfInvalidating struct {
+	retainedVisual
+	loop *MainLoop
+}
+
+func (s *selfInvalidating) Render(w writer.Writer) {
+	s.retainedVisual.Render(w)
+	s.loop.Invalidate(s)
+	s.invalidate()
+}
+
+func (s *selfInvalidating) Tick(time.Time) {
+	s.loop.Invalidate(s)
+	s.invalidate()
+}
+
+// TestInvalidateFromRenderAndTick ensures a visual may invalidate itself while
+// the loop renders or ticks it without deadlocking.
+func TestInvalidateFromRenderAndTick(t *testing.T) {
+	loop := StartWith(Config{Output: io.Discard, TickInterval: time.Millisecond})
+	ml := loop.(*MainLoop)
+	v := &selfInvalidating{retainedVisual: retainedVisual{text: "a"}, loop: ml}
+	if _, err := ml.Mount(v); err != nil {
+		t.Fatalf("mount failed: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ml.renderFrame()
+		ml.handleEvent(tickEvent{time: time.Now()})
+		ml.renderFrame()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("invalidating from Render or Tick deadlocked")
+	}
+	if v.renders != 2 {
+		t.Fatalf("expected the invalidation during Render to force a repaint, got %d renders", v.renders)
+	}
+}
+
+type textVisual struct {
+	dummyVisual
+	text string
+}
+
+func (v *textVisual) Render(w writer.Writer) { w.Write([]byte(v.text)) }
+
+// TestSnapshot checks styled and plain snapshots before and after rendering
+func TestSnapshot(t *testing.T) {
+	loop := StartWith(Config{Output: io.Discard, TickInterval: time.Millisecond})
+	v := &textVisual{text: "\033[32mready\033[0m\n"}
+	if _, err := loop.Mount(v); err != nil {
+		t.Fatalf("mount failed: %v", err)
+	}
+
+	if got := loop.Snapshot(); got != v.text {
+		t.Fatalf("expected composed frame before first render, got %q", got)
+	}
+
+	loop.(*MainLoop).renderFrame()
+	v.text = "changed"
+	if got := loop.Snapshot(); got != "\033[32mready\033[0m\n" {
+		t.Fatalf("expected last rendered frame, got %q", got)
+	}
+	if got := loop.SnapshotPlain(); got != "ready\n" {
+		t.Fatalf("expected plain snapshot, got %q", got)
+	}
+}
+
+type contextVisual struct {
+	dummyVisual
+	ctx context.Context
+}
+
+func (v *contextVisual) Mounted(ctx context.Context) { v.ctx = ctx }
+func (v *contextVisual) Unmounted()                  {}
+
+// TestLoopValues verifies visuals can read loop values from their mount context
+func TestLoopValues(t *testing.T) {
+	type themeKey struct{}
+	loop := Start(WithOutput(io.Discard), WithValue(themeKey{}, "dark"))
+
+	v := &contextVisual{}
+	if _, err := loop.Mount(v); err != nil {
+		t.Fatalf("mount failed: %v", err)
+	}
+
+	if theme, ok := ValueFrom[string](v.ctx, themeKey{}); !ok || theme != "dark" {
+		t.Fatalf("expected theme dark, got %q (%v)", theme, ok)
+	}
+	if tty, ok := ValueFrom[TTYInfo](v.ctx, TTYInfoKey); !ok || tty.IsTTY {
+		t.Fatalf("expected detected non-TTY info, got %+v (%v)", tty, ok)
+	}
+
+	// Values set after mounting are visible through the same context.
+	loop.SetValue(themeKey{}, "light")
+	if theme, _ := ValueFrom[string](v.ctx, themeKey{}); theme != "light" {
+		t.Fatalf("expected updated theme light, got %q", theme)
+	}
+	loop.SetValue(themeKey{}, nil)
+	if loop.Value(themeKey{}) != nil {
+		t.Fatal("expected nil value to remove the key")
+	}
+}
+
+// TestMaxFPSCoalescesFrames verifies renders faster than the cap are deferred
+func TestMaxFPSCoalescesFrames(t *testing.T) {
+	loop := Start(WithOutput(io.Discard), WithTickInterval(time.Millisecond), WithMaxFPS(20))
+	ml := loop.(*MainLoop)
+	defer ml.ticker.Stop()
+	defer ml.stopFrameTimer()
+
+	v := &retainedVisual{text: "a"}
+	loop.Mount(v)
+
+	if _, rendered := ml.renderCapped(); !rendered {
+		t.Fatal("expected first frame to render immediately")
+	}
+	for i := 0; i < 5; i++ {
+		v.invalidate()
+		if _, rendered := ml.renderCapped(); rendered {
+			t.Fatal("expected frames within the cap to be deferred")
+		}
+	}
+
+	select {
+	case <-ml.frameDue():
+		ml.frameTimer = nil
+		ml.renderNow()
+	case <-time.After(time.Second):
+		t.Fatal("expected a deferred frame to be scheduled")
+	}
+	if v.renders != 2 {
+		t.Fatalf("expected bursts to coalesce into one frame, got %d renders", v.renders)
+	}
+}
@@ -1,6 +1,7 @@
 package runfx
 
 import (
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -20,12 +21,18 @@ type VisualID uint64
 type Multiplexer struct {
 	nextID  uint64
 	visuals map[VisualID]Visual
+	cache   map[VisualID][]byte // last output of Invalidatable visuals
+	dirty   map[VisualID]bool   // Invalidatable visuals awaiting a repaint
 	mu      sync.Mutex
 }
 
 // NewMultiplexer creates a new instance of the multiplexer.
 func NewMultiplexer() *Multiplexer {
-	return &Multiplexer{visuals: make(map[VisualID]Visual)}
+	return &Multiplexer{
+		visuals: make(map[VisualID]Visual),
+		cache:   make(map[VisualID][]byte),
+		dirty:   make(map[VisualID]bool),
+	}
 }
 
 // Mount registers a new visual component and assigns it a unique ID.
@@ -37,6 +44,7 @@ func (m *Multiplexer) Mount(v Visual) VisualID {
 	// Atomically generates a unique ID.
 	id := VisualID(atomic.AddUint64(&m.nextID, 1))
 	m.visuals[id] = v
+	m.dirty[id] = true
 	return id
 }
 
@@ -45,6 +53,39 @@ func (m *Multiplexer) Unmount(id VisualID) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	delete(m.visuals, id)
+	delete(m.cache, id)
+	delete(m.dirty, id)
+}
+
+// Invalidate marks a visual as needing a repaint on the next render.
+func (m *Multiplexer) Invalidate(id VisualID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.visuals[id]; ok {
+		m.dirty[id] = true
+	}
+}
+
+// Find returns the ID of a mounted visual.
+func (m *Multiplexer) Find(v Visual) (VisualID, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, mounted := range m.visuals {
+		if sameVisual(mounted, v) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// sameVisual compares two visuals without panicking on non-comparable types.
+func sameVisual(a, b Visual) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
 }
 
 // GetVisual retrieves a visual component by its ID.
@@ -55,14 +96,20 @@ func (m *Multiplexer) GetVisual(id VisualID) (Visual, bool) {
 	return v, ok
 }
 
-// ListVisuals returns a list of the IDs of all mounted components.
+// ListVisuals returns the IDs of all mounted components in mount order.
 func (m *Multiplexer) ListVisuals() []VisualID {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	return m.orderedIDs()
+}
+
+// orderedIDs returns visual IDs sorted by mount order. Callers must hold mu.
+func (m *Multiplexer) orderedIDs() []VisualID {
 	ids := make([]VisualID, 0, len(m.visuals))
 	for id := range m.visuals {
 		ids = append(ids, id)
 	}
+	slices.Sort(ids)
 	return ids
 }
 
@@ -73,25 +120,73 @@ func (m *Multiplexer) Count() int {
 	return len(m.visuals)
 }
 
-// Render iterates through all mounted visuals and writes their output to w.
+// Render iterates through all mounted visuals in mount order and writes their
+// output to w. Invalidatable visuals are only re-rendered when dirty; otherwise
+// their cached output from the previous render is reused.
+//
+// Visuals render without m.mu held, so they may invalidate themselves, or
+// call any other method of the multiplexer, from Render.
 func (m *Multiplexer) Render(w writer.Writer) {
+	type entry struct {
+		id     VisualID
+		v      Visual
+		cached []byte // Reused output; nil to render
+	}
+
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	ids := m.orderedIDs()
+	entries := make([]entry, 0, len(ids))
+	for _, id := range ids {
+		v := m.visuals[id]
+		if v == nil {
+			continue
+		}
+		e := entry{id: id, v: v}
+		if _, retained := v.(Invalidatable); retained && !m.dirty[id] {
+			e.cached = m.cache[id]
+		}
+		// Cleared before rendering, so an Invalidate during Render is kept.
+		m.dirty[id] = false
+		entries = append(entries, e)
+	}
+	m.mu.Unlock()
 
-	for _, v := range m.visuals {
-		if v != nil {
-			v.Render(w)
+	for _, e := range entries {
+		if e.cached != nil {
+			w.Write(e.cached)
+			continue
+		}
+		if _, retained := e.v.(Invalidatable); !retained {
+			e.v.Render(w)
+			continue
+		}
+		bw := &bufferWriter{}
+		e.v.Render(bw)
+		out := append([]byte{}, bw.Bytes()...)
+		m.mu.Lock()
+		if _, mounted := m.visuals[e.id]; mounted {
+			m.cache[e.id] = out
 		}
+		m.mu.Unlock()
+		w.Write(out)
 	}
 }
 
 // OnResize notifies all visual components of a terminal resize event.
+// Visuals are notified without m.mu held.
 func (m *Multiplexer) OnResize(cols, rows int) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-	for _, v := range m.visuals {
-		if v != nil {
-			v.OnResize(cols, rows)
+	ids := m.orderedIDs()
+	visuals := make([]Visual, 0, len(ids))
+	for _, id := range ids {
+		if v := m.visuals[id]; v != nil {
+			visuals = append(visuals, v)
+			m.dirty[id] = true
 		}
 	}
+	m.mu.Unlock()
+
+	for _, v := range visuals {
+		v.OnResize(cols, rows)
+	}
 }
@@ -0,0 +1,72 @@
+package runfx
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ttyOwner describes the current holder of the process-wide terminal.
+type ttyOwner struct {
+	name  string
+	loop  Loop
+	since time.Time
+}
+
+// ttyRegistry arbitrates terminal ownership so that only one loop (or other
+// full-screen component) drives the TTY at a time.
+var ttyRegistry struct {
+	mu    sync.Mutex
+	owner *ttyOwner
+}
+
+// AcquireTTY claims exclusive ownership of the terminal for name. It returns
+// a release function that must be called once the terminal is no longer
+// used, or an error wrapping ErrTTYOwned that names the current owner.
+func AcquireTTY(name string) (release func(), err error) {
+	return acquireTTY(name, nil)
+}
+
+func acquireTTY(name string, loop Loop) (func(), error) {
+	ttyRegistry.mu.Lock()
+	defer ttyRegistry.mu.Unlock()
+
+	if cur := ttyRegistry.owner; cur != nil {
+		return nil, fmt.Errorf("%w: held by %s since %s; mount visuals into the active loop (runfx.Shared) instead of starting another",
+			ErrTTYOwned, cur.name, cur.since.Format(time.TimeOnly))
+	}
+
+	owner := &ttyOwner{name: name, loop: loop, since: time.Now()}
+	ttyRegistry.owner = owner
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ttyRegistry.mu.Lock()
+			defer ttyRegistry.mu.Unlock()
+			if ttyRegistry.owner == owner {
+				ttyRegistry.owner = nil
+			}
+		})
+	}, nil
+}
+
+// TTYOwner reports the name of the current terminal owner, if any.
+func TTYOwner() (string, bool) {
+	ttyRegistry.mu.Lock()
+	defer ttyRegistry.mu.Unlock()
+	if ttyRegistry.owner == nil {
+		return "", false
+	}
+	return ttyRegistry.owner.name, true
+}
+
+// ActiveLoop returns the running loop that owns the terminal, if any.
+func ActiveLoop() (Loop, bool) {
+	ttyRegistry.mu.Lock()
+	defer ttyRegistry.mu.Unlock()
+	if ttyRegistry.owner == nil || ttyRegistry.owner.loop == nil {
+		return nil, false
+	}
+	return ttyRegistry.owner.loop, true
+}
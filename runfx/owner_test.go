@@ -0,0 +1,49 @@
+package runfx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestAcquireTTYRejectsDoubleOwnership checks the descriptive ownership error
+func TestAcquireTTYRejectsDoubleOwnership(t *testing.T) {
+	release, err := AcquireTTY("first")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = AcquireTTY("second")
+	if !errors.Is(err, ErrTTYOwned) {
+		t.Fatalf("expected ErrTTYOwned, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "first") {
+		t.Errorf("expected error to name the owner, got %q", err)
+	}
+
+	release()
+	release()
+	if _, ok := TTYOwner(); ok {
+		t.Fatal("expected terminal to be released")
+	}
+
+	release, err = AcquireTTY("second")
+	if err != nil {
+		t.Fatalf("expected reacquire to succeed, got %v", err)
+	}
+	release()
+}
+
+// TestSharedReturnsActiveLoop verifies Shared reuses the owning loop
+func TestSharedReturnsActiveLoop(t *testing.T) {
+	owner := Start(WithOutput(&strings.Builder{}))
+	release, err := acquireTTY("test loop", owner)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer release()
+
+	if got := Shared(); got != owner {
+		t.Fatal("expected Shared to return the active loop")
+	}
+}
@@ -0,0 +1,77 @@
+package runfx
+
+import (
+	"context"
+	"time"
+)
+
+// ResizePolicy controls how terminal resize events are propagated to visuals.
+type ResizePolicy int
+
+const (
+	// ResizeDebounced waits for the terminal to stop resizing for the configured
+	// debounce window before notifying visuals. This is the default.
+	ResizeDebounced ResizePolicy = iota
+	// ResizeImmediate notifies visuals as soon as a resize is detected.
+	ResizeImmediate
+	// ResizeOnNextTick coalesces resizes and notifies visuals on the next tick.
+	ResizeOnNextTick
+)
+
+// DefaultResizeDebounce is the quiet period used by ResizeDebounced.
+const DefaultResizeDebounce = 50 * time.Millisecond
+
+// String returns a human-readable name for the policy.
+func (p ResizePolicy) String() string {
+	switch p {
+	case ResizeDebounced:
+		return "Debounced"
+	case ResizeImmediate:
+		return "Immediate"
+	case ResizeOnNextTick:
+		return "OnNextTick"
+	default:
+		return "Unknown"
+	}
+}
+
+// scheduleResize is invoked for every raw resize notification. Under the
+// debounced policy it restarts the debounce timer so that a storm of
+// SIGWINCH signals during a drag-resize results in a single event.
+func (ml *MainLoop) scheduleResize(ctx context.Context) {
+	if ml.resizePolicy != ResizeDebounced || ml.resizeDebounce <= 0 {
+		ml.emitResize(ctx)
+		return
+	}
+
+	ml.resizeMu.Lock()
+	defer ml.resizeMu.Unlock()
+	if ml.resizeTimer != nil {
+		ml.resizeTimer.Stop()
+	}
+	ml.resizeTimer = time.AfterFunc(ml.resizeDebounce, func() {
+		ml.emitResize(ctx)
+	})
+}
+
+// emitResize reads the current terminal size and queues a resize event.
+func (ml *MainLoop) emitResize(ctx context.Context) {
+	cols, rows, err := ml.writer.GetSize()
+	if err != nil {
+		return
+	}
+	select {
+	case ml.events <- resizeEvent{cols: cols, rows: rows}:
+	case <-ctx.Done():
+	}
+}
+
+// stopResizeTimer cancels any pending debounced resize.
+func (ml *MainLoop) stopResizeTimer() {
+	ml.resizeMu.Lock()
+	defer ml.resizeMu.Unlock()
+	if ml.resizeTimer != nil {
+		ml.resizeTimer.Stop()
+		ml.resizeTimer = nil
+	}
+}
@@ -0,0 +1,88 @@
+package runfx
+
+import (
+	"context"
+	"sync"
+)
+
+// valueKey is the type of the keys predefined by runfx.
+type valueKey string
+
+// TTYInfoKey holds the TTYInfo detected for the loop's output, so visuals can
+// read capabilities without re-running terminal detection.
+const TTYInfoKey valueKey = "runfx.tty"
+
+// loopValues stores values attached to a loop. Lookups happen on every
+// Render/Tick, so reads only take a read lock.
+type loopValues struct {
+	mu sync.RWMutex
+	m  map[any]any
+}
+
+func newLoopValues(initial map[any]any) *loopValues {
+	m := make(map[any]any, len(initial)+1)
+	for k, v := range initial {
+		m[k] = v
+	}
+	return &loopValues{m: m}
+}
+
+func (lv *loopValues) get(key any) (any, bool) {
+	lv.mu.RLock()
+	defer lv.mu.RUnlock()
+	v, ok := lv.m[key]
+	return v, ok
+}
+
+func (lv *loopValues) set(key, val any) {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+	if val == nil {
+		delete(lv.m, key)
+		return
+	}
+	lv.m[key] = val
+}
+
+// valuesContext resolves keys against the loop's values before its parent,
+// so values set after mounting are still visible to visuals.
+type valuesContext struct {
+	context.Context
+	values *loopValues
+}
+
+func (c valuesContext) Value(key any) any {
+	if v, ok := c.values.get(key); ok {
+		return v
+	}
+	return c.Context.Value(key)
+}
+
+// ValueFrom returns the value stored under key in ctx as a T. Visuals use it
+// with the context received in Mounted to read loop values during Render or
+// Tick.
+func ValueFrom[T any](ctx context.Context, key any) (T, bool) {
+	var zero T
+	if ctx == nil {
+		return zero, false
+	}
+	v, ok := ctx.Value(key).(T)
+	return v, ok
+}
+
+// SetValue attaches val to the loop under key, replacing any previous value.
+// A nil val removes the key. Keys follow context.WithValue conventions.
+func (ml *MainLoop) SetValue(key, val any) {
+	ml.values.set(key, val)
+}
+
+// Value returns the value attached to the loop under key, or nil.
+func (ml *MainLoop) Value(key any) any {
+	v, _ := ml.values.get(key)
+	return v
+}
+
+// withValues wraps ctx so that it exposes the loop's values.
+func (ml *MainLoop) withValues(ctx context.Context) context.Context {
+	return valuesContext{Context: ctx, values: ml.values}
+}
@@ -0,0 +1,243 @@
+package runfx
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges lists code points that occupy two terminal cells: East Asian
+// Wide and Fullwidth characters plus emoji with default emoji presentation.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x17000, 0x18AFF}, {0x1B000, 0x1B2FF}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F251}, {0x1F300, 0x1F64F},
+	{0x1F680, 0x1F6FF}, {0x1F7E0, 0x1F7EB}, {0x1F90C, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+const (
+	zeroWidthJoiner = '\u200d'
+	emojiVariation  = '\ufe0f'
+)
+
+// RuneWidth returns the number of terminal cells r occupies: 0 for control
+// characters and combining marks, 2 for wide characters and emoji, 1 otherwise.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case r < 0x1100:
+		if unicode.In(r, unicode.Mn, unicode.Me) {
+			return 0
+		}
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf), r >= 0xFE00 && r <= 0xFE0F:
+		return 0
+	}
+
+	i := sort.Search(len(wideRanges), func(i int) bool { return wideRanges[i][1] >= r })
+	if i < len(wideRanges) && r >= wideRanges[i][0] {
+		return 2
+	}
+	return 1
+}
+
+// StringWidth returns the display width of s in terminal cells. ANSI escape
+// sequences (CSI, OSC and two-byte escapes) are ignored, a variation selector
+// 16 widens the preceding symbol to emoji width, and characters joined with a
+// zero width joiner are counted once.
+func StringWidth(s string) int {
+	width := 0
+	prevWidth := 0
+	joined := false
+
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			i += escapeLen(s[i:])
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+
+		switch {
+		case r == zeroWidthJoiner:
+			joined = true
+			continue
+		case r == emojiVariation:
+			if prevWidth == 1 {
+				width++
+				prevWidth = 2
+			}
+			continue
+		}
+
+		w := RuneWidth(r)
+		if joined && w > 0 {
+			// Part of an emoji ZWJ sequence rendered as a single glyph.
+			joined = false
+			continue
+		}
+		width += w
+		if w > 0 {
+			prevWidth = w
+		}
+	}
+	return width
+}
+
+// stripANSI removes escape sequences (colors, cursor movement, hyperlinks)
+// from s, leaving only the visible text. Unlike color.StripANSI it skips
+// every kind of sequence, not only colors.
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			i += escapeLen(s[i:])
+			continue
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
+
+// escapeLen returns the byte length of the escape sequence at the start of s.
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[': // CSI: parameters end with a byte in 0x40–0x7E
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7E {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']': // OSC: terminated by BEL or ST (ESC \)
+		for i := 2; i < len(s); i++ {
+			if s[i] == 0x07 {
+				return i + 1
+			}
+			if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	default:
+		return 2
+	}
+}
+
+// frameLineWidths returns the display width of each line of a frame. Only
+// the text after the last carriage return of a line is visible.
+func frameLineWidths(frame []byte) []int {
+	var widths []int
+	start := 0
+	for i := 0; i <= len(frame); i++ {
+		if i == len(frame) || frame[i] == '\n' {
+			line := frame[start:i]
+			for j := len(line) - 1; j >= 0; j-- {
+				if line[j] == '\r' {
+					line = line[j+1:]
+					break
+				}
+			}
+			widths = append(widths, StringWidth(string(line)))
+			start = i + 1
+		}
+	}
+	return widths
+}
+
+// padFrame appends spaces to each line of cur so that any wider content from
+// the previous frame is fully overwritten, measuring widths in terminal cells.
+func padFrame(cur []byte, curWidths, prevWidths []int) []byte {
+	out := make([]byte, 0, len(cur)+16)
+	line := 0
+	for _, b := range cur {
+		if b == '\n' {
+			out = appendPad(out, prevWidths, curWidths, line)
+			line++
+		}
+		out = append(out, b)
+	}
+	out = appendPad(out, prevWidths, curWidths, line)
+
+	// Blank out lines that only existed in the previous frame. The carriage
+	// return matters in raw mode, where a bare newline keeps the column.
+	for extra := line + 1; extra < len(prevWidths); extra++ {
+		out = append(out, '\r', '\n')
+		out = appendSpaces(out, prevWidths[extra])
+	}
+	return out
+}
+
+func appendPad(out []byte, prevWidths, curWidths []int, line int) []byte {
+	if line >= len(prevWidths) || line >= len(curWidths) {
+		return out
+	}
+	return appendSpaces(out, prevWidths[line]-curWidths[line])
+}
+
+func appendSpaces(out []byte, n int) []byte {
+	for ; n > 0; n-- {
+		out = append(out, ' ')
+	}
+	return out
+}
+
+// TruncateWidth shortens s so that it occupies at most max terminal cells.
+// Escape sequences are kept intact and do not count towards the width.
+func TruncateWidth(s string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+	if StringWidth(s) <= max {
+		return s
+	}
+
+	var b []byte
+	width := 0
+	full := false
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			// Escapes are kept even past the cut so styles are reset.
+			n := escapeLen(s[i:])
+			b = append(b, s[i:i+n]...)
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		if full {
+			continue
+		}
+		w := RuneWidth(r)
+		if width+w > max {
+			full = true
+			continue
+		}
+		b = append(b, s[i-size:i]...)
+		width += w
+	}
+	return string(b)
+}
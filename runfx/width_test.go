@@ -0,0 +1,49 @@
+package runfx
+
+import "testing"
+
+// TestStringWidth checks cell widths for ASCII, CJK, emoji and escapes
+func TestStringWidth(t *testing.T) {
+	cases := []struct {
+		in   string
+		want int
+	}{
+		{"hello", 5},
+		{"日本語", 6},
+		{"a🚀b", 4},
+		{"é", 1},
+		{"\x1b[31mred\x1b[0m", 3},
+		{"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", 4},
+		{"ℹ️", 2},
+		{"👩‍💻", 2},
+		{"ｆｕｌｌ", 8},
+	}
+	for _, c := range cases {
+		if got := StringWidth(c.in); got != c.want {
+			t.Errorf("StringWidth(%q) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
+
+// TestPadFrameUsesCellWidth verifies leftovers are padded by display width
+func TestPadFrameUsesCellWidth(t *testing.T) {
+	prev := frameLineWidths([]byte("漢字漢字\nsecond"))
+	cur := []byte("ab")
+	got := string(padFrame(cur, frameLineWidths(cur), prev))
+	want := "ab      \r\n      "
+	if got != want {
+		t.Fatalf("padFrame = %q, want %q", got, want)
+	}
+}
+
+// TestPadFrameShrinksByTwoLines verifies dropped lines are blanked from the
+// first column
+func TestPadFrameShrinksByTwoLines(t *testing.T) {
+	prev := frameLineWidths([]byte("one\ntwo\nthree"))
+	cur := []byte("one")
+	got := string(padFrame(cur, frameLineWidths(cur), prev))
+	want := "one\r\n   \r\n     "
+	if got != want {
+		t.Fatalf("padFrame = %q, want %q", got, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -180,3 +181,38 @@ func isColorDisabled() bool {
 
 	return false
 }
+
+// SupportsHyperlinks reports whether the terminal is known to render OSC 8
+// hyperlinks. FORCE_HYPERLINK=1 or 0 overrides detection.
+func (d *Detector) SupportsHyperlinks() bool {
+	if force := os.Getenv("FORCE_HYPERLINK"); force != "" {
+		return force != "0" && force != "false"
+	}
+	if !d.SupportsANSI() {
+		return false
+	}
+	return hyperlinkTerminal()
+}
+
+// hyperlinkTerminal checks environment hints for terminals with OSC 8 support.
+func hyperlinkTerminal() bool {
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty", "Tabby", "rio":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+	// VTE based terminals (GNOME Terminal, Tilix, ...) since 0.50
+	if vte, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true
+	}
+
+	term := strings.ToLower(os.Getenv("TERM"))
+	for _, name := range []string{"kitty", "alacritty", "foot", "ghostty", "wezterm"} {
+		if strings.Contains(term, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -45,3 +45,17 @@ func TestIsTerminalAndEnableANSI(t *testing.T) {
 func TestIsColorDisabled(t *testing.T) {
 	_ = isColorDisabled()
 }
+
+func TestSupportsHyperlinksOverride(t *testing.T) {
+	det := NewDetector(&bytes.Buffer{})
+
+	t.Setenv("FORCE_HYPERLINK", "1")
+	if !det.SupportsHyperlinks() {
+		t.Error("FORCE_HYPERLINK=1 should enable hyperlinks")
+	}
+
+	t.Setenv("FORCE_HYPERLINK", "0")
+	if det.SupportsHyperlinks() {
+		t.Error("FORCE_HYPERLINK=0 should disable hyperlinks")
+	}
+}
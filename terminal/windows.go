@@ -7,9 +7,14 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 	"unsafe"
 )
 
+// resizePollInterval is how often the console size is sampled on Windows,
+// which has no SIGWINCH equivalent for console applications.
+const resizePollInterval = 200 * time.Millisecond
+
 // Windows-specific syscalls
 var (
 	kernel32           = syscall.NewLazyDLL("kernel32.dll")
@@ -44,7 +49,8 @@ func enableANSI() bool {
 	return r != 0
 }
 
-// listenForSignals handles Windows signals (no SIGWINCH, only SIGINT/SIGTERM)
+// listenForSignals handles Windows signals (SIGINT/SIGTERM). Since there is no
+// SIGWINCH on Windows, resizes are detected by polling the console size.
 func listenForSignals(ctx context.Context, handler *SignalHandler) {
 	stopCh := make(chan os.Signal, 1)
 
@@ -53,6 +59,11 @@ func listenForSignals(ctx context.Context, handler *SignalHandler) {
 
 	defer signal.Stop(stopCh)
 
+	// Poll console dimensions to emulate SIGWINCH on ConHost/Windows Terminal
+	resizeTicker := time.NewTicker(resizePollInterval)
+	defer resizeTicker.Stop()
+	lastCols, lastRows, _ := GetSize()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -62,6 +73,15 @@ func listenForSignals(ctx context.Context, handler *SignalHandler) {
 			return
 		case <-handler.stopCh:
 			return
+		case <-resizeTicker.C:
+			cols, rows, err := GetSize()
+			if err != nil || (cols == lastCols && rows == lastRows) {
+				continue
+			}
+			lastCols, lastRows = cols, rows
+			if handler.onResize != nil {
+				handler.onResize()
+			}
 		case <-stopCh:
 			if handler.onStop != nil {
 				handler.onStop()
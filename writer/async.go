@@ -6,83 +6,202 @@ import (
 	"github.com/garaekz/tfx/internal/share"
 )
 
-// AsyncWriter provides an asynchronous, buffered writer decorator.
-// It wraps another writer and performs writes in a separate goroutine.
+// OverflowPolicy decides what an AsyncWriter does with an entry when its
+// queue is full.
+type OverflowPolicy int
+
+const (
+	OverflowBlock      OverflowPolicy = iota // Wait for room in the queue
+	OverflowDropNewest                       // Drop the entry being written
+	OverflowDropOldest                       // Drop the oldest queued entry to make room
+)
+
+// String returns the name of the policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	default:
+		return "block"
+	}
+}
+
+// AsyncOptions configures an AsyncWriter.
+type AsyncOptions struct {
+	BufferSize int            // Capacity of the queue
+	Workers    int            // Goroutines writing to the underlying writer
+	Overflow   OverflowPolicy // What to do when the queue is full
+}
+
+// DefaultAsyncOptions returns a queue of 1000 entries drained by one worker,
+// blocking when full.
+func DefaultAsyncOptions() AsyncOptions {
+	return AsyncOptions{BufferSize: 1000, Workers: 1, Overflow: OverflowBlock}
+}
+
+// AsyncWriter provides an asynchronous, buffered writer decorator. Entries
+// are queued in a bounded ring buffer and written to the underlying writer
+// by a fixed set of workers, so a burst of entries costs no goroutines and
+// a bounded amount of memory. With one worker, entries keep their order.
 type AsyncWriter struct {
 	underlyingWriter share.Writer
-	logCh            chan *share.Entry
+	opts             AsyncOptions
 	errCh            chan error
-	doneCh           chan struct{}
-	wg               sync.WaitGroup
+
+	mu       sync.Mutex
+	cond     *sync.Cond // Signals every change of the queue
+	queue    []*share.Entry
+	head     int // Index of the oldest entry
+	size     int // Number of queued entries
+	inFlight int // Entries taken by workers but not yet written
+	dropped  uint64
+	closed   bool
+
+	workers   sync.WaitGroup
+	closeOnce sync.Once
+	closeErr  error
 }
 
-// NewAsyncWriter creates a new asynchronous writer.
+// NewAsyncWriter creates an asynchronous writer with a queue of bufferSize
+// entries and the other defaults of DefaultAsyncOptions.
 func NewAsyncWriter(underlying share.Writer, bufferSize int) *AsyncWriter {
+	opts := DefaultAsyncOptions()
+	opts.BufferSize = bufferSize
+	return NewAsyncWriterWithOptions(underlying, opts)
+}
+
+// NewAsyncWriterWithOptions creates an asynchronous writer configured by
+// opts.
+func NewAsyncWriterWithOptions(underlying share.Writer, opts AsyncOptions) *AsyncWriter {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = DefaultAsyncOptions().BufferSize
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+
 	aw := &AsyncWriter{
 		underlyingWriter: underlying,
-		logCh:            make(chan *share.Entry, bufferSize),
+		opts:             opts,
 		errCh:            make(chan error, 1),
-		doneCh:           make(chan struct{}),
+		queue:            make([]*share.Entry, opts.BufferSize),
 	}
+	aw.cond = sync.NewCond(&aw.mu)
 
-	aw.wg.Add(1) // Add for the run goroutine itself
-	go aw.run()
-
+	aw.workers.Add(opts.Workers)
+	for range opts.Workers {
+		go aw.run()
+	}
 	return aw
 }
 
-// Write sends a log entry to the buffer.
-// This method is non-blocking.
+// Write queues a log entry. When the queue is full it blocks or drops an
+// entry, depending on the overflow policy. Entries written after Close are
+// dropped.
 func (aw *AsyncWriter) Write(entry *share.Entry) error {
-	aw.wg.Add(1) // Increment WaitGroup for each message sent
-	select {
-	case aw.logCh <- entry:
-		return nil
-	case <-aw.doneCh:
-		// Writer is closed, drop the log and decrement wg
-		aw.wg.Done()
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	for aw.size == len(aw.queue) && !aw.closed {
+		switch aw.opts.Overflow {
+		case OverflowDropNewest:
+			aw.dropped++
+			return nil
+		case OverflowDropOldest:
+			aw.queue[aw.head] = nil
+			aw.head = (aw.head + 1) % len(aw.queue)
+			aw.size--
+			aw.dropped++
+		default:
+			aw.cond.Wait()
+		}
+	}
+	if aw.closed {
+		aw.dropped++
 		return nil
 	}
+
+	aw.queue[(aw.head+aw.size)%len(aw.queue)] = entry
+	aw.size++
+	aw.cond.Broadcast()
+	return nil
 }
 
-// Close flushes the buffer and stops the writer.
+// Close writes the queued entries, stops the workers and closes the
+// underlying writer.
 func (aw *AsyncWriter) Close() error {
-	close(aw.logCh)
-	aw.wg.Wait() // Wait for all messages to be processed
-	close(aw.doneCh)
-	return aw.underlyingWriter.Close()
+	aw.closeOnce.Do(func() {
+		aw.mu.Lock()
+		aw.closed = true
+		aw.cond.Broadcast()
+		aw.mu.Unlock()
+
+		aw.workers.Wait()
+		aw.closeErr = aw.underlyingWriter.Close()
+	})
+	return aw.closeErr
 }
 
-// Errors returns a channel for receiving write errors.
+// Errors returns a channel for receiving write errors. It holds the first
+// error not yet received; later ones are dropped while it is full.
 func (aw *AsyncWriter) Errors() <-chan error {
 	return aw.errCh
 }
 
-// Flush waits for all buffered messages to be written.
+// Flush waits until every entry queued so far is written, then flushes the
+// underlying writer if it supports it.
 func (aw *AsyncWriter) Flush() {
-	// To flush, we need to ensure all items in logCh are processed.
-	// A simple way is to send a signal and wait for it to be processed.
-	// However, a more robust way is to temporarily close the channel,
-	// wait for the run goroutine to finish, and then re-open it.
-	// This is complex with a single channel, so we'll use a simpler approach
-	// for now: rely on the underlying writer's flush if available, or a short sleep.
-	// For a true flush, the channel needs to be drained.
-	// For now, we'll just ensure the underlying writer is flushed if it supports it.
+	aw.mu.Lock()
+	for aw.size > 0 || aw.inFlight > 0 {
+		aw.cond.Wait()
+	}
+	aw.mu.Unlock()
+
 	if flusher, ok := aw.underlyingWriter.(interface{ Flush() }); ok {
 		flusher.Flush()
 	}
-	// Also wait for all messages sent to logCh to be processed
-	// This is handled by aw.wg.Wait() in Close(), but for Flush() we need a way
-	// to signal the run goroutine to process all current messages and then wait.
-	// A common pattern is to send a "flush signal" through the channel,
-	// but that requires modifying the channel type or using a separate channel.
-	// For simplicity, we'll just wait for the main wg, assuming Close() will be called eventually.
-	// If a true blocking flush is needed without closing, a more complex mechanism is required.
 }
 
-// run is the background goroutine that performs writes.
+// Dropped returns how many entries were dropped since the writer was
+// created.
+func (aw *AsyncWriter) Dropped() uint64 {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	return aw.dropped
+}
+
+// Len returns the number of queued entries.
+func (aw *AsyncWriter) Len() int {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	return aw.size
+}
+
+// run is a worker goroutine: it writes queued entries until the writer is
+// closed and the queue is drained.
 func (aw *AsyncWriter) run() {
-	for entry := range aw.logCh {
+	defer aw.workers.Done()
+
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	for {
+		for aw.size == 0 && !aw.closed {
+			aw.cond.Wait()
+		}
+		if aw.size == 0 {
+			return
+		}
+
+		entry := aw.queue[aw.head]
+		aw.queue[aw.head] = nil
+		aw.head = (aw.head + 1) % len(aw.queue)
+		aw.size--
+		aw.inFlight++
+		aw.cond.Broadcast()
+		aw.mu.Unlock()
+
 		if err := aw.underlyingWriter.Write(entry); err != nil {
 			select {
 			case aw.errCh <- err:
@@ -90,7 +209,9 @@ func (aw *AsyncWriter) run() {
 				// Error channel is full, drop the error
 			}
 		}
-		aw.wg.Done() // Decrement WaitGroup after writing
+
+		aw.mu.Lock()
+		aw.inFlight--
+		aw.cond.Broadcast()
 	}
-	aw.wg.Done() // Decrement for the run goroutine itself when logCh is closed
 }
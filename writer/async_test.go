@@ -0,0 +1,119 @@
+package writer
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// gatedWriter records entries and blocks each write until released.
+type gatedWriter struct {
+	mu      sync.Mutex
+	gate    chan struct{}
+	entries []string
+}
+
+func (w *gatedWriter) Write(entry *share.Entry) error {
+	if w.gate != nil {
+		<-w.gate
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.entries = append(w.entries, entry.Message)
+	return nil
+}
+
+func (w *gatedWriter) Close() error { return nil }
+
+func (w *gatedWriter) messages() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.entries...)
+}
+
+// fillBlocked writes first, waits until the worker holds it, then writes
+// rest into the queue.
+func fillBlocked(aw *AsyncWriter, first string, rest ...string) {
+	aw.Write(&share.Entry{Message: first})
+	for {
+		aw.mu.Lock()
+		busy := aw.inFlight == 1
+		aw.mu.Unlock()
+		if busy {
+			break
+		}
+	}
+	for _, msg := range rest {
+		aw.Write(&share.Entry{Message: msg})
+	}
+}
+
+func TestAsyncWriterKeepsOrderAndFlushes(t *testing.T) {
+	under := &gatedWriter{}
+	aw := NewAsyncWriter(under, 4)
+	defer aw.Close()
+
+	for _, msg := range []string{"a", "b", "c", "d", "e", "f"} {
+		aw.Write(&share.Entry{Message: msg})
+	}
+	aw.Flush()
+
+	got := under.messages()
+	if len(got) != 6 || got[0] != "a" || got[5] != "f" {
+		t.Fatalf("expected all entries in order after Flush, got %v", got)
+	}
+	if aw.Dropped() != 0 {
+		t.Fatalf("blocking policy must not drop, got %d", aw.Dropped())
+	}
+}
+
+func TestAsyncWriterDropNewest(t *testing.T) {
+	under := &gatedWriter{gate: make(chan struct{})}
+	aw := NewAsyncWriterWithOptions(under, AsyncOptions{BufferSize: 2, Overflow: OverflowDropNewest})
+
+	fillBlocked(aw, "held", "q1", "q2", "new1", "new2")
+	if aw.Dropped() != 2 || aw.Len() != 2 {
+		t.Fatalf("expected 2 dropped and 2 queued, got %d and %d", aw.Dropped(), aw.Len())
+	}
+	close(under.gate)
+	aw.Close()
+
+	if got := under.messages(); len(got) != 3 || got[1] != "q1" || got[2] != "q2" {
+		t.Fatalf("expected the oldest entries to survive, got %v", got)
+	}
+}
+
+func TestAsyncWriterDropOldest(t *testing.T) {
+	under := &gatedWriter{gate: make(chan struct{})}
+	aw := NewAsyncWriterWithOptions(under, AsyncOptions{BufferSize: 2, Overflow: OverflowDropOldest})
+
+	fillBlocked(aw, "held", "q1", "q2", "new1", "new2")
+	if aw.Dropped() != 2 {
+		t.Fatalf("expected 2 dropped, got %d", aw.Dropped())
+	}
+	close(under.gate)
+	aw.Close()
+
+	if got := under.messages(); len(got) != 3 || got[1] != "new1" || got[2] != "new2" {
+		t.Fatalf("expected the newest entries to survive, got %v", got)
+	}
+}
+
+func TestAsyncWriterCloseDrainsAndDropsLateWrites(t *testing.T) {
+	under := &gatedWriter{}
+	aw := NewAsyncWriterWithOptions(under, AsyncOptions{BufferSize: 8, Workers: 3})
+	for range 8 {
+		aw.Write(&share.Entry{Message: "x"})
+	}
+	aw.Close()
+	aw.Close()
+
+	if got := len(under.messages()); got != 8 {
+		t.Fatalf("expected Close to drain the queue, got %d entries", got)
+	}
+	aw.Write(&share.Entry{Message: "late"})
+	if aw.Dropped() != 1 {
+		t.Fatalf("expected writes after Close to be dropped, got %d", aw.Dropped())
+	}
+}
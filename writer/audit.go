@@ -0,0 +1,166 @@
+package writer
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"maps"
+	"os"
+	"sync"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// Field keys of the chain added to audit entries.
+const (
+	AuditSeqKey  = "audit_seq"
+	AuditPrevKey = "audit_prev"
+	AuditHashKey = "audit_hash"
+)
+
+// ErrAuditBroken is returned by VerifyAudit for a modified, removed or
+// reordered entry.
+var ErrAuditBroken = errors.New("audit chain broken")
+
+// auditHashSuffix starts the hash at the end of every audit line.
+const auditHashSuffix = `,"` + AuditHashKey + `":"`
+
+// AuditWriter writes tamper-evident JSON lines. Every line carries its
+// sequence number, the hash of the previous line and its own hash, an
+// HMAC-SHA256 when a key is given and a plain SHA-256 otherwise, computed
+// over the line without the hash. Modifying, removing or reordering lines
+// breaks the chain, which VerifyAudit detects; truncating the end is
+// detected by comparing the last hash with one kept elsewhere.
+type AuditWriter struct {
+	mu     sync.Mutex
+	out    io.Writer
+	key    []byte
+	enc    *JSONEncoder
+	seq    uint64
+	prev   string
+	closer io.Closer
+}
+
+// NewAuditWriter starts a new audit chain on out.
+func NewAuditWriter(out io.Writer, key []byte) *AuditWriter {
+	return &AuditWriter{out: out, key: key, enc: NewJSONEncoder(DefaultJSONKeys)}
+}
+
+// OpenAuditFile opens the audit file filename for appending, creating it
+// if needed. The chain continues from the existing entries, which must
+// verify with key.
+func OpenAuditFile(filename string, key []byte) (*AuditWriter, error) {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit file: %w", err)
+	}
+	report, err := VerifyAudit(file, key)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("existing audit file %s: %w", filename, err)
+	}
+
+	w := NewAuditWriter(file, key)
+	w.seq, w.prev = report.Entries, report.LastHash
+	w.closer = file
+	return w, nil
+}
+
+// Write appends entry to the chain.
+func (w *AuditWriter) Write(entry *share.Entry) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	chained := *entry
+	chained.Fields = make(share.Fields, len(entry.Fields)+2)
+	maps.Copy(chained.Fields, entry.Fields)
+	chained.Fields[AuditSeqKey] = w.seq + 1
+	chained.Fields[AuditPrevKey] = w.prev
+
+	line := w.enc.AppendEntry(nil, &chained)
+	sum := auditSum(w.key, line)
+	line = append(line[:len(line)-1], auditHashSuffix...)
+	line = append(line, sum...)
+	line = append(line, '"', '}', '\n')
+
+	if _, err := w.out.Write(line); err != nil {
+		return err
+	}
+	w.seq++
+	w.prev = sum
+	return nil
+}
+
+// Close closes the file opened by OpenAuditFile.
+func (w *AuditWriter) Close() error {
+	if w.closer != nil {
+		return w.closer.Close()
+	}
+	return nil
+}
+
+// auditSum returns the hex hash of line, keyed when key is not empty.
+func auditSum(key, line []byte) string {
+	var h hash.Hash
+	if len(key) > 0 {
+		h = hmac.New(sha256.New, key)
+	} else {
+		h = sha256.New()
+	}
+	h.Write(line)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AuditReport summarizes a verified audit log.
+type AuditReport struct {
+	Entries  uint64 // Number of entries
+	LastHash string // Hash of the last entry, to compare with a stored copy
+}
+
+// VerifyAudit checks the chain of an audit log written by AuditWriter with
+// key. It fails with ErrAuditBroken, naming the first bad line, when a line
+// was modified, removed or reordered.
+func VerifyAudit(r io.Reader, key []byte) (AuditReport, error) {
+	var report AuditReport
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		i := bytes.LastIndex(line, []byte(auditHashSuffix))
+		if i < 0 || !bytes.HasSuffix(line, []byte(`"}`)) {
+			return report, fmt.Errorf("line %d: missing hash: %w", lineNo, ErrAuditBroken)
+		}
+		sum := string(line[i+len(auditHashSuffix) : len(line)-2])
+		body := append(line[:i:i], '}')
+
+		var chain struct {
+			Seq  uint64 `json:"audit_seq"`
+			Prev string `json:"audit_prev"`
+		}
+		if err := json.Unmarshal(body, &chain); err != nil {
+			return report, fmt.Errorf("line %d: %v: %w", lineNo, err, ErrAuditBroken)
+		}
+		switch {
+		case chain.Seq != report.Entries+1:
+			return report, fmt.Errorf("line %d: sequence %d, want %d: %w", lineNo, chain.Seq, report.Entries+1, ErrAuditBroken)
+		case chain.Prev != report.LastHash:
+			return report, fmt.Errorf("line %d: previous hash mismatch: %w", lineNo, ErrAuditBroken)
+		case !hmac.Equal([]byte(sum), []byte(auditSum(key, body))):
+			return report, fmt.Errorf("line %d: hash mismatch: %w", lineNo, ErrAuditBroken)
+		}
+		report.Entries++
+		report.LastHash = sum
+	}
+	return report, scanner.Err()
+}
@@ -0,0 +1,95 @@
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func writeAudit(t *testing.T, w *AuditWriter, msgs ...string) {
+	t.Helper()
+	for _, msg := range msgs {
+		entry := &share.Entry{
+			Level:     share.LevelInfo,
+			Message:   msg,
+			Timestamp: time.Unix(1700000000, 0),
+			Fields:    share.Fields{"user": "ana"},
+		}
+		if err := w.Write(entry); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+}
+
+func TestAuditWriterVerifies(t *testing.T) {
+	for _, key := range [][]byte{nil, []byte("secret")} {
+		var buf bytes.Buffer
+		writeAudit(t, NewAuditWriter(&buf, key), "login", "update", "logout")
+
+		report, err := VerifyAudit(bytes.NewReader(buf.Bytes()), key)
+		if err != nil {
+			t.Fatalf("VerifyAudit(key=%q): %v", key, err)
+		}
+		if report.Entries != 3 || len(report.LastHash) != 64 {
+			t.Errorf("report = %+v, want 3 entries and a SHA-256 hash", report)
+		}
+	}
+}
+
+func TestAuditWriterDetectsTampering(t *testing.T) {
+	key := []byte("secret")
+	var buf bytes.Buffer
+	writeAudit(t, NewAuditWriter(&buf, key), "login", "update", "logout")
+	lines := strings.SplitAfter(buf.String(), "\n")
+
+	tests := map[string]string{
+		"modified":  strings.Replace(buf.String(), "update", "delete", 1),
+		"removed":   lines[0] + lines[2],
+		"reordered": lines[1] + lines[0] + lines[2],
+	}
+	for name, log := range tests {
+		if _, err := VerifyAudit(strings.NewReader(log), key); !errors.Is(err, ErrAuditBroken) {
+			t.Errorf("%s: err = %v, want ErrAuditBroken", name, err)
+		}
+	}
+
+	if _, err := VerifyAudit(bytes.NewReader(buf.Bytes()), []byte("other")); !errors.Is(err, ErrAuditBroken) {
+		t.Errorf("wrong key: err = %v, want ErrAuditBroken", err)
+	}
+}
+
+func TestOpenAuditFileContinuesChain(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "audit.log")
+	key := []byte("secret")
+
+	for _, msg := range []string{"first", "second"} {
+		w, err := OpenAuditFile(filename, key)
+		if err != nil {
+			t.Fatalf("OpenAuditFile: %v", err)
+		}
+		writeAudit(t, w, msg)
+		w.Close()
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := VerifyAudit(bytes.NewReader(data), key)
+	if err != nil || report.Entries != 2 {
+		t.Fatalf("VerifyAudit = %+v, %v; want 2 entries", report, err)
+	}
+
+	if err := os.WriteFile(filename, bytes.Replace(data, []byte("first"), []byte("forged"), 1), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenAuditFile(filename, key); !errors.Is(err, ErrAuditBroken) {
+		t.Errorf("OpenAuditFile on a tampered file: err = %v, want ErrAuditBroken", err)
+	}
+}
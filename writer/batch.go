@@ -0,0 +1,227 @@
+package writer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// Message is an encoded entry waiting to be shipped in a batch.
+type Message struct {
+	Key   []byte // Partitioning key, see PublishOptions.KeyField
+	Value []byte // The encoded entry
+	Level share.Level
+	Time  time.Time
+}
+
+// batcher queues messages and ships them in batches from a background
+// goroutine, when batchSize messages are queued, flushInterval passes or a
+// flush is asked for. Failed batches are retried with exponential backoff
+// while retryable says so, then dropped. It is the engine of HTTPWriter and
+// PublishWriter.
+type batcher struct {
+	batchSize     int
+	flushInterval time.Duration
+	overflow      OverflowPolicy
+	maxRetries    int
+	retryBackoff  time.Duration
+	send          func(batch []Message) error
+	retryable     func(err error) bool             // Nil retries every error
+	onDrop        func(batch []Message, err error) // Called with batches that failed
+	errCh         chan error
+
+	mu      sync.Mutex
+	cond    *sync.Cond // Signals every change of the queue
+	queue   []Message
+	head    int
+	size    int
+	sending int // Messages of the batch being shipped
+	dropped uint64
+	closed  bool
+
+	wake chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// start initializes b with a queue of bufferSize messages and starts its
+// goroutine.
+func (b *batcher) start(bufferSize int) {
+	b.errCh = make(chan error, 1)
+	b.queue = make([]Message, bufferSize)
+	b.wake = make(chan struct{}, 1)
+	b.done = make(chan struct{})
+	b.cond = sync.NewCond(&b.mu)
+	go b.run()
+}
+
+// add queues msg. When the queue is full it blocks or drops a message,
+// depending on the overflow policy.
+func (b *batcher) add(msg Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.size == len(b.queue) && !b.closed {
+		switch b.overflow {
+		case OverflowDropNewest:
+			b.dropped++
+			return
+		case OverflowDropOldest:
+			b.queue[b.head] = Message{}
+			b.head = (b.head + 1) % len(b.queue)
+			b.size--
+			b.dropped++
+		default:
+			b.notify()
+			b.cond.Wait()
+		}
+	}
+	if b.closed {
+		b.dropped++
+		return
+	}
+	b.queue[(b.head+b.size)%len(b.queue)] = msg
+	b.size++
+	if b.size >= b.batchSize {
+		b.notify()
+	}
+}
+
+// notify wakes the goroutine without blocking.
+func (b *batcher) notify() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Flush ships the queued entries now and waits until they are delivered or
+// dropped.
+func (b *batcher) Flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.notify()
+	for (b.size > 0 || b.sending > 0) && !b.closed {
+		b.cond.Wait()
+	}
+}
+
+// Close ships the queued entries and stops the writer.
+func (b *batcher) Close() error {
+	b.once.Do(func() {
+		b.mu.Lock()
+		b.closed = true
+		b.cond.Broadcast()
+		b.mu.Unlock()
+		b.notify()
+		<-b.done
+	})
+	return nil
+}
+
+// Errors returns a channel for receiving shipping errors. It holds the
+// first error not yet received; later ones are dropped while it is full.
+func (b *batcher) Errors() <-chan error {
+	return b.errCh
+}
+
+// Dropped returns how many entries were dropped, because the queue was
+// full or their batch failed, since the writer was created.
+func (b *batcher) Dropped() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Len returns the number of queued entries.
+func (b *batcher) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size
+}
+
+// run ships the queued messages whenever a batch fills up, the interval
+// passes or a flush is asked for, until the batcher is closed and the
+// queue is drained.
+func (b *batcher) run() {
+	defer close(b.done)
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.wake:
+		case <-ticker.C:
+		}
+		for {
+			batch, closed := b.take()
+			if len(batch) == 0 {
+				if closed {
+					return
+				}
+				break
+			}
+			b.ship(batch)
+		}
+	}
+}
+
+// take removes up to batchSize messages from the queue and reports whether
+// the batcher is closed.
+func (b *batcher) take() ([]Message, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := min(b.size, b.batchSize)
+	batch := make([]Message, n)
+	for i := range batch {
+		batch[i] = b.queue[b.head]
+		b.queue[b.head] = Message{}
+		b.head = (b.head + 1) % len(b.queue)
+	}
+	b.size -= n
+	b.sending = n
+	b.cond.Broadcast()
+	return batch, b.closed
+}
+
+// ship sends batch, retrying as configured, and drops it if every attempt
+// fails.
+func (b *batcher) ship(batch []Message) {
+	err := b.sendRetrying(batch)
+	if err != nil && b.onDrop != nil {
+		b.onDrop(batch, err)
+	}
+
+	b.mu.Lock()
+	b.sending = 0
+	if err != nil {
+		b.dropped += uint64(len(batch))
+	}
+	b.cond.Broadcast()
+	b.mu.Unlock()
+
+	if err == nil {
+		return
+	}
+	select {
+	case b.errCh <- err:
+	default:
+		// Error channel is full, drop the error
+	}
+}
+
+// sendRetrying sends batch, retrying failures that may succeed later.
+func (b *batcher) sendRetrying(batch []Message) error {
+	backoff := b.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := b.send(batch)
+		if err == nil || attempt == b.maxRetries {
+			return err
+		}
+		if b.retryable != nil && !b.retryable(err) {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,147 @@
+package writer
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// BufferedOptions configures a BufferedWriter.
+type BufferedOptions struct {
+	Size          int           // Bytes buffered before they are written out
+	FlushInterval time.Duration // Longest time output stays buffered; zero waits for Size or Flush
+}
+
+// DefaultBufferedOptions buffers up to 64KB for at most a second.
+func DefaultBufferedOptions() BufferedOptions {
+	return BufferedOptions{
+		Size:          64 << 10,
+		FlushInterval: time.Second,
+	}
+}
+
+// BufferedWriter collects formatted output and writes it out in large
+// chunks, when Size bytes are buffered, FlushInterval passes or Flush is
+// called, saving a syscall per entry on high-volume outputs. Unlike
+// bufio.Writer it is safe for concurrent use and flushes on its own:
+//
+//	out := writer.NewBufferedWriter(os.Stdout, writer.DefaultBufferedOptions())
+//	defer out.Close()
+//	logger.AddWriter(writer.NewConsoleWriter(out, opts))
+//
+// Entries still buffered when the process dies are lost; flush before
+// exiting.
+type BufferedWriter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	buf     []byte
+	size    int
+	err     error // First write error, returned until Reset
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// NewBufferedWriter buffers writes to out. Zero options take their
+// defaults, except a zero FlushInterval, which disables timed flushes.
+func NewBufferedWriter(out io.Writer, opts BufferedOptions) *BufferedWriter {
+	if opts.Size <= 0 {
+		opts.Size = DefaultBufferedOptions().Size
+	}
+	w := &BufferedWriter{
+		out:     out,
+		buf:     make([]byte, 0, opts.Size),
+		size:    opts.Size,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if opts.FlushInterval > 0 {
+		go w.run(opts.FlushInterval)
+	} else {
+		close(w.stopped)
+	}
+	return w
+}
+
+// Write buffers p, writing the buffer out first when p does not fit. Writes
+// larger than the buffer go straight out.
+func (w *BufferedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return 0, w.err
+	}
+	if len(w.buf)+len(p) > w.size {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+	if len(p) >= w.size {
+		if _, err := w.out.Write(p); err != nil {
+			w.err = err
+			return 0, err
+		}
+		return len(p), nil
+	}
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// Flush writes the buffered output out.
+func (w *BufferedWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flush()
+}
+
+func (w *BufferedWriter) flush() error {
+	if w.err != nil || len(w.buf) == 0 {
+		return w.err
+	}
+	if _, err := w.out.Write(w.buf); err != nil {
+		w.err = err
+		return err
+	}
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Buffered returns the number of bytes waiting to be written out.
+func (w *BufferedWriter) Buffered() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.buf)
+}
+
+// Reset discards the buffered output and the write error, and writes to out
+// from now on, e.g. after the file behind the writer was rotated.
+func (w *BufferedWriter) Reset(out io.Writer) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.out = out
+	w.buf = w.buf[:0]
+	w.err = nil
+}
+
+// Close stops timed flushes and writes the buffered output out. It does not
+// close the underlying writer.
+func (w *BufferedWriter) Close() error {
+	w.once.Do(func() { close(w.stop) })
+	<-w.stopped
+	return w.Flush()
+}
+
+// run flushes every interval until the writer is closed.
+func (w *BufferedWriter) run(interval time.Duration) {
+	defer close(w.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.Flush()
+		case <-w.stop:
+			return
+		}
+	}
+}
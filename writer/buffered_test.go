@@ -0,0 +1,181 @@
+package writer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+	"github.com/garaekz/tfx/internal/testutil"
+)
+
+// countingWriter counts the writes reaching it.
+type countingWriter struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	writes int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writes++
+	return c.buf.Write(p)
+}
+
+func (c *countingWriter) state() (string, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.String(), c.writes
+}
+
+func TestBufferedWriterFlushesOnSize(t *testing.T) {
+	out := &countingWriter{}
+	w := NewBufferedWriter(out, BufferedOptions{Size: 10})
+	defer w.Close()
+
+	w.Write([]byte("abcd"))
+	w.Write([]byte("efgh"))
+	if got, writes := out.state(); writes != 0 || w.Buffered() != 8 {
+		t.Fatalf("expected the writes buffered, got %q in %d writes", got, writes)
+	}
+	w.Write([]byte("ijkl")) // Does not fit
+	if got, writes := out.state(); got != "abcdefgh" || writes != 1 {
+		t.Errorf("expected the buffer written out, got %q in %d writes", got, writes)
+	}
+	w.Write([]byte("a large write"))
+	if got, writes := out.state(); got != "abcdefghijkla large write" || writes != 3 {
+		t.Errorf("expected a large write to go straight out, got %q in %d writes", got, writes)
+	}
+
+	w.Write([]byte("tail"))
+	w.Close()
+	if got, _ := out.state(); !strings.HasSuffix(got, "tail") {
+		t.Errorf("expected Close to flush, got %q", got)
+	}
+}
+
+func TestBufferedWriterFlushesOnInterval(t *testing.T) {
+	out := &countingWriter{}
+	w := NewBufferedWriter(out, BufferedOptions{Size: 1 << 10, FlushInterval: 5 * time.Millisecond})
+	defer w.Close()
+
+	w.Write([]byte("line\n"))
+	deadline := time.Now().Add(time.Second)
+	for {
+		if got, _ := out.state(); got == "line\n" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the buffer flushed after the interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBufferedWriterConcurrent(t *testing.T) {
+	out := &countingWriter{}
+	w := NewBufferedWriter(out, BufferedOptions{Size: 64, FlushInterval: time.Millisecond})
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				w.Write([]byte("entry\n"))
+			}
+		}()
+	}
+	wg.Wait()
+	w.Close()
+	if got, _ := out.state(); strings.Count(got, "entry\n") != 800 || len(got) != 800*6 {
+		t.Errorf("expected 800 whole entries, got %d bytes", len(got))
+	}
+}
+
+func TestFileWriterBuffered(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	opts := DefaultFileOptions()
+	opts.BufferSize = 1 << 10
+	opts.MaxSize = 1 << 20
+	w, err := NewFileWriter(name, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "buffered", Timestamp: time.Now()})
+	if data, _ := os.ReadFile(name); len(data) != 0 {
+		t.Fatalf("expected the entry buffered, got %q", data)
+	}
+	w.Flush()
+	if data, _ := os.ReadFile(name); !strings.Contains(string(data), "buffered") {
+		t.Errorf("expected Flush to write the entry, got %q", data)
+	}
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "closing", Timestamp: time.Now()})
+	w.Close()
+	if data, _ := os.ReadFile(name); !strings.Contains(string(data), "closing") {
+		t.Errorf("expected Close to write the entry, got %q", data)
+	}
+	if err := w.Write(&share.Entry{Level: share.LevelInfo, Message: "late"}); err == nil {
+		t.Error("expected an error writing after Close")
+	}
+}
+
+func TestFileWriterBufferedRotation(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	opts := DefaultFileOptions()
+	opts.BufferSize = 1 << 10
+	opts.MaxSize = 150 // Two entries, so the four rotate once
+	opts.Compress = false
+	w, err := NewFileWriter(name, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	for i := range 4 {
+		w.Write(&share.Entry{Level: share.LevelInfo, Message: strings.Repeat("x", 40) + string(rune('a'+i)), Timestamp: time.Now()})
+	}
+	w.Flush()
+
+	backups, _ := filepath.Glob(filepath.Join(dir, "app.*.log"))
+	current, _ := os.ReadFile(name)
+	if len(backups) != 1 || len(current) == 0 || len(current) > 150 {
+		t.Fatalf("expected the file rotated, got backups %v and %d bytes", backups, len(current))
+	}
+	var all string
+	for _, b := range backups {
+		data, _ := os.ReadFile(b)
+		all += string(data)
+	}
+	all += string(current)
+	for _, c := range "abcd" {
+		if !strings.Contains(all, strings.Repeat("x", 40)+string(c)) {
+			t.Errorf("expected entry %c kept across rotation", c)
+		}
+	}
+}
+
+func TestConsoleWriterFlushesBufferedOutput(t *testing.T) {
+	out := &testutil.SafeBuffer{}
+	buffered := NewBufferedWriter(out, BufferedOptions{Size: 1 << 10})
+	defer buffered.Close()
+	w := NewConsoleWriter(buffered, ConsoleOptions{Format: share.FormatText, DisableColor: true})
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "hello"})
+	if out.String() != "" {
+		t.Fatalf("expected the line buffered, got %q", out.String())
+	}
+	w.Flush()
+	if !strings.Contains(out.String(), "hello") {
+		t.Errorf("expected Flush to write the line, got %q", out.String())
+	}
+}
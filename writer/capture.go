@@ -0,0 +1,125 @@
+package writer
+
+import (
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// CaptureWriter records entries in memory so tests can query what was
+// logged instead of matching formatted output:
+//
+//	capture := writer.NewCaptureWriter()
+//	logger.AddWriter(capture)
+//	...
+//	if !capture.ContainsMessage("connection refused") { ... }
+//	errs := capture.Entries(share.LevelError)
+//
+// It is safe for concurrent use.
+type CaptureWriter struct {
+	mu      sync.Mutex
+	entries []share.Entry
+}
+
+// NewCaptureWriter creates an empty CaptureWriter.
+func NewCaptureWriter() *CaptureWriter {
+	return &CaptureWriter{}
+}
+
+// Write records a copy of entry, which the logger may reuse afterwards.
+func (w *CaptureWriter) Write(entry *share.Entry) error {
+	e := *entry
+	e.Fields = maps.Clone(entry.Fields)
+	if entry.Caller != nil {
+		caller := *entry.Caller
+		e.Caller = &caller
+	}
+	w.mu.Lock()
+	w.entries = append(w.entries, e)
+	w.mu.Unlock()
+	return nil
+}
+
+// Close does nothing; the recorded entries stay available.
+func (w *CaptureWriter) Close() error {
+	return nil
+}
+
+// Entries returns the recorded entries of the given levels in the order
+// they were written, or all of them when no level is given.
+func (w *CaptureWriter) Entries(levels ...share.Level) []share.Entry {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var out []share.Entry
+	for _, e := range w.entries {
+		if len(levels) == 0 || containsLevel(levels, e.Level) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Messages returns the messages of the recorded entries.
+func (w *CaptureWriter) Messages() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]string, len(w.entries))
+	for i, e := range w.entries {
+		out[i] = e.Message
+	}
+	return out
+}
+
+// ContainsMessage reports whether a recorded message contains substr.
+func (w *CaptureWriter) ContainsMessage(substr string) bool {
+	_, ok := w.Find(func(e share.Entry) bool { return strings.Contains(e.Message, substr) })
+	return ok
+}
+
+// ContainsField reports whether a recorded entry has the field key set to
+// value.
+func (w *CaptureWriter) ContainsField(key string, value any) bool {
+	_, ok := w.Find(func(e share.Entry) bool {
+		v, ok := e.Fields[key]
+		return ok && reflect.DeepEqual(v, value)
+	})
+	return ok
+}
+
+// Find returns the first recorded entry match accepts.
+func (w *CaptureWriter) Find(match func(share.Entry) bool) (share.Entry, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, e := range w.entries {
+		if match(e) {
+			return e, true
+		}
+	}
+	return share.Entry{}, false
+}
+
+// Len returns the number of recorded entries.
+func (w *CaptureWriter) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.entries)
+}
+
+// Reset forgets the recorded entries.
+func (w *CaptureWriter) Reset() {
+	w.mu.Lock()
+	w.entries = nil
+	w.mu.Unlock()
+}
+
+func containsLevel(levels []share.Level, level share.Level) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package writer
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func TestCaptureWriter(t *testing.T) {
+	w := NewCaptureWriter()
+	entry := &share.Entry{Level: share.LevelError, Message: "connection refused", Fields: share.Fields{"port": 5432}}
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "starting"})
+	w.Write(entry)
+	entry.Message, entry.Fields["port"] = "reused", 0 // As the logger pool would
+
+	if errs := w.Entries(share.LevelError); len(errs) != 1 || errs[0].Message != "connection refused" {
+		t.Errorf("unexpected error entries %+v", errs)
+	}
+	if n := len(w.Entries()); n != 2 {
+		t.Errorf("expected all entries without levels, got %d", n)
+	}
+	if n := len(w.Entries(share.LevelWarn, share.LevelInfo)); n != 1 {
+		t.Errorf("expected one warning or info entry, got %d", n)
+	}
+	if !w.ContainsMessage("refused") || w.ContainsMessage("reused") {
+		t.Errorf("unexpected messages %q", w.Messages())
+	}
+	if !w.ContainsField("port", 5432) || w.ContainsField("port", 0) {
+		t.Error("expected the fields recorded as written")
+	}
+
+	w.Reset()
+	if w.Len() != 0 || w.ContainsMessage("starting") {
+		t.Error("expected Reset to forget the entries")
+	}
+}
+
+func TestCaptureWriterConcurrent(t *testing.T) {
+	w := NewCaptureWriter()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				w.Write(&share.Entry{Level: share.LevelInfo, Message: "x"})
+				w.ContainsMessage("x")
+			}
+		}()
+	}
+	wg.Wait()
+	if w.Len() != 800 {
+		t.Errorf("expected 800 entries, got %d", w.Len())
+	}
+}
@@ -3,6 +3,7 @@ package writer
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -23,6 +24,7 @@ type ConsoleWriter struct {
 // Options for console writer
 type ConsoleOptions struct {
 	Level        share.Level
+	Below        share.Level // Entries at this level or above are skipped; LevelTrace, the zero value, skips none
 	Format       share.Format
 	Timestamp    bool
 	TimeFormat   string
@@ -32,6 +34,8 @@ type ConsoleOptions struct {
 	ShowCaller   bool
 	ForceColor   bool
 	DisableColor bool
+	JSONKeys     JSONKeys    // Key names of FormatJSON entries; empty keys use DefaultJSONKeys
+	Labels       LevelLabels // Localized level names and emoji; nil keeps the defaults
 }
 
 // NewConsoleWriter creates a new console writer
@@ -51,6 +55,13 @@ func NewConsoleWriter(output io.Writer, opts ConsoleOptions) *ConsoleWriter {
 	}
 }
 
+// Output returns the writer the entries are written to.
+func (w *ConsoleWriter) Output() io.Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.output
+}
+
 func (w *ConsoleWriter) UpdateOptions(output io.Writer, opts ConsoleOptions) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -65,54 +76,77 @@ func (w *ConsoleWriter) Write(entry *share.Entry) error {
 	if entry.Level < share.Level(w.options.Level) {
 		return nil
 	}
+	if w.options.Below > share.LevelTrace && entry.Level >= w.options.Below {
+		return nil
+	}
 
-	var output string
+	bp := linePool.Get().(*[]byte)
+	buf := (*bp)[:0]
 	switch w.options.Format {
 	case share.FormatBadge:
-		output = w.formatBadge(entry)
+		buf = w.appendBadge(buf, entry)
 	case share.FormatJSON:
-		output = w.formatJSON(entry)
+		enc := JSONEncoder{Keys: w.options.JSONKeys}
+		buf = enc.AppendEntry(buf, entry)
 	case share.FormatText:
-		output = w.formatText(entry)
+		buf = w.appendText(buf, entry)
+	case share.FormatPretty:
+		buf = append(buf, w.formatPretty(entry)...)
 	default:
-		output = w.formatBadge(entry)
+		buf = w.appendBadge(buf, entry)
 	}
+	buf = append(buf, '\n')
 
 	w.mu.Lock()
-	defer w.mu.Unlock()
+	_, err := Routed(w.output).Write(buf)
+	w.mu.Unlock()
 
-	_, err := fmt.Fprintln(w.output, output)
+	if cap(buf) <= maxPooledLine {
+		*bp = buf
+		linePool.Put(bp)
+	}
 	return err
 }
 
+// maxPooledLine is the capacity above which line buffers are not reused, so
+// one huge entry does not pin its buffer.
+const maxPooledLine = 64 << 10
+
+// linePool holds the buffers entries are formatted into.
+var linePool = sync.Pool{New: func() any {
+	buf := make([]byte, 0, 256)
+	return &buf
+}}
+
 // formatBadge formats entry as a badge log
 func (w *ConsoleWriter) formatBadge(entry *share.Entry) string {
-	var parts []string
+	return string(w.appendBadge(nil, entry))
+}
+
+// appendBadge appends the badge log line of entry to buf.
+func (w *ConsoleWriter) appendBadge(buf []byte, entry *share.Entry) []byte {
+	colored := w.supportsColor() && !w.options.DisableColor
 
 	// Indentation
-	if entry.IndentStr != "" {
-		parts = append(parts, entry.IndentStr)
-	}
+	buf = append(buf, entry.IndentStr...)
 
 	// Timestamp with universal [HH:MM:SS] styling
 	if w.options.Timestamp {
-		ts := entry.Timestamp.Format(w.options.TimeFormat)
-		if w.supportsColor() && !w.options.DisableColor {
-			ts = color.Style(ts, color.ModernGray)
-			parts = append(parts, fmt.Sprintf("⏰ [%s] ", ts))
-		} else {
-			parts = append(parts, fmt.Sprintf("[%s] ", ts))
+		if colored {
+			buf = append(buf, "⏰ "...)
 		}
+		buf = append(buf, '[')
+		buf = entry.Timestamp.AppendFormat(buf, w.options.TimeFormat)
+		buf = append(buf, "] "...)
 	}
 
 	// Badge/Level - this is already padded for consistent width
-	badge := w.formatBadgeTag(entry)
-	parts = append(parts, badge)
+	buf = append(buf, w.formatBadgeTag(entry)...)
 
 	// Caller info; dim on debug level
 	if w.options.ShowCaller && entry.Caller != nil {
-		raw := fmt.Sprintf("%s:%d", w.shortFilename(entry.Caller.File), entry.Caller.Line)
-		if w.supportsColor() && !w.options.DisableColor {
+		raw := w.shortFilename(entry.Caller.File) + ":" + strconv.Itoa(entry.Caller.Line)
+		if colored {
 			cfg := color.StyleConfig{
 				Text: raw,
 				// lighter slate for visibility; always undimmed
@@ -122,27 +156,28 @@ func (w *ConsoleWriter) formatBadge(entry *share.Entry) string {
 			}
 			raw = color.NewStyle(cfg)
 		}
-		parts = append(parts, fmt.Sprintf(" 📍 %s", raw))
+		buf = append(buf, " 📍 "...)
+		buf = append(buf, raw...)
 	}
 
-	// Message with proper spacing
-	message := entry.Message
-	if w.supportsColor() && !w.options.DisableColor {
-		message = w.colorizeMessage(entry, message)
+	// Message with proper spacing, separated from the badge with a tab for
+	// consistency
+	buf = append(buf, '\t')
+	if colored {
+		buf = w.appendMessage(buf, entry, entry.Message)
+	} else {
+		buf = append(buf, entry.Message...)
 	}
-	// separate badge and message with tab for consistency
-	parts = append(parts, "\t"+message)
 
-	// Fields with clean separation
+	// Fields with clean separation; fieldsStr contains individual key/value
+	// styling
 	if len(entry.Fields) > 0 {
-		fieldsStr := w.formatFields(entry.Fields)
-		if fieldsStr != "" {
-			// fieldsStr contains individual key/value styling
-			parts = append(parts, "\t"+fieldsStr)
+		if fieldsStr := w.formatFields(entry.Fields); fieldsStr != "" {
+			buf = append(buf, '\t')
+			buf = append(buf, fieldsStr...)
 		}
 	}
-
-	return strings.Join(parts, "")
+	return buf
 }
 
 // formatBadgeTag formats the badge/level part
@@ -166,31 +201,58 @@ func (w *ConsoleWriter) formatBadgeTag(entry *share.Entry) string {
 			tagColor = w.getLevelColor(entry.Level)
 		}
 	} else {
-		tag = w.getLevelTag(entry.Level)
-		tagColor = w.getLevelColor(entry.Level)
+		return w.levelBadge(entry.Level)
 	}
+	return w.renderBadgeTag(tag, tagColor, entry.Level)
+}
+
+// levelBadgeKey identifies a rendered level badge.
+type levelBadgeKey struct {
+	level   share.Level
+	label   LevelLabel
+	color   color.Color
+	mode    color.Mode
+	colored bool
+	width   int
+}
+
+// levelBadges caches rendered level badges, which only depend on their key,
+// so entries without a custom badge are not styled one by one.
+var levelBadges = struct {
+	sync.RWMutex
+	m map[levelBadgeKey]string
+}{m: make(map[levelBadgeKey]string)}
+
+// levelBadge returns the badge of level, rendering it on first use.
+func (w *ConsoleWriter) levelBadge(level share.Level) string {
+	key := levelBadgeKey{
+		level:   level,
+		label:   w.options.Labels[level],
+		color:   w.getLevelColor(level),
+		mode:    w.GetColorMode(),
+		colored: w.supportsColor() && !w.options.DisableColor,
+		width:   w.badgeWidth,
+	}
+	levelBadges.RLock()
+	badge, ok := levelBadges.m[key]
+	levelBadges.RUnlock()
+	if ok {
+		return badge
+	}
+
+	badge = w.renderBadgeTag(w.getLevelTag(level), key.color, level)
+	levelBadges.Lock()
+	levelBadges.m[key] = badge
+	levelBadges.Unlock()
+	return badge
+}
 
+// renderBadgeTag styles the badge tag for an entry of level.
+func (w *ConsoleWriter) renderBadgeTag(tag string, tagColor color.Color, level share.Level) string {
 	// Emoji prefix for level
 	emoji := ""
 	if w.supportsColor() && !w.options.DisableColor {
-		switch entry.Level {
-		case share.LevelSuccess:
-			emoji = "✅"
-		case share.LevelError:
-			emoji = "❌"
-		case share.LevelWarn:
-			emoji = "⚠️ "
-		case share.LevelInfo:
-			emoji = "ℹ️ "
-		case share.LevelDebug:
-			emoji = "🐛"
-		case share.LevelTrace:
-			emoji = "🔍"
-		case share.LevelFatal:
-			emoji = "🚨"
-		case share.LevelPanic:
-			emoji = "💣"
-		}
+		emoji = w.levelEmoji(level)
 	}
 
 	// Multi-part badge: gray background and accent color for second word
@@ -207,7 +269,7 @@ func (w *ConsoleWriter) formatBadgeTag(entry *share.Entry) string {
 			ForeGround: fgMain,
 			Background: bg,
 			Bold:       true,
-			Dim:        entry.Level == share.LevelInfo,
+			Dim:        level == share.LevelInfo,
 			Mode:       mode,
 		}
 		styleAccent := color.StyleConfig{
@@ -239,7 +301,7 @@ func (w *ConsoleWriter) formatBadgeTag(entry *share.Entry) string {
 			ForeGround: fgColor,
 			Background: tagColor,
 			Bold:       true,
-			Dim:        entry.Level == share.LevelInfo,
+			Dim:        level == share.LevelInfo,
 			Mode:       w.GetColorMode(),
 		}
 		return color.NewStyle(style)
@@ -253,6 +315,14 @@ func (w *ConsoleWriter) colorizeMessage(entry *share.Entry, message string) stri
 	if w.options.DisableColor {
 		return message
 	}
+	return string(w.appendMessage(nil, entry, message))
+}
+
+// appendMessage appends message to buf in the color of entry.
+func (w *ConsoleWriter) appendMessage(buf []byte, entry *share.Entry, message string) []byte {
+	if message == "" {
+		return buf
+	}
 	var fg color.Color
 	if msgType, ok := entry.Fields["type"].(string); ok && msgType == "success" {
 		fg = color.ModernGreen
@@ -274,16 +344,72 @@ func (w *ConsoleWriter) colorizeMessage(entry *share.Entry, message string) stri
 			fg = color.ModernSlate
 		}
 	}
-	style := color.StyleConfig{
-		Text:       message,
-		ForeGround: fg,
-		Mode:       w.GetColorMode(),
+	prefix, suffix := styleAffixes(fg, w.GetColorMode())
+	buf = append(buf, prefix...)
+	buf = append(buf, message...)
+	return append(buf, suffix...)
+}
+
+// styleKey identifies the escape codes of a foreground color.
+type styleKey struct {
+	fg   color.Color
+	mode color.Mode
+}
+
+// affixCache caches the escape codes of styleAffixes.
+var affixCache = struct {
+	sync.RWMutex
+	m map[styleKey][2]string
+}{m: make(map[styleKey][2]string)}
+
+// styleAffixes returns the escape codes that color text with fg in mode,
+// rendering them on first use.
+func styleAffixes(fg color.Color, mode color.Mode) (prefix, suffix string) {
+	key := styleKey{fg: fg, mode: mode}
+	affixCache.RLock()
+	affix, ok := affixCache.m[key]
+	affixCache.RUnlock()
+	if !ok {
+		styled := color.NewStyle(color.StyleConfig{Text: "\x00", ForeGround: fg, Mode: mode})
+		affix[0], affix[1], _ = strings.Cut(styled, "\x00")
+		affixCache.Lock()
+		affixCache.m[key] = affix
+		affixCache.Unlock()
+	}
+	return affix[0], affix[1]
+}
+
+// levelEmoji returns the badge emoji of a level
+func (w *ConsoleWriter) levelEmoji(level share.Level) string {
+	var emoji string
+	switch level {
+	case share.LevelSuccess:
+		emoji = "✅"
+	case share.LevelError:
+		emoji = "❌"
+	case share.LevelWarn:
+		emoji = "⚠️ "
+	case share.LevelInfo:
+		emoji = "ℹ️ "
+	case share.LevelDebug:
+		emoji = "🐛"
+	case share.LevelTrace:
+		emoji = "🔍"
+	case share.LevelFatal:
+		emoji = "🚨"
+	case share.LevelPanic:
+		emoji = "💣"
 	}
-	return color.NewStyle(style)
+	return w.options.Labels.emoji(level, emoji)
 }
 
 // getLevelTag returns the tag for a level
 func (w *ConsoleWriter) getLevelTag(level share.Level) string {
+	return w.options.Labels.name(level, defaultLevelTag(level))
+}
+
+// defaultLevelTag returns the English badge tag of a level
+func defaultLevelTag(level share.Level) string {
 	switch level {
 	case share.LevelTrace:
 		return "Trace"
@@ -336,10 +462,10 @@ func (w *ConsoleWriter) formatFields(fields share.Fields) string {
 		return ""
 	}
 	var parts []string
-	for key, value := range fields {
+	fields.Flatten(func(key string, value any) {
 		if key == "badge" || key == "badge_color" || key == "type" || key == "badge_styled" ||
 			key == "badge_style" || key == "bg_color" || key == "bold" || key == "italic" || key == "underline" {
-			continue
+			return
 		}
 		// key in gray, value in default color
 		// key in gray and value in slate for contrast
@@ -355,70 +481,54 @@ func (w *ConsoleWriter) formatFields(fields share.Fields) string {
 			keyStr = color.NewStyle(cfg)
 		}
 		parts = append(parts, fmt.Sprintf("%s=%s", keyStr, valRaw))
-	}
+	})
 	if len(parts) == 0 {
 		return ""
 	}
 	return fmt.Sprintf("🔖 %s", strings.Join(parts, " • "))
 }
 
-// formatJSON formats entry as JSON (simple implementation)
+// formatJSON formats entry as a JSON object
 func (w *ConsoleWriter) formatJSON(entry *share.Entry) string {
-	// This is a simplified JSON formatter
-	// In a real implementation, you'd use json.Marshal
-	parts := []string{
-		fmt.Sprintf(`"level":"%s"`, entry.Level.String()),
-		fmt.Sprintf(`"msg":"%s"`, entry.Message),
-		fmt.Sprintf(`"time":"%s"`, entry.Timestamp.Format("2006-01-02T15:04:05.000Z")),
-	}
-
-	// Add fields
-	for key, value := range entry.Fields {
-		if key == "badge" || key == "badge_color" {
-			continue
-		}
-		parts = append(parts, fmt.Sprintf(`"%s":"%v"`, key, value))
-	}
-
-	// Add caller if available
-	if entry.Caller != nil {
-		parts = append(parts, fmt.Sprintf(`"caller":"%s:%d"`, entry.Caller.File, entry.Caller.Line))
-	}
-
-	return fmt.Sprintf("{%s}", strings.Join(parts, ","))
+	return string(NewJSONEncoder(w.options.JSONKeys).AppendEntry(nil, entry))
 }
 
 // formatText formats entry as plain text
 func (w *ConsoleWriter) formatText(entry *share.Entry) string {
-	var parts []string
+	return string(w.appendText(nil, entry))
+}
 
+// appendText appends the plain text line of entry to buf.
+func (w *ConsoleWriter) appendText(buf []byte, entry *share.Entry) []byte {
 	// Timestamp
 	if w.options.Timestamp {
-		timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
-		parts = append(parts, timestamp)
+		buf = entry.Timestamp.AppendFormat(buf, "2006-01-02 15:04:05")
+		buf = append(buf, ' ')
 	}
 
 	// Level
-	parts = append(parts, entry.Level.String())
+	buf = append(buf, w.options.Labels.name(entry.Level, entry.Level.String())...)
 
 	// Caller
 	if w.options.ShowCaller && entry.Caller != nil {
-		caller := fmt.Sprintf("%s:%d", w.shortFilename(entry.Caller.File), entry.Caller.Line)
-		parts = append(parts, caller)
+		buf = append(buf, ' ')
+		buf = append(buf, w.shortFilename(entry.Caller.File)...)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(entry.Caller.Line), 10)
 	}
 
 	// Message
-	parts = append(parts, entry.Message)
+	buf = append(buf, ' ')
+	buf = append(buf, entry.Message...)
 
 	// Fields
 	if len(entry.Fields) > 0 {
-		fieldsStr := w.formatFields(entry.Fields)
-		if fieldsStr != "" {
-			parts = append(parts, fieldsStr)
+		if fieldsStr := w.formatFields(entry.Fields); fieldsStr != "" {
+			buf = append(buf, ' ')
+			buf = append(buf, fieldsStr...)
 		}
 	}
-
-	return strings.Join(parts, " ")
+	return buf
 }
 
 // Helper methods
@@ -470,6 +580,16 @@ func (w *ConsoleWriter) shortFilename(filename string) string {
 	return filename
 }
 
+// Flush writes out the output of the writer if it buffers, as a
+// BufferedWriter or bufio.Writer does.
+func (w *ConsoleWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if flusher, ok := w.output.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+}
+
 // Close closes the writer (no-op for console)
 func (w *ConsoleWriter) Close() error {
 	return nil
@@ -203,7 +203,7 @@ func TestFormatJSON(t *testing.T) {
 	if !strings.Contains(output, `"time":"2023-01-01T10:00:00.000Z"`) {
 		t.Errorf("Expected timestamp, got %q", output)
 	}
-	if !strings.Contains(output, `"data":"123"`) {
+	if !strings.Contains(output, `"data":123`) {
 		t.Errorf("Expected field, got %q", output)
 	}
 	if !strings.Contains(output, `"caller":"file.go:42"`) {
@@ -574,3 +574,18 @@ func TestConsoleWriterClose(t *testing.T) {
 		t.Errorf("Close() returned an error: %v", err)
 	}
 }
+
+func TestConsoleWriterBelow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewConsoleWriter(buf, ConsoleOptions{Format: share.FormatText, Below: share.LevelWarn, DisableColor: true})
+
+	writer.Write(&share.Entry{Level: share.LevelInfo, Message: "kept"})
+	writer.Write(&share.Entry{Level: share.LevelWarn, Message: "skipped"})
+
+	if out := buf.String(); !strings.Contains(out, "kept") || strings.Contains(out, "skipped") {
+		t.Errorf("expected only entries below Warn, got %q", out)
+	}
+	if writer.Output() != buf {
+		t.Error("expected Output to return the writer")
+	}
+}
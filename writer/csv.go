@@ -0,0 +1,188 @@
+package writer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// Columns of CSVOptions.Columns taken from the entry rather than its
+// fields.
+const (
+	CSVTime    = "time"
+	CSVLevel   = "level"
+	CSVMessage = "msg"
+	CSVCaller  = "caller" // file:line
+)
+
+// CSVOptions configures a CSVWriter.
+type CSVOptions struct {
+	Level      share.Level
+	Columns    []string // CSVTime, CSVLevel, CSVMessage, CSVCaller or field keys; dotted keys reach into groups
+	Comma      rune     // Field delimiter; ',' when zero, '\t' for TSV
+	Header     bool     // Write the column names first, unless appending to a non-empty file
+	TimeFormat string   // Layout of the time column
+}
+
+// DefaultCSVOptions writes time, level and message columns with a header.
+func DefaultCSVOptions() CSVOptions {
+	return CSVOptions{
+		Level:      share.LevelTrace,
+		Columns:    []string{CSVTime, CSVLevel, CSVMessage},
+		Comma:      ',',
+		Header:     true,
+		TimeFormat: time.RFC3339,
+	}
+}
+
+// CSVWriter writes entries as RFC 4180 records, one column per configured
+// key, for spreadsheets and quick audits of batch jobs:
+//
+//	opts := writer.DefaultCSVOptions()
+//	opts.Columns = append(opts.Columns, "job", "http.status")
+//	w, err := writer.NewCSVFile("jobs.csv", opts)
+//
+// Missing fields leave their column empty; fields without a column are not
+// written.
+type CSVWriter struct {
+	mu      sync.Mutex
+	csv     *csv.Writer
+	closer  io.Closer // The file opened by NewCSVFile
+	options CSVOptions
+	record  []string
+	header  bool // Header still to be written
+	closed  bool
+}
+
+// NewCSVWriter writes entries to out, which it does not close.
+func NewCSVWriter(out io.Writer, opts CSVOptions) *CSVWriter {
+	def := DefaultCSVOptions()
+	if len(opts.Columns) == 0 {
+		opts.Columns = def.Columns
+	}
+	if opts.Comma == 0 {
+		opts.Comma = def.Comma
+	}
+	if opts.TimeFormat == "" {
+		opts.TimeFormat = def.TimeFormat
+	}
+	cw := csv.NewWriter(out)
+	cw.Comma = opts.Comma
+	return &CSVWriter{
+		csv:     cw,
+		options: opts,
+		record:  make([]string, len(opts.Columns)),
+		header:  opts.Header,
+	}
+}
+
+// NewCSVFile appends entries to the file filename, creating it and its
+// directory if needed. The header is only written to an empty file.
+func NewCSVFile(filename string, opts CSVOptions) (*CSVWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat log file: %w", err)
+	}
+	w := NewCSVWriter(file, opts)
+	w.header = opts.Header && stat.Size() == 0
+	w.closer = file
+	return w, nil
+}
+
+// Write writes entry as one record.
+func (w *CSVWriter) Write(entry *share.Entry) error {
+	if entry.Level < w.options.Level {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return os.ErrClosed
+	}
+	if w.header {
+		w.header = false
+		if err := w.csv.Write(w.options.Columns); err != nil {
+			return err
+		}
+	}
+	for i, col := range w.options.Columns {
+		w.record[i] = w.column(entry, col)
+	}
+	if err := w.csv.Write(w.record); err != nil {
+		return err
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// column returns the text of column col for entry.
+func (w *CSVWriter) column(entry *share.Entry, col string) string {
+	switch col {
+	case CSVTime:
+		return entry.Timestamp.Format(w.options.TimeFormat)
+	case CSVLevel:
+		return entry.Level.String()
+	case CSVMessage:
+		return entry.IndentStr + entry.Message
+	case CSVCaller:
+		if entry.Caller == nil {
+			return ""
+		}
+		return entry.Caller.File + ":" + strconv.Itoa(entry.Caller.Line)
+	}
+	if v, ok := lookupField(entry.Fields, col); ok {
+		return fieldText(v)
+	}
+	return ""
+}
+
+// lookupField returns the field key, which may be a dotted path into
+// nested Fields as flattened by share.Fields.Flatten.
+func lookupField(fields share.Fields, key string) (any, bool) {
+	if v, ok := fields[key]; ok {
+		return v, true
+	}
+	for i := range len(key) {
+		if key[i] != '.' {
+			continue
+		}
+		if nested, ok := fields[key[:i]].(share.Fields); ok {
+			if v, ok := lookupField(nested, key[i+1:]); ok {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Close closes the file opened by NewCSVFile.
+func (w *CSVWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	w.csv.Flush()
+	err := w.csv.Error()
+	if w.closer != nil {
+		err = errors.Join(err, w.closer.Close())
+	}
+	return err
+}
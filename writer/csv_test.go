@@ -0,0 +1,70 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var out bytes.Buffer
+	opts := DefaultCSVOptions()
+	opts.Columns = []string{CSVTime, CSVLevel, CSVMessage, "job", "http.status", "missing", CSVCaller}
+	w := NewCSVWriter(&out, opts)
+
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	w.Write(&share.Entry{
+		Level:     share.LevelError,
+		Message:   "failed, \"retrying\"\nlater",
+		Timestamp: ts,
+		Fields:    share.Fields{"job": "import", "http": share.Fields{"status": 503}, "other": 1},
+		Caller:    &share.CallerInfo{File: "job.go", Line: 7},
+	})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "done", Timestamp: ts})
+	w.Close()
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV %q: %v", out.String(), err)
+	}
+	want := [][]string{
+		opts.Columns,
+		{"2024-05-01T12:00:00Z", "ERROR", "failed, \"retrying\"\nlater", "import", "503", "", "job.go:7"},
+		{"2024-05-01T12:00:00Z", "INFO", "done", "", "", "", ""},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d records, got %q", len(want), records)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if records[i][j] != want[i][j] {
+				t.Errorf("record %d column %d: got %q, want %q", i, j, records[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestCSVFileTSVAppends(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "jobs.tsv")
+	opts := DefaultCSVOptions()
+	opts.Comma = '\t'
+	opts.Columns = []string{CSVLevel, CSVMessage}
+	for _, msg := range []string{"first run", "second run"} {
+		w, err := NewCSVFile(name, opts)
+		if err != nil {
+			t.Fatalf("NewCSVFile: %v", err)
+		}
+		w.Write(&share.Entry{Level: share.LevelInfo, Message: msg})
+		w.Close()
+	}
+
+	data, _ := os.ReadFile(name)
+	if got := string(data); got != "level\tmsg\nINFO\tfirst run\nINFO\tsecond run\n" {
+		t.Errorf("expected one header and both records, got %q", got)
+	}
+}
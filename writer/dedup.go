@@ -0,0 +1,224 @@
+package writer
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// DedupOptions configures a DedupWriter.
+type DedupOptions struct {
+	Window  time.Duration // How long after an entry its duplicates are suppressed
+	MaxKeys int           // Distinct entries tracked at once; further ones pass through
+}
+
+// DefaultDedupOptions suppresses duplicates for 10 seconds, tracking up to
+// 10000 distinct entries.
+func DefaultDedupOptions() DedupOptions {
+	return DedupOptions{
+		Window:  10 * time.Second,
+		MaxKeys: 10000,
+	}
+}
+
+// dedupRun is an entry whose duplicates are being suppressed.
+type dedupRun struct {
+	first   share.Entry
+	last    time.Time // Time of the latest duplicate
+	repeats int
+}
+
+// DedupWriter suppresses entries identical, in level, message and fields, to
+// one written within the window before them, even when other entries came
+// in between. When the window of an entry closes, its suppressed duplicates
+// are reported as one entry such as "connection refused (repeated 57 times
+// in 10s)" with a "repeated" field, as logfx.WithDedup does for consecutive
+// entries of a logger.
+type DedupWriter struct {
+	next    share.Writer
+	options DedupOptions
+
+	mu   sync.Mutex
+	runs map[string]*dedupRun
+	key  []byte
+
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// NewDedupWriter wraps next, suppressing duplicates as configured by opts.
+// Zero options take their defaults.
+func NewDedupWriter(next share.Writer, opts DedupOptions) *DedupWriter {
+	def := DefaultDedupOptions()
+	if opts.Window <= 0 {
+		opts.Window = def.Window
+	}
+	if opts.MaxKeys <= 0 {
+		opts.MaxKeys = def.MaxKeys
+	}
+	w := &DedupWriter{
+		next:    next,
+		options: opts,
+		runs:    make(map[string]*dedupRun),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write writes entry unless it duplicates one written within the window.
+func (w *DedupWriter) Write(entry *share.Entry) error {
+	ts := entry.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	w.mu.Lock()
+	summaries := w.expire(ts, false)
+	w.key = appendDedupKey(w.key[:0], entry)
+	if run, ok := w.runs[string(w.key)]; ok {
+		run.repeats++
+		run.last = ts
+		w.mu.Unlock()
+		return w.writeAll(summaries)
+	}
+	if len(w.runs) < w.options.MaxKeys {
+		first := *entry
+		first.Timestamp = ts
+		first.Fields = maps.Clone(entry.Fields)
+		if entry.Caller != nil {
+			caller := *entry.Caller
+			first.Caller = &caller
+		}
+		w.runs[string(w.key)] = &dedupRun{first: first}
+	}
+	w.mu.Unlock()
+
+	err := w.writeAll(summaries)
+	if werr := w.next.Write(entry); werr != nil {
+		return werr
+	}
+	return err
+}
+
+// appendDedupKey appends the identity of entry: its level, message and
+// fields, encoded with sorted keys.
+func appendDedupKey(buf []byte, entry *share.Entry) []byte {
+	buf = strconv.AppendInt(buf, int64(entry.Level), 10)
+	buf = append(buf, 0)
+	buf = append(buf, entry.Message...)
+	buf = append(buf, 0)
+	return appendValue(buf, entry.Fields, 0)
+}
+
+// expire ends the runs whose window closed by now, or all of them, and
+// returns their summaries in time order. Callers hold w.mu.
+func (w *DedupWriter) expire(now time.Time, all bool) []*share.Entry {
+	var summaries []*share.Entry
+	for key, run := range w.runs {
+		if !all && now.Sub(run.first.Timestamp) < w.options.Window {
+			continue
+		}
+		delete(w.runs, key)
+		if summary := run.summary(); summary != nil {
+			summaries = append(summaries, summary)
+		}
+	}
+	slices.SortFunc(summaries, func(a, b *share.Entry) int { return a.Timestamp.Compare(b.Timestamp) })
+	return summaries
+}
+
+// summary returns the entry reporting the repeats of run, or nil when there
+// were none.
+func (run *dedupRun) summary() *share.Entry {
+	if run.repeats == 0 {
+		return nil
+	}
+	summary := run.first
+	summary.Fields = make(share.Fields, len(run.first.Fields)+1)
+	maps.Copy(summary.Fields, run.first.Fields)
+	summary.Fields["repeated"] = run.repeats
+	summary.Message = fmt.Sprintf("%s (repeated %d times in %s)",
+		run.first.Message, run.repeats, formatRepeatWindow(run.last.Sub(run.first.Timestamp)))
+	summary.Timestamp = run.last
+	return &summary
+}
+
+// formatRepeatWindow renders how long a run of duplicates lasted.
+func formatRepeatWindow(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+// writeAll writes entries to the wrapped writer, returning the first error.
+func (w *DedupWriter) writeAll(entries []*share.Entry) error {
+	var first error
+	for _, e := range entries {
+		if err := w.next.Write(e); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// run reports the runs whose window closed while no entry came in, until
+// the writer is closed.
+func (w *DedupWriter) run() {
+	defer close(w.stopped)
+	ticker := time.NewTicker(max(w.options.Window/4, 10*time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			w.mu.Lock()
+			summaries := w.expire(now, false)
+			w.mu.Unlock()
+			w.writeAll(summaries)
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// flushRuns ends every run, writing the summaries of those with repeats.
+func (w *DedupWriter) flushRuns() error {
+	w.mu.Lock()
+	summaries := w.expire(time.Time{}, true)
+	w.mu.Unlock()
+	return w.writeAll(summaries)
+}
+
+// Flush writes the summaries of the pending duplicates, starting new
+// windows, and flushes the wrapped writer if it supports it.
+func (w *DedupWriter) Flush() {
+	w.flushRuns()
+	if flusher, ok := w.next.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
+
+// Writers returns the wrapped writer.
+func (w *DedupWriter) Writers() []share.Writer {
+	return []share.Writer{w.next}
+}
+
+// Close writes the summaries of the pending duplicates and closes the
+// wrapped writer.
+func (w *DedupWriter) Close() error {
+	w.once.Do(func() { close(w.stop) })
+	<-w.stopped
+	err := w.flushRuns()
+	if cerr := w.next.Close(); cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+package writer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func TestDedupWriterSuppressesWithinWindow(t *testing.T) {
+	capture := NewCaptureWriter()
+	w := NewDedupWriter(capture, DedupOptions{Window: time.Hour})
+	defer w.Close()
+
+	start := time.Now()
+	refused := func(d time.Duration) *share.Entry {
+		return &share.Entry{Level: share.LevelError, Message: "connection refused", Fields: share.Fields{"port": 5432}, Timestamp: start.Add(d)}
+	}
+	w.Write(refused(0))
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "retrying", Timestamp: start.Add(time.Second)})
+	w.Write(refused(2 * time.Second)) // Not consecutive, still a duplicate
+	w.Write(refused(3 * time.Second))
+	w.Write(&share.Entry{Level: share.LevelError, Message: "connection refused", Fields: share.Fields{"port": 6379}, Timestamp: start.Add(4 * time.Second)})
+
+	if got := capture.Messages(); len(got) != 3 {
+		t.Fatalf("expected the duplicates suppressed, got %q", got)
+	}
+
+	w.Flush()
+	summary, ok := capture.Find(func(e share.Entry) bool { return e.Fields["repeated"] != nil })
+	if !ok {
+		t.Fatalf("expected a summary on Flush, got %q", capture.Messages())
+	}
+	if summary.Message != "connection refused (repeated 2 times in 3s)" || summary.Fields["repeated"] != 2 || summary.Fields["port"] != 5432 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if capture.Len() != 4 {
+		t.Errorf("expected one summary, got %q", capture.Messages())
+	}
+}
+
+func TestDedupWriterWindowCloses(t *testing.T) {
+	capture := NewCaptureWriter()
+	w := NewDedupWriter(capture, DedupOptions{Window: 20 * time.Millisecond})
+	defer w.Close()
+
+	for range 3 {
+		w.Write(&share.Entry{Level: share.LevelWarn, Message: "slow", Timestamp: time.Now()})
+	}
+	deadline := time.Now().Add(time.Second)
+	for !capture.ContainsMessage("repeated 2 times") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a summary when the window closed, got %q", capture.Messages())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	w.Write(&share.Entry{Level: share.LevelWarn, Message: "slow", Timestamp: time.Now()})
+	if n := len(capture.Entries(share.LevelWarn)); n != 3 {
+		t.Errorf("expected the entry written again in a new window, got %q", capture.Messages())
+	}
+}
+
+func TestDedupWriterCloseReportsPending(t *testing.T) {
+	capture := NewCaptureWriter()
+	w := NewDedupWriter(capture, DedupOptions{Window: time.Hour, MaxKeys: 1})
+	ts := time.Now()
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "a", Timestamp: ts})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "b", Timestamp: ts})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "b", Timestamp: ts}) // Untracked beyond MaxKeys
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "a", Timestamp: ts})
+	w.Close()
+
+	if got := capture.Messages(); len(got) != 4 || got[3] != "a (repeated 1 times in 0s)" {
+		t.Errorf("unexpected entries %q", got)
+	}
+}
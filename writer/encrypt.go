@@ -0,0 +1,165 @@
+package writer
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encrypted log files, written by FileWriter with FileOptions.EncryptionKey,
+// are a sequence of segments, one per time the file was opened. A segment
+// starts with the magic "TFXE", a version byte and a random 16-byte segment
+// ID, followed by chunks: a 4-byte big-endian ciphertext length, a random
+// 12-byte nonce and the AES-GCM ciphertext of one or more log lines. The
+// segment ID and chunk number are authenticated with each chunk, so chunks
+// cannot be reordered, dropped from within a segment or moved between
+// segments unnoticed. A file cut at a chunk boundary still decrypts, up to
+// the cut.
+var encryptedMagic = []byte("TFXE")
+
+const (
+	encryptedVersion = 1
+	segmentIDSize    = 16
+	maxChunkSize     = 1 << 30 // Below the magic read as a length
+)
+
+// ErrEncryptedLog is returned by DecryptLog for a file that is not an
+// encrypted log, was damaged or tampered with, or for the wrong key.
+var ErrEncryptedLog = errors.New("invalid encrypted log")
+
+// NewLogKey returns a random 32-byte key for FileOptions.EncryptionKey,
+// selecting AES-256. Keep it apart from the logs.
+func NewLogKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// newLogAEAD returns the AES-GCM cipher of key, which must be 16, 24 or 32
+// bytes long.
+func newLogAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealer encrypts each write to out as one chunk of a new segment.
+type sealer struct {
+	out     io.Writer
+	aead    cipher.AEAD
+	segment [segmentIDSize]byte
+	seq     uint64
+	buf     []byte
+}
+
+// newSealer starts a segment on out.
+func newSealer(out io.Writer, aead cipher.AEAD) (*sealer, error) {
+	s := &sealer{out: out, aead: aead}
+	if _, err := rand.Read(s.segment[:]); err != nil {
+		return nil, err
+	}
+	header := append(append(append([]byte(nil), encryptedMagic...), encryptedVersion), s.segment[:]...)
+	if _, err := out.Write(header); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Write encrypts p as one chunk.
+func (s *sealer) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if len(p) > maxChunkSize {
+		return 0, fmt.Errorf("log chunk of %d bytes exceeds %d", len(p), maxChunkSize)
+	}
+	nonceSize := s.aead.NonceSize()
+	buf := append(s.buf[:0], make([]byte, 4+nonceSize)...)
+	nonce := buf[4 : 4+nonceSize]
+	if _, err := rand.Read(nonce); err != nil {
+		return 0, err
+	}
+	buf = s.aead.Seal(buf, nonce, p, chunkAAD(s.segment[:], s.seq))
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-4-nonceSize))
+	if _, err := s.out.Write(buf); err != nil {
+		return 0, err
+	}
+	s.seq++
+	if cap(buf) <= maxPooledLine {
+		s.buf = buf
+	}
+	return len(p), nil
+}
+
+// chunkAAD returns the data authenticated with chunk seq of segment.
+func chunkAAD(segment []byte, seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte(nil), segment...), seq)
+}
+
+// DecryptLog writes the log lines of the encrypted log file src, written
+// with key, to dst:
+//
+//	f, _ := os.Open("app.log")
+//	err := writer.DecryptLog(os.Stdout, f, key)
+//
+// Lines are written as their chunks are verified, so on an error dst holds
+// the lines before the damage.
+func DecryptLog(dst io.Writer, src io.Reader, key []byte) error {
+	aead, err := newLogAEAD(key)
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(src)
+	var (
+		segment []byte
+		seq     uint64
+		head    [4]byte
+		buf     []byte
+	)
+	for {
+		if _, err := io.ReadFull(r, head[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("%w: truncated chunk", ErrEncryptedLog)
+		}
+		if bytes.Equal(head[:], encryptedMagic) {
+			header := make([]byte, 1+segmentIDSize)
+			if _, err := io.ReadFull(r, header); err != nil || header[0] != encryptedVersion {
+				return fmt.Errorf("%w: bad segment header", ErrEncryptedLog)
+			}
+			segment, seq = header[1:], 0
+			continue
+		}
+		if segment == nil {
+			return fmt.Errorf("%w: missing header", ErrEncryptedLog)
+		}
+		n := int(binary.BigEndian.Uint32(head[:]))
+		if n > maxChunkSize+aead.Overhead() {
+			return fmt.Errorf("%w: bad chunk length", ErrEncryptedLog)
+		}
+		nonceSize := aead.NonceSize()
+		buf = append(buf[:0], make([]byte, nonceSize+n)...)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return fmt.Errorf("%w: truncated chunk", ErrEncryptedLog)
+		}
+		plain, err := aead.Open(buf[nonceSize:nonceSize], buf[:nonceSize], buf[nonceSize:], chunkAAD(segment, seq))
+		if err != nil {
+			return fmt.Errorf("%w: chunk %d fails authentication", ErrEncryptedLog, seq)
+		}
+		if _, err := dst.Write(plain); err != nil {
+			return err
+		}
+		seq++
+	}
+}
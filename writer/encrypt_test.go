@@ -0,0 +1,140 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func newEncryptedFile(t *testing.T, name string, key []byte, configure func(*FileOptions)) *FileWriter {
+	t.Helper()
+	opts := DefaultFileOptions()
+	opts.EncryptionKey = key
+	opts.Compress = false
+	if configure != nil {
+		configure(&opts)
+	}
+	w, err := NewFileWriter(name, opts)
+	if err != nil {
+		t.Fatalf("NewFileWriter: %v", err)
+	}
+	return w
+}
+
+func decryptFile(t *testing.T, name string, key []byte) (string, error) {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	err = DecryptLog(&out, bytes.NewReader(data), key)
+	return out.String(), err
+}
+
+func TestEncryptedFileRoundTrip(t *testing.T) {
+	key, err := NewLogKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(t.TempDir(), "secret.log")
+
+	for _, msg := range []string{"card 4242 charged", "password reset for alice"} {
+		w := newEncryptedFile(t, name, key, nil)
+		w.Write(&share.Entry{Level: share.LevelInfo, Message: msg, Timestamp: time.Now()})
+		w.Close()
+	}
+
+	raw, _ := os.ReadFile(name)
+	if bytes.Contains(raw, []byte("4242")) || bytes.Contains(raw, []byte("alice")) {
+		t.Fatal("expected no plaintext in the file")
+	}
+	got, err := decryptFile(t, name, key)
+	if err != nil {
+		t.Fatalf("DecryptLog: %v", err)
+	}
+	if !strings.Contains(got, "card 4242 charged") || !strings.Contains(got, "password reset for alice") || strings.Count(got, "\n") != 2 {
+		t.Errorf("expected both segments decrypted, got %q", got)
+	}
+}
+
+func TestEncryptedFileBufferedRotation(t *testing.T) {
+	key, _ := NewLogKey()
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	w := newEncryptedFile(t, name, key, func(o *FileOptions) {
+		o.BufferSize = 1 << 10
+		o.MaxSize = 150 // Two entries, so the four rotate once
+	})
+	for i := range 4 {
+		w.Write(&share.Entry{Level: share.LevelInfo, Message: strings.Repeat("x", 40) + string(rune('a'+i)), Timestamp: time.Now()})
+	}
+	w.Close()
+
+	backups, _ := filepath.Glob(filepath.Join(dir, "app.*.log"))
+	if len(backups) != 1 {
+		t.Fatalf("expected one rotated file, got %v", backups)
+	}
+	old, err := decryptFile(t, backups[0], key)
+	if err != nil {
+		t.Fatalf("DecryptLog of the backup: %v", err)
+	}
+	current, err := decryptFile(t, name, key)
+	if err != nil {
+		t.Fatalf("DecryptLog of the current file: %v", err)
+	}
+	if strings.Count(old, "\n") != 2 || strings.Count(current, "\n") != 2 || !strings.Contains(current, "xd") {
+		t.Errorf("unexpected split across rotation: %q and %q", old, current)
+	}
+}
+
+func TestDecryptLogDetectsTampering(t *testing.T) {
+	key, _ := NewLogKey()
+	name := filepath.Join(t.TempDir(), "app.log")
+	w := newEncryptedFile(t, name, key, nil)
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "first", Timestamp: time.Now()})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "second", Timestamp: time.Now()})
+	w.Close()
+	data, _ := os.ReadFile(name)
+
+	flipped := bytes.Clone(data)
+	flipped[len(flipped)-1] ^= 1
+	var out bytes.Buffer
+	if err := DecryptLog(&out, bytes.NewReader(flipped), key); !errors.Is(err, ErrEncryptedLog) {
+		t.Errorf("expected a modified chunk rejected, got %v", err)
+	}
+	if !strings.Contains(out.String(), "first") || strings.Contains(out.String(), "second") {
+		t.Errorf("expected the lines before the damage, got %q", out.String())
+	}
+
+	// Swap the two chunks
+	header := len(encryptedMagic) + 1 + segmentIDSize
+	first := 4 + 12 + int(binary.BigEndian.Uint32(data[header:]))
+	swapped := append(append(bytes.Clone(data[:header]), data[header+first:]...), data[header:header+first]...)
+	if err := DecryptLog(&bytes.Buffer{}, bytes.NewReader(swapped), key); !errors.Is(err, ErrEncryptedLog) {
+		t.Errorf("expected reordered chunks rejected, got %v", err)
+	}
+
+	other, _ := NewLogKey()
+	if err := DecryptLog(&bytes.Buffer{}, bytes.NewReader(data), other); !errors.Is(err, ErrEncryptedLog) {
+		t.Errorf("expected the wrong key rejected, got %v", err)
+	}
+	if err := DecryptLog(&bytes.Buffer{}, strings.NewReader("plain text log\n"), key); !errors.Is(err, ErrEncryptedLog) {
+		t.Errorf("expected a plain file rejected, got %v", err)
+	}
+}
+
+func TestNewFileWriterRejectsBadKey(t *testing.T) {
+	opts := DefaultFileOptions()
+	opts.EncryptionKey = []byte("short")
+	if _, err := NewFileWriter(filepath.Join(t.TempDir(), "app.log"), opts); err == nil {
+		t.Error("expected an error for an invalid key")
+	}
+}
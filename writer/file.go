@@ -1,7 +1,10 @@
 package writer
 
 import (
+	"crypto/cipher"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,6 +20,9 @@ type FileWriter struct {
 	file        *os.File
 	options     FileOptions
 	currentSize int64
+	out         io.Writer       // The file, or its sealer when encrypted
+	buffer      *BufferedWriter // Nil unless BufferSize is set
+	aead        cipher.AEAD     // Nil unless EncryptionKey is set
 	mu          sync.Mutex
 }
 
@@ -29,8 +35,25 @@ type FileOptions struct {
 	MaxAge      int   // Maximum number of days to retain files
 	Compress    bool  // Whether to compress rotated files
 	Permissions os.FileMode
+	JSONKeys    JSONKeys // Key names of FormatJSON entries; empty keys use fileJSONKeys
+
+	// BufferSize buffers up to this many bytes of entries in memory and
+	// syncs the file only when they are written out, instead of on every
+	// entry; zero writes and syncs each entry. Buffered entries are lost
+	// if the process dies before Flush or Close.
+	BufferSize    int
+	FlushInterval time.Duration // Longest time entries stay buffered; zero waits for BufferSize or Flush
+
+	// EncryptionKey encrypts the file with AES-GCM under this 16, 24 or
+	// 32-byte key, see NewLogKey; read it back with DecryptLog. Each write
+	// to the file is sealed as one chunk, so set BufferSize to encrypt many
+	// entries per chunk. MaxSize counts the log text, not the ciphertext.
+	EncryptionKey []byte
 }
 
+// fileJSONKeys are the default key names of JSON log files.
+var fileJSONKeys = JSONKeys{Time: "timestamp", Level: "level", Message: "message", Caller: "caller"}
+
 // DefaultFileOptions returns sensible defaults for file writing
 func DefaultFileOptions() FileOptions {
 	return FileOptions{
@@ -71,6 +94,19 @@ func NewFileWriter(filename string, opts FileOptions) (*FileWriter, error) {
 		options:     opts,
 		currentSize: stat.Size(),
 	}
+	if opts.EncryptionKey != nil {
+		if writer.aead, err = newLogAEAD(opts.EncryptionKey); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	if writer.out, err = writer.sink(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to start encrypted log: %w", err)
+	}
+	if opts.BufferSize > 0 {
+		writer.buffer = NewBufferedWriter(writer.out, BufferedOptions{Size: opts.BufferSize, FlushInterval: opts.FlushInterval})
+	}
 
 	// Clean up old files
 	go writer.cleanup()
@@ -99,6 +135,9 @@ func (w *FileWriter) Write(entry *share.Entry) error {
 
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	if w.file == nil {
+		return os.ErrClosed
+	}
 
 	// Check if rotation is needed
 	if w.needsRotation(int64(len(output))) {
@@ -107,8 +146,14 @@ func (w *FileWriter) Write(entry *share.Entry) error {
 		}
 	}
 
+	if w.buffer != nil {
+		n, err := w.buffer.Write([]byte(output))
+		w.currentSize += int64(n)
+		return err
+	}
+
 	// Write to file
-	n, err := w.file.WriteString(output)
+	n, err := io.WriteString(w.out, output)
 	if err != nil {
 		return err
 	}
@@ -122,6 +167,15 @@ func (w *FileWriter) Write(entry *share.Entry) error {
 	return nil
 }
 
+// sink returns the writer of the formatted output to file: file itself, or
+// a sealer starting an encrypted segment on it.
+func (w *FileWriter) sink(file *os.File) (io.Writer, error) {
+	if w.aead == nil {
+		return file, nil
+	}
+	return newSealer(file, w.aead)
+}
+
 // needsRotation checks if the file needs rotation
 func (w *FileWriter) needsRotation(additionalSize int64) bool {
 	return w.currentSize+additionalSize > w.options.MaxSize
@@ -129,6 +183,13 @@ func (w *FileWriter) needsRotation(additionalSize int64) bool {
 
 // rotate rotates the current log file
 func (w *FileWriter) rotate() error {
+	// Write out the entries buffered for the current file
+	if w.buffer != nil {
+		if err := w.buffer.Flush(); err != nil {
+			return err
+		}
+	}
+
 	// Close current file
 	if err := w.file.Close(); err != nil {
 		return err
@@ -153,8 +214,18 @@ func (w *FileWriter) rotate() error {
 		return err
 	}
 
+	out, err := w.sink(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
 	w.file = file
+	w.out = out
 	w.currentSize = 0
+	if w.buffer != nil {
+		w.buffer.Reset(out)
+	}
 
 	return nil
 }
@@ -236,29 +307,13 @@ func (w *FileWriter) cleanup() {
 	}
 }
 
-// formatJSON formats entry as JSON for file output
+// formatJSON formats entry as a JSON object for file output
 func (w *FileWriter) formatJSON(entry *share.Entry) string {
-	// Simplified JSON formatting - in real implementation use json.Marshal
-	parts := []string{
-		fmt.Sprintf(`"timestamp":"%s"`, entry.Timestamp.Format(time.RFC3339)),
-		fmt.Sprintf(`"level":"%s"`, entry.Level.String()),
-		fmt.Sprintf(`"message":"%s"`, w.escapeJSON(entry.Message)),
-	}
-
-	// Add caller if available
-	if entry.Caller != nil {
-		parts = append(parts, fmt.Sprintf(`"caller":"%s:%d"`, entry.Caller.File, entry.Caller.Line))
+	encoder := &JSONEncoder{
+		Keys:       w.options.JSONKeys.withDefaults(fileJSONKeys),
+		TimeFormat: time.RFC3339,
 	}
-
-	// Add fields
-	for key, value := range entry.Fields {
-		if key == "badge" || key == "badge_color" {
-			continue
-		}
-		parts = append(parts, fmt.Sprintf(`"%s":"%s"`, key, w.escapeJSON(fmt.Sprintf("%v", value))))
-	}
-
-	return fmt.Sprintf("{%s}", strings.Join(parts, ","))
+	return string(encoder.AppendEntry(nil, entry))
 }
 
 // formatText formats entry as plain text for file output
@@ -284,12 +339,12 @@ func (w *FileWriter) formatText(entry *share.Entry) string {
 	// Fields
 	if len(entry.Fields) > 0 {
 		var fieldParts []string
-		for key, value := range entry.Fields {
+		entry.Fields.Flatten(func(key string, value any) {
 			if key == "badge" || key == "badge_color" {
-				continue
+				return
 			}
 			fieldParts = append(fieldParts, fmt.Sprintf("%s=%v", key, value))
-		}
+		})
 		if len(fieldParts) > 0 {
 			parts = append(parts, fmt.Sprintf("fields=(%s)", strings.Join(fieldParts, " ")))
 		}
@@ -299,16 +354,6 @@ func (w *FileWriter) formatText(entry *share.Entry) string {
 }
 
 // Helper functions
-func (w *FileWriter) escapeJSON(s string) string {
-	// Simple JSON escaping - in real implementation use proper JSON escaping
-	s = strings.ReplaceAll(s, "\\", "\\\\")
-	s = strings.ReplaceAll(s, "\"", "\\\"")
-	s = strings.ReplaceAll(s, "\n", "\\n")
-	s = strings.ReplaceAll(s, "\r", "\\r")
-	s = strings.ReplaceAll(s, "\t", "\\t")
-	return s
-}
-
 func (w *FileWriter) shortFilename(filename string) string {
 	parts := strings.Split(filename, "/")
 	if len(parts) > 0 {
@@ -317,13 +362,29 @@ func (w *FileWriter) shortFilename(filename string) string {
 	return filename
 }
 
-// Close closes the file writer
+// Flush writes the buffered entries out and syncs the file.
+func (w *FileWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buffer == nil || w.file == nil {
+		return
+	}
+	if w.buffer.Flush() == nil {
+		w.file.Sync()
+	}
+}
+
+// Close writes the buffered entries out and closes the file writer
 func (w *FileWriter) Close() error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
 	if w.file != nil {
-		err := w.file.Close()
+		var err error
+		if w.buffer != nil {
+			err = w.buffer.Close()
+		}
+		err = errors.Join(err, w.file.Close())
 		w.file = nil
 		return err
 	}
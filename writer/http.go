@@ -0,0 +1,339 @@
+package writer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// HTTPFormat is the payload of the requests of an HTTPWriter.
+type HTTPFormat int
+
+const (
+	// HTTPFormatJSON posts a JSON array of entries, as generic webhooks and
+	// the Datadog logs intake accept.
+	HTTPFormatJSON HTTPFormat = iota
+	// HTTPFormatNDJSON posts newline-delimited JSON entries.
+	HTTPFormatNDJSON
+	// HTTPFormatLoki posts to the Grafana Loki push API: one stream per
+	// level, labeled with Labels and "level", whose lines are JSON entries.
+	HTTPFormatLoki
+	// HTTPFormatOTLP posts OpenTelemetry logs in the JSON encoding of
+	// OTLP/HTTP, with Labels as resource attributes, see DefaultOTLPOptions.
+	HTTPFormatOTLP
+)
+
+// String returns the name of the format.
+func (f HTTPFormat) String() string {
+	switch f {
+	case HTTPFormatNDJSON:
+		return "ndjson"
+	case HTTPFormatLoki:
+		return "loki"
+	case HTTPFormatOTLP:
+		return "otlp"
+	default:
+		return "json"
+	}
+}
+
+// HTTPOptions configures an HTTPWriter.
+type HTTPOptions struct {
+	URL           string
+	Level         share.Level
+	Format        HTTPFormat
+	Headers       map[string]string // Extra request headers, e.g. "DD-API-KEY" or "Authorization"
+	Labels        map[string]string // Loki stream labels or OTLP resource attributes; with the JSON formats, fields added to every entry
+	Gzip          bool              // Compress request bodies
+	BatchSize     int               // Entries that trigger a request
+	FlushInterval time.Duration     // Longest time an entry waits for its batch
+	BufferSize    int               // Entries queued for sending
+	Overflow      OverflowPolicy    // What Write does when the queue is full; OverflowBlock applies backpressure
+	MaxRetries    int               // Retries of a failed request before its batch is dropped
+	RetryBackoff  time.Duration     // Wait before the first retry, doubled after each one
+	Timeout       time.Duration     // Limit of each request
+	Client        *http.Client      // Defaults to a client with Timeout
+	JSONKeys      JSONKeys          // Key names of the entries; empty keys use DefaultJSONKeys
+}
+
+// DefaultHTTPOptions batches Info and above as JSON arrays of up to 100
+// entries, at least every second, queuing up to 10000 entries and retrying
+// failed requests 3 times.
+func DefaultHTTPOptions() HTTPOptions {
+	return HTTPOptions{
+		Level:         share.LevelInfo,
+		Format:        HTTPFormatJSON,
+		BatchSize:     100,
+		FlushInterval: time.Second,
+		BufferSize:    10000,
+		Overflow:      OverflowBlock,
+		MaxRetries:    3,
+		RetryBackoff:  500 * time.Millisecond,
+		Timeout:       10 * time.Second,
+	}
+}
+
+// HTTPError is the error of a request answered with an unexpected status.
+type HTTPError struct {
+	StatusCode int
+	Body       string // Start of the response body
+}
+
+func (e *HTTPError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("http log shipping: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("http log shipping: status %d: %s", e.StatusCode, e.Body)
+}
+
+// HTTPWriter ships entries in batches over HTTP, to Loki, Datadog, an
+// OpenTelemetry collector or any webhook. Write queues the entry; a background goroutine posts a batch
+// when BatchSize entries are queued or FlushInterval passes, retrying
+// throttled and failed requests with exponential backoff. When the queue
+// is full Write blocks or drops entries, as Overflow says, so a slow
+// endpoint slows the application down or loses entries rather than
+// growing memory without bound.
+//
+//	w, err := writer.NewHTTPWriter(writer.HTTPOptions{
+//		URL:    "http://loki:3100/loki/api/v1/push",
+//		Format: writer.HTTPFormatLoki,
+//		Labels: map[string]string{"app": "api"},
+//	})
+type HTTPWriter struct {
+	*batcher
+	options HTTPOptions
+	enc     *JSONEncoder
+	client  *http.Client
+}
+
+// NewHTTPWriter starts shipping to the URL of opts; zero options take the
+// values of DefaultHTTPOptions.
+func NewHTTPWriter(opts HTTPOptions) (*HTTPWriter, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("missing http log shipping URL")
+	}
+	def := DefaultHTTPOptions()
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = def.BatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = def.FlushInterval
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = def.BufferSize
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = def.RetryBackoff
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = def.Timeout
+	}
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: opts.Timeout}
+	}
+
+	w := &HTTPWriter{
+		options: opts,
+		enc:     NewJSONEncoder(opts.JSONKeys),
+		client:  client,
+	}
+	w.batcher = &batcher{
+		batchSize:     opts.BatchSize,
+		flushInterval: opts.FlushInterval,
+		overflow:      opts.Overflow,
+		maxRetries:    opts.MaxRetries,
+		retryBackoff:  opts.RetryBackoff,
+		send:          w.post,
+		retryable:     retryableHTTP,
+	}
+	w.start(opts.BufferSize)
+	return w, nil
+}
+
+// Write queues entry for the next batch.
+func (w *HTTPWriter) Write(entry *share.Entry) error {
+	if entry.Level < w.options.Level {
+		return nil
+	}
+	msg := Message{Level: entry.Level, Time: entry.Timestamp}
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+	switch w.options.Format {
+	case HTTPFormatOTLP:
+		msg.Value = appendOTLPRecord(nil, entry, msg.Time, time.Now())
+	case HTTPFormatLoki:
+		msg.Value = w.enc.AppendEntry(nil, entry)
+	default:
+		if len(w.options.Labels) > 0 {
+			labeled := *entry
+			labeled.Fields = make(share.Fields, len(entry.Fields)+len(w.options.Labels))
+			for k, v := range w.options.Labels {
+				labeled.Fields[k] = v
+			}
+			maps.Copy(labeled.Fields, entry.Fields)
+			entry = &labeled
+		}
+		msg.Value = w.enc.AppendEntry(nil, entry)
+	}
+	w.add(msg)
+	return nil
+}
+
+// retryableHTTP reports whether a request that failed with err may succeed
+// if sent again: after a network error, throttling or a server error.
+func retryableHTTP(err error) bool {
+	if httpErr, ok := err.(*HTTPError); ok {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	return true
+}
+
+// post sends batch in one request.
+func (w *HTTPWriter) post(batch []Message) error {
+	body, err := w.encode(batch)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, w.options.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if w.options.Format == HTTPFormatNDJSON {
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if w.options.Gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	for k, v := range w.options.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return &HTTPError{StatusCode: resp.StatusCode, Body: string(bytes.TrimSpace(msg))}
+}
+
+// encode builds the request body of batch.
+func (w *HTTPWriter) encode(batch []Message) ([]byte, error) {
+	var buf []byte
+	switch w.options.Format {
+	case HTTPFormatNDJSON:
+		for _, item := range batch {
+			buf = append(buf, item.Value...)
+			buf = append(buf, '\n')
+		}
+	case HTTPFormatLoki:
+		buf = w.appendLoki(buf, batch)
+	case HTTPFormatOTLP:
+		buf = w.appendOTLP(buf, batch)
+	default:
+		buf = append(buf, '[')
+		for i, item := range batch {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, item.Value...)
+		}
+		buf = append(buf, ']')
+	}
+	if !w.options.Gzip {
+		return buf, nil
+	}
+
+	var zbuf bytes.Buffer
+	zw := gzip.NewWriter(&zbuf)
+	if _, err := zw.Write(buf); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return zbuf.Bytes(), nil
+}
+
+// appendLoki appends the Loki push request of batch: one stream per level,
+// keeping the order of the entries within each.
+func (w *HTTPWriter) appendLoki(buf []byte, batch []Message) []byte {
+	var levels []share.Level
+	byLevel := make(map[share.Level][]Message)
+	for _, item := range batch {
+		if _, ok := byLevel[item.Level]; !ok {
+			levels = append(levels, item.Level)
+		}
+		byLevel[item.Level] = append(byLevel[item.Level], item)
+	}
+
+	labelKeys := slices.Sorted(maps.Keys(w.options.Labels))
+	buf = append(buf, `{"streams":[`...)
+	for i, level := range levels {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, `{"stream":{`...)
+		for _, k := range labelKeys {
+			if k == "level" {
+				continue // Set per stream below
+			}
+			buf = appendString(buf, k)
+			buf = append(buf, ':')
+			buf = appendString(buf, w.options.Labels[k])
+			buf = append(buf, ',')
+		}
+		buf = append(buf, `"level":`...)
+		buf = appendString(buf, lokiLevel(level))
+		buf = append(buf, `},"values":[`...)
+		for j, item := range byLevel[level] {
+			if j > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, `["`...)
+			buf = strconv.AppendInt(buf, item.Time.UnixNano(), 10)
+			buf = append(buf, `",`...)
+			buf = appendString(buf, string(item.Value))
+			buf = append(buf, ']')
+		}
+		buf = append(buf, "]}"...)
+	}
+	return append(buf, "]}"...)
+}
+
+// lokiLevel returns the level label Grafana recognizes for level.
+func lokiLevel(level share.Level) string {
+	switch level {
+	case share.LevelTrace:
+		return "trace"
+	case share.LevelDebug:
+		return "debug"
+	case share.LevelInfo, share.LevelSuccess:
+		return "info"
+	case share.LevelWarn:
+		return "warning"
+	case share.LevelError:
+		return "error"
+	default:
+		return "critical"
+	}
+}
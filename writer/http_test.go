@@ -0,0 +1,209 @@
+package writer
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// collector records the bodies posted to it, answering with the statuses
+// of replies in turn and 204 after them.
+type collector struct {
+	mu      sync.Mutex
+	bodies  []string
+	headers []http.Header
+	replies []int
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = zr
+	}
+	data, _ := io.ReadAll(body)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bodies = append(c.bodies, string(data))
+	c.headers = append(c.headers, r.Header.Clone())
+	status := http.StatusNoContent
+	if len(c.replies) > 0 {
+		status, c.replies = c.replies[0], c.replies[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (c *collector) requests() ([]string, []http.Header) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.bodies...), append([]http.Header(nil), c.headers...)
+}
+
+func newCollector(t *testing.T, replies ...int) (*collector, string) {
+	c := &collector{replies: replies}
+	srv := httptest.NewServer(c)
+	t.Cleanup(srv.Close)
+	return c, srv.URL
+}
+
+func TestHTTPWriterBatchesJSON(t *testing.T) {
+	c, url := newCollector(t)
+	w, err := NewHTTPWriter(HTTPOptions{
+		URL:           url,
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		Headers:       map[string]string{"DD-API-KEY": "secret"},
+		Labels:        map[string]string{"service": "api"},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPWriter: %v", err)
+	}
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "one"})
+	w.Write(&share.Entry{Level: share.LevelError, Message: "two", Fields: share.Fields{"service": "own"}})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "three"})
+	w.Flush()
+
+	bodies, headers := c.requests()
+	if len(bodies) != 2 {
+		t.Fatalf("expected a full batch and a flushed one, got %d requests", len(bodies))
+	}
+	var batch []map[string]any
+	if err := json.Unmarshal([]byte(bodies[0]), &batch); err != nil {
+		t.Fatalf("invalid body %q: %v", bodies[0], err)
+	}
+	if len(batch) != 2 || batch[0]["msg"] != "one" || batch[0]["service"] != "api" || batch[1]["service"] != "own" {
+		t.Errorf("unexpected batch %v", batch)
+	}
+	if headers[0].Get("DD-API-KEY") != "secret" || headers[0].Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected headers %v", headers[0])
+	}
+}
+
+func TestHTTPWriterLokiGzip(t *testing.T) {
+	c, url := newCollector(t)
+	w, err := NewHTTPWriter(HTTPOptions{
+		URL:    url,
+		Format: HTTPFormatLoki,
+		Gzip:   true,
+		Labels: map[string]string{"app": "api"},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPWriter: %v", err)
+	}
+	defer w.Close()
+
+	ts := time.Unix(1700000000, 5)
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "started", Timestamp: ts})
+	w.Write(&share.Entry{Level: share.LevelWarn, Message: "slow", Timestamp: ts})
+	w.Flush()
+
+	bodies, _ := c.requests()
+	if len(bodies) != 1 {
+		t.Fatalf("expected one request, got %d", len(bodies))
+	}
+	var push struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal([]byte(bodies[0]), &push); err != nil {
+		t.Fatalf("invalid Loki body %q: %v", bodies[0], err)
+	}
+	if len(push.Streams) != 2 {
+		t.Fatalf("expected a stream per level, got %+v", push)
+	}
+	s := push.Streams[1]
+	if s.Stream["app"] != "api" || s.Stream["level"] != "warning" || len(s.Values) != 1 {
+		t.Errorf("unexpected stream %+v", s)
+	}
+	if s.Values[0][0] != "1700000000000000005" || !strings.Contains(s.Values[0][1], `"msg":"slow"`) {
+		t.Errorf("unexpected value %v", s.Values[0])
+	}
+}
+
+func TestHTTPWriterRetries(t *testing.T) {
+	c, url := newCollector(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	w, err := NewHTTPWriter(HTTPOptions{URL: url, Format: HTTPFormatNDJSON, MaxRetries: 2, RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewHTTPWriter: %v", err)
+	}
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "eventually"})
+	w.Flush()
+
+	bodies, headers := c.requests()
+	if len(bodies) != 3 || w.Dropped() != 0 {
+		t.Errorf("expected two retries and no drop, got %d requests and %d dropped", len(bodies), w.Dropped())
+	}
+	if headers[0].Get("Content-Type") != "application/x-ndjson" || !strings.HasSuffix(bodies[2], "}\n") {
+		t.Errorf("unexpected NDJSON request %q %v", bodies[2], headers[0])
+	}
+}
+
+func TestHTTPWriterDropsRejectedBatch(t *testing.T) {
+	c, url := newCollector(t, http.StatusBadRequest)
+	w, err := NewHTTPWriter(HTTPOptions{URL: url, MaxRetries: 3, RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewHTTPWriter: %v", err)
+	}
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "rejected"})
+	w.Flush()
+
+	if bodies, _ := c.requests(); len(bodies) != 1 {
+		t.Errorf("expected no retry of a client error, got %d requests", len(bodies))
+	}
+	if w.Dropped() != 1 {
+		t.Errorf("expected the batch dropped, got %d", w.Dropped())
+	}
+	var httpErr *HTTPError
+	if err := <-w.Errors(); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected the status error, got %v", err)
+	}
+}
+
+func TestHTTPWriterOverflow(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+
+	w, err := NewHTTPWriter(HTTPOptions{URL: srv.URL, BatchSize: 1, BufferSize: 2, Overflow: OverflowDropNewest})
+	if err != nil {
+		t.Fatalf("NewHTTPWriter: %v", err)
+	}
+	defer w.Close()
+	defer close(block) // Before closing w, which sends the queue
+	for range 10 {
+		w.Write(&share.Entry{Level: share.LevelInfo, Message: "x"})
+	}
+	if w.Dropped() < 7 || w.Len() > 2 {
+		t.Errorf("expected a bounded queue, got %d queued and %d dropped", w.Len(), w.Dropped())
+	}
+}
+
+func TestNewHTTPWriterRequiresURL(t *testing.T) {
+	if _, err := NewHTTPWriter(HTTPOptions{}); err == nil {
+		t.Error("expected an error without a URL")
+	}
+}
@@ -0,0 +1,187 @@
+package writer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// JournaldSocket is the native protocol socket of systemd-journald.
+const JournaldSocket = "/run/systemd/journal/socket"
+
+// ErrNoJournald is returned by NewJournaldWriter when the journal socket
+// does not exist.
+var ErrNoJournald = errors.New("journald socket not available")
+
+// JournaldOptions configures a JournaldWriter.
+type JournaldOptions struct {
+	Level      share.Level
+	Identifier string // SYSLOG_IDENTIFIER of the entries; defaults to the program name
+	Socket     string // Defaults to JournaldSocket
+}
+
+// DefaultJournaldOptions logs Info and above under the program name.
+func DefaultJournaldOptions() JournaldOptions {
+	return JournaldOptions{
+		Level:      share.LevelInfo,
+		Identifier: filepath.Base(os.Args[0]),
+		Socket:     JournaldSocket,
+	}
+}
+
+// JournaldWriter sends entries to systemd-journald over its native
+// protocol, so they keep their priority and fields instead of becoming
+// plain text lines. The message goes to MESSAGE, the level to PRIORITY and
+// TFX_LEVEL, the caller to CODE_FILE, CODE_LINE and CODE_FUNC, and every
+// other field to an upper-cased journal field: "request_id" becomes
+// REQUEST_ID and the group field "http.status" HTTP_STATUS. Query them with
+// journalctl, e.g. journalctl REQUEST_ID=42.
+type JournaldWriter struct {
+	mu      sync.Mutex
+	conn    *net.UnixConn
+	addr    *net.UnixAddr
+	options JournaldOptions
+	buf     []byte
+}
+
+// NewJournaldWriter connects to the journal socket of opts. It fails with
+// ErrNoJournald when journald is not running.
+func NewJournaldWriter(opts JournaldOptions) (*JournaldWriter, error) {
+	if opts.Socket == "" {
+		opts.Socket = JournaldSocket
+	}
+	if opts.Identifier == "" {
+		opts.Identifier = filepath.Base(os.Args[0])
+	}
+	if _, err := os.Stat(opts.Socket); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoJournald, err)
+	}
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journald socket: %w", err)
+	}
+	return &JournaldWriter{
+		conn:    conn,
+		addr:    &net.UnixAddr{Name: opts.Socket, Net: "unixgram"},
+		options: opts,
+	}, nil
+}
+
+// JournaldAvailable reports whether the journald socket exists.
+func JournaldAvailable() bool {
+	_, err := os.Stat(JournaldSocket)
+	return err == nil
+}
+
+// Write sends entry to the journal. Entries too large for a datagram are
+// passed in a temporary file, as sd_journal_send does.
+func (w *JournaldWriter) Write(entry *share.Entry) error {
+	if entry.Level < w.options.Level {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = w.appendEntry(w.buf[:0], entry)
+	_, _, err := w.conn.WriteMsgUnix(w.buf, nil, w.addr)
+	if err != nil && isMsgTooLarge(err) {
+		err = sendJournalFile(w.conn, w.addr, w.buf)
+	}
+	return err
+}
+
+// Close closes the connection to the journal.
+func (w *JournaldWriter) Close() error {
+	return w.conn.Close()
+}
+
+// appendEntry appends the native protocol encoding of entry.
+func (w *JournaldWriter) appendEntry(buf []byte, entry *share.Entry) []byte {
+	buf = appendJournalField(buf, "MESSAGE", entry.IndentStr+entry.Message)
+	buf = appendJournalField(buf, "PRIORITY", strconv.Itoa(JournalPriority(entry.Level)))
+	buf = appendJournalField(buf, "TFX_LEVEL", entry.Level.String())
+	if w.options.Identifier != "" {
+		buf = appendJournalField(buf, "SYSLOG_IDENTIFIER", w.options.Identifier)
+	}
+	if c := entry.Caller; c != nil {
+		buf = appendJournalField(buf, "CODE_FILE", c.File)
+		buf = appendJournalField(buf, "CODE_LINE", strconv.Itoa(c.Line))
+		if c.Function != "" {
+			buf = appendJournalField(buf, "CODE_FUNC", c.Function)
+		}
+	}
+	entry.Fields.Flatten(func(key string, value any) {
+		if IsPresentationField(key) {
+			return
+		}
+		if name := JournalFieldName(key); name != "" {
+			buf = appendJournalField(buf, name, fieldText(value))
+		}
+	})
+	return buf
+}
+
+// JournalPriority maps a level to its syslog priority.
+func JournalPriority(level share.Level) int {
+	switch level {
+	case share.LevelTrace, share.LevelDebug:
+		return 7 // debug
+	case share.LevelInfo, share.LevelSuccess:
+		return 6 // info
+	case share.LevelWarn:
+		return 4 // warning
+	case share.LevelError:
+		return 3 // err
+	default:
+		return 2 // crit
+	}
+}
+
+// JournalFieldName converts a field key to a journal field name: upper
+// case letters, digits and underscores, not starting with an underscore or
+// digit, which journald reserves or rejects. It returns "" for keys with
+// nothing usable.
+func JournalFieldName(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	name := strings.TrimLeft(b.String(), "_0123456789")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// appendJournalField appends one field. Values with newlines use the
+// binary form: the name, a newline, the little-endian 64-bit length and
+// the raw value.
+func appendJournalField(buf []byte, name, value string) []byte {
+	buf = append(buf, name...)
+	if strings.IndexByte(value, '\n') < 0 {
+		buf = append(buf, '=')
+		buf = append(buf, value...)
+		return append(buf, '\n')
+	}
+	buf = append(buf, '\n')
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(len(value)))
+	buf = append(buf, value...)
+	return append(buf, '\n')
+}
@@ -0,0 +1,20 @@
+//go:build !unix
+
+package writer
+
+import (
+	"errors"
+	"net"
+	"os"
+)
+
+// isMsgTooLarge reports false: the platform has no journald.
+func isMsgTooLarge(error) bool { return false }
+
+// sendJournalFile fails: the platform cannot pass file descriptors.
+func sendJournalFile(*net.UnixConn, *net.UnixAddr, []byte) error {
+	return errors.New("journald file passing not supported")
+}
+
+// UnderJournald reports false: the platform has no systemd.
+func UnderJournald(*os.File) bool { return false }
@@ -0,0 +1,191 @@
+//go:build unix
+
+package writer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// parseJournalFields decodes the native protocol encoding.
+func parseJournalFields(data []byte) (map[string]string, error) {
+	fields := make(map[string]string)
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			return nil, errors.New("journal field without newline")
+		}
+		line := data[:i]
+		data = data[i+1:]
+		if name, value, ok := bytes.Cut(line, []byte{'='}); ok {
+			fields[string(name)] = string(value)
+			continue
+		}
+		if len(data) < 8 {
+			return nil, errors.New("truncated journal field length")
+		}
+		n := binary.LittleEndian.Uint64(data)
+		data = data[8:]
+		if uint64(len(data)) < n+1 {
+			return nil, errors.New("truncated journal field value")
+		}
+		fields[string(line)] = string(data[:n])
+		data = data[n+1:]
+	}
+	return fields, nil
+}
+
+// fakeJournal listens on a journal socket in a temporary directory.
+func fakeJournal(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram sockets unavailable: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, socket
+}
+
+// readJournal reads one entry from the fake journal, following a passed
+// file descriptor.
+func readJournal(t *testing.T, conn *net.UnixConn) map[string]string {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 1<<20)
+	oob := make([]byte, 64)
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	data := buf[:n]
+	if oobn > 0 {
+		msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+		if err != nil || len(msgs) != 1 {
+			t.Fatalf("control message: %v", err)
+		}
+		fds, err := syscall.ParseUnixRights(&msgs[0])
+		if err != nil || len(fds) != 1 {
+			t.Fatalf("unix rights: %v", err)
+		}
+		file := os.NewFile(uintptr(fds[0]), "journal")
+		defer file.Close()
+		file.Seek(0, 0)
+		var b bytes.Buffer
+		b.ReadFrom(file)
+		data = b.Bytes()
+	}
+	fields, err := parseJournalFields(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return fields
+}
+
+func TestJournaldWriter(t *testing.T) {
+	conn, socket := fakeJournal(t)
+	w, err := NewJournaldWriter(JournaldOptions{Level: share.LevelDebug, Identifier: "app", Socket: socket})
+	if err != nil {
+		t.Fatalf("NewJournaldWriter: %v", err)
+	}
+	defer w.Close()
+
+	err = w.Write(&share.Entry{
+		Level:   share.LevelWarn,
+		Message: "disk\nalmost full",
+		Fields: share.Fields{
+			"request_id": 42,
+			"http":       share.Fields{"status": "503"},
+			"badge":      "DISK",
+		},
+		Caller: &share.CallerInfo{File: "main.go", Line: 7, Function: "main.run"},
+	})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	fields := readJournal(t, conn)
+	want := map[string]string{
+		"MESSAGE":           "disk\nalmost full",
+		"PRIORITY":          "4",
+		"TFX_LEVEL":         "WARN",
+		"SYSLOG_IDENTIFIER": "app",
+		"CODE_FILE":         "main.go",
+		"CODE_LINE":         "7",
+		"CODE_FUNC":         "main.run",
+		"REQUEST_ID":        "42",
+		"HTTP_STATUS":       "503",
+	}
+	for name, value := range want {
+		if fields[name] != value {
+			t.Errorf("%s = %q, want %q", name, fields[name], value)
+		}
+	}
+	if _, ok := fields["BADGE"]; ok {
+		t.Error("presentation field sent to the journal")
+	}
+
+	w.Write(&share.Entry{Level: share.LevelTrace, Message: "filtered"})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: strings.Repeat("x", 512*1024)})
+	if fields := readJournal(t, conn); len(fields["MESSAGE"]) != 512*1024 {
+		t.Errorf("large entry: got message of %d bytes", len(fields["MESSAGE"]))
+	}
+}
+
+func TestNewJournaldWriterMissingSocket(t *testing.T) {
+	_, err := NewJournaldWriter(JournaldOptions{Socket: filepath.Join(t.TempDir(), "none")})
+	if !errors.Is(err, ErrNoJournald) {
+		t.Errorf("expected ErrNoJournald, got %v", err)
+	}
+}
+
+func TestJournalFieldName(t *testing.T) {
+	tests := map[string]string{
+		"request_id":  "REQUEST_ID",
+		"http.status": "HTTP_STATUS",
+		"_private":    "PRIVATE",
+		"2fa":         "FA",
+		"ünïcode":     "N_CODE",
+		"__":          "",
+	}
+	for key, want := range tests {
+		if got := JournalFieldName(key); got != want {
+			t.Errorf("JournalFieldName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestUnderJournald(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(file.Fd()), &st); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("JOURNAL_STREAM", "")
+	if UnderJournald(file) {
+		t.Error("expected false without JOURNAL_STREAM")
+	}
+	t.Setenv("JOURNAL_STREAM", fmt.Sprintf("%d:%d", uint64(st.Dev), uint64(st.Ino)))
+	if !UnderJournald(file) {
+		t.Error("expected true for the announced stream")
+	}
+	if UnderJournald(os.Stdin) {
+		t.Error("expected false for another file")
+	}
+}
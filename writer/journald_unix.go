@@ -0,0 +1,66 @@
+//go:build unix
+
+package writer
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// isMsgTooLarge reports whether err means a datagram exceeded the socket
+// limit.
+func isMsgTooLarge(err error) bool {
+	return errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS)
+}
+
+// sendJournalFile passes data to the journal in an unlinked temporary file,
+// the fallback of the native protocol for entries too large for a
+// datagram.
+func sendJournalFile(conn *net.UnixConn, addr *net.UnixAddr, data []byte) error {
+	dir := "/dev/shm"
+	if _, err := os.Stat(dir); err != nil {
+		dir = os.TempDir()
+	}
+	file, err := os.CreateTemp(dir, "tfx-journal-")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	_, _, err = conn.WriteMsgUnix(nil, syscall.UnixRights(int(file.Fd())), addr)
+	return err
+}
+
+// UnderJournald reports whether f, usually os.Stderr or os.Stdout, is the
+// journal stream systemd connects services to, as announced by the
+// JOURNAL_STREAM variable. Loggers use it to send entries to the journal
+// natively instead of as text lines.
+func UnderJournald(f *os.File) bool {
+	stream := os.Getenv("JOURNAL_STREAM")
+	if f == nil || stream == "" {
+		return false
+	}
+	devStr, inoStr, ok := strings.Cut(stream, ":")
+	if !ok {
+		return false
+	}
+	dev, err1 := strconv.ParseUint(devStr, 10, 64)
+	ino, err2 := strconv.ParseUint(inoStr, 10, 64)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(f.Fd()), &st); err != nil {
+		return false
+	}
+	return uint64(st.Dev) == dev && uint64(st.Ino) == ino
+}
@@ -0,0 +1,355 @@
+package writer
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// JSONTimeFormat is the default timestamp layout of JSON entries: RFC 3339
+// with millisecond precision.
+const JSONTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// JSONKeys names the top-level keys of JSON entries. Empty keys use the
+// defaults of the writer.
+type JSONKeys struct {
+	Time    string
+	Level   string
+	Message string
+	Caller  string
+}
+
+// DefaultJSONKeys are the keys used by ConsoleWriter.
+var DefaultJSONKeys = JSONKeys{Time: "time", Level: "level", Message: "msg", Caller: "caller"}
+
+// withDefaults fills empty keys from def.
+func (k JSONKeys) withDefaults(def JSONKeys) JSONKeys {
+	if k.Time == "" {
+		k.Time = def.Time
+	}
+	if k.Level == "" {
+		k.Level = def.Level
+	}
+	if k.Message == "" {
+		k.Message = def.Message
+	}
+	if k.Caller == "" {
+		k.Caller = def.Caller
+	}
+	return k
+}
+
+// presentationFields are fields that only style console output.
+var presentationFields = map[string]bool{
+	"badge": true, "badge_color": true, "badge_styled": true, "badge_style": true,
+	"bg_color": true, "bold": true, "italic": true, "underline": true,
+}
+
+// IsPresentationField reports whether the field key only styles console
+// output, and so is left out of structured output.
+func IsPresentationField(key string) bool {
+	return presentationFields[key]
+}
+
+// JSONEncoder encodes entries as single-line JSON objects. Unlike
+// fmt-based formatting it escapes strings properly, keeps numbers and
+// booleans typed and renders nested maps and slices as JSON values. Keys
+// appear in a stable order: level, message, time, the fields sorted by key,
+// then the caller.
+type JSONEncoder struct {
+	Keys       JSONKeys
+	TimeFormat string // Defaults to JSONTimeFormat
+}
+
+// NewJSONEncoder creates an encoder with the given keys, using
+// DefaultJSONKeys for empty ones.
+func NewJSONEncoder(keys JSONKeys) *JSONEncoder {
+	return &JSONEncoder{Keys: keys.withDefaults(DefaultJSONKeys)}
+}
+
+// Format implements share.Formatter.
+func (e *JSONEncoder) Format(entry *share.Entry) ([]byte, error) {
+	return e.AppendEntry(nil, entry), nil
+}
+
+// AppendEntry appends the JSON encoding of entry, without a trailing
+// newline, to buf.
+func (e *JSONEncoder) AppendEntry(buf []byte, entry *share.Entry) []byte {
+	keys := e.Keys.withDefaults(DefaultJSONKeys)
+	layout := e.TimeFormat
+	if layout == "" {
+		layout = JSONTimeFormat
+	}
+
+	buf = append(buf, '{')
+	buf = appendKey(buf, keys.Level, true)
+	buf = appendString(buf, entry.Level.String())
+	buf = appendKey(buf, keys.Message, false)
+	buf = appendString(buf, entry.Message)
+	buf = appendKey(buf, keys.Time, false)
+	buf = appendTime(buf, entry.Timestamp, layout)
+
+	// Sort the names in a stack array for the usual handful of fields
+	var stack [16]string
+	names := stack[:0]
+	for name := range entry.Fields {
+		if !presentationFields[name] {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		buf = appendKey(buf, name, false)
+		buf = appendValue(buf, entry.Fields[name], 0)
+	}
+
+	if entry.Caller != nil {
+		buf = appendKey(buf, keys.Caller, false)
+		buf = appendString(buf, entry.Caller.File)
+		buf = append(buf[:len(buf)-1], ':')
+		buf = strconv.AppendInt(buf, int64(entry.Caller.Line), 10)
+		buf = append(buf, '"')
+	}
+	return append(buf, '}')
+}
+
+// appendTime appends t formatted with layout as a JSON string, formatting
+// in place unless the layout could produce characters needing escapes.
+func appendTime(buf []byte, t time.Time, layout string) []byte {
+	if strings.ContainsAny(layout, "\"\\") || strings.IndexFunc(layout, unicode.IsControl) >= 0 {
+		return appendString(buf, t.Format(layout))
+	}
+	buf = append(buf, '"')
+	buf = t.AppendFormat(buf, layout)
+	return append(buf, '"')
+}
+
+// appendKey appends an object key and its colon, preceded by a comma unless
+// it is the first key.
+func appendKey(buf []byte, key string, first bool) []byte {
+	if !first {
+		buf = append(buf, ',')
+	}
+	buf = appendString(buf, key)
+	return append(buf, ':')
+}
+
+// fieldText renders a field value as text for outputs that take string
+// values: strings as they are, everything else as in JSON output.
+func fieldText(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	encoded := appendValue(nil, v, 0)
+	var s string
+	if len(encoded) > 0 && encoded[0] == '"' && json.Unmarshal(encoded, &s) == nil {
+		return s
+	}
+	return string(encoded)
+}
+
+// maxJSONDepth bounds the nesting of encoded values, guarding against
+// self-referencing maps and slices.
+const maxJSONDepth = 32
+
+// appendValue appends the JSON encoding of v.
+func appendValue(buf []byte, v any, depth int) []byte {
+	if depth > maxJSONDepth {
+		return appendString(buf, "<max depth exceeded>")
+	}
+
+	switch x := v.(type) {
+	case nil:
+		return append(buf, "null"...)
+	case string:
+		return appendString(buf, x)
+	case bool:
+		return strconv.AppendBool(buf, x)
+	case int:
+		return strconv.AppendInt(buf, int64(x), 10)
+	case int8:
+		return strconv.AppendInt(buf, int64(x), 10)
+	case int16:
+		return strconv.AppendInt(buf, int64(x), 10)
+	case int32:
+		return strconv.AppendInt(buf, int64(x), 10)
+	case int64:
+		return strconv.AppendInt(buf, x, 10)
+	case uint:
+		return strconv.AppendUint(buf, uint64(x), 10)
+	case uint8:
+		return strconv.AppendUint(buf, uint64(x), 10)
+	case uint16:
+		return strconv.AppendUint(buf, uint64(x), 10)
+	case uint32:
+		return strconv.AppendUint(buf, uint64(x), 10)
+	case uint64:
+		return strconv.AppendUint(buf, x, 10)
+	case float32:
+		return appendFloat(buf, float64(x), 32)
+	case float64:
+		return appendFloat(buf, x, 64)
+	case time.Time:
+		return appendString(buf, x.Format(time.RFC3339Nano))
+	case time.Duration:
+		return appendString(buf, x.String())
+	case json.Marshaler:
+		if data, err := x.MarshalJSON(); err == nil && json.Valid(data) {
+			return append(buf, data...)
+		}
+		return appendString(buf, fmt.Sprint(x))
+	case error:
+		return appendString(buf, x.Error())
+	case encoding.TextMarshaler:
+		if text, err := x.MarshalText(); err == nil {
+			return appendString(buf, string(text))
+		}
+		return appendString(buf, fmt.Sprint(x))
+	case fmt.Stringer:
+		return appendString(buf, x.String())
+	case share.Fields:
+		return appendMap(buf, reflect.ValueOf(map[string]any(x)), depth)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return append(buf, "null"...)
+		}
+		return appendValue(buf, rv.Elem().Interface(), depth+1)
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			return appendMap(buf, rv, depth)
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return append(buf, "null"...)
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			break // Let encoding/json render bytes as base64
+		}
+		buf = append(buf, '[')
+		for i := range rv.Len() {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendValue(buf, rv.Index(i).Interface(), depth+1)
+		}
+		return append(buf, ']')
+	case reflect.String:
+		return appendString(buf, rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(buf, rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(buf, rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return appendFloat(buf, rv.Float(), 64)
+	case reflect.Bool:
+		return strconv.AppendBool(buf, rv.Bool())
+	}
+
+	// Structs and everything else: defer to encoding/json, falling back to
+	// the fmt representation for values it cannot encode
+	if data, err := json.Marshal(v); err == nil {
+		return append(buf, data...)
+	}
+	return appendString(buf, fmt.Sprintf("%+v", v))
+}
+
+// appendMap appends a map with string keys as an object with sorted keys.
+func appendMap(buf []byte, rv reflect.Value, depth int) []byte {
+	if rv.IsNil() {
+		return append(buf, "null"...)
+	}
+	keys := rv.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		switch {
+		case a.String() < b.String():
+			return -1
+		case a.String() > b.String():
+			return 1
+		}
+		return 0
+	})
+	buf = append(buf, '{')
+	for i, key := range keys {
+		buf = appendKey(buf, key.String(), i == 0)
+		buf = appendValue(buf, rv.MapIndex(key).Interface(), depth+1)
+	}
+	return append(buf, '}')
+}
+
+// appendFloat appends f as a JSON number. NaN and infinities, which JSON
+// cannot represent, become strings.
+func appendFloat(buf []byte, f float64, bits int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return appendString(buf, strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	return strconv.AppendFloat(buf, f, 'g', -1, bits)
+}
+
+// hexDigits are used to escape control characters.
+const hexDigits = "0123456789abcdef"
+
+// appendString appends s as a quoted JSON string, escaping quotes,
+// backslashes and control characters and replacing invalid UTF-8.
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				buf = append(buf, '\\', c)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 break JavaScript parsers that embed JSON
+		if r == '\u2028' || r == '\u2029' {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, `\u202`...)
+			buf = append(buf, hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
@@ -0,0 +1,102 @@
+package writer
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func TestJSONEncoderProducesValidJSON(t *testing.T) {
+	entry := &share.Entry{
+		Level:     share.LevelError,
+		Message:   "quote \" backslash \\ newline \n tab \t bell \a",
+		Timestamp: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+		Fields: share.Fields{
+			"count":    42,
+			"ratio":    0.5,
+			"ok":       true,
+			"missing":  nil,
+			"err":      errors.New("boom"),
+			"elapsed":  1500 * time.Millisecond,
+			"tags":     []string{"a", "b"},
+			"nested":   map[string]any{"inner": share.Fields{"depth": 2}},
+			"nan":      math.NaN(),
+			"invalid":  "bad \xff byte",
+			"badge":    "DB",
+			"bg_color": color.Red,
+		},
+	}
+
+	out := NewJSONEncoder(JSONKeys{}).AppendEntry(nil, entry)
+
+	var decoded map[string]any
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("invalid JSON %s: %v", out, err)
+	}
+	if decoded["msg"] != entry.Message {
+		t.Errorf("message did not round-trip: %q", decoded["msg"])
+	}
+	if decoded["count"] != float64(42) || decoded["ok"] != true || decoded["missing"] != nil {
+		t.Errorf("scalar fields must keep their types: %s", out)
+	}
+	if decoded["err"] != "boom" || decoded["elapsed"] != "1.5s" || decoded["nan"] != "NaN" {
+		t.Errorf("unexpected special values: %s", out)
+	}
+	nested := decoded["nested"].(map[string]any)["inner"].(map[string]any)
+	if nested["depth"] != float64(2) {
+		t.Errorf("nested maps must be objects: %s", out)
+	}
+	if tags := decoded["tags"].([]any); len(tags) != 2 {
+		t.Errorf("slices must be arrays: %s", out)
+	}
+	if _, ok := decoded["badge"]; ok {
+		t.Errorf("presentation fields must be omitted: %s", out)
+	}
+}
+
+func TestJSONEncoderKeysAndOrder(t *testing.T) {
+	entry := &share.Entry{
+		Level:     share.LevelInfo,
+		Message:   "hello",
+		Timestamp: time.Date(2023, 1, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600)),
+		Fields:    share.Fields{"b": 1, "a": 2},
+		Caller:    &share.CallerInfo{File: "main.go", Line: 7},
+	}
+
+	out := string(NewJSONEncoder(JSONKeys{Message: "message", Time: "@timestamp"}).AppendEntry(nil, entry))
+	want := `{"level":"INFO","message":"hello","@timestamp":"2023-01-01T10:00:00.000+01:00","a":2,"b":1,"caller":"main.go:7"}`
+	if out != want {
+		t.Errorf("got  %s\nwant %s", out, want)
+	}
+}
+
+func TestFileWriterJSON(t *testing.T) {
+	dir := t.TempDir()
+	opts := DefaultFileOptions()
+	opts.Format = share.FormatJSON
+	w, err := NewFileWriter(dir+"/app.log", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	entry := &share.Entry{
+		Level:     share.LevelWarn,
+		Message:   `disk "data" almost full`,
+		Timestamp: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+		Fields:    share.Fields{"used": 0.93},
+	}
+	out := w.formatJSON(entry)
+	if !strings.HasPrefix(out, `{"level":"WARN","message":"disk \"data\" almost full","timestamp":"2023-01-01T10:00:00Z"`) {
+		t.Errorf("unexpected file JSON %s", out)
+	}
+	if !strings.Contains(out, `"used":0.93`) {
+		t.Errorf("expected numeric field, got %s", out)
+	}
+}
@@ -0,0 +1,31 @@
+package writer
+
+import "github.com/garaekz/tfx/internal/share"
+
+// LevelLabel is how a level is shown on the console.
+type LevelLabel struct {
+	Name  string // Replaces the level name in badges and text lines
+	Emoji string // Replaces the badge emoji, shown when colors are enabled
+}
+
+// LevelLabels maps levels to their console labels, so CLIs can show
+// localized names such as "ERREUR" or "AVISO". Missing levels and empty
+// label fields keep the default. JSON output always uses the canonical
+// level names, so it stays machine-readable.
+type LevelLabels map[share.Level]LevelLabel
+
+// name returns the label name of level, or def when it has none.
+func (ll LevelLabels) name(level share.Level, def string) string {
+	if name := ll[level].Name; name != "" {
+		return name
+	}
+	return def
+}
+
+// emoji returns the label emoji of level, or def when it has none.
+func (ll LevelLabels) emoji(level share.Level, def string) string {
+	if emoji := ll[level].Emoji; emoji != "" {
+		return emoji
+	}
+	return def
+}
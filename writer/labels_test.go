@@ -0,0 +1,40 @@
+package writer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func TestLevelLabels(t *testing.T) {
+	labels := LevelLabels{
+		share.LevelError: {Name: "ERREUR"},
+		share.LevelWarn:  {Emoji: "🚧"},
+	}
+	entry := &share.Entry{Level: share.LevelError, Message: "disque plein"}
+
+	for _, format := range []share.Format{share.FormatBadge, share.FormatText} {
+		buf := &bytes.Buffer{}
+		w := NewConsoleWriter(buf, ConsoleOptions{Format: format, DisableColor: true, Labels: labels})
+		w.Write(entry)
+		if out := buf.String(); !strings.Contains(out, "ERREUR") || strings.Contains(out, "ERROR") {
+			t.Errorf("format %v: expected localized name, got %q", format, out)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	w := NewConsoleWriter(buf, ConsoleOptions{Format: share.FormatJSON, Labels: labels})
+	w.Write(entry)
+	if out := buf.String(); !strings.Contains(out, `"ERROR"`) || strings.Contains(out, "ERREUR") {
+		t.Errorf("expected canonical name in JSON, got %q", out)
+	}
+
+	if got := labels.name(share.LevelWarn, "WARN"); got != "WARN" {
+		t.Errorf("expected default name for empty label, got %q", got)
+	}
+	if got := labels.emoji(share.LevelWarn, "⚠️"); got != "🚧" {
+		t.Errorf("expected localized emoji, got %q", got)
+	}
+}
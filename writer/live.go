@@ -0,0 +1,57 @@
+package writer
+
+import (
+	"io"
+	"sync"
+)
+
+// consoleRoute sends console lines to the log region of a live display.
+type consoleRoute struct {
+	screen io.Writer // Where the display draws
+	region io.Writer // Where console lines go instead
+}
+
+var (
+	routeMu sync.RWMutex
+	route   *consoleRoute
+)
+
+// RouteConsole makes console writers that write to screen write their lines
+// to region instead, until restore is called. Writers targeting other
+// outputs, including other terminals, are left alone.
+// Live displays drawing on screen, such as a runfx loop, use it so log lines
+// appear in a log region instead of clobbering the frame.
+func RouteConsole(screen, region io.Writer) (restore func()) {
+	r := &consoleRoute{screen: screen, region: region}
+	routeMu.Lock()
+	prev := route
+	route = r
+	routeMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			routeMu.Lock()
+			defer routeMu.Unlock()
+			if route == r {
+				route = prev
+			}
+		})
+	}
+}
+
+// Routed returns the writer console lines meant for out are written to:
+// the region set by RouteConsole when out is its screen, out otherwise. Writers of their own console lines, such as progress bars
+// printing above themselves, use it to stay out of a live display's frame.
+func Routed(out io.Writer) io.Writer {
+	routeMu.RLock()
+	r := route
+	routeMu.RUnlock()
+	if r == nil {
+		return out
+	}
+	if out == r.screen {
+		return r.region
+	}
+	return out
+}
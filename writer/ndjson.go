@@ -0,0 +1,182 @@
+package writer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// NDJSONTimeFormat is the timestamp layout of NDJSON entries: RFC 3339 in
+// UTC with nanosecond precision and fixed width, so it also sorts as text.
+const NDJSONTimeFormat = "2006-01-02T15:04:05.000000000Z07:00"
+
+// NDJSONEncoder encodes entries in the tfx NDJSON schema, one object per
+// line with the keys always in this order:
+//
+//	time    string  NDJSONTimeFormat, in UTC
+//	level   string  trace, debug, info, success, warn, error, fatal or panic
+//	msg     string  the message, with the group indent of the entry
+//	fields  object  the fields sorted by key, values typed and nested Fields
+//	                as objects; left out when there are none
+//	caller  object  {"file": string, "line": number, "function": string};
+//	                left out without caller information
+//
+// Fields of their own keep user data from clashing with the schema keys,
+// and console styling fields are left out. Unlike JSONEncoder, whose keys
+// and layout are configurable, the schema is fixed so ingestion pipelines
+// can rely on it:
+//
+//	{"time":"2024-05-01T12:00:00.123456789Z","level":"warn","msg":"disk almost full","fields":{"used":0.93}}
+type NDJSONEncoder struct{}
+
+// Format implements share.Formatter.
+func (NDJSONEncoder) Format(entry *share.Entry) ([]byte, error) {
+	return NDJSONEncoder{}.AppendEntry(nil, entry), nil
+}
+
+// AppendEntry appends the NDJSON encoding of entry, with its trailing
+// newline, to buf.
+func (NDJSONEncoder) AppendEntry(buf []byte, entry *share.Entry) []byte {
+	buf = append(buf, `{"time":`...)
+	buf = appendTime(buf, entry.Timestamp.UTC(), NDJSONTimeFormat)
+	buf = append(buf, `,"level":`...)
+	buf = appendString(buf, strings.ToLower(entry.Level.String()))
+	buf = append(buf, `,"msg":`...)
+	buf = appendString(buf, entry.IndentStr+entry.Message)
+
+	var stack [16]string
+	names := stack[:0]
+	for name := range entry.Fields {
+		if !presentationFields[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		slices.Sort(names)
+		buf = append(buf, `,"fields":{`...)
+		for i, name := range names {
+			buf = appendKey(buf, name, i == 0)
+			buf = appendValue(buf, entry.Fields[name], 0)
+		}
+		buf = append(buf, '}')
+	}
+
+	if c := entry.Caller; c != nil {
+		buf = append(buf, `,"caller":{"file":`...)
+		buf = appendString(buf, c.File)
+		buf = append(buf, `,"line":`...)
+		buf = strconv.AppendInt(buf, int64(c.Line), 10)
+		buf = append(buf, `,"function":`...)
+		buf = appendString(buf, c.Function)
+		buf = append(buf, '}')
+	}
+	return append(buf, "}\n"...)
+}
+
+// NDJSONOptions configures an NDJSONWriter.
+type NDJSONOptions struct {
+	Level         share.Level
+	Permissions   os.FileMode   // Of a file created by NewNDJSONFile
+	BufferSize    int           // Bytes buffered before they are written out; zero writes each entry
+	FlushInterval time.Duration // Longest time entries stay buffered
+}
+
+// DefaultNDJSONOptions writes every entry as it is logged.
+func DefaultNDJSONOptions() NDJSONOptions {
+	return NDJSONOptions{
+		Level:       share.LevelTrace,
+		Permissions: 0o644,
+	}
+}
+
+// NDJSONWriter writes entries in the NDJSONEncoder schema, separate from
+// the console formats, for log shippers and ingestion pipelines.
+type NDJSONWriter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	buffer  *BufferedWriter // Nil unless BufferSize is set
+	closer  io.Closer       // The file opened by NewNDJSONFile
+	options NDJSONOptions
+	buf     []byte
+}
+
+// NewNDJSONWriter writes entries to out, which it does not close.
+func NewNDJSONWriter(out io.Writer, opts NDJSONOptions) *NDJSONWriter {
+	w := &NDJSONWriter{out: out, options: opts}
+	if opts.BufferSize > 0 {
+		w.buffer = NewBufferedWriter(out, BufferedOptions{Size: opts.BufferSize, FlushInterval: opts.FlushInterval})
+		w.out = w.buffer
+	}
+	return w
+}
+
+// NewNDJSONFile appends entries to the file filename, creating it and its
+// directory if needed. Use FileWriter with FormatJSON for rotation.
+func NewNDJSONFile(filename string, opts NDJSONOptions) (*NDJSONWriter, error) {
+	if opts.Permissions == 0 {
+		opts.Permissions = DefaultNDJSONOptions().Permissions
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, opts.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	w := NewNDJSONWriter(file, opts)
+	w.closer = file
+	return w, nil
+}
+
+// Write writes entry as one line.
+func (w *NDJSONWriter) Write(entry *share.Entry) error {
+	if entry.Level < w.options.Level {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.out == nil {
+		return os.ErrClosed
+	}
+	w.buf = NDJSONEncoder{}.AppendEntry(w.buf[:0], entry)
+	_, err := w.out.Write(w.buf)
+	if cap(w.buf) > maxPooledLine {
+		w.buf = nil
+	}
+	return err
+}
+
+// Flush writes the buffered entries out.
+func (w *NDJSONWriter) Flush() {
+	if w.buffer != nil {
+		w.buffer.Flush()
+	}
+}
+
+// Close writes the buffered entries out and closes the file opened by
+// NewNDJSONFile.
+func (w *NDJSONWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.out == nil {
+		return nil
+	}
+	w.out = nil
+	var err error
+	if w.buffer != nil {
+		err = w.buffer.Close()
+	}
+	if w.closer != nil {
+		err = errors.Join(err, w.closer.Close())
+	}
+	return err
+}
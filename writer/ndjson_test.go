@@ -0,0 +1,89 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func TestNDJSONEncoderSchema(t *testing.T) {
+	entry := &share.Entry{
+		Level:     share.LevelWarn,
+		Message:   `disk "data" almost full`,
+		Timestamp: time.Date(2024, 5, 1, 14, 0, 0, 123, time.FixedZone("CEST", 2*3600)),
+		Fields: share.Fields{
+			"used":  0.93,
+			"msg":   "user data",
+			"disk":  share.Fields{"name": "data", "size": 512},
+			"badge": "DISK",
+		},
+		Caller: &share.CallerInfo{File: "main.go", Line: 42, Function: "main.check"},
+	}
+	got := string(NDJSONEncoder{}.AppendEntry(nil, entry))
+	want := `{"time":"2024-05-01T12:00:00.000000123Z","level":"warn","msg":"disk \"data\" almost full",` +
+		`"fields":{"disk":{"name":"data","size":512},"msg":"user data","used":0.93},` +
+		`"caller":{"file":"main.go","line":42,"function":"main.check"}}` + "\n"
+	if got != want {
+		t.Errorf("unexpected encoding\n got %s\nwant %s", got, want)
+	}
+	if !json.Valid([]byte(got)) {
+		t.Error("expected valid JSON")
+	}
+
+	bare := string(NDJSONEncoder{}.AppendEntry(nil, &share.Entry{Level: share.LevelInfo, Message: "hi", Fields: share.Fields{"badge": "X"}}))
+	if strings.Contains(bare, "fields") || strings.Contains(bare, "caller") {
+		t.Errorf("expected empty sections left out, got %s", bare)
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var out bytes.Buffer
+	opts := DefaultNDJSONOptions()
+	opts.Level = share.LevelInfo
+	w := NewNDJSONWriter(&out, opts)
+
+	w.Write(&share.Entry{Level: share.LevelDebug, Message: "skipped"})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "one"})
+	w.Write(&share.Entry{Level: share.LevelError, Message: "two"})
+	w.Close()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected two lines, got %q", out.String())
+	}
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &obj); err != nil || obj["level"] != "error" || obj["msg"] != "two" {
+		t.Errorf("unexpected line %q: %v", lines[1], err)
+	}
+	if err := w.Write(&share.Entry{Level: share.LevelInfo}); err == nil {
+		t.Error("expected an error writing after Close")
+	}
+}
+
+func TestNDJSONFileBuffered(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "logs", "app.ndjson")
+	opts := DefaultNDJSONOptions()
+	opts.BufferSize = 1 << 10
+	w, err := NewNDJSONFile(name, opts)
+	if err != nil {
+		t.Fatalf("NewNDJSONFile: %v", err)
+	}
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "buffered"})
+	if data, _ := os.ReadFile(name); len(data) != 0 {
+		t.Fatalf("expected the entry buffered, got %q", data)
+	}
+	w.Flush()
+	if data, _ := os.ReadFile(name); !strings.Contains(string(data), `"msg":"buffered"`) {
+		t.Errorf("expected Flush to write the entry, got %q", data)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
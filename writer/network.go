@@ -0,0 +1,299 @@
+package writer
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// NetworkOptions configures a NetworkWriter.
+type NetworkOptions struct {
+	Network      string // "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6" or "unix"
+	Address      string // Collector address, e.g. "logs.internal:5170"
+	Level        share.Level
+	BufferSize   int           // Entries kept while disconnected; the oldest are dropped beyond it
+	DialTimeout  time.Duration // Limit of each connection attempt
+	WriteTimeout time.Duration // Limit of each write before the connection is dropped
+	MinBackoff   time.Duration // Wait after the first failed attempt, doubled after each one
+	MaxBackoff   time.Duration // Limit of the wait between attempts
+	JSONKeys     JSONKeys      // Key names of the entries; empty keys use DefaultJSONKeys
+}
+
+// DefaultNetworkOptions streams Info and above over TCP, keeping up to
+// 10000 entries while the collector is unreachable and retrying from 100ms
+// up to 30s apart.
+func DefaultNetworkOptions() NetworkOptions {
+	return NetworkOptions{
+		Network:      "tcp",
+		Level:        share.LevelInfo,
+		BufferSize:   10000,
+		DialTimeout:  5 * time.Second,
+		WriteTimeout: 5 * time.Second,
+		MinBackoff:   100 * time.Millisecond,
+		MaxBackoff:   30 * time.Second,
+	}
+}
+
+// NetworkStats reports the state of a NetworkWriter.
+type NetworkStats struct {
+	Sent       uint64 // Entries written to the connection
+	Dropped    uint64 // Entries dropped because the buffer was full or the writer closed
+	Reconnects uint64 // Connections made after the first one
+	Buffered   int    // Entries waiting to be sent
+	Connected  bool
+}
+
+// NetworkWriter streams entries as newline-delimited JSON to a collector
+// such as Vector, Fluent Bit or Logstash. Write only queues the entry: a
+// background goroutine connects, sends and, when the connection fails,
+// reconnects with exponential backoff, buffering entries meanwhile. Over
+// UDP every entry is one datagram.
+type NetworkWriter struct {
+	options NetworkOptions
+	enc     *JSONEncoder
+
+	mu         sync.Mutex
+	cond       *sync.Cond // Signals every change of the queue or connection
+	queue      [][]byte
+	head       int
+	size       int
+	pending    bool // The sender holds a line taken from the queue
+	connected  bool
+	failing    bool // The last connection attempt failed
+	closed     bool
+	sent       uint64
+	dropped    uint64
+	reconnects uint64
+
+	closeCh   chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewNetworkWriter starts streaming to the collector of opts. It only fails
+// for an unsupported network; an unreachable collector is retried in the
+// background.
+func NewNetworkWriter(opts NetworkOptions) (*NetworkWriter, error) {
+	def := DefaultNetworkOptions()
+	switch opts.Network {
+	case "":
+		opts.Network = def.Network
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6", "unix":
+	default:
+		return nil, fmt.Errorf("unsupported network %q", opts.Network)
+	}
+	if opts.Address == "" {
+		return nil, fmt.Errorf("missing %s address", opts.Network)
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = def.BufferSize
+	}
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = def.DialTimeout
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = def.WriteTimeout
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = def.MinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(def.MaxBackoff, opts.MinBackoff)
+	}
+
+	w := &NetworkWriter{
+		options: opts,
+		enc:     NewJSONEncoder(opts.JSONKeys),
+		queue:   make([][]byte, opts.BufferSize),
+		closeCh: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	w.cond = sync.NewCond(&w.mu)
+	go w.run()
+	return w, nil
+}
+
+// Write queues entry for sending. When the buffer is full the oldest entry
+// is dropped. Entries written after Close are dropped.
+func (w *NetworkWriter) Write(entry *share.Entry) error {
+	if entry.Level < w.options.Level {
+		return nil
+	}
+	line := w.enc.AppendEntry(nil, entry)
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		w.dropped++
+		return nil
+	}
+	if w.size == len(w.queue) {
+		w.queue[w.head] = nil
+		w.head = (w.head + 1) % len(w.queue)
+		w.size--
+		w.dropped++
+	}
+	w.queue[(w.head+w.size)%len(w.queue)] = line
+	w.size++
+	w.cond.Broadcast()
+	return nil
+}
+
+// Flush waits until the buffered entries are sent, or until a connection
+// attempt fails, in which case they stay buffered for the next one.
+func (w *NetworkWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for (w.size > 0 || w.pending) && !w.failing && !w.closed {
+		w.cond.Wait()
+	}
+}
+
+// Close sends the buffered entries if the collector is reachable, drops
+// them otherwise, and closes the connection.
+func (w *NetworkWriter) Close() error {
+	w.closeOnce.Do(func() {
+		w.mu.Lock()
+		w.closed = true
+		w.cond.Broadcast()
+		w.mu.Unlock()
+		close(w.closeCh)
+		<-w.done
+	})
+	return nil
+}
+
+// Dropped returns how many entries were dropped since the writer was
+// created.
+func (w *NetworkWriter) Dropped() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.dropped
+}
+
+// Stats returns the counters and state of the writer.
+func (w *NetworkWriter) Stats() NetworkStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	buffered := w.size
+	if w.pending {
+		buffered++
+	}
+	return NetworkStats{
+		Sent:       w.sent,
+		Dropped:    w.dropped,
+		Reconnects: w.reconnects,
+		Buffered:   buffered,
+		Connected:  w.connected,
+	}
+}
+
+// run is the sender goroutine: it sends the queued lines in order,
+// reconnecting as needed, until the writer is closed and the queue is
+// drained or the collector is unreachable.
+func (w *NetworkWriter) run() {
+	defer close(w.done)
+
+	var conn net.Conn
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+	backoff := w.options.MinBackoff
+	dialed := false
+
+	var line []byte // Taken from the queue but not sent yet
+	for {
+		w.mu.Lock()
+		if line == nil {
+			for w.size == 0 && !w.closed {
+				w.cond.Wait()
+			}
+			if w.size == 0 {
+				w.mu.Unlock()
+				return
+			}
+			line = w.queue[w.head]
+			w.queue[w.head] = nil
+			w.head = (w.head + 1) % len(w.queue)
+			w.size--
+			w.pending = true
+		}
+		closed := w.closed
+		w.mu.Unlock()
+
+		if conn == nil {
+			c, err := net.DialTimeout(w.options.Network, w.options.Address, w.options.DialTimeout)
+			if err != nil {
+				w.mu.Lock()
+				w.failing = true
+				w.cond.Broadcast()
+				if closed {
+					w.dropAll()
+					w.mu.Unlock()
+					return
+				}
+				w.mu.Unlock()
+
+				select {
+				case <-time.After(backoff):
+				case <-w.closeCh:
+				}
+				backoff = min(backoff*2, w.options.MaxBackoff)
+				continue
+			}
+			conn = c
+			backoff = w.options.MinBackoff
+			w.mu.Lock()
+			if dialed {
+				w.reconnects++
+			}
+			dialed = true
+			w.connected = true
+			w.failing = false
+			w.mu.Unlock()
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(w.options.WriteTimeout))
+		_, err := conn.Write(line)
+
+		w.mu.Lock()
+		if err != nil && closed {
+			w.dropAll()
+			w.mu.Unlock()
+			return
+		}
+		if err != nil {
+			// Keep the line and send it again on a new connection
+			conn.Close()
+			conn = nil
+			w.connected = false
+		} else {
+			line = nil
+			w.pending = false
+			w.sent++
+		}
+		w.cond.Broadcast()
+		w.mu.Unlock()
+	}
+}
+
+// dropAll drops the queued entries and the pending one when the writer is
+// closed with the collector unreachable. Callers hold w.mu.
+func (w *NetworkWriter) dropAll() {
+	for ; w.size > 0; w.size-- {
+		w.queue[w.head] = nil
+		w.head = (w.head + 1) % len(w.queue)
+		w.dropped++
+	}
+	if w.pending {
+		w.pending = false
+		w.dropped++
+	}
+	w.cond.Broadcast()
+}
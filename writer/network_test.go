@@ -0,0 +1,176 @@
+package writer
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// readLines reads n JSON lines from the first connection of ln.
+func readLines(t *testing.T, ln net.Listener, n int) []map[string]any {
+	t.Helper()
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	scanner := bufio.NewScanner(conn)
+	var lines []map[string]any
+	for len(lines) < n && scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) < n {
+		t.Fatalf("read %d lines, want %d: %v", len(lines), n, scanner.Err())
+	}
+	return lines
+}
+
+func TestNetworkWriterTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("tcp unavailable: %v", err)
+	}
+	defer ln.Close()
+
+	opts := DefaultNetworkOptions()
+	opts.Address = ln.Addr().String()
+	w, err := NewNetworkWriter(opts)
+	if err != nil {
+		t.Fatalf("NewNetworkWriter: %v", err)
+	}
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "first", Fields: share.Fields{"n": 1}})
+	w.Write(&share.Entry{Level: share.LevelDebug, Message: "filtered"})
+	w.Write(&share.Entry{Level: share.LevelError, Message: "second"})
+
+	lines := readLines(t, ln, 2)
+	if lines[0]["msg"] != "first" || lines[0]["n"] != 1.0 || lines[1]["level"] != "ERROR" {
+		t.Errorf("unexpected lines %v", lines)
+	}
+	w.Flush()
+	if stats := w.Stats(); stats.Sent != 2 || stats.Buffered != 0 || !stats.Connected {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestNetworkWriterReconnects(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "collector.sock")
+	opts := DefaultNetworkOptions()
+	opts.Network = "unix"
+	opts.Address = socket
+	opts.MinBackoff = 5 * time.Millisecond
+	opts.MaxBackoff = 20 * time.Millisecond
+	w, err := NewNetworkWriter(opts)
+	if err != nil {
+		t.Fatalf("NewNetworkWriter: %v", err)
+	}
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "buffered"})
+	w.Flush() // Returns once the collector proves unreachable
+	if stats := w.Stats(); stats.Connected || stats.Buffered != 1 {
+		t.Fatalf("expected a buffered entry while disconnected, got %+v", stats)
+	}
+
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	defer ln.Close()
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "live"})
+
+	lines := readLines(t, ln, 2)
+	if lines[0]["msg"] != "buffered" || lines[1]["msg"] != "live" {
+		t.Errorf("expected entries in order, got %v", lines)
+	}
+
+	// A second connection after the collector restarts counts as a reconnect
+	ln.Close()
+	ln, err = net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for w.Stats().Reconnects == 0 && time.Now().Before(deadline) {
+		w.Write(&share.Entry{Level: share.LevelInfo, Message: "after restart"})
+		time.Sleep(10 * time.Millisecond)
+	}
+	if w.Stats().Reconnects == 0 {
+		t.Error("expected a reconnect")
+	}
+}
+
+func TestNetworkWriterDropsOldest(t *testing.T) {
+	opts := DefaultNetworkOptions()
+	opts.Network = "unix"
+	opts.Address = filepath.Join(t.TempDir(), "none.sock")
+	opts.BufferSize = 2
+	w, err := NewNetworkWriter(opts)
+	if err != nil {
+		t.Fatalf("NewNetworkWriter: %v", err)
+	}
+
+	for range 5 {
+		w.Write(&share.Entry{Level: share.LevelInfo, Message: "x"})
+	}
+	if dropped := w.Dropped(); dropped < 2 {
+		t.Errorf("expected entries beyond the buffer dropped, got %d", dropped)
+	}
+
+	w.Close()
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "closed"})
+	if stats := w.Stats(); stats.Dropped != 6 || stats.Buffered != 0 {
+		t.Errorf("expected every entry dropped after closing unreachable, got %+v", stats)
+	}
+}
+
+func TestNetworkWriterUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("udp unavailable: %v", err)
+	}
+	defer pc.Close()
+
+	opts := DefaultNetworkOptions()
+	opts.Network = "udp"
+	opts.Address = pc.LocalAddr().String()
+	w, err := NewNetworkWriter(opts)
+	if err != nil {
+		t.Fatalf("NewNetworkWriter: %v", err)
+	}
+	defer w.Close()
+	w.Write(&share.Entry{Level: share.LevelWarn, Message: "datagram"})
+
+	pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 4096)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var line map[string]any
+	if err := json.Unmarshal(buf[:n], &line); err != nil || line["msg"] != "datagram" {
+		t.Errorf("unexpected datagram %q: %v", buf[:n], err)
+	}
+}
+
+func TestNewNetworkWriterErrors(t *testing.T) {
+	if _, err := NewNetworkWriter(NetworkOptions{Network: "carrier-pigeon", Address: "x"}); err == nil {
+		t.Error("expected an error for an unsupported network")
+	}
+	if _, err := NewNetworkWriter(NetworkOptions{Network: "tcp"}); err == nil {
+		t.Error("expected an error for a missing address")
+	}
+}
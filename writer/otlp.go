@@ -0,0 +1,213 @@
+package writer
+
+import (
+	"encoding/hex"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// OTLPEndpoint is the default logs endpoint of an OpenTelemetry collector.
+const OTLPEndpoint = "http://localhost:4318/v1/logs"
+
+// Field keys read into the trace context of OTLP log records rather than
+// their attributes, as logfx adds them.
+const (
+	otlpTraceIDKey = "trace_id"
+	otlpSpanIDKey  = "span_id"
+)
+
+// DefaultOTLPOptions exports to a local collector over OTLP/HTTP with JSON
+// encoding, under a service named after the program. Labels become the
+// resource attributes of the records; add "deployment.environment" or
+// "service.version" there.
+func DefaultOTLPOptions() HTTPOptions {
+	opts := DefaultHTTPOptions()
+	opts.URL = OTLPEndpoint
+	opts.Format = HTTPFormatOTLP
+	opts.Labels = map[string]string{"service.name": filepath.Base(os.Args[0])}
+	return opts
+}
+
+// NewOTLPWriter exports entries to the OTLP/HTTP logs endpoint, e.g.
+// "http://otel-collector:4318/v1/logs", as serviceName. Use NewHTTPWriter
+// with DefaultOTLPOptions for headers, compression or batching.
+func NewOTLPWriter(endpoint, serviceName string) (*HTTPWriter, error) {
+	opts := DefaultOTLPOptions()
+	if endpoint != "" {
+		opts.URL = endpoint
+	}
+	if serviceName != "" {
+		opts.Labels["service.name"] = serviceName
+	}
+	return NewHTTPWriter(opts)
+}
+
+// OTLPSeverity maps a level to its OpenTelemetry severity number. Success
+// is INFO2, above plain Info, and Panic FATAL2, above Fatal.
+func OTLPSeverity(level share.Level) int {
+	switch level {
+	case share.LevelTrace:
+		return 1
+	case share.LevelDebug:
+		return 5
+	case share.LevelInfo:
+		return 9
+	case share.LevelSuccess:
+		return 10
+	case share.LevelWarn:
+		return 13
+	case share.LevelError:
+		return 17
+	case share.LevelFatal:
+		return 21
+	default:
+		return 22
+	}
+}
+
+// appendOTLPRecord appends the OTLP JSON log record of entry: the message
+// as body, the level as severity, trace and span IDs as its trace context
+// and the other fields, groups nested, and the caller as attributes.
+func appendOTLPRecord(buf []byte, entry *share.Entry, ts, observed time.Time) []byte {
+	buf = append(buf, `{"timeUnixNano":"`...)
+	buf = strconv.AppendInt(buf, ts.UnixNano(), 10)
+	buf = append(buf, `","observedTimeUnixNano":"`...)
+	buf = strconv.AppendInt(buf, observed.UnixNano(), 10)
+	buf = append(buf, `","severityNumber":`...)
+	buf = strconv.AppendInt(buf, int64(OTLPSeverity(entry.Level)), 10)
+	buf = append(buf, `,"severityText":`...)
+	buf = appendString(buf, entry.Level.String())
+	buf = append(buf, `,"body":{"stringValue":`...)
+	buf = appendString(buf, entry.IndentStr+entry.Message)
+	buf = append(buf, '}')
+
+	traceID, _ := entry.Fields[otlpTraceIDKey].(string)
+	spanID, _ := entry.Fields[otlpSpanIDKey].(string)
+	if !isHexID(traceID, 16) || !isHexID(spanID, 8) {
+		traceID, spanID = "", "" // Not OpenTelemetry IDs; keep them as attributes
+	}
+	if traceID != "" {
+		buf = append(buf, `,"traceId":"`...)
+		buf = append(buf, traceID...)
+		buf = append(buf, `","spanId":"`...)
+		buf = append(buf, spanID...)
+		buf = append(buf, '"')
+	}
+
+	buf = append(buf, `,"attributes":[`...)
+	n := 0
+	for _, key := range slices.Sorted(maps.Keys(entry.Fields)) {
+		if IsPresentationField(key) || traceID != "" && (key == otlpTraceIDKey || key == otlpSpanIDKey) {
+			continue
+		}
+		buf = appendOTLPAttribute(buf, n, key, entry.Fields[key], 0)
+		n++
+	}
+	if c := entry.Caller; c != nil {
+		buf = appendOTLPAttribute(buf, n, "code.filepath", c.File, 0)
+		buf = appendOTLPAttribute(buf, n+1, "code.lineno", c.Line, 0)
+		if c.Function != "" {
+			buf = appendOTLPAttribute(buf, n+2, "code.function", c.Function, 0)
+		}
+	}
+	return append(buf, "]}"...)
+}
+
+// appendOTLPAttribute appends the i-th key-value pair of a list.
+func appendOTLPAttribute(buf []byte, i int, key string, value any, depth int) []byte {
+	if i > 0 {
+		buf = append(buf, ',')
+	}
+	buf = append(buf, `{"key":`...)
+	buf = appendString(buf, key)
+	buf = append(buf, `,"value":`...)
+	buf = appendOTLPValue(buf, value, depth)
+	return append(buf, '}')
+}
+
+// appendOTLPValue appends the OTLP AnyValue of v. Numbers and booleans keep
+// their type, nested Fields become key-value lists and anything else its
+// text as in JSON output.
+func appendOTLPValue(buf []byte, v any, depth int) []byte {
+	switch x := v.(type) {
+	case bool:
+		buf = append(buf, `{"boolValue":`...)
+		buf = strconv.AppendBool(buf, x)
+	case int:
+		buf = appendOTLPInt(buf, int64(x))
+	case int8:
+		buf = appendOTLPInt(buf, int64(x))
+	case int16:
+		buf = appendOTLPInt(buf, int64(x))
+	case int32:
+		buf = appendOTLPInt(buf, int64(x))
+	case int64:
+		buf = appendOTLPInt(buf, x)
+	case uint8:
+		buf = appendOTLPInt(buf, int64(x))
+	case uint16:
+		buf = appendOTLPInt(buf, int64(x))
+	case uint32:
+		buf = appendOTLPInt(buf, int64(x))
+	case float32:
+		buf = append(buf, `{"doubleValue":`...)
+		buf = appendFloat(buf, float64(x), 32)
+	case float64:
+		buf = append(buf, `{"doubleValue":`...)
+		buf = appendFloat(buf, x, 64)
+	case share.Fields:
+		if depth >= maxJSONDepth {
+			buf = append(buf, `{"stringValue":"<max depth exceeded>"`...)
+			break
+		}
+		buf = append(buf, `{"kvlistValue":{"values":[`...)
+		for i, key := range slices.Sorted(maps.Keys(x)) {
+			buf = appendOTLPAttribute(buf, i, key, x[key], depth+1)
+		}
+		return append(buf, "]}}"...)
+	default:
+		buf = append(buf, `{"stringValue":`...)
+		buf = appendString(buf, fieldText(v))
+	}
+	return append(buf, '}')
+}
+
+// appendOTLPInt opens an intValue, which OTLP JSON encodes as a string.
+func appendOTLPInt(buf []byte, n int64) []byte {
+	buf = append(buf, `{"intValue":"`...)
+	buf = strconv.AppendInt(buf, n, 10)
+	return append(buf, '"')
+}
+
+// isHexID reports whether id is the hex encoding of a non-zero ID of size
+// bytes.
+func isHexID(id string, size int) bool {
+	b, err := hex.DecodeString(id)
+	if err != nil || len(b) != size {
+		return false
+	}
+	return slices.ContainsFunc(b, func(c byte) bool { return c != 0 })
+}
+
+// appendOTLP appends the OTLP export request of batch: one resource with
+// Labels as its attributes, holding the records of the tfx scope.
+func (w *HTTPWriter) appendOTLP(buf []byte, batch []Message) []byte {
+	buf = append(buf, `{"resourceLogs":[{"resource":{"attributes":[`...)
+	for i, key := range slices.Sorted(maps.Keys(w.options.Labels)) {
+		buf = appendOTLPAttribute(buf, i, key, w.options.Labels[key], 0)
+	}
+	buf = append(buf, `]},"scopeLogs":[{"scope":{"name":"github.com/garaekz/tfx"},"logRecords":[`...)
+	for i, item := range batch {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, item.Value...)
+	}
+	return append(buf, "]}]}]}"...)
+}
@@ -0,0 +1,122 @@
+package writer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// otlpRequest is the part of an OTLP/HTTP JSON export request the tests
+// check.
+type otlpRequest struct {
+	ResourceLogs []struct {
+		Resource struct {
+			Attributes []otlpKeyValue `json:"attributes"`
+		} `json:"resource"`
+		ScopeLogs []struct {
+			LogRecords []struct {
+				TimeUnixNano   string         `json:"timeUnixNano"`
+				SeverityNumber int            `json:"severityNumber"`
+				SeverityText   string         `json:"severityText"`
+				Body           map[string]any `json:"body"`
+				TraceID        string         `json:"traceId"`
+				SpanID         string         `json:"spanId"`
+				Attributes     []otlpKeyValue `json:"attributes"`
+			} `json:"logRecords"`
+		} `json:"scopeLogs"`
+	} `json:"resourceLogs"`
+}
+
+type otlpKeyValue struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+func otlpAttr(attrs []otlpKeyValue, key string) map[string]any {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+func TestOTLPWriter(t *testing.T) {
+	c, url := newCollector(t)
+	w, err := NewOTLPWriter(url, "checkout")
+	if err != nil {
+		t.Fatalf("NewOTLPWriter: %v", err)
+	}
+	defer w.Close()
+
+	w.Write(&share.Entry{
+		Level:     share.LevelWarn,
+		Message:   "payment slow",
+		Timestamp: time.Unix(1700000000, 0),
+		Fields: share.Fields{
+			"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+			"span_id":  "00f067aa0ba902b7",
+			"attempt":  3,
+			"ratio":    0.5,
+			"retried":  true,
+			"http":     share.Fields{"status": 503},
+			"badge":    "PAY",
+		},
+		Caller: &share.CallerInfo{File: "pay.go", Line: 12, Function: "pay.Charge"},
+	})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "custom ids", Fields: share.Fields{"trace_id": "req-1"}})
+	w.Flush()
+
+	bodies, _ := c.requests()
+	if len(bodies) != 1 {
+		t.Fatalf("expected one request, got %d", len(bodies))
+	}
+	var req otlpRequest
+	if err := json.Unmarshal([]byte(bodies[0]), &req); err != nil {
+		t.Fatalf("invalid OTLP body %q: %v", bodies[0], err)
+	}
+	rl := req.ResourceLogs[0]
+	if v := otlpAttr(rl.Resource.Attributes, "service.name"); v["stringValue"] != "checkout" {
+		t.Errorf("unexpected service.name %v", v)
+	}
+
+	records := rl.ScopeLogs[0].LogRecords
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	r := records[0]
+	if r.SeverityNumber != 13 || r.SeverityText != "WARN" || r.Body["stringValue"] != "payment slow" ||
+		r.TimeUnixNano != "1700000000000000000" {
+		t.Errorf("unexpected record %+v", r)
+	}
+	if r.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || r.SpanID != "00f067aa0ba902b7" || otlpAttr(r.Attributes, "trace_id") != nil {
+		t.Errorf("expected the trace context on the record, got %+v", r)
+	}
+	if otlpAttr(r.Attributes, "attempt")["intValue"] != "3" || otlpAttr(r.Attributes, "ratio")["doubleValue"] != 0.5 ||
+		otlpAttr(r.Attributes, "retried")["boolValue"] != true || otlpAttr(r.Attributes, "badge") != nil {
+		t.Errorf("unexpected attributes %+v", r.Attributes)
+	}
+	if kv, _ := otlpAttr(r.Attributes, "http")["kvlistValue"].(map[string]any); kv == nil {
+		t.Errorf("expected the group as a key-value list, got %+v", otlpAttr(r.Attributes, "http"))
+	}
+	if otlpAttr(r.Attributes, "code.lineno")["intValue"] != "12" || otlpAttr(r.Attributes, "code.function")["stringValue"] != "pay.Charge" {
+		t.Errorf("expected caller attributes, got %+v", r.Attributes)
+	}
+
+	if r := records[1]; r.TraceID != "" || otlpAttr(r.Attributes, "trace_id")["stringValue"] != "req-1" {
+		t.Errorf("expected a non-OpenTelemetry ID kept as attribute, got %+v", r)
+	}
+}
+
+func TestOTLPSeverity(t *testing.T) {
+	prev := 0
+	for level := share.LevelTrace; level <= share.LevelPanic; level++ {
+		sev := OTLPSeverity(level)
+		if sev <= prev || sev > 24 {
+			t.Errorf("severity of %v = %d, want increasing within 1..24", level, sev)
+		}
+		prev = sev
+	}
+}
@@ -0,0 +1,120 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// prettyIndent indents the field block of FormatPretty entries.
+const prettyIndent = "    "
+
+// formatPretty formats entry for development: the badge line as in
+// FormatBadge without fields, then one line per field below it, sorted by
+// key, with nested maps and slices rendered as indented JSON and values
+// colored by type.
+func (w *ConsoleWriter) formatPretty(entry *share.Entry) string {
+	// Keep only the fields that style the badge on the first line
+	head := *entry
+	head.Fields = make(share.Fields)
+	var names []string
+	for key, value := range entry.Fields {
+		if presentationFields[key] || key == "type" {
+			head.Fields[key] = value
+			continue
+		}
+		names = append(names, key)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	b.WriteString(w.formatBadge(&head))
+	mode := color.ModeNoColor
+	if w.supportsColor() && !w.options.DisableColor {
+		mode = w.GetColorMode()
+	}
+	for _, name := range names {
+		b.WriteString("\n")
+		b.WriteString(entry.IndentStr + prettyIndent)
+		b.WriteString(prettyStyle(name, prettyColors['k'], mode))
+		b.WriteString(": ")
+
+		var indented bytes.Buffer
+		value := appendValue(nil, entry.Fields[name], 0)
+		if err := json.Indent(&indented, value, entry.IndentStr+prettyIndent, "  "); err != nil {
+			indented.Reset()
+			indented.Write(value)
+		}
+		text := indented.String()
+		if s, ok := entry.Fields[name].(string); ok && !strings.ContainsAny(s, "\n\"\\") {
+			// Plain strings read better unquoted
+			text = prettyStyle(s, prettyColors['s'], mode)
+		} else {
+			text = colorizeJSON(text, mode)
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+// prettyColors maps JSON token kinds to their color.
+var prettyColors = map[byte]color.Color{
+	'k': color.ModernSlate,  // Object keys
+	's': color.ModernGreen,  // Strings
+	'n': color.ModernCyan,   // Numbers
+	'l': color.ModernPurple, // true, false and null
+}
+
+// prettyStyle colors text with fg in mode.
+func prettyStyle(text string, fg color.Color, mode color.Mode) string {
+	return color.NewStyle(color.StyleConfig{Text: text, ForeGround: fg, Mode: mode})
+}
+
+// colorizeJSON colors the tokens of an indented JSON text in mode.
+func colorizeJSON(text string, mode color.Mode) string {
+	if mode == color.ModeNoColor {
+		return text
+	}
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(text) && text[end] != '"' {
+				if text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(text))
+			kind := byte('s')
+			if rest := strings.TrimLeft(text[end:], " "); strings.HasPrefix(rest, ":") {
+				kind = 'k'
+			}
+			b.WriteString(prettyStyle(text[i:end], prettyColors[kind], mode))
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(text) && strings.IndexByte("0123456789.eE+-", text[end]) >= 0 {
+				end++
+			}
+			b.WriteString(prettyStyle(text[i:end], prettyColors['n'], mode))
+			i = end
+		case strings.HasPrefix(text[i:], "true"), strings.HasPrefix(text[i:], "null"):
+			b.WriteString(prettyStyle(text[i:i+4], prettyColors['l'], mode))
+			i += 4
+		case strings.HasPrefix(text[i:], "false"):
+			b.WriteString(prettyStyle(text[i:i+5], prettyColors['l'], mode))
+			i += 5
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
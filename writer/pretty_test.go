@@ -0,0 +1,79 @@
+package writer
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func TestFormatPretty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewConsoleWriter(buf, ConsoleOptions{DisableColor: true})
+
+	entry := &share.Entry{
+		Level:     share.LevelInfo,
+		Message:   "request served",
+		Timestamp: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+		Fields: share.Fields{
+			"status": 200,
+			"path":   "/users",
+			"query":  map[string]any{"page": 2, "q": "bob"},
+			"badge":  "HTTP",
+		},
+	}
+
+	lines := strings.Split(writer.formatPretty(entry), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("expected header and 6 field lines, got %q", lines)
+	}
+	if !strings.Contains(lines[0], "request served") || strings.Contains(lines[0], "status") {
+		t.Errorf("expected message without fields on the first line, got %q", lines[0])
+	}
+	if strings.Contains(strings.Join(lines[1:], "\n"), "badge") {
+		t.Errorf("presentation fields must not be listed, got %q", lines)
+	}
+	if lines[1] != "    path: /users" {
+		t.Errorf("expected sorted unquoted string field, got %q", lines[1])
+	}
+	if lines[2] != `    query: {` {
+		t.Errorf("expected nested map to open an indented block, got %q", lines[2])
+	}
+	if lines[3] != `      "page": 2,` {
+		t.Errorf("unexpected nested line %q", lines[3])
+	}
+	if lines[5] != "    }" || lines[6] != "    status: 200" {
+		t.Errorf("unexpected block end %q", lines[5:])
+	}
+}
+
+func TestFormatPrettyNested(t *testing.T) {
+	writer := NewConsoleWriter(&bytes.Buffer{}, ConsoleOptions{DisableColor: true})
+	entry := &share.Entry{
+		Level:   share.LevelWarn,
+		Message: "retry",
+		Fields:  share.Fields{"attempt": 3, "tags": []string{"a", "b"}},
+	}
+
+	want := "    attempt: 3\n    tags: [\n      \"a\",\n      \"b\"\n    ]"
+	if got := writer.formatPretty(entry); !strings.HasSuffix(got, want) {
+		t.Errorf("expected suffix %q, got %q", want, got)
+	}
+}
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func TestColorizeJSON(t *testing.T) {
+	plain := `{"ok": true, "n": -1.5, "s": "x: y", "z": null}`
+	got := colorizeJSON(plain, color.ModeTrueColor)
+	if got == plain {
+		t.Fatal("expected tokens to be colored")
+	}
+	if stripped := ansiPattern.ReplaceAllString(got, ""); stripped != plain {
+		t.Errorf("coloring must not change the text, got %q", stripped)
+	}
+}
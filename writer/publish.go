@@ -0,0 +1,171 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// PublishFunc delivers a batch of messages to a message bus such as Kafka,
+// NATS or SQS. It returns an error when the batch should be retried, or one
+// wrapped with Permanent when retrying cannot help. ctx expires after
+// PublishOptions.Timeout.
+type PublishFunc func(ctx context.Context, batch []Message) error
+
+// permanentError marks an error that retrying cannot fix.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so a PublishWriter drops the batch at once instead of
+// retrying it, e.g. for a message the bus rejects as too large.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// PublishOptions configures a PublishWriter.
+type PublishOptions struct {
+	Level         share.Level
+	KeyField      string                                   // Field whose value becomes Message.Key, e.g. "request_id"; empty leaves keys nil
+	Encode        func(entry *share.Entry) ([]byte, error) // Serializes entries; nil encodes them as JSON
+	JSONKeys      JSONKeys                                 // Key names of the default JSON encoding
+	BatchSize     int                                      // Messages that trigger a publish
+	FlushInterval time.Duration                            // Longest time a message waits for its batch
+	BufferSize    int                                      // Messages queued for publishing
+	Overflow      OverflowPolicy                           // What Write does when the queue is full; OverflowBlock applies backpressure
+	MaxRetries    int                                      // Retries of a failed publish before its batch is dropped
+	RetryBackoff  time.Duration                            // Wait before the first retry, doubled after each one
+	Timeout       time.Duration                            // Deadline of each publish call
+	OnDrop        func(batch []Message, err error)         // Receives the batches dropped after failing, e.g. to spool them to disk
+}
+
+// DefaultPublishOptions publishes Info and above as JSON in batches of up
+// to 100 messages, at least every second, queuing up to 10000 messages and
+// retrying failed publishes 3 times.
+func DefaultPublishOptions() PublishOptions {
+	return PublishOptions{
+		Level:         share.LevelInfo,
+		BatchSize:     100,
+		FlushInterval: time.Second,
+		BufferSize:    10000,
+		Overflow:      OverflowBlock,
+		MaxRetries:    3,
+		RetryBackoff:  500 * time.Millisecond,
+		Timeout:       10 * time.Second,
+	}
+}
+
+// PublishWriter ships entries to a message bus through a PublishFunc, so
+// tfx needs no client library of its own: the writer serializes, batches
+// and retries, and the callback only hands the batch to the client of the
+// application. With kafka-go:
+//
+//	w, err := writer.NewPublishWriter(func(ctx context.Context, batch []writer.Message) error {
+//		msgs := make([]kafka.Message, len(batch))
+//		for i, m := range batch {
+//			msgs[i] = kafka.Message{Key: m.Key, Value: m.Value}
+//		}
+//		return kw.WriteMessages(ctx, msgs...)
+//	}, writer.DefaultPublishOptions())
+//
+// and with NATS, publishing each message on a subject:
+//
+//	w, err := writer.NewPublishWriter(func(ctx context.Context, batch []writer.Message) error {
+//		for _, m := range batch {
+//			if err := nc.Publish("logs.api", m.Value); err != nil {
+//				return err
+//			}
+//		}
+//		return nc.FlushWithContext(ctx)
+//	}, writer.DefaultPublishOptions())
+type PublishWriter struct {
+	*batcher
+	options PublishOptions
+	enc     *JSONEncoder
+}
+
+// NewPublishWriter starts publishing through publish; zero options take the
+// values of DefaultPublishOptions.
+func NewPublishWriter(publish PublishFunc, opts PublishOptions) (*PublishWriter, error) {
+	if publish == nil {
+		return nil, fmt.Errorf("missing publish function")
+	}
+	def := DefaultPublishOptions()
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = def.BatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = def.FlushInterval
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = def.BufferSize
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = def.RetryBackoff
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = def.Timeout
+	}
+
+	w := &PublishWriter{options: opts, enc: NewJSONEncoder(opts.JSONKeys)}
+	w.batcher = &batcher{
+		batchSize:     opts.BatchSize,
+		flushInterval: opts.FlushInterval,
+		overflow:      opts.Overflow,
+		maxRetries:    opts.MaxRetries,
+		retryBackoff:  opts.RetryBackoff,
+		send: func(batch []Message) error {
+			ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+			defer cancel()
+			return publish(ctx, batch)
+		},
+		retryable: func(err error) bool { return !IsPermanent(err) },
+		onDrop:    opts.OnDrop,
+	}
+	w.start(opts.BufferSize)
+	return w, nil
+}
+
+// Write serializes entry and queues it for the next batch. It fails only
+// when a custom Encode does.
+func (w *PublishWriter) Write(entry *share.Entry) error {
+	if entry.Level < w.options.Level {
+		return nil
+	}
+	msg := Message{Level: entry.Level, Time: entry.Timestamp}
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+	if w.options.Encode != nil {
+		value, err := w.options.Encode(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode entry: %w", err)
+		}
+		msg.Value = value
+	} else {
+		msg.Value = w.enc.AppendEntry(nil, entry)
+	}
+	if w.options.KeyField != "" {
+		if key, ok := entry.Fields[w.options.KeyField]; ok {
+			msg.Key = []byte(fieldText(key))
+		}
+	}
+	w.add(msg)
+	return nil
+}
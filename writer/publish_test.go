@@ -0,0 +1,145 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// fakeBus records the published batches, failing with the errors of fails
+// in turn.
+type fakeBus struct {
+	mu      sync.Mutex
+	batches [][]Message
+	fails   []error
+}
+
+func (b *fakeBus) publish(ctx context.Context, batch []Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("publish without deadline")
+	}
+	if len(b.fails) > 0 {
+		err := b.fails[0]
+		b.fails = b.fails[1:]
+		return err
+	}
+	b.batches = append(b.batches, batch)
+	return nil
+}
+
+func (b *fakeBus) published() [][]Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([][]Message(nil), b.batches...)
+}
+
+func TestPublishWriterBatches(t *testing.T) {
+	bus := &fakeBus{}
+	opts := DefaultPublishOptions()
+	opts.BatchSize = 2
+	opts.FlushInterval = time.Hour
+	opts.KeyField = "request_id"
+	w, err := NewPublishWriter(bus.publish, opts)
+	if err != nil {
+		t.Fatalf("NewPublishWriter: %v", err)
+	}
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "one", Fields: share.Fields{"request_id": 7}})
+	w.Write(&share.Entry{Level: share.LevelDebug, Message: "filtered"})
+	w.Write(&share.Entry{Level: share.LevelWarn, Message: "two"})
+	w.Write(&share.Entry{Level: share.LevelError, Message: "three"})
+	w.Flush()
+
+	batches := bus.published()
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Fatalf("expected a full batch and a flushed one, got %v", batches)
+	}
+	m := batches[0][0]
+	if string(m.Key) != "7" || m.Level != share.LevelInfo || m.Time.IsZero() || string(m.Value) == "" {
+		t.Errorf("unexpected message %+v", m)
+	}
+	if batches[0][1].Key != nil {
+		t.Errorf("expected no key without the field, got %q", batches[0][1].Key)
+	}
+}
+
+func TestPublishWriterEncode(t *testing.T) {
+	bus := &fakeBus{}
+	opts := DefaultPublishOptions()
+	opts.Encode = func(entry *share.Entry) ([]byte, error) {
+		if entry.Message == "" {
+			return nil, errors.New("empty message")
+		}
+		return []byte(entry.Level.String() + " " + entry.Message), nil
+	}
+	w, err := NewPublishWriter(bus.publish, opts)
+	if err != nil {
+		t.Fatalf("NewPublishWriter: %v", err)
+	}
+	defer w.Close()
+
+	if err := w.Write(&share.Entry{Level: share.LevelInfo}); err == nil {
+		t.Error("expected the encoding error")
+	}
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "custom"})
+	w.Flush()
+	if batches := bus.published(); len(batches) != 1 || string(batches[0][0].Value) != "INFO custom" {
+		t.Errorf("unexpected batches %v", batches)
+	}
+}
+
+func TestPublishWriterErrorPolicies(t *testing.T) {
+	rejected := errors.New("message too large")
+	bus := &fakeBus{fails: []error{errors.New("broker down"), Permanent(rejected)}}
+	var mu sync.Mutex
+	var spooled []Message
+	opts := DefaultPublishOptions()
+	opts.BatchSize = 1
+	opts.RetryBackoff = time.Millisecond
+	opts.OnDrop = func(batch []Message, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if errors.Is(err, rejected) {
+			spooled = append(spooled, batch...)
+		}
+	}
+	w, err := NewPublishWriter(bus.publish, opts)
+	if err != nil {
+		t.Fatalf("NewPublishWriter: %v", err)
+	}
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "retried"})
+	w.Flush()
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "rejected"})
+	w.Flush()
+
+	if batches := bus.published(); len(batches) != 1 || w.Dropped() != 1 {
+		t.Errorf("expected the transient failure retried and the permanent one dropped, got %d published, %d dropped",
+			len(batches), w.Dropped())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(spooled) != 1 {
+		t.Errorf("expected OnDrop to receive the rejected batch, got %v", spooled)
+	}
+	if err := <-w.Errors(); !IsPermanent(err) || !errors.Is(err, rejected) {
+		t.Errorf("expected the permanent error, got %v", err)
+	}
+}
+
+func TestNewPublishWriterRequiresFunc(t *testing.T) {
+	if _, err := NewPublishWriter(nil, DefaultPublishOptions()); err == nil {
+		t.Error("expected an error without a publish function")
+	}
+	if Permanent(nil) != nil {
+		t.Error("expected Permanent(nil) to be nil")
+	}
+}
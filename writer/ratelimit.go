@@ -0,0 +1,191 @@
+package writer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// RateLimitOptions configures a RateLimitWriter.
+type RateLimitOptions struct {
+	Rate           float64       // Entries per second let through on average
+	Burst          int           // Entries let through at once after a quiet period
+	NoticeInterval time.Duration // Shortest time between notices of suppressed entries
+	Exempt         share.Level   // Entries from this level on are never limited; LevelTrace limits all
+}
+
+// DefaultRateLimitOptions lets 100 entries per second through with bursts
+// of 200, noticing suppressed ones every 10s. Fatal and Panic entries are
+// never limited.
+func DefaultRateLimitOptions() RateLimitOptions {
+	return RateLimitOptions{
+		Rate:           100,
+		Burst:          200,
+		NoticeInterval: 10 * time.Second,
+		Exempt:         share.LevelFatal,
+	}
+}
+
+// RateLimitWriter protects a slow writer, such as a NetworkWriter, from
+// bursts of entries with a token bucket: each entry takes a token, tokens
+// refill at Rate per second up to Burst, and entries finding none are
+// suppressed. Suppressed entries are counted and reported in a Warn entry
+// such as "rate limit suppressed 512 log entries" at most once per
+// NoticeInterval, so their volume stays visible.
+type RateLimitWriter struct {
+	next    share.Writer
+	options RateLimitOptions
+
+	mu         sync.Mutex
+	tokens     float64
+	refilled   time.Time
+	suppressed int    // Since the last notice
+	total      uint64 // Since the writer was created
+	noticed    time.Time
+
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// NewRateLimitWriter wraps next, limiting entries as configured by opts.
+// Zero options take their defaults.
+func NewRateLimitWriter(next share.Writer, opts RateLimitOptions) *RateLimitWriter {
+	def := DefaultRateLimitOptions()
+	if opts.Rate <= 0 {
+		opts.Rate = def.Rate
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = max(1, int(opts.Rate))
+	}
+	if opts.NoticeInterval <= 0 {
+		opts.NoticeInterval = def.NoticeInterval
+	}
+	now := time.Now()
+	w := &RateLimitWriter{
+		next:     next,
+		options:  opts,
+		tokens:   float64(opts.Burst),
+		refilled: now,
+		noticed:  now,
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write writes entry if a token is left, or suppresses it.
+func (w *RateLimitWriter) Write(entry *share.Entry) error {
+	if w.options.Exempt > share.LevelTrace && entry.Level >= w.options.Exempt {
+		return w.next.Write(entry)
+	}
+
+	now := time.Now()
+	w.mu.Lock()
+	elapsed := now.Sub(w.refilled).Seconds()
+	w.tokens = min(float64(w.options.Burst), w.tokens+elapsed*w.options.Rate)
+	w.refilled = now
+	allowed := w.tokens >= 1
+	if allowed {
+		w.tokens--
+	} else {
+		w.suppressed++
+		w.total++
+	}
+	notice := w.takeNotice(now, false)
+	w.mu.Unlock()
+
+	if notice != nil {
+		w.next.Write(notice)
+	}
+	if !allowed {
+		return nil
+	}
+	return w.next.Write(entry)
+}
+
+// takeNotice returns the entry reporting the entries suppressed since the
+// last one, or nil when there are none or, unless force is set, the notice
+// interval has not passed. Callers hold w.mu.
+func (w *RateLimitWriter) takeNotice(now time.Time, force bool) *share.Entry {
+	if w.suppressed == 0 || !force && now.Sub(w.noticed) < w.options.NoticeInterval {
+		return nil
+	}
+	n := w.suppressed
+	w.suppressed = 0
+	w.noticed = now
+	return &share.Entry{
+		Level:     share.LevelWarn,
+		Message:   fmt.Sprintf("rate limit suppressed %d log entries", n),
+		Fields:    share.Fields{"suppressed": n},
+		Timestamp: now,
+	}
+}
+
+// Suppressed returns how many entries were suppressed since the writer was
+// created.
+func (w *RateLimitWriter) Suppressed() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.total
+}
+
+// run writes the notices due while no entry comes in, until the writer is
+// closed.
+func (w *RateLimitWriter) run() {
+	defer close(w.stopped)
+	ticker := time.NewTicker(w.options.NoticeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			w.mu.Lock()
+			notice := w.takeNotice(now, false)
+			w.mu.Unlock()
+			if notice != nil {
+				w.next.Write(notice)
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// writeNotice writes the notice of the entries suppressed so far, if any.
+func (w *RateLimitWriter) writeNotice() error {
+	w.mu.Lock()
+	notice := w.takeNotice(time.Now(), true)
+	w.mu.Unlock()
+	if notice == nil {
+		return nil
+	}
+	return w.next.Write(notice)
+}
+
+// Flush writes the pending notice and flushes the wrapped writer if it
+// supports it.
+func (w *RateLimitWriter) Flush() {
+	w.writeNotice()
+	if flusher, ok := w.next.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
+
+// Writers returns the wrapped writer.
+func (w *RateLimitWriter) Writers() []share.Writer {
+	return []share.Writer{w.next}
+}
+
+// Close writes the pending notice and closes the wrapped writer.
+func (w *RateLimitWriter) Close() error {
+	w.once.Do(func() { close(w.stop) })
+	<-w.stopped
+	err := w.writeNotice()
+	if cerr := w.next.Close(); cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+package writer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+func TestRateLimitWriterBurstAndNotice(t *testing.T) {
+	capture := NewCaptureWriter()
+	w := NewRateLimitWriter(capture, RateLimitOptions{Rate: 1, Burst: 3, NoticeInterval: time.Hour, Exempt: share.LevelFatal})
+	defer w.Close()
+
+	for range 10 {
+		w.Write(&share.Entry{Level: share.LevelError, Message: "flood"})
+	}
+	w.Write(&share.Entry{Level: share.LevelFatal, Message: "exempt"})
+
+	if n := len(capture.Entries(share.LevelError)); n != 3 {
+		t.Errorf("expected the burst let through, got %d entries", n)
+	}
+	if !capture.ContainsMessage("exempt") {
+		t.Error("expected exempt levels never limited")
+	}
+	if w.Suppressed() != 7 || capture.ContainsMessage("suppressed") {
+		t.Errorf("expected 7 suppressed and no notice before the interval, got %d", w.Suppressed())
+	}
+
+	w.Flush()
+	notices := capture.Entries(share.LevelWarn)
+	if len(notices) != 1 || notices[0].Message != "rate limit suppressed 7 log entries" || notices[0].Fields["suppressed"] != 7 {
+		t.Errorf("unexpected notices %+v", notices)
+	}
+	w.Flush()
+	if len(capture.Entries(share.LevelWarn)) != 1 {
+		t.Error("expected no notice without new suppressed entries")
+	}
+}
+
+func TestRateLimitWriterRefills(t *testing.T) {
+	capture := NewCaptureWriter()
+	w := NewRateLimitWriter(capture, RateLimitOptions{Rate: 200, Burst: 1})
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "one"})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "suppressed"})
+	time.Sleep(20 * time.Millisecond) // Refills the single token
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "two"})
+
+	if got := capture.Messages(); len(got) != 2 || got[1] != "two" {
+		t.Errorf("expected the bucket refilled, got %q", got)
+	}
+}
+
+func TestRateLimitWriterNoticeInterval(t *testing.T) {
+	capture := NewCaptureWriter()
+	w := NewRateLimitWriter(capture, RateLimitOptions{Rate: 1, Burst: 1, NoticeInterval: 10 * time.Millisecond})
+	defer w.Close()
+
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "one"})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "two"})
+	deadline := time.Now().Add(time.Second)
+	for !capture.ContainsMessage("rate limit suppressed 1 log entries") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a periodic notice, got %q", capture.Messages())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,125 @@
+package writer
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// Route selects the entries a writer receives: those from Level up to, but
+// not including, Below and, when Badges is set, carrying one of them.
+type Route struct {
+	Writer share.Writer
+	Level  share.Level // Lowest level routed
+	Below  share.Level // Levels from this one on are not routed; LevelTrace routes up to Panic
+	Badges []string    // Badge tags routed, as logged by logfx.Badge; empty routes any entry
+}
+
+// matches reports whether entry takes the route.
+func (r *Route) matches(entry *share.Entry) bool {
+	if entry.Level < r.Level || r.Below > share.LevelTrace && entry.Level >= r.Below {
+		return false
+	}
+	if len(r.Badges) == 0 {
+		return true
+	}
+	badge, _ := entry.Fields["badge"].(string)
+	return slices.Contains(r.Badges, badge)
+}
+
+// RouteOptions configures a RouteWriter.
+type RouteOptions struct {
+	Routes   []Route
+	Fallback share.Writer // Receives the entries no route matches; nil drops them
+	First    bool         // Write an entry to its first matching route only
+}
+
+// RouteWriter sends entries to different writers by level range or badge,
+// so a logger configured with one writer can split its output:
+//
+//	logger.AddWriter(writer.NewRouteWriter(writer.RouteOptions{Routes: []writer.Route{
+//		{Writer: debugFile, Level: share.LevelTrace, Below: share.LevelInfo},
+//		{Writer: console, Level: share.LevelInfo, Below: share.LevelError},
+//		{Writer: network, Level: share.LevelError},
+//	}}))
+//
+// By default an entry goes to every route it matches.
+type RouteWriter struct {
+	options RouteOptions
+	writers []share.Writer // Distinct writers of the routes and fallback
+}
+
+// NewRouteWriter creates a RouteWriter with the given routes.
+func NewRouteWriter(opts RouteOptions) *RouteWriter {
+	w := &RouteWriter{options: opts}
+	for _, r := range opts.Routes {
+		if r.Writer != nil && !slices.Contains(w.writers, r.Writer) {
+			w.writers = append(w.writers, r.Writer)
+		}
+	}
+	if opts.Fallback != nil && !slices.Contains(w.writers, opts.Fallback) {
+		w.writers = append(w.writers, opts.Fallback)
+	}
+	return w
+}
+
+// Write writes entry to the writers of its routes, or to the fallback when
+// none matches, joining their errors.
+func (w *RouteWriter) Write(entry *share.Entry) error {
+	var errs []error
+	matched := false
+	for i := range w.options.Routes {
+		r := &w.options.Routes[i]
+		if r.Writer == nil || !r.matches(entry) {
+			continue
+		}
+		matched = true
+		if err := r.Writer.Write(entry); err != nil {
+			errs = append(errs, err)
+		}
+		if w.options.First {
+			break
+		}
+	}
+	if !matched && w.options.Fallback != nil {
+		return w.options.Fallback.Write(entry)
+	}
+	return errors.Join(errs...)
+}
+
+// Writers returns the distinct writers entries are routed to.
+func (w *RouteWriter) Writers() []share.Writer {
+	return slices.Clone(w.writers)
+}
+
+// Flush flushes the writers that support it.
+func (w *RouteWriter) Flush() {
+	for _, inner := range w.writers {
+		if flusher, ok := inner.(interface{ Flush() }); ok {
+			flusher.Flush()
+		}
+	}
+}
+
+// Dropped returns the dropped entries of the writers that count them.
+func (w *RouteWriter) Dropped() uint64 {
+	var n uint64
+	for _, inner := range w.writers {
+		if d, ok := inner.(interface{ Dropped() uint64 }); ok {
+			n += d.Dropped()
+		}
+	}
+	return n
+}
+
+// Close closes every writer once, joining their errors.
+func (w *RouteWriter) Close() error {
+	var errs []error
+	for _, inner := range w.writers {
+		if err := inner.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
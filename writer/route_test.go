@@ -0,0 +1,76 @@
+package writer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// failingWriter fails every write.
+type failingWriter struct{ err error }
+
+func (f failingWriter) Write(*share.Entry) error { return f.err }
+func (f failingWriter) Close() error             { return nil }
+
+func TestRouteWriterByLevel(t *testing.T) {
+	debug, console, network := NewCaptureWriter(), NewCaptureWriter(), NewCaptureWriter()
+	w := NewRouteWriter(RouteOptions{Routes: []Route{
+		{Writer: debug, Level: share.LevelTrace, Below: share.LevelInfo},
+		{Writer: console, Level: share.LevelInfo, Below: share.LevelError},
+		{Writer: network, Level: share.LevelError},
+	}})
+
+	for _, level := range []share.Level{share.LevelTrace, share.LevelDebug, share.LevelInfo, share.LevelWarn, share.LevelError, share.LevelPanic} {
+		w.Write(&share.Entry{Level: level, Message: level.String()})
+	}
+	if debug.Len() != 2 || console.Len() != 2 || network.Len() != 2 {
+		t.Errorf("unexpected split: debug %q, console %q, network %q", debug.Messages(), console.Messages(), network.Messages())
+	}
+	if len(network.Entries(share.LevelPanic)) != 1 {
+		t.Error("expected the open-ended route to take Panic")
+	}
+	if len(w.Writers()) != 3 {
+		t.Errorf("expected three writers, got %d", len(w.Writers()))
+	}
+}
+
+func TestRouteWriterBadgesAndFallback(t *testing.T) {
+	db, all, rest := NewCaptureWriter(), NewCaptureWriter(), NewCaptureWriter()
+	w := NewRouteWriter(RouteOptions{
+		Routes: []Route{
+			{Writer: db, Badges: []string{"DB", "SQL"}},
+			{Writer: all, Level: share.LevelWarn},
+		},
+		Fallback: rest,
+	})
+
+	w.Write(&share.Entry{Level: share.LevelWarn, Message: "slow query", Fields: share.Fields{"badge": "SQL"}})
+	w.Write(&share.Entry{Level: share.LevelInfo, Message: "cache", Fields: share.Fields{"badge": "CACHE"}})
+	if !db.ContainsMessage("slow query") || !all.ContainsMessage("slow query") {
+		t.Error("expected an entry written to every matching route")
+	}
+	if db.Len() != 1 || !rest.ContainsMessage("cache") || rest.Len() != 1 {
+		t.Errorf("expected unmatched entries in the fallback, got %q", rest.Messages())
+	}
+
+	first := NewRouteWriter(RouteOptions{First: true, Routes: []Route{{Writer: db}, {Writer: all}}})
+	db.Reset()
+	all.Reset()
+	first.Write(&share.Entry{Level: share.LevelError, Message: "once"})
+	if db.Len() != 1 || all.Len() != 0 {
+		t.Error("expected First to stop at the first matching route")
+	}
+}
+
+func TestRouteWriterJoinsErrors(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	ok := NewCaptureWriter()
+	w := NewRouteWriter(RouteOptions{Routes: []Route{
+		{Writer: failingWriter{errA}}, {Writer: ok}, {Writer: failingWriter{errB}},
+	}})
+	err := w.Write(&share.Entry{Level: share.LevelInfo, Message: "x"})
+	if !errors.Is(err, errA) || !errors.Is(err, errB) || ok.Len() != 1 {
+		t.Errorf("expected both errors and the entry delivered, got %v", err)
+	}
+}
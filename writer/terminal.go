@@ -11,14 +11,32 @@ import (
 	"golang.org/x/term"
 
 	"github.com/garaekz/tfx/color"
+	"github.com/garaekz/tfx/internal/share"
 	"github.com/garaekz/tfx/terminal"
 )
 
+// HyperlinkMode controls how OSC 8 hyperlink sequences are written.
+type HyperlinkMode int
+
+const (
+	HyperlinksAuto HyperlinkMode = iota // pass through when the terminal supports them
+	HyperlinksOn                        // always pass through
+	HyperlinksOff                       // always strip, keeping the link text
+)
+
+// Hyperlink returns text wrapped in an OSC 8 sequence linking to url.
+// Terminals without hyperlink support get the plain text when written
+// through a TerminalWriter.
+func Hyperlink(url, text string) string {
+	return share.Hyperlink(url, text)
+}
+
 // TerminalOptions configures the terminal writer behavior.
 type TerminalOptions struct {
-	ForceColor   bool // force color support
-	DisableColor bool // disable all colors
-	DoubleBuffer bool // flicker-free updates
+	ForceColor   bool          // force color support
+	DisableColor bool          // disable all colors
+	DoubleBuffer bool          // flicker-free updates
+	Hyperlinks   HyperlinkMode // OSC 8 hyperlink handling
 }
 
 // TerminalWriter handles raw terminal output with double-buffering and color support.
@@ -28,27 +46,82 @@ type TerminalWriter struct {
 
 	mu      sync.Mutex
 	prevBuf []byte
+	partial []byte // Incomplete hyperlink held back from the last Write
 
-	opts TerminalOptions
+	opts       TerminalOptions
+	hyperlinks bool // Resolved from opts.Hyperlinks once
 }
 
+// maxPartialHyperlink bounds the bytes held back waiting for the end of a
+// hyperlink; beyond it they are written out as they are.
+const maxPartialHyperlink = 4096
+
 // NewTerminalWriter creates a new TerminalWriter.
 // Pass os.Stdout (or any *os.File) to support raw mode & size detection.
 func NewTerminalWriter(out io.Writer, opts TerminalOptions) *TerminalWriter {
-	return &TerminalWriter{
+	w := &TerminalWriter{
 		out:      out,
 		detector: terminal.NewDetector(out),
 		prevBuf:  nil,
 		opts:     opts,
 	}
+	switch opts.Hyperlinks {
+	case HyperlinksOn:
+		w.hyperlinks = true
+	case HyperlinksOff:
+		w.hyperlinks = false
+	default:
+		w.hyperlinks = w.detector.SupportsHyperlinks()
+	}
+	return w
 }
 
-// Write implements io.Writer. Applies double-buffering if enabled.
+// Write implements io.Writer. Applies double-buffering if enabled and strips
+// OSC 8 hyperlinks when they are not supported. A hyperlink split across
+// writes is held back until its end arrives, or until Flush.
 func (w *TerminalWriter) Write(p []byte) (int, error) {
+	out := p
+	if !w.hyperlinks {
+		out = w.stripHyperlinks(p)
+		if len(out) == 0 && len(p) > 0 {
+			return len(p), nil
+		}
+	}
+	if _, err := w.write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// stripHyperlinks strips the hyperlinks of p joined to the partial one
+// held back from the last write, holding back any new partial one.
+func (w *TerminalWriter) stripHyperlinks(p []byte) []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.partial) > 0 {
+		p = append(w.partial, p...)
+		w.partial = nil
+	}
+	if cut := share.IncompleteHyperlink(p); cut < len(p) && len(p)-cut <= maxPartialHyperlink {
+		w.partial = append([]byte(nil), p[cut:]...)
+		p = p[:cut]
+	}
+	return share.StripHyperlinks(p)
+}
+
+// write writes out, through the double buffer if enabled.
+func (w *TerminalWriter) write(out []byte) (int, error) {
 	if w.opts.DoubleBuffer {
-		return w.writeBuffered(p)
+		return w.writeBuffered(out)
 	}
-	return w.out.Write(p)
+	return w.out.Write(out)
+}
+
+// SupportsHyperlinks reports whether OSC 8 hyperlinks are passed through.
+// In HyperlinksAuto mode the terminal is detected once, by NewTerminalWriter.
+func (w *TerminalWriter) SupportsHyperlinks() bool {
+	return w.hyperlinks
 }
 
 // writeBuffered writes only when content changes.
@@ -102,6 +175,11 @@ func (w *TerminalWriter) GetColorMode() color.Mode {
 	}
 }
 
+// Output returns the writer the terminal output is written to.
+func (w *TerminalWriter) Output() io.Writer {
+	return w.out
+}
+
 // IsTerminal reports if out is a terminal.
 func (w *TerminalWriter) IsTerminal() bool {
 	return terminal.IsTerminal(w.out)
@@ -171,8 +249,19 @@ func (w *TerminalWriter) RestoreMode(state *term.State) error {
 	return fmt.Errorf("terminal: restore mode not supported on this writer")
 }
 
-// Flush is a no-op (satisfies interface).
-func (w *TerminalWriter) Flush() error { return nil }
+// Flush writes out a hyperlink held back by Write that never ended, with
+// its escape sequence stripped.
+func (w *TerminalWriter) Flush() error {
+	w.mu.Lock()
+	partial := w.partial
+	w.partial = nil
+	w.mu.Unlock()
+	if len(partial) == 0 {
+		return nil
+	}
+	_, err := w.write(share.StripHyperlinks(partial))
+	return err
+}
 
 // Close is a no-op (satisfies interface).
 func (w *TerminalWriter) Close() error { return nil }
@@ -190,3 +190,75 @@ func TestTerminalWriterCloseFlush(t *testing.T) {
 		t.Errorf("Close failed: %v", err)
 	}
 }
+
+func TestTerminalWriterHyperlinks(t *testing.T) {
+	link := Hyperlink("https://example.com", "docs")
+
+	buf := &bytes.Buffer{}
+	tw := NewTerminalWriter(buf, TerminalOptions{Hyperlinks: HyperlinksOff})
+	n, err := tw.Write([]byte("see " + link + "!"))
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if n != len("see "+link+"!") {
+		t.Errorf("Write returned wrong count: got %d", n)
+	}
+	if buf.String() != "see docs!" {
+		t.Errorf("expected hyperlink to be stripped, got %q", buf.String())
+	}
+
+	buf.Reset()
+	tw = NewTerminalWriter(buf, TerminalOptions{Hyperlinks: HyperlinksOn})
+	tw.Write([]byte(link))
+	if buf.String() != link {
+		t.Errorf("expected hyperlink passthrough, got %q", buf.String())
+	}
+
+	// BEL terminated sequences are recognized as well.
+	buf.Reset()
+	tw = NewTerminalWriter(buf, TerminalOptions{Hyperlinks: HyperlinksOff})
+	tw.Write([]byte("\033]8;id=1;https://example.com\adocs\033]8;;\a"))
+	if buf.String() != "docs" {
+		t.Errorf("expected BEL terminated hyperlink to be stripped, got %q", buf.String())
+	}
+}
+
+// TestTerminalWriterSplitHyperlink strips hyperlinks split across writes
+func TestTerminalWriterSplitHyperlink(t *testing.T) {
+	link := Hyperlink("https://example.com", "docs")
+	tests := []struct {
+		name   string
+		chunks []string
+	}{
+		{"split in the opener", []string{"see " + link[:2], link[2:] + "!"}},
+		{"split in the url", []string{"see " + link[:10], link[10:] + "!"}},
+		{"split in the terminator", []string{"see " + link[:len(link)-1], link[len(link)-1:] + "!"}},
+		{"split byte by byte", append([]string{"see "}, append(strings.Split(link, ""), "!")...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tw := NewTerminalWriter(buf, TerminalOptions{Hyperlinks: HyperlinksOff})
+			for _, chunk := range tt.chunks {
+				if n, err := tw.Write([]byte(chunk)); err != nil || n != len(chunk) {
+					t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+				}
+			}
+			if buf.String() != "see docs!" {
+				t.Errorf("expected the split hyperlink to be stripped, got %q", buf.String())
+			}
+		})
+	}
+
+	// A hyperlink that never ends is written out by Flush.
+	buf := &bytes.Buffer{}
+	tw := NewTerminalWriter(buf, TerminalOptions{Hyperlinks: HyperlinksOff})
+	tw.Write([]byte("see \033]8;;https://example.com"))
+	if buf.String() != "see " {
+		t.Errorf("expected the partial hyperlink to be held back, got %q", buf.String())
+	}
+	tw.Flush()
+	if buf.String() != "see " {
+		t.Errorf("expected Flush to drop the unterminated sequence, got %q", buf.String())
+	}
+}
@@ -0,0 +1,141 @@
+package writer
+
+import (
+	"maps"
+	"unicode/utf8"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// Transform maps an entry on its way to a writer. It may modify entry,
+// which is a copy with its own fields, and returns the entry to write, or
+// nil to drop it.
+type Transform func(entry *share.Entry) *share.Entry
+
+// TransformWriter applies transforms to entries before delegating them to
+// another writer, so shared rules such as static fields or size limits are
+// set up once per output instead of in a hook of every logger:
+//
+//	w := writer.NewTransformWriter(fileWriter,
+//		writer.AddFields(share.Fields{"app": "api", "version": version}),
+//		writer.RenameFields(map[string]string{"msg_id": "message_id"}),
+//		writer.TruncateMessage(8<<10),
+//	)
+//
+// Other writers of the logger see the entries unchanged.
+type TransformWriter struct {
+	next       share.Writer
+	transforms []Transform
+}
+
+// NewTransformWriter wraps next, applying transforms in order.
+func NewTransformWriter(next share.Writer, transforms ...Transform) *TransformWriter {
+	return &TransformWriter{next: next, transforms: transforms}
+}
+
+// Write transforms a copy of entry and writes the result, unless a
+// transform dropped it.
+func (w *TransformWriter) Write(entry *share.Entry) error {
+	e := *entry
+	e.Fields = maps.Clone(entry.Fields)
+	out := &e
+	for _, transform := range w.transforms {
+		if out = transform(out); out == nil {
+			return nil
+		}
+	}
+	return w.next.Write(out)
+}
+
+// Close closes the wrapped writer.
+func (w *TransformWriter) Close() error {
+	return w.next.Close()
+}
+
+// Flush flushes the wrapped writer if it supports it.
+func (w *TransformWriter) Flush() {
+	if flusher, ok := w.next.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
+
+// Dropped returns the dropped entries of the wrapped writer if it counts
+// them.
+func (w *TransformWriter) Dropped() uint64 {
+	if d, ok := w.next.(interface{ Dropped() uint64 }); ok {
+		return d.Dropped()
+	}
+	return 0
+}
+
+// AddFields adds fields to every entry, such as the host, application and
+// version. Fields of the entry with the same keys win.
+func AddFields(fields share.Fields) Transform {
+	return func(entry *share.Entry) *share.Entry {
+		if entry.Fields == nil {
+			entry.Fields = make(share.Fields, len(fields))
+		}
+		for k, v := range fields {
+			if _, ok := entry.Fields[k]; !ok {
+				entry.Fields[k] = v
+			}
+		}
+		return entry
+	}
+}
+
+// RenameFields renames fields, keyed by their old names, e.g. to match the
+// schema of a log platform.
+func RenameFields(names map[string]string) Transform {
+	return func(entry *share.Entry) *share.Entry {
+		for from, to := range names {
+			if v, ok := entry.Fields[from]; ok {
+				delete(entry.Fields, from)
+				entry.Fields[to] = v
+			}
+		}
+		return entry
+	}
+}
+
+// DropFields removes fields, e.g. internal ones an output must not see.
+func DropFields(keys ...string) Transform {
+	return func(entry *share.Entry) *share.Entry {
+		for _, k := range keys {
+			delete(entry.Fields, k)
+		}
+		return entry
+	}
+}
+
+// TruncateMessage shortens messages longer than max bytes, cutting at a
+// character boundary and ending them with "…". The original length is
+// kept in a "message_size" field.
+func TruncateMessage(max int) Transform {
+	return func(entry *share.Entry) *share.Entry {
+		if len(entry.Message) <= max {
+			return entry
+		}
+		cut := max
+		for cut > 0 && !utf8.RuneStart(entry.Message[cut]) {
+			cut--
+		}
+		if entry.Fields == nil {
+			entry.Fields = make(share.Fields, 1)
+		}
+		entry.Fields["message_size"] = len(entry.Message)
+		entry.Message = entry.Message[:cut] + "…"
+		return entry
+	}
+}
+
+// MinLevel drops entries below level, for an output that only needs the
+// important ones.
+func MinLevel(level share.Level) Transform {
+	return func(entry *share.Entry) *share.Entry {
+		if entry.Level < level {
+			return nil
+		}
+		return entry
+	}
+}
@@ -0,0 +1,80 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/garaekz/tfx/internal/share"
+)
+
+// recordingWriter keeps the entries written to it.
+type recordingWriter struct {
+	entries []*share.Entry
+	closed  bool
+}
+
+func (r *recordingWriter) Write(entry *share.Entry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *recordingWriter) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestTransformWriter(t *testing.T) {
+	rec := &recordingWriter{}
+	w := NewTransformWriter(rec,
+		MinLevel(share.LevelInfo),
+		AddFields(share.Fields{"app": "api", "host": "web-1"}),
+		RenameFields(map[string]string{"uid": "user_id"}),
+		DropFields("secret"),
+		TruncateMessage(8),
+	)
+
+	original := &share.Entry{
+		Level:   share.LevelWarn,
+		Message: "ünïcode message",
+		Fields:  share.Fields{"uid": 7, "host": "own", "secret": "x"},
+	}
+	w.Write(&share.Entry{Level: share.LevelDebug, Message: "dropped"})
+	w.Write(original)
+
+	if len(rec.entries) != 1 {
+		t.Fatalf("expected one entry, got %d", len(rec.entries))
+	}
+	got := rec.entries[0]
+	if got.Fields["app"] != "api" || got.Fields["host"] != "own" || got.Fields["user_id"] != 7 {
+		t.Errorf("unexpected fields %v", got.Fields)
+	}
+	if _, ok := got.Fields["uid"]; ok {
+		t.Error("expected uid renamed")
+	}
+	if _, ok := got.Fields["secret"]; ok {
+		t.Error("expected secret dropped")
+	}
+	if got.Message != "ünïcod…" || got.Fields["message_size"] != len(original.Message) {
+		t.Errorf("unexpected truncation %q %v", got.Message, got.Fields["message_size"])
+	}
+
+	if original.Message != "ünïcode message" || len(original.Fields) != 3 || original.Fields["uid"] != 7 {
+		t.Errorf("expected the original entry unchanged, got %+v", original)
+	}
+
+	w.Flush()
+	if w.Dropped() != 0 {
+		t.Error("expected no drops from a writer that does not count them")
+	}
+	w.Close()
+	if !rec.closed {
+		t.Error("expected Close to close the wrapped writer")
+	}
+}
+
+func TestTruncateMessageShort(t *testing.T) {
+	entry := &share.Entry{Message: strings.Repeat("x", 8)}
+	if got := TruncateMessage(8)(entry); got.Message != entry.Message || got.Fields != nil {
+		t.Errorf("expected a short message untouched, got %+v", got)
+	}
+}